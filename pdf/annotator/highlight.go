@@ -0,0 +1,117 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	pdfcore "github.com/unidoc/unidoc/pdf/core"
+	pdf "github.com/unidoc/unidoc/pdf/model"
+)
+
+// Defines a text highlight over one or more quadrilaterals, typically one per line of the highlighted
+// text.  Each quadrilateral is given by 8 numbers in the order of the QuadPoints entry: the x, y
+// coordinates of its upper left, upper right, lower left and lower right corners.
+type HighlightAnnotationDef struct {
+	Quads    [][8]float64
+	Color    *pdf.PdfColorDeviceRGB // Yellow if nil.
+	Opacity  float64                // Alpha value (0-1). Opaque if 0.
+	Contents string                 // Optional text of the annotation, e.g. a review comment.
+}
+
+// Creates a text highlight annotation object with appearance stream that can be added to page PDF
+// annotations.
+func CreateHighlightAnnotation(hlDef HighlightAnnotationDef) (*pdf.PdfAnnotation, error) {
+	if len(hlDef.Quads) == 0 {
+		common.Log.Debug("Highlight annotation without quadrilaterals")
+		return nil, errors.New("Range check error")
+	}
+
+	if hlDef.Color == nil {
+		hlDef.Color = pdf.NewPdfColorDeviceRGB(1, 1, 0)
+	}
+	if hlDef.Opacity <= 0 {
+		// Highlights are multiplied with the page, so opaque ones keep the text readable.
+		hlDef.Opacity = 1
+	}
+
+	hlAnnotation := pdf.NewPdfAnnotationHighlight()
+
+	var quadPoints []float64
+	for _, quad := range hlDef.Quads {
+		quadPoints = append(quadPoints, quad[:]...)
+	}
+	hlAnnotation.QuadPoints = pdfcore.MakeArrayFromFloats(quadPoints)
+
+	r, g, b := hlDef.Color.R(), hlDef.Color.G(), hlDef.Color.B()
+	hlAnnotation.C = pdfcore.MakeArrayFromFloats([]float64{r, g, b})
+
+	if hlDef.Opacity < 1.0 {
+		hlAnnotation.CA = pdfcore.MakeFloat(hlDef.Opacity)
+	}
+	if hlDef.Contents != "" {
+		hlAnnotation.Contents = pdfcore.MakeString(hlDef.Contents)
+	}
+
+	// Make the appearance stream (for uniform appearance).
+	apDict, bbox, err := makeHighlightAnnotationAppearanceStream(hlDef)
+	if err != nil {
+		return nil, err
+	}
+
+	hlAnnotation.AP = apDict
+	hlAnnotation.Rect = pdfcore.MakeArrayFromFloats([]float64{bbox.Llx, bbox.Lly, bbox.Urx, bbox.Ury})
+
+	return hlAnnotation.PdfAnnotation, nil
+}
+
+func makeHighlightAnnotationAppearanceStream(hlDef HighlightAnnotationDef) (*pdfcore.PdfObjectDictionary, *pdf.PdfRectangle, error) {
+	form := pdf.NewXObjectForm()
+	form.Resources = pdf.NewPdfPageResources()
+
+	// Highlights are multiplied with the page so the text under them stays readable.
+	gsState := pdfcore.MakeDict()
+	gsState.Set("BM", pdfcore.MakeName("Multiply"))
+	if hlDef.Opacity < 1.0 {
+		gsState.Set("ca", pdfcore.MakeFloat(hlDef.Opacity))
+		gsState.Set("CA", pdfcore.MakeFloat(hlDef.Opacity))
+	}
+	err := form.Resources.AddExtGState("gs1", gsState)
+	if err != nil {
+		common.Log.Debug("Unable to add extgstate gs1")
+		return nil, nil, err
+	}
+
+	// The quadrilaterals are in page coordinates, so the form's bounding box is the annotation
+	// rectangle and it has the identity matrix.
+	bbox := &pdf.PdfRectangle{Llx: math.Inf(1), Lly: math.Inf(1), Urx: math.Inf(-1), Ury: math.Inf(-1)}
+	cc := contentstream.NewContentCreator()
+	cc.Add_q().Add_gs("gs1")
+	cc.Add_rg(hlDef.Color.R(), hlDef.Color.G(), hlDef.Color.B())
+	for _, quad := range hlDef.Quads {
+		// Corners in drawing order: upper left, upper right, lower right, lower left.
+		cc.Add_m(quad[0], quad[1]).Add_l(quad[2], quad[3]).Add_l(quad[6], quad[7]).Add_l(quad[4], quad[5]).Add_h()
+		for i := 0; i < 8; i += 2 {
+			bbox.Llx, bbox.Lly = math.Min(bbox.Llx, quad[i]), math.Min(bbox.Lly, quad[i+1])
+			bbox.Urx, bbox.Ury = math.Max(bbox.Urx, quad[i]), math.Max(bbox.Ury, quad[i+1])
+		}
+	}
+	cc.Add_f().Add_Q()
+
+	err = form.SetContentStream(cc.Bytes(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	form.BBox = bbox.ToPdfObject()
+
+	apDict := pdfcore.MakeDict()
+	apDict.Set("N", form.ToPdfObject())
+
+	return apDict, bbox, nil
+}
@@ -0,0 +1,739 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package contentstream
+
+import (
+	"github.com/unidoc/unidoc/common"
+	. "github.com/unidoc/unidoc/pdf/core"
+	. "github.com/unidoc/unidoc/pdf/model"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+)
+
+// maxFormDepth is the maximum nesting depth of form XObjects processed.
+const maxFormDepth = 16
+
+// TextState holds the text state parameters (9.3 "Text State Parameters and Operators") and the
+// matrices of the current text object (9.4.2 "Text-Positioning Operators").
+type TextState struct {
+	Tc       float64       // Character spacing in unscaled text space units.
+	Tw       float64       // Word spacing in unscaled text space units.
+	Th       float64       // Horizontal scaling, as a fraction (Tz/100).
+	Tl       float64       // Leading in unscaled text space units.
+	Tfs      float64       // Font size.
+	Ts       float64       // Text rise in unscaled text space units.
+	Tr       int           // Text rendering mode.
+	FontName PdfObjectName // Font resource name set by Tf. Empty for fonts set by gs.
+	Font     *PdfFont      // Font, nil if none is set or it cannot be loaded.
+	Tm       Matrix        // Text matrix.
+	Tlm      Matrix        // Text line matrix.
+}
+
+// newTextState returns the initial text state.
+func newTextState() TextState {
+	return TextState{Th: 1, Tm: IdentityMatrix(), Tlm: IdentityMatrix()}
+}
+
+// PathSegmentType is the type of a segment of a path.
+type PathSegmentType int
+
+const (
+	PathMoveTo  PathSegmentType = iota // Starts a subpath at Points[0].
+	PathLineTo                         // Line to Points[0].
+	PathCurveTo                        // Cubic Bézier curve with control points Points[0] and Points[1] to Points[2].
+	PathClose                          // Line to the start of the subpath, which closes it.
+)
+
+// PathPoint is a point of a path.
+type PathPoint struct {
+	X float64
+	Y float64
+}
+
+// PathSegment is a segment of a path.
+type PathSegment struct {
+	Type   PathSegmentType
+	Points []PathPoint
+}
+
+// Path is a path built by path construction operators (8.5.2 "Path Construction Operators"). v and y
+// curves are stored as full cubic curves and rectangles (re) as closed subpaths of 4 lines.
+type Path struct {
+	Segments []PathSegment
+}
+
+// Empty returns true if `p` has no segments.
+func (p Path) Empty() bool {
+	return len(p.Segments) == 0
+}
+
+// CurrentPoint returns the current point of `p`. The bool return flag is false if `p` is empty.
+func (p Path) CurrentPoint() (PathPoint, bool) {
+	for i := len(p.Segments) - 1; i >= 0; i-- {
+		seg := p.Segments[i]
+		if seg.Type != PathClose {
+			return seg.Points[len(seg.Points)-1], true
+		}
+		// The current point of a closed subpath is its start.
+		for j := i - 1; j >= 0; j-- {
+			if p.Segments[j].Type == PathMoveTo {
+				return p.Segments[j].Points[0], true
+			}
+		}
+		return PathPoint{}, false
+	}
+	return PathPoint{}, false
+}
+
+// MoveTo starts a new subpath at (`x`, `y`).
+func (p *Path) MoveTo(x, y float64) {
+	p.Segments = append(p.Segments, PathSegment{PathMoveTo, []PathPoint{{x, y}}})
+}
+
+// LineTo appends a line to (`x`, `y`).
+func (p *Path) LineTo(x, y float64) {
+	p.Segments = append(p.Segments, PathSegment{PathLineTo, []PathPoint{{x, y}}})
+}
+
+// CurveTo appends a cubic Bézier curve with control points (`x1`, `y1`) and (`x2`, `y2`) to
+// (`x3`, `y3`).
+func (p *Path) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
+	p.Segments = append(p.Segments, PathSegment{PathCurveTo, []PathPoint{{x1, y1}, {x2, y2}, {x3, y3}}})
+}
+
+// Close closes the current subpath.
+func (p *Path) Close() {
+	if p.Empty() || p.Segments[len(p.Segments)-1].Type == PathClose {
+		return
+	}
+	p.Segments = append(p.Segments, PathSegment{Type: PathClose})
+}
+
+// Rect appends the rectangle with corner (`x`, `y`), width `w` and height `h` as a closed subpath.
+func (p *Path) Rect(x, y, w, h float64) {
+	p.MoveTo(x, y)
+	p.LineTo(x+w, y)
+	p.LineTo(x+w, y+h)
+	p.LineTo(x, y+h)
+	p.Close()
+}
+
+// Transform returns a copy of `p` with its points transformed by `m`.
+func (p Path) Transform(m Matrix) Path {
+	out := Path{Segments: make([]PathSegment, len(p.Segments))}
+	for i, seg := range p.Segments {
+		pts := make([]PathPoint, len(seg.Points))
+		for j, pt := range seg.Points {
+			pts[j].X, pts[j].Y = m.Transform(pt.X, pt.Y)
+		}
+		out.Segments[i] = PathSegment{seg.Type, pts}
+	}
+	return out
+}
+
+// ClipPath is a path in device space that clips painting (8.5.4 "Clipping Path Operators").
+type ClipPath struct {
+	Path    Path
+	EvenOdd bool // Clipping by the even-odd rule (W*) rather than the nonzero winding number rule (W).
+}
+
+// addClip intersects the current clipping path with `path` in device space.
+func (csp *ContentStreamProcessor) addClip(path Path, evenOdd bool) {
+	gs := &csp.graphicsState
+	// The clip list is copied, as it may be shared with saved states.
+	clip := make([]ClipPath, len(gs.Clip), len(gs.Clip)+1)
+	copy(clip, gs.Clip)
+	gs.Clip = append(clip, ClipPath{path, evenOdd})
+}
+
+// endPath ends the current path after a path painting operator, making it a clipping path if it was
+// preceded by W or W*.
+func (csp *ContentStreamProcessor) endPath() {
+	if csp.clipRule != "" {
+		gs := &csp.graphicsState
+		csp.addClip(gs.Path.Transform(gs.CTM), csp.clipRule == "W*")
+	}
+	csp.clipRule = ""
+	csp.graphicsState.Path = Path{}
+}
+
+// w, J, j, M, i: Set the line width, line cap style, line join style, miter limit or flatness.
+func (csp *ContentStreamProcessor) handleCommand_lineParam(op *ContentStreamOperation) error {
+	f, err := GetNumbersAsFloat(op.Params)
+	if err != nil || len(f) != 1 {
+		common.Log.Debug("Invalid %s command, skipping over", op.Operand)
+		return nil
+	}
+	csp.setLineParam(op.Operand, f[0])
+	return nil
+}
+
+// setLineParam sets the parameter set by operator or graphics state parameter dictionary key `key`
+// to `v`.
+func (csp *ContentStreamProcessor) setLineParam(key string, v float64) {
+	gs := &csp.graphicsState
+	switch key {
+	case "w", "LW":
+		gs.LineWidth = v
+	case "J", "LC":
+		gs.LineCap = int(v)
+	case "j", "LJ":
+		gs.LineJoin = int(v)
+	case "M", "ML":
+		gs.MiterLimit = v
+	case "i", "FL":
+		gs.Flatness = v
+	}
+}
+
+// d: Set the dash pattern.
+// dashArray dashPhase d
+func (csp *ContentStreamProcessor) handleCommand_d(op *ContentStreamOperation) error {
+	if len(op.Params) != 2 || !csp.setDash(op.Params[0], op.Params[1]) {
+		common.Log.Debug("Invalid d command, skipping over")
+	}
+	return nil
+}
+
+// setDash sets the dash pattern to dash array `arr` and dash phase `phase`. Returns false if they are
+// invalid.
+func (csp *ContentStreamProcessor) setDash(arr, phase PdfObject) bool {
+	a, ok := TraceToDirectObject(arr).(*PdfObjectArray)
+	if !ok {
+		return false
+	}
+	dash, err := a.ToFloat64Array()
+	if err != nil {
+		return false
+	}
+	f, err := GetNumbersAsFloat([]PdfObject{TraceToDirectObject(phase)})
+	if err != nil {
+		return false
+	}
+	csp.graphicsState.DashArray, csp.graphicsState.DashPhase = dash, f[0]
+	return true
+}
+
+// ri: Set the color rendering intent.
+func (csp *ContentStreamProcessor) handleCommand_ri(op *ContentStreamOperation) error {
+	if len(op.Params) != 1 {
+		common.Log.Debug("Invalid ri command, skipping over")
+		return nil
+	}
+	if name, ok := op.Params[0].(*PdfObjectName); ok {
+		csp.graphicsState.RenderingIntent = string(*name)
+	}
+	return nil
+}
+
+// gs: Set the parameters of a graphics state parameter dictionary (8.4.5 "Graphics State Parameter
+// Dictionaries").
+func (csp *ContentStreamProcessor) handleCommand_gs(op *ContentStreamOperation, resources *PdfPageResources) error {
+	if len(op.Params) != 1 {
+		common.Log.Debug("Invalid gs command, skipping over")
+		return nil
+	}
+	name, ok := op.Params[0].(*PdfObjectName)
+	if !ok || resources == nil {
+		return nil
+	}
+	obj, found := resources.GetExtGState(*name)
+	if !found {
+		common.Log.Debug("ExtGState %s not found", *name)
+		return nil
+	}
+	dict, ok := TraceToDirectObject(obj).(*PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("ExtGState %s not a dictionary (%T)", *name, obj)
+		return nil
+	}
+
+	gs := &csp.graphicsState
+	number := func(key PdfObjectName) (float64, bool) {
+		obj := TraceToDirectObject(dict.Get(key))
+		if obj == nil {
+			return 0, false
+		}
+		f, err := GetNumbersAsFloat([]PdfObject{obj})
+		if err != nil {
+			return 0, false
+		}
+		return f[0], true
+	}
+	boolean := func(key PdfObjectName) (bool, bool) {
+		b, ok := TraceToDirectObject(dict.Get(key)).(*PdfObjectBool)
+		if !ok {
+			return false, false
+		}
+		return bool(*b), true
+	}
+
+	for _, key := range []PdfObjectName{"LW", "LC", "LJ", "ML", "FL"} {
+		if v, ok := number(key); ok {
+			csp.setLineParam(string(key), v)
+		}
+	}
+	if arr, ok := TraceToDirectObject(dict.Get("D")).(*PdfObjectArray); ok && len(*arr) == 2 {
+		csp.setDash((*arr)[0], (*arr)[1])
+	}
+	if ri, ok := TraceToDirectObject(dict.Get("RI")).(*PdfObjectName); ok {
+		gs.RenderingIntent = string(*ri)
+	}
+	if arr, ok := TraceToDirectObject(dict.Get("Font")).(*PdfObjectArray); ok && len(*arr) == 2 {
+		if size, err := GetNumbersAsFloat([]PdfObject{TraceToDirectObject((*arr)[1])}); err == nil {
+			gs.Text.Font = csp.loadFont((*arr)[0])
+			gs.Text.FontName = ""
+			gs.Text.Tfs = size[0]
+		}
+	}
+	if v, ok := number("CA"); ok {
+		gs.StrokeAlpha = v
+	}
+	if v, ok := number("ca"); ok {
+		gs.FillAlpha = v
+	}
+	switch bm := TraceToDirectObject(dict.Get("BM")).(type) {
+	case *PdfObjectName:
+		gs.BlendMode = string(*bm)
+	case *PdfObjectArray:
+		// The first blend mode of the array is used.
+		if len(*bm) > 0 {
+			if name, ok := TraceToDirectObject((*bm)[0]).(*PdfObjectName); ok {
+				gs.BlendMode = string(*name)
+			}
+		}
+	}
+	if smask := dict.Get("SMask"); smask != nil {
+		if name, ok := TraceToDirectObject(smask).(*PdfObjectName); ok && *name == "None" {
+			gs.SoftMask = nil
+		} else {
+			gs.SoftMask = smask
+		}
+	}
+	if v, ok := boolean("AIS"); ok {
+		gs.AlphaIsShape = v
+	}
+	// OP sets the overprint of both stroking and nonstroking painting unless op is present.
+	if v, ok := boolean("OP"); ok {
+		gs.OverprintStroking, gs.OverprintNonStroking = v, v
+	}
+	if v, ok := boolean("op"); ok {
+		gs.OverprintNonStroking = v
+	}
+	if v, ok := number("OPM"); ok {
+		gs.OverprintMode = int(v)
+	}
+	return nil
+}
+
+// m, l, c, v, y, h, re: Path construction.
+func (csp *ContentStreamProcessor) handleCommand_path(op *ContentStreamOperation) error {
+	f, err := GetNumbersAsFloat(op.Params)
+	if err != nil {
+		common.Log.Debug("Invalid %s command, skipping over", op.Operand)
+		return nil
+	}
+	p := &csp.graphicsState.Path
+	switch {
+	case op.Operand == "m" && len(f) == 2:
+		p.MoveTo(f[0], f[1])
+	case op.Operand == "l" && len(f) == 2:
+		p.LineTo(f[0], f[1])
+	case op.Operand == "c" && len(f) == 6:
+		p.CurveTo(f[0], f[1], f[2], f[3], f[4], f[5])
+	case op.Operand == "v" && len(f) == 4:
+		// The current point is the first control point.
+		cur, ok := p.CurrentPoint()
+		if !ok {
+			cur = PathPoint{f[0], f[1]}
+		}
+		p.CurveTo(cur.X, cur.Y, f[0], f[1], f[2], f[3])
+	case op.Operand == "y" && len(f) == 4:
+		// The end point is the second control point.
+		p.CurveTo(f[0], f[1], f[2], f[3], f[2], f[3])
+	case op.Operand == "h" && len(f) == 0:
+		p.Close()
+	case op.Operand == "re" && len(f) == 4:
+		p.Rect(f[0], f[1], f[2], f[3])
+	default:
+		common.Log.Debug("Invalid %s command, skipping over", op.Operand)
+	}
+	return nil
+}
+
+// Tc, Tw, Tz, TL, Ts, Tr: Set a text state parameter.
+func (csp *ContentStreamProcessor) handleCommand_textParam(op *ContentStreamOperation) error {
+	f, err := GetNumbersAsFloat(op.Params)
+	if err != nil || len(f) != 1 {
+		common.Log.Debug("Invalid %s command, skipping over", op.Operand)
+		return nil
+	}
+	ts := &csp.graphicsState.Text
+	switch op.Operand {
+	case "Tc":
+		ts.Tc = f[0]
+	case "Tw":
+		ts.Tw = f[0]
+	case "Tz":
+		ts.Th = f[0] / 100
+	case "TL":
+		ts.Tl = f[0]
+	case "Ts":
+		ts.Ts = f[0]
+	case "Tr":
+		ts.Tr = int(f[0])
+	}
+	return nil
+}
+
+// Tf: Set the font and font size.
+// font size Tf
+func (csp *ContentStreamProcessor) handleCommand_Tf(op *ContentStreamOperation, resources *PdfPageResources) error {
+	if len(op.Params) != 2 {
+		common.Log.Debug("Invalid Tf command, skipping over")
+		return nil
+	}
+	name, ok := op.Params[0].(*PdfObjectName)
+	size, err := GetNumbersAsFloat(op.Params[1:])
+	if !ok || err != nil {
+		common.Log.Debug("Invalid Tf command, skipping over")
+		return nil
+	}
+	ts := &csp.graphicsState.Text
+	ts.FontName = *name
+	ts.Tfs = size[0]
+	ts.Font = nil
+	if resources != nil {
+		if fontObj, found := resources.GetFontByName(*name); found {
+			ts.Font = csp.loadFont(fontObj)
+		} else {
+			common.Log.Debug("Font %s not found in resources", *name)
+		}
+	}
+	return nil
+}
+
+// loadFont returns the font of font dictionary `fontObj`, loading it into the font cache if it has not
+// been loaded before. nil is returned if the font cannot be loaded.
+func (csp *ContentStreamProcessor) loadFont(fontObj PdfObject) *PdfFont {
+	if font, has := csp.fontCache[fontObj]; has {
+		return font
+	}
+	font, err := NewPdfFontFromPdfObject(fontObj)
+	if err != nil {
+		common.Log.Debug("Unable to load font: %v", err)
+		font = nil
+	}
+	csp.fontCache[fontObj] = font
+	return font
+}
+
+// Td, TD, Tm, T*: Set the text matrix and text line matrix.
+func (csp *ContentStreamProcessor) handleCommand_textPosition(op *ContentStreamOperation) error {
+	f, err := GetNumbersAsFloat(op.Params)
+	if err != nil {
+		common.Log.Debug("Invalid %s command, skipping over", op.Operand)
+		return nil
+	}
+	ts := &csp.graphicsState.Text
+	switch {
+	case op.Operand == "T*":
+		ts.nextLine(0, -ts.Tl)
+	case op.Operand == "Tm" && len(f) == 6:
+		ts.Tm = NewMatrix(f[0], f[1], f[2], f[3], f[4], f[5])
+		ts.Tlm = ts.Tm
+	case (op.Operand == "Td" || op.Operand == "TD") && len(f) == 2:
+		if op.Operand == "TD" {
+			ts.Tl = -f[1]
+		}
+		ts.nextLine(f[0], f[1])
+	default:
+		common.Log.Debug("Invalid %s command, skipping over", op.Operand)
+	}
+	return nil
+}
+
+// ', ": Move to the next line (and set the word and character spacing) before showing text. The text
+// matrix is advanced past the text after the operation has been handled.
+// string '
+// aw ac string "
+func (csp *ContentStreamProcessor) handleCommand_quote(op *ContentStreamOperation) error {
+	ts := &csp.graphicsState.Text
+	if op.Operand == "\"" && len(op.Params) == 3 {
+		if f, err := GetNumbersAsFloat(op.Params[:2]); err == nil {
+			ts.Tw, ts.Tc = f[0], f[1]
+		}
+	}
+	ts.nextLine(0, -ts.Tl)
+	return nil
+}
+
+// nextLine moves to the start of the next line, offset from the start of the current line by
+// (`tx`, `ty`).
+func (ts *TextState) nextLine(tx, ty float64) {
+	ts.Tlm = NewMatrix(1, 0, 0, 1, tx, ty).Mult(ts.Tlm)
+	ts.Tm = ts.Tlm
+}
+
+// translate moves the text position by (`tx`, `ty`) in text space.
+func (ts *TextState) translate(tx, ty float64) {
+	ts.Tm = NewMatrix(1, 0, 0, 1, tx, ty).Mult(ts.Tm)
+}
+
+// TextGlyph is a glyph shown by a text showing operator (9.4.4 "Text Space Details").
+type TextGlyph struct {
+	Code uint64 // Character code.
+	// Width is the horizontal displacement w0 of the glyph in unscaled text space units.
+	Width float64
+	// Matrix maps unscaled text space units with the glyph's origin at (0, 0) to user space. The
+	// origin of glyphs in vertical writing mode is their vertical origin.
+	Matrix Matrix
+	// Tm is the text matrix when the glyph is shown, End the text matrix after its displacement,
+	// before the character and word spacing that follow it.
+	Tm  Matrix
+	End Matrix
+}
+
+// Glyphs returns the glyphs of string `data` shown in text state `ts` and advances the text matrix
+// past them. Nothing is shown if the font of `ts` is nil.
+func (ts *TextState) Glyphs(data []byte) []TextGlyph {
+	if ts.Font == nil {
+		return nil
+	}
+	codes := ts.Font.CharcodeBytesToCharcodes(data)
+	glyphs := make([]TextGlyph, 0, len(codes))
+	// Word spacing is applied to single byte codes 32.
+	singleByte := len(codes) == len(data)
+	for _, code := range codes {
+		g := TextGlyph{Code: code, Tm: ts.Tm}
+		if metrics, ok := ts.Font.GetCharMetrics(code); ok {
+			g.Width = metrics.Wx / 1000
+		}
+		spacing := ts.Tc
+		if singleByte && code == 32 {
+			spacing += ts.Tw
+		}
+		if w1y, vx, vy, vertical := ts.Font.GetVerticalMetrics(code); vertical {
+			// The glyph's vertical origin is offset by (vx, vy) from its horizontal origin and the
+			// glyph extends down the column by its vertical displacement (9.7.4.3 "Glyph Metrics in
+			// CIDFonts").
+			g.Matrix = NewMatrix(ts.Tfs, 0, 0, ts.Tfs, -vx/1000*ts.Tfs, ts.Ts-vy/1000*ts.Tfs).Mult(ts.Tm)
+			ts.translate(0, w1y/1000*ts.Tfs)
+			g.End = ts.Tm
+			ts.translate(0, spacing)
+		} else {
+			g.Matrix = NewMatrix(ts.Tfs*ts.Th, 0, 0, ts.Tfs, 0, ts.Ts).Mult(ts.Tm)
+			ts.translate(g.Width*ts.Tfs*ts.Th, 0)
+			g.End = ts.Tm
+			ts.translate(spacing*ts.Th, 0)
+		}
+		glyphs = append(glyphs, g)
+	}
+	return glyphs
+}
+
+// Adjust moves the text position by the number `n` of a TJ array: `n` thousandths of text space units
+// against the writing direction.
+func (ts *TextState) Adjust(n float64) {
+	n = n / 1000 * ts.Tfs
+	if ts.Font != nil && ts.Font.IsVertical() {
+		ts.translate(0, -n)
+	} else {
+		ts.translate(-n*ts.Th, 0)
+	}
+}
+
+// advanceText advances the text matrix past the text shown by text showing operation `op` and adds
+// the outlines of its glyphs to the text clipping path if the text rendering mode clips.
+func (csp *ContentStreamProcessor) advanceText(op *ContentStreamOperation) {
+	if len(op.Params) == 0 {
+		return
+	}
+	switch op.Operand {
+	case "Tj", "'", "\"":
+		if s, ok := op.Params[len(op.Params)-1].(*PdfObjectString); ok {
+			csp.showText([]byte(*s))
+		}
+	case "TJ":
+		arr, ok := op.Params[0].(*PdfObjectArray)
+		if !ok {
+			return
+		}
+		for _, obj := range *arr {
+			switch v := obj.(type) {
+			case *PdfObjectString:
+				csp.showText([]byte(*v))
+			case *PdfObjectFloat, *PdfObjectInteger:
+				f, _ := GetNumbersAsFloat([]PdfObject{v})
+				csp.graphicsState.Text.Adjust(f[0])
+			}
+		}
+	}
+}
+
+// showText advances the text matrix past the glyphs of string `data`, adding their outlines to the
+// text clipping path if the text rendering mode clips.
+func (csp *ContentStreamProcessor) showText(data []byte) {
+	gs := &csp.graphicsState
+	glyphs := gs.Text.Glyphs(data)
+	if gs.Text.Tr < 4 || gs.Text.Tr > 7 || gs.Text.Font == nil {
+		return
+	}
+	if csp.textClip == nil {
+		csp.textClip = &Path{}
+	}
+	for _, g := range glyphs {
+		outline := csp.glyphOutline(gs.Text.Font, g.Code, g.Width)
+		if outline == nil {
+			continue
+		}
+		path := outlinePath(outline).Transform(g.Matrix.Mult(gs.CTM))
+		csp.textClip.Segments = append(csp.textClip.Segments, path.Segments...)
+	}
+}
+
+// endText ends the text object at ET, intersecting the clipping path with the glyphs shown with
+// clipping text rendering modes. Text objects in which such glyphs are shown without outlines clip
+// everything.
+func (csp *ContentStreamProcessor) endText() {
+	if csp.textClip == nil {
+		return
+	}
+	csp.addClip(*csp.textClip, false)
+	csp.textClip = nil
+}
+
+// GlyphOutlineFunc returns the outline of the glyph of character code `code` of `font`, with
+// horizontal displacement `width`, in unscaled text space units. It returns nil if the glyph has no
+// outline.
+type GlyphOutlineFunc func(font *PdfFont, code uint64, width float64) *fonts.GlyphOutline
+
+// SetGlyphOutlineFunc sets the function that returns the outlines of the glyphs shown with clipping
+// text rendering modes, which are added to the clipping path. By default the outlines of the
+// embedded font programs are used (PdfFont.GetGlyphOutline).
+func (csp *ContentStreamProcessor) SetGlyphOutlineFunc(f GlyphOutlineFunc) {
+	csp.glyphOutline = f
+}
+
+// defaultGlyphOutline is the GlyphOutlineFunc that returns the glyph outlines of embedded font
+// programs.
+func defaultGlyphOutline(font *PdfFont, code uint64, width float64) *fonts.GlyphOutline {
+	outline, ok := font.GetGlyphOutline(code)
+	if !ok {
+		return nil
+	}
+	return outline
+}
+
+// outlinePath returns glyph outline `outline` as a path. Quadratic curves are converted to cubic
+// curves.
+func outlinePath(outline *fonts.GlyphOutline) Path {
+	var p Path
+	for _, seg := range outline.Segments {
+		pts := seg.Points
+		switch seg.Op {
+		case fonts.OutlineMoveTo:
+			p.MoveTo(pts[0].X, pts[0].Y)
+		case fonts.OutlineLineTo:
+			p.LineTo(pts[0].X, pts[0].Y)
+		case fonts.OutlineQuadTo:
+			cur, ok := p.CurrentPoint()
+			if !ok {
+				cur = PathPoint{pts[0].X, pts[0].Y}
+			}
+			c, end := pts[0], pts[1]
+			p.CurveTo(cur.X+2*(c.X-cur.X)/3, cur.Y+2*(c.Y-cur.Y)/3,
+				end.X+2*(c.X-end.X)/3, end.Y+2*(c.Y-end.Y)/3, end.X, end.Y)
+		case fonts.OutlineCubeTo:
+			p.CurveTo(pts[0].X, pts[0].Y, pts[1].X, pts[1].Y, pts[2].X, pts[2].Y)
+		case fonts.OutlineClose:
+			p.Close()
+		}
+	}
+	return p
+}
+
+// EnableFormRecursion makes the processor paint form XObjects: the operations of the content stream
+// of a form painted by Do are processed after the Do operation, with the form's resources and the
+// graphics state in which it is painted (8.10.1 "Form XObjects"). The handlers are called for them as
+// for the operations of the stream being processed. Forms nested more than 16 deep and forms that
+// paint themselves are skipped.
+func (csp *ContentStreamProcessor) EnableFormRecursion() {
+	csp.recurseForms = true
+}
+
+// processForm processes the form XObject painted by Do operation `op` of a stream with resources
+// `resources`.
+func (csp *ContentStreamProcessor) processForm(op *ContentStreamOperation, resources *PdfPageResources) error {
+	if len(op.Params) != 1 || resources == nil {
+		return nil
+	}
+	name, ok := op.Params[0].(*PdfObjectName)
+	if !ok {
+		return nil
+	}
+	stream, xtype := resources.GetXObjectByName(*name)
+	if xtype != XObjectTypeForm {
+		return nil
+	}
+	if csp.formDepth >= maxFormDepth || csp.activeForms[stream] {
+		common.Log.Debug("Form %s nested too deeply or painting itself, skipping", *name)
+		return nil
+	}
+	form, err := NewXObjectFormFromStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to load form XObject %s: %v", *name, err)
+		return nil
+	}
+	data, err := DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to decode form XObject %s: %v", *name, err)
+		return nil
+	}
+	operations, err := NewContentStreamParser(string(data)).Parse()
+	if err != nil {
+		common.Log.Debug("Unable to parse form XObject %s: %v", *name, err)
+		return nil
+	}
+	formResources := form.Resources
+	if formResources == nil {
+		// Forms without resources use those of the stream that paints them (PDF 1.1 and earlier).
+		formResources = resources
+	}
+
+	// The form is painted as if enclosed by q and Q, with its matrix concatenated to the CTM and
+	// clipped to its bounding box.
+	saved := csp.graphicsState
+	gs := &csp.graphicsState
+	gs.Path = Path{}
+	if arr, ok := TraceToDirectObject(form.Matrix).(*PdfObjectArray); ok {
+		if f, err := arr.ToFloat64Array(); err == nil && len(f) == 6 {
+			gs.CTM = NewMatrix(f[0], f[1], f[2], f[3], f[4], f[5]).Mult(gs.CTM)
+		}
+	}
+	if arr, ok := TraceToDirectObject(form.BBox).(*PdfObjectArray); ok {
+		if bbox, err := NewPdfRectangle(*arr); err == nil {
+			var clip Path
+			clip.Rect(bbox.Llx, bbox.Lly, bbox.Urx-bbox.Llx, bbox.Ury-bbox.Lly)
+			csp.addClip(clip.Transform(gs.CTM), false)
+		}
+	}
+
+	sub := NewContentStreamProcessor(*operations)
+	sub.handlers = csp.handlers
+	gs.Form = stream
+	sub.SetInitialGraphicsState(*gs)
+	sub.fontCache = csp.fontCache
+	sub.glyphOutline = csp.glyphOutline
+	sub.recurseForms = true
+	sub.formDepth = csp.formDepth + 1
+	sub.activeForms = csp.activeForms
+
+	csp.activeForms[stream] = true
+	err = sub.Process(formResources)
+	delete(csp.activeForms, stream)
+	csp.graphicsState = saved
+	return err
+}
@@ -198,6 +198,12 @@ func (this *ContentStreamInlineImage) GetEncoder() (core.StreamEncoder, error) {
 	return newEncoderFromInlineImage(this)
 }
 
+// GetEncodedData returns the image data of the inline image as stored in the content stream, i.e.
+// encoded with its Filter.
+func (this *ContentStreamInlineImage) GetEncodedData() []byte {
+	return this.stream
+}
+
 // Is a mask ?
 // The image mask entry in the image dictionary specifies that the image data shall be used as a stencil
 // mask for painting in the current color. The mask data is 1bpc, grayscale.
@@ -13,14 +13,89 @@ import (
 	. "github.com/unidoc/unidoc/pdf/model"
 )
 
-// Basic graphics state implementation.
-// Initially only implementing and tracking a portion of the information specified.  Easy to add more.
+// GraphicsState is the graphics state of a content stream (8.4 "Graphics State"), as maintained by
+// ContentStreamProcessor.
 type GraphicsState struct {
 	ColorspaceStroking    PdfColorspace
 	ColorspaceNonStroking PdfColorspace
 	ColorStroking         PdfColor
 	ColorNonStroking      PdfColor
 	CTM                   Matrix
+
+	// ColorComponentsStroking and ColorComponentsNonStroking are the components of the colors as
+	// given by the operands of the color operators, without the name of Pattern colors. Colors of
+	// Separation and DeviceN colorspaces are converted to their alternate spaces, so their tints are
+	// only given by their components. After CS and cs they are the components of the initial colors of
+	// Separation, DeviceN and Indexed colorspaces and nil for other colorspaces.
+	ColorComponentsStroking    []float64
+	ColorComponentsNonStroking []float64
+
+	// StreamMatrix maps the default coordinate space of the content stream being processed, the page's
+	// or a form's, to device space. It is the CTM at the start of the stream. Patterns are mapped to
+	// this space by their pattern matrices (8.7.2 "General Properties of Patterns").
+	StreamMatrix Matrix
+
+	// Line parameters set by the w, J, j, M and d operators or graphics state parameter dictionaries.
+	LineWidth  float64
+	LineCap    int
+	LineJoin   int
+	MiterLimit float64
+	DashArray  []float64
+	DashPhase  float64
+
+	RenderingIntent string  // ri operator or RI entry.
+	Flatness        float64 // i operator or FL entry.
+
+	// Parameters set only by graphics state parameter dictionaries (gs).
+	StrokeAlpha          float64   // CA
+	FillAlpha            float64   // ca
+	BlendMode            string    // BM
+	SoftMask             PdfObject // SMask: a soft-mask dictionary, or nil for None.
+	AlphaIsShape         bool      // AIS
+	OverprintStroking    bool      // OP
+	OverprintNonStroking bool      // op
+	OverprintMode        int       // OPM
+
+	// Text holds the text state parameters and, inside text objects, the text matrices.
+	Text TextState
+
+	// Clip holds the clipping paths in device space whose intersection is the current clipping path.
+	// There is no clipping if it is empty. The glyphs shown in a text object with text rendering modes
+	// 4 to 7 are added as one clipping path at its end (ET).
+	Clip []ClipPath
+
+	// Path is the current path in user space, built by the path construction operators. It is cleared
+	// after the path painting operator that ends it has been handled and is not saved by q.
+	Path Path
+
+	// MarkedContent holds the tags of the marked-content sequences enclosing the operation, outermost
+	// first, including the sequence begun by a BMC or BDC operation and the one ended by an EMC
+	// operation. Like Path, it is not saved by q.
+	MarkedContent []PdfObjectName
+
+	// Form is the form XObject whose content stream is being processed, nil for the stream passed to
+	// the processor unless it is set in the initial graphics state.
+	Form *PdfObjectStream
+}
+
+// NewGraphicsState returns the initial graphics state of a page, with the CTM mapping default user
+// space to itself (8.4.1 Table 52).
+func NewGraphicsState() GraphicsState {
+	return GraphicsState{
+		ColorspaceStroking:    NewPdfColorspaceDeviceGray(),
+		ColorspaceNonStroking: NewPdfColorspaceDeviceGray(),
+		ColorStroking:         NewPdfColorDeviceGray(0),
+		ColorNonStroking:      NewPdfColorDeviceGray(0),
+		CTM:                   IdentityMatrix(),
+		LineWidth:             1,
+		MiterLimit:            10,
+		RenderingIntent:       "RelativeColorimetric",
+		Flatness:              1,
+		StrokeAlpha:           1,
+		FillAlpha:             1,
+		BlendMode:             "Normal",
+		Text:                  newTextState(),
+	}
 }
 
 type Orientation int
@@ -64,6 +139,30 @@ type ContentStreamProcessor struct {
 
 	handlers     []HandlerEntry
 	currentIndex int
+
+	initialState *GraphicsState
+
+	// clipRule is the operator (W or W*) that makes the current path a clipping path once it is
+	// painted, or "" if there is none.
+	clipRule string
+	// fontCache holds the fonts set by Tf and graphics state parameter dictionaries, by font object.
+	fontCache map[PdfObject]*PdfFont
+
+	// Form XObjects are processed by nested processors if recurseForms is true.
+	recurseForms bool
+	// formDepth is the nesting depth of the stream processed: 0 for the outermost stream.
+	formDepth int
+	// activeForms holds the form XObjects being processed, to detect forms that paint themselves.
+	activeForms map[*PdfObjectStream]bool
+	// markedBase is the number of marked-content sequences begun outside the stream, which are not
+	// ended by its EMC operations.
+	markedBase int
+
+	// glyphOutline returns the outlines of the glyphs shown with clipping text rendering modes.
+	glyphOutline GlyphOutlineFunc
+	// textClip accumulates the outlines of the glyphs shown with clipping text rendering modes in
+	// device space. It is nil if no such glyphs have been shown in the current text object.
+	textClip *Path
 }
 
 type HandlerFunc func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error
@@ -101,10 +200,19 @@ func NewContentStreamProcessor(ops []*ContentStreamOperation) *ContentStreamProc
 	csp.handlers = []HandlerEntry{}
 	csp.currentIndex = 0
 	csp.operations = ops
+	csp.fontCache = map[PdfObject]*PdfFont{}
+	csp.activeForms = map[*PdfObjectStream]bool{}
+	csp.glyphOutline = defaultGlyphOutline
 
 	return &csp
 }
 
+// SetInitialGraphicsState sets the graphics state at the start of processing, e.g. the state in which a
+// form XObject is painted. By default processing starts with the initial graphics state of a page.
+func (csp *ContentStreamProcessor) SetInitialGraphicsState(gs GraphicsState) {
+	csp.initialState = &gs
+}
+
 func (csp *ContentStreamProcessor) AddHandler(condition HandlerConditionEnum, operand string, handler HandlerFunc) {
 	entry := HandlerEntry{}
 	entry.Condition = condition
@@ -126,9 +234,11 @@ func (csp *ContentStreamProcessor) getColorspace(name string, resources *PdfPage
 	}
 
 	// Next check the colorspace dictionary.
-	cs, has := resources.ColorSpace.Colorspaces[name]
-	if has {
-		return cs, nil
+	if resources != nil && resources.ColorSpace != nil {
+		cs, has := resources.ColorSpace.Colorspaces[name]
+		if has {
+			return cs, nil
+		}
 	}
 
 	// Lastly check other potential colormaps.
@@ -216,11 +326,13 @@ func (csp *ContentStreamProcessor) getInitialColor(cs PdfColorspace) (PdfColor,
 // Process the entire operations.
 func (this *ContentStreamProcessor) Process(resources *PdfPageResources) error {
 	// Initialize graphics state
-	this.graphicsState.ColorspaceStroking = NewPdfColorspaceDeviceGray()
-	this.graphicsState.ColorspaceNonStroking = NewPdfColorspaceDeviceGray()
-	this.graphicsState.ColorStroking = NewPdfColorDeviceGray(0)
-	this.graphicsState.ColorNonStroking = NewPdfColorDeviceGray(0)
-	this.graphicsState.CTM = IdentityMatrix()
+	this.graphicsState = NewGraphicsState()
+	if this.initialState != nil {
+		this.graphicsState = *this.initialState
+	}
+	this.graphicsState.StreamMatrix = this.graphicsState.CTM
+	this.markedBase = len(this.graphicsState.MarkedContent)
+	this.textClip = nil
 
 	for _, op := range this.operations {
 		var err error
@@ -230,7 +342,57 @@ func (this *ContentStreamProcessor) Process(resources *PdfPageResources) error {
 		case "q":
 			this.graphicsStack.Push(this.graphicsState)
 		case "Q":
+			if len(this.graphicsStack) == 0 {
+				common.Log.Debug("Q operand without q, skipping over")
+				break
+			}
+			path, marked := this.graphicsState.Path, this.graphicsState.MarkedContent
 			this.graphicsState = this.graphicsStack.Pop()
+			this.graphicsState.Path, this.graphicsState.MarkedContent = path, marked
+
+		// Graphics state (Table 57 p. 127)
+		case "w", "J", "j", "M", "i":
+			err = this.handleCommand_lineParam(op)
+		case "d":
+			err = this.handleCommand_d(op)
+		case "ri":
+			err = this.handleCommand_ri(op)
+		case "gs":
+			err = this.handleCommand_gs(op, resources)
+
+		// Path construction and clipping (Tables 59 and 61)
+		case "m", "l", "c", "v", "y", "h", "re":
+			err = this.handleCommand_path(op)
+		case "s", "b", "b*":
+			this.graphicsState.Path.Close()
+		case "W", "W*":
+			this.clipRule = op.Operand
+
+		// Text objects, state and positioning (Tables 105, 107 and 108)
+		case "BT":
+			this.graphicsState.Text.Tm = IdentityMatrix()
+			this.graphicsState.Text.Tlm = IdentityMatrix()
+			this.textClip = nil
+		case "Tc", "Tw", "Tz", "TL", "Ts", "Tr":
+			err = this.handleCommand_textParam(op)
+		case "Tf":
+			err = this.handleCommand_Tf(op, resources)
+		case "Td", "TD", "Tm", "T*":
+			err = this.handleCommand_textPosition(op)
+		case "'", "\"":
+			err = this.handleCommand_quote(op)
+
+		// Marked content (Table 320)
+		case "BMC", "BDC":
+			var tag PdfObjectName
+			if len(op.Params) > 0 {
+				if name, ok := op.Params[0].(*PdfObjectName); ok {
+					tag = *name
+				}
+			}
+			// The slice is copied, as it can be shared with the states passed to handlers.
+			marked := this.graphicsState.MarkedContent
+			this.graphicsState.MarkedContent = append(marked[:len(marked):len(marked)], tag)
 
 		// Color operations (Table 74 p. 179)
 		case "CS":
@@ -279,6 +441,30 @@ func (this *ContentStreamProcessor) Process(resources *PdfPageResources) error {
 				return err
 			}
 		}
+
+		// Changes of the state that follow the operation: painted paths are cleared, shown text
+		// advances the text matrix and clips at the end of the text object, marked content is ended
+		// and forms are painted.
+		switch op.Operand {
+		case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*", "n":
+			this.endPath()
+		case "Tj", "'", "\"", "TJ":
+			this.advanceText(op)
+		case "ET":
+			this.endText()
+		case "EMC":
+			if marked := this.graphicsState.MarkedContent; len(marked) > this.markedBase {
+				this.graphicsState.MarkedContent = marked[:len(marked)-1]
+			} else {
+				common.Log.Debug("EMC operand without BMC or BDC in the stream, skipping over")
+			}
+		case "Do":
+			if this.recurseForms {
+				if err := this.processForm(op, resources); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	return nil
@@ -313,6 +499,7 @@ func (csp *ContentStreamProcessor) handleCommand_CS(op *ContentStreamOperation,
 		return err
 	}
 	csp.graphicsState.ColorStroking = color
+	csp.graphicsState.ColorComponentsStroking = initialColorComponents(cs)
 
 	return nil
 }
@@ -346,6 +533,7 @@ func (csp *ContentStreamProcessor) handleCommand_cs(op *ContentStreamOperation,
 		return err
 	}
 	csp.graphicsState.ColorNonStroking = color
+	csp.graphicsState.ColorComponentsNonStroking = initialColorComponents(cs)
 
 	return nil
 }
@@ -368,9 +556,40 @@ func (this *ContentStreamProcessor) handleCommand_SC(op *ContentStreamOperation,
 	}
 
 	this.graphicsState.ColorStroking = color
+	this.graphicsState.ColorComponentsStroking = colorOperands(op.Params)
+	return nil
+}
+
+// initialColorComponents returns the components of the initial color of colorspace `cs` for
+// Separation, DeviceN and Indexed colorspaces, nil for other colorspaces (8.6.8 Table 74).
+func initialColorComponents(cs PdfColorspace) []float64 {
+	switch cs.(type) {
+	case *PdfColorspaceSpecialSeparation, *PdfColorspaceDeviceN:
+		comps := make([]float64, cs.GetNumComponents())
+		for i := range comps {
+			comps[i] = 1
+		}
+		return comps
+	case *PdfColorspaceSpecialIndexed:
+		return []float64{0}
+	}
 	return nil
 }
 
+// colorOperands returns the color components of the operands `params` of a color operator, which are
+// followed by the name of the pattern of Pattern colors.
+func colorOperands(params []PdfObject) []float64 {
+	var comps []float64
+	for _, obj := range params {
+		f, err := GetNumbersAsFloat([]PdfObject{obj})
+		if err != nil {
+			break
+		}
+		comps = append(comps, f[0])
+	}
+	return comps
+}
+
 func isPatternCS(cs PdfColorspace) bool {
 	_, isPattern := cs.(*PdfColorspaceSpecialPattern)
 	return isPattern
@@ -394,6 +613,7 @@ func (this *ContentStreamProcessor) handleCommand_SCN(op *ContentStreamOperation
 	}
 
 	this.graphicsState.ColorStroking = color
+	this.graphicsState.ColorComponentsStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -416,6 +636,7 @@ func (this *ContentStreamProcessor) handleCommand_sc(op *ContentStreamOperation,
 	}
 
 	this.graphicsState.ColorNonStroking = color
+	this.graphicsState.ColorComponentsNonStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -439,6 +660,7 @@ func (this *ContentStreamProcessor) handleCommand_scn(op *ContentStreamOperation
 	}
 
 	this.graphicsState.ColorNonStroking = color
+	this.graphicsState.ColorComponentsNonStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -460,6 +682,7 @@ func (this *ContentStreamProcessor) handleCommand_G(op *ContentStreamOperation,
 
 	this.graphicsState.ColorspaceStroking = cs
 	this.graphicsState.ColorStroking = color
+	this.graphicsState.ColorComponentsStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -481,6 +704,7 @@ func (this *ContentStreamProcessor) handleCommand_g(op *ContentStreamOperation,
 
 	this.graphicsState.ColorspaceNonStroking = cs
 	this.graphicsState.ColorNonStroking = color
+	this.graphicsState.ColorComponentsNonStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -502,6 +726,7 @@ func (this *ContentStreamProcessor) handleCommand_RG(op *ContentStreamOperation,
 
 	this.graphicsState.ColorspaceStroking = cs
 	this.graphicsState.ColorStroking = color
+	this.graphicsState.ColorComponentsStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -522,6 +747,7 @@ func (this *ContentStreamProcessor) handleCommand_rg(op *ContentStreamOperation,
 
 	this.graphicsState.ColorspaceNonStroking = cs
 	this.graphicsState.ColorNonStroking = color
+	this.graphicsState.ColorComponentsNonStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -543,6 +769,7 @@ func (this *ContentStreamProcessor) handleCommand_K(op *ContentStreamOperation,
 
 	this.graphicsState.ColorspaceStroking = cs
 	this.graphicsState.ColorStroking = color
+	this.graphicsState.ColorComponentsStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -563,6 +790,7 @@ func (this *ContentStreamProcessor) handleCommand_k(op *ContentStreamOperation,
 
 	this.graphicsState.ColorspaceNonStroking = cs
 	this.graphicsState.ColorNonStroking = color
+	this.graphicsState.ColorComponentsNonStroking = colorOperands(op.Params)
 
 	return nil
 }
@@ -580,7 +808,7 @@ func (this *ContentStreamProcessor) handleCommand_cm(op *ContentStreamOperation,
 		return err
 	}
 	m := NewMatrix(f[0], f[1], f[2], f[3], f[4], f[5])
-	this.graphicsState.CTM = this.graphicsState.CTM.mult(m)
+	this.graphicsState.CTM = m.Mult(this.graphicsState.CTM)
 
 	return nil
 }
@@ -606,9 +834,9 @@ func NewMatrix(a, b, c, d, tx, ty float64) Matrix {
 	}
 }
 
-// mult returns a × b
-// a and b need to be created by newMatrix or this function. i.e. They must be affine transforms
-func (a Matrix) mult(b Matrix) Matrix {
+// Mult returns a × b, the transform that applies a and then b.
+// a and b need to be created by NewMatrix or this function. i.e. They must be affine transforms
+func (a Matrix) Mult(b Matrix) Matrix {
 	return Matrix{
 		a[0]*b[0] + a[1]*b[3], a[0]*b[1] + a[1]*b[4], 0,
 		a[3]*b[0] + a[4]*b[3], a[3]*b[1] + a[4]*b[4], 0,
@@ -616,10 +844,10 @@ func (a Matrix) mult(b Matrix) Matrix {
 	}
 }
 
-// transform returns coordinates x, y transformed by m
+// Transform returns coordinates x, y transformed by m
 func (m Matrix) Transform(x, y float64) (float64, float64) {
-	xp := x*m[0] + y*m[1] + m[6]
-	yp := x*m[3] + y*m[4] + m[7]
+	xp := x*m[0] + y*m[3] + m[6]
+	yp := x*m[1] + y*m[4] + m[7]
 	return xp, yp
 }
 
@@ -0,0 +1,303 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package contentstream
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/unidoc/unidoc/pdf/core"
+	. "github.com/unidoc/unidoc/pdf/model"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+)
+
+// processContents processes content stream `contents` with resources `resources`, calling `handler`
+// for each operation.
+func processContents(t *testing.T, contents string, resources *PdfPageResources, recurseForms bool,
+	handler HandlerFunc) {
+	operations, err := NewContentStreamParser(contents).Parse()
+	if err != nil {
+		t.Fatalf("Error parsing contents: %v", err)
+	}
+	processor := NewContentStreamProcessor(*operations)
+	if recurseForms {
+		processor.EnableFormRecursion()
+	}
+	processor.AddHandler(HandlerConditionEnumAllOperands, "", handler)
+	if err := processor.Process(resources); err != nil {
+		t.Fatalf("Error processing contents: %v", err)
+	}
+}
+
+func matricesEqual(a, b Matrix) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-6 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestProcessorGraphicsState(t *testing.T) {
+	resources := NewPdfPageResources()
+	gsDict := MakeDict()
+	gsDict.Set("CA", MakeFloat(0.5))
+	gsDict.Set("ca", MakeFloat(0.25))
+	gsDict.Set("BM", MakeArray(MakeName("Multiply"), MakeName("Normal")))
+	op := PdfObjectBool(true)
+	gsDict.Set("OP", &op)
+	gsDict.Set("LW", MakeInteger(3))
+	resources.AddExtGState("GS1", gsDict)
+	fontDict := MakeDict()
+	fontDict.Set("Type", MakeName("Font"))
+	fontDict.Set("Subtype", MakeName("Type1"))
+	fontDict.Set("BaseFont", MakeName("Helvetica"))
+	resources.SetFontByName("F1", fontDict)
+
+	contents := `2 w 1 J 1 j 5 M [3 1] 2 d q 4 w Q
+q /GS1 gs 10 10 m 20 20 l 30 30 40 40 50 50 c h 0 0 10 10 re W n
+2 0 0 2 5 5 cm 0 0 1 1 re W* n
+BT /F1 12 Tf 2 Tc 150 Tz 14 TL 10 20 Td (AB) Tj T* ET Q Q`
+
+	var states = map[string]GraphicsState{}
+	processContents(t, contents, resources, false,
+		func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error {
+			key := op.Operand
+			if _, has := states[key]; has {
+				key += "2"
+			}
+			states[key] = gs
+			return nil
+		})
+
+	gs := states["Q"]
+	if gs.LineWidth != 2 || gs.LineCap != 1 || gs.LineJoin != 1 || gs.MiterLimit != 5 ||
+		len(gs.DashArray) != 2 || gs.DashPhase != 2 {
+		t.Errorf("Invalid line parameters after Q: %+v", gs)
+	}
+	gs = states["gs"]
+	if gs.StrokeAlpha != 0.5 || gs.FillAlpha != 0.25 || gs.BlendMode != "Multiply" || gs.LineWidth != 3 ||
+		!gs.OverprintStroking || !gs.OverprintNonStroking {
+		t.Errorf("Invalid ExtGState parameters: %+v", gs)
+	}
+
+	// The path is available to the handler of the painting operator and is then cleared.
+	gs = states["n"]
+	if len(gs.Path.Segments) != 9 || gs.Path.Segments[2].Type != PathCurveTo ||
+		gs.Path.Segments[3].Type != PathClose || len(gs.Clip) != 0 {
+		t.Errorf("Invalid path at n: %+v %+v", gs.Path, gs.Clip)
+	}
+	gs = states["cm"]
+	if !gs.Path.Empty() || len(gs.Clip) != 1 || gs.Clip[0].EvenOdd {
+		t.Errorf("Invalid clip after n: %+v %+v", gs.Path, gs.Clip)
+	}
+	// The second clipping path is in device space.
+	gs = states["BT"]
+	if len(gs.Clip) != 2 || !gs.Clip[1].EvenOdd {
+		t.Fatalf("Invalid clip: %+v", gs.Clip)
+	}
+	if pt := gs.Clip[1].Path.Segments[2].Points[0]; pt.X != 7 || pt.Y != 7 {
+		t.Errorf("Invalid clip point %v", pt)
+	}
+
+	// Text state.
+	gs = states["Tj"]
+	ts := gs.Text
+	if ts.Font == nil || ts.FontName != "F1" || ts.Tfs != 12 || ts.Tc != 2 || ts.Th != 1.5 || ts.Tl != 14 {
+		t.Errorf("Invalid text state: %+v", ts)
+	}
+	if !matricesEqual(ts.Tm, NewMatrix(1, 0, 0, 1, 10, 20)) {
+		t.Errorf("Invalid Tm at Tj: %v", ts.Tm)
+	}
+	// The text matrix is advanced by the widths of A and B (667/1000 em) and character spacing.
+	gs = states["T*"]
+	if !matricesEqual(gs.Text.Tm, NewMatrix(1, 0, 0, 1, 10, 6)) {
+		t.Errorf("Invalid Tm at T*: %v", gs.Text.Tm)
+	}
+	gs = states["ET"]
+	if !matricesEqual(gs.Text.Tlm, NewMatrix(1, 0, 0, 1, 10, 6)) {
+		t.Errorf("Invalid Tlm at ET: %v", gs.Text.Tlm)
+	}
+
+	// Everything is restored by the last Q and the unmatched Q is skipped.
+	gs = states["Q2"]
+	if len(gs.Clip) != 0 || gs.LineWidth != 2 || gs.FillAlpha != 1 || !matricesEqual(gs.CTM, IdentityMatrix()) {
+		t.Errorf("Invalid state after Q: %+v", gs)
+	}
+}
+
+func TestProcessorTextAdvance(t *testing.T) {
+	resources := NewPdfPageResources()
+	fontDict := MakeDict()
+	fontDict.Set("Type", MakeName("Font"))
+	fontDict.Set("Subtype", MakeName("Type1"))
+	fontDict.Set("BaseFont", MakeName("Helvetica"))
+	resources.SetFontByName("F1", fontDict)
+
+	var tms []Matrix
+	processContents(t, "BT /F1 10 Tf 5 Tw [(A) -500 ( )] TJ (A) Tj ET", resources, false,
+		func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error {
+			if op.Operand == "Tj" || op.Operand == "ET" {
+				tms = append(tms, gs.Text.Tm)
+			}
+			return nil
+		})
+	// A is 6.67 wide, the adjustment moves by 5 and the space is 2.78 wide plus word spacing 5.
+	expected := []float64{6.67 + 5 + 2.78 + 5, 6.67 + 5 + 2.78 + 5 + 6.67}
+	for i, tm := range tms {
+		if math.Abs(tm[6]-expected[i]) > 1e-6 {
+			t.Errorf("Text position %d: %v != %v", i, tm[6], expected[i])
+		}
+	}
+}
+
+func TestProcessorFormRecursion(t *testing.T) {
+	form, err := MakeStream([]byte("1 0 0 rg 0 0 5 5 re f /Self Do"), nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	form.Set("Type", MakeName("XObject"))
+	form.Set("Subtype", MakeName("Form"))
+	form.Set("BBox", MakeArrayFromFloats([]float64{0, 0, 10, 10}))
+	form.Set("Matrix", MakeArrayFromFloats([]float64{1, 0, 0, 1, 100, 0}))
+	formResources := MakeDict()
+	formResources.Set("XObject", MakeDict())
+	form.Set("Resources", formResources)
+	// The form paints itself, which is skipped.
+	formResources.Get("XObject").(*PdfObjectDictionary).Set("Self", form)
+
+	resources := NewPdfPageResources()
+	resources.SetXObjectByName("Fm1", form)
+
+	var fills []GraphicsState
+	var ops []string
+	processContents(t, "2 0 0 2 0 0 cm /Fm1 Do 0 0 1 1 re f", resources, true,
+		func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error {
+			ops = append(ops, op.Operand)
+			if op.Operand == "f" {
+				fills = append(fills, gs)
+			}
+			return nil
+		})
+	if len(ops) != 8 {
+		t.Fatalf("Invalid operations %v", ops)
+	}
+	if len(fills) != 2 {
+		t.Fatalf("Invalid fills %d", len(fills))
+	}
+	if !matricesEqual(fills[0].CTM, NewMatrix(2, 0, 0, 2, 200, 0)) || len(fills[0].Clip) != 1 {
+		t.Errorf("Invalid state in form: %v %v", fills[0].CTM, fills[0].Clip)
+	}
+	if _, ok := fills[0].ColorNonStroking.(*PdfColorDeviceRGB); !ok {
+		t.Errorf("Invalid color in form: %v", fills[0].ColorNonStroking)
+	}
+	if !matricesEqual(fills[1].CTM, NewMatrix(2, 0, 0, 2, 0, 0)) || len(fills[1].Clip) != 0 {
+		t.Errorf("Invalid state after form: %v %v", fills[1].CTM, fills[1].Clip)
+	}
+	if _, ok := fills[1].ColorNonStroking.(*PdfColorDeviceGray); !ok {
+		t.Errorf("Invalid color after form: %v", fills[1].ColorNonStroking)
+	}
+}
+
+func TestProcessorTextClip(t *testing.T) {
+	resources := NewPdfPageResources()
+	fontDict := MakeDict()
+	fontDict.Set("Type", MakeName("Font"))
+	fontDict.Set("Subtype", MakeName("Type1"))
+	fontDict.Set("BaseFont", MakeName("Helvetica"))
+	resources.SetFontByName("F1", fontDict)
+
+	operations, err := NewContentStreamParser("BT /F1 10 Tf 7 Tr 5 5 Td (AA) Tj 0 Tr (A) Tj ET n").Parse()
+	if err != nil {
+		t.Fatalf("Error parsing contents: %v", err)
+	}
+	processor := NewContentStreamProcessor(*operations)
+	// Each glyph is a unit square.
+	var codes []uint64
+	processor.SetGlyphOutlineFunc(func(font *PdfFont, code uint64, width float64) *fonts.GlyphOutline {
+		codes = append(codes, code)
+		outline := &fonts.GlyphOutline{}
+		outline.MoveTo(0, 0)
+		outline.LineTo(1, 0)
+		outline.QuadTo(1, 1, 0, 1)
+		outline.Close()
+		return outline
+	})
+	var clips [][]ClipPath
+	processor.AddHandler(HandlerConditionEnumAllOperands, "",
+		func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error {
+			if op.Operand == "ET" || op.Operand == "n" {
+				clips = append(clips, gs.Clip)
+			}
+			return nil
+		})
+	if err := processor.Process(resources); err != nil {
+		t.Fatalf("Error processing contents: %v", err)
+	}
+
+	// Only the glyphs shown with a clipping mode clip, from the end of the text object.
+	if len(codes) != 2 || len(clips) != 2 || len(clips[0]) != 0 || len(clips[1]) != 1 {
+		t.Fatalf("Invalid text clip: %v %v", codes, clips)
+	}
+	segs := clips[1][0].Path.Segments
+	if len(segs) != 8 || segs[2].Type != PathCurveTo {
+		t.Fatalf("Invalid text clip path: %+v", segs)
+	}
+	// The second A is 6.67 to the right of the first, which is scaled by the font size.
+	if pt := segs[5].Points[0]; math.Abs(pt.X-(5+6.67+10)) > 1e-6 || pt.Y != 5 {
+		t.Errorf("Invalid text clip point %v", pt)
+	}
+}
+
+func TestProcessorStreamState(t *testing.T) {
+	form, err := MakeStream([]byte("/Sep cs 0.5 scn /Tag BMC EMC EMC /Tag2 BMC 0 0 1 1 re f"), nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	form.Set("Type", MakeName("XObject"))
+	form.Set("Subtype", MakeName("Form"))
+	form.Set("BBox", MakeArrayFromFloats([]float64{0, 0, 10, 10}))
+	form.Set("Matrix", MakeArrayFromFloats([]float64{1, 0, 0, 1, 10, 0}))
+
+	resources := NewPdfPageResources()
+	resources.SetXObjectByName("Fm1", form)
+	sep := NewPdfColorspaceSpecialSeparation()
+	sep.ColorantName = MakeName("Spot")
+	sep.AlternateSpace = NewPdfColorspaceDeviceGray()
+	fn := &PdfFunctionType2{Domain: []float64{0, 1}, C0: []float64{1}, C1: []float64{0}, N: 1}
+	sep.TintTransform = fn
+	resources.ColorSpace = NewPdfPageResourcesColorspaces()
+	resources.ColorSpace.Set("Sep", sep)
+
+	var fills []GraphicsState
+	processContents(t, "2 0 0 2 0 0 cm /Outer BMC /Fm1 Do 0 0 1 1 re f EMC", resources, true,
+		func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error {
+			if op.Operand == "f" {
+				fills = append(fills, gs)
+			}
+			return nil
+		})
+	if len(fills) != 2 {
+		t.Fatalf("Invalid fills %d", len(fills))
+	}
+
+	// The form's EMC operations do not end the marked content that encloses it.
+	gs := fills[0]
+	if gs.Form != form || !matricesEqual(gs.StreamMatrix, NewMatrix(2, 0, 0, 2, 20, 0)) ||
+		len(gs.MarkedContent) != 2 || gs.MarkedContent[0] != "Outer" || gs.MarkedContent[1] != "Tag2" {
+		t.Errorf("Invalid form state: %v %v %v", gs.Form, gs.StreamMatrix, gs.MarkedContent)
+	}
+	// The components give the tint of the Separation color, which is converted to its alternate space.
+	if len(gs.ColorComponentsNonStroking) != 1 || gs.ColorComponentsNonStroking[0] != 0.5 {
+		t.Errorf("Invalid color components: %v", gs.ColorComponentsNonStroking)
+	}
+	gs = fills[1]
+	if gs.Form != nil || !matricesEqual(gs.StreamMatrix, IdentityMatrix()) || len(gs.MarkedContent) != 1 ||
+		gs.ColorComponentsNonStroking != nil {
+		t.Errorf("Invalid state after form: %v %v %v %v", gs.Form, gs.StreamMatrix, gs.MarkedContent,
+			gs.ColorComponentsNonStroking)
+	}
+}
@@ -0,0 +1,362 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package contentstream
+
+import (
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	. "github.com/unidoc/unidoc/pdf/core"
+	. "github.com/unidoc/unidoc/pdf/model"
+)
+
+// RewriteFunc is called for each operation `op` of a content stream being rewritten, with the
+// graphics state `gs` and resources `resources` with which it is performed. As for the handlers of
+// ContentStreamProcessor, `gs` includes the changes made by `op` itself. It returns the operations
+// that take the place of `op` in the rewritten stream:
+//   - []*ContentStreamOperation{op} keeps the operation,
+//   - nil drops it,
+//   - other operations replace it, and operations before or after `op` are inserted there.
+type RewriteFunc func(op *ContentStreamOperation, gs GraphicsState,
+	resources *PdfPageResources) ([]*ContentStreamOperation, error)
+
+// ContentStreamRewriter rewrites content streams by passing each operation through a chain of
+// RewriteFuncs, in the order they were added. The operations returned by one function are passed to
+// the next one with the graphics state of the original operation.
+type ContentStreamRewriter struct {
+	funcs []RewriteFunc
+
+	// visited holds the form XObjects that have been rewritten, or is nil if forms are not rewritten.
+	visited map[*PdfObjectStream]bool
+}
+
+// NewContentStreamRewriter returns a rewriter that applies `funcs` in order.
+func NewContentStreamRewriter(funcs ...RewriteFunc) *ContentStreamRewriter {
+	return &ContentStreamRewriter{funcs: funcs, visited: map[*PdfObjectStream]bool{}}
+}
+
+// AddRewriteFunc appends `f` to the functions applied by the rewriter.
+func (rw *ContentStreamRewriter) AddRewriteFunc(f RewriteFunc) {
+	rw.funcs = append(rw.funcs, f)
+}
+
+// DisableFormRecursion makes the rewriter leave the form XObjects painted by the streams it rewrites
+// unchanged, e.g. when the caller rewrites them itself.
+func (rw *ContentStreamRewriter) DisableFormRecursion() {
+	rw.visited = nil
+}
+
+// Rewrite returns the operations of content stream `ops` with resources `resources` rewritten by the
+// functions of `rw`. The content streams of the form XObjects painted by the stream, and by those
+// forms, are rewritten in place with the graphics state in which they are first painted. Each form is
+// rewritten once by `rw`, so forms shared by the pages of a document are rewritten once if the same
+// rewriter is used for all of them.
+func (rw *ContentStreamRewriter) Rewrite(ops ContentStreamOperations,
+	resources *PdfPageResources) (ContentStreamOperations, error) {
+	return rw.rewrite(ops, resources, nil)
+}
+
+// rewrite returns the operations `ops` of a content stream with resources `resources` rewritten by the
+// functions of `rw`, starting with graphics state `initial`, or the initial state of a page if nil.
+func (rw *ContentStreamRewriter) rewrite(ops ContentStreamOperations, resources *PdfPageResources,
+	initial *GraphicsState) (ContentStreamOperations, error) {
+	var out ContentStreamOperations
+	processor := NewContentStreamProcessor(ops)
+	if initial != nil {
+		processor.SetInitialGraphicsState(*initial)
+	}
+	processor.AddHandler(HandlerConditionEnumAllOperands, "",
+		func(op *ContentStreamOperation, gs GraphicsState, resources *PdfPageResources) error {
+			rewritten := []*ContentStreamOperation{op}
+			for _, f := range rw.funcs {
+				var next []*ContentStreamOperation
+				for _, op := range rewritten {
+					ops, err := f(op, gs, resources)
+					if err != nil {
+						return err
+					}
+					next = append(next, ops...)
+				}
+				rewritten = next
+			}
+			for _, op := range rewritten {
+				if op.Operand == "Do" {
+					if err := rw.rewriteForm(op, gs, resources); err != nil {
+						return err
+					}
+				}
+			}
+			out = append(out, rewritten...)
+			return nil
+		})
+	if err := processor.Process(resources); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// rewriteForm rewrites the content stream of the form XObject painted by Do operation `op` of a stream
+// with resources `resources` in graphics state `gs`, unless it has been rewritten already. Forms that
+// cannot be loaded are logged and skipped.
+func (rw *ContentStreamRewriter) rewriteForm(op *ContentStreamOperation, gs GraphicsState,
+	resources *PdfPageResources) error {
+	if rw.visited == nil || len(op.Params) != 1 || resources == nil {
+		return nil
+	}
+	name, ok := op.Params[0].(*PdfObjectName)
+	if !ok {
+		return nil
+	}
+	stream, xtype := resources.GetXObjectByName(*name)
+	if xtype != XObjectTypeForm || rw.visited[stream] {
+		return nil
+	}
+	rw.visited[stream] = true
+
+	form, err := NewXObjectFormFromStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to load form XObject %s: %v", *name, err)
+		return nil
+	}
+	data, err := DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to decode form XObject %s: %v", *name, err)
+		return nil
+	}
+	ops, err := NewContentStreamParser(string(data)).Parse()
+	if err != nil {
+		common.Log.Debug("Unable to parse form XObject %s: %v", *name, err)
+		return nil
+	}
+	formResources := form.Resources
+	if formResources == nil {
+		// Forms without resources use those of the stream that paints them (PDF 1.1 and earlier).
+		formResources = resources
+	}
+
+	// The form is painted with its matrix concatenated to the CTM, as if enclosed by q and Q.
+	gs.Path = Path{}
+	if arr, ok := TraceToDirectObject(form.Matrix).(*PdfObjectArray); ok {
+		if f, err := arr.ToFloat64Array(); err == nil && len(f) == 6 {
+			gs.CTM = NewMatrix(f[0], f[1], f[2], f[3], f[4], f[5]).Mult(gs.CTM)
+		}
+	}
+	rewritten, err := rw.rewrite(*ops, formResources, &gs)
+	if err != nil {
+		return err
+	}
+	if err := form.SetContentStream(rewritten.Bytes(), nil); err != nil {
+		return err
+	}
+	form.ToPdfObject()
+	return nil
+}
+
+// RewriteString returns content stream `contents` with resources `resources` rewritten by the
+// functions of `rw`.
+func (rw *ContentStreamRewriter) RewriteString(contents string, resources *PdfPageResources) (string, error) {
+	ops, err := NewContentStreamParser(contents).Parse()
+	if err != nil {
+		return "", err
+	}
+	rewritten, err := rw.Rewrite(*ops, resources)
+	if err != nil {
+		return "", err
+	}
+	return string(rewritten.Bytes()), nil
+}
+
+// RewritePage rewrites the content streams of `page` with the functions of `rw` and replaces them by
+// a single stream encoded with `encoder` (raw if nil).
+func (rw *ContentStreamRewriter) RewritePage(page *PdfPage, encoder StreamEncoder) error {
+	contents, err := page.GetAllContentStreams()
+	if err != nil {
+		return err
+	}
+	rewritten, err := rw.RewriteString(contents, page.Resources)
+	if err != nil {
+		return err
+	}
+	return page.SetContentStreams([]string{rewritten}, encoder)
+}
+
+// RemoveImages returns a RewriteFunc that removes the image XObjects and inline images painted by a
+// content stream. Form XObjects are kept, and the images they paint are removed as their streams are
+// rewritten.
+func RemoveImages() RewriteFunc {
+	return func(op *ContentStreamOperation, gs GraphicsState,
+		resources *PdfPageResources) ([]*ContentStreamOperation, error) {
+		switch op.Operand {
+		case "BI":
+			return nil, nil
+		case "Do":
+			if len(op.Params) == 1 && resources != nil {
+				if name, ok := op.Params[0].(*PdfObjectName); ok {
+					if _, xtype := resources.GetXObjectByName(*name); xtype == XObjectTypeImage {
+						return nil, nil
+					}
+				}
+			}
+		}
+		return []*ContentStreamOperation{op}, nil
+	}
+}
+
+// RemoveText returns a RewriteFunc that removes the text shown by a content stream. The text showing
+// operators are removed, apart from the line moves of ' and ", and text objects are kept, as they can
+// change the graphics state of the operations that follow them.
+func RemoveText() RewriteFunc {
+	return func(op *ContentStreamOperation, gs GraphicsState,
+		resources *PdfPageResources) ([]*ContentStreamOperation, error) {
+		switch op.Operand {
+		case "Tj", "TJ":
+			return nil, nil
+		case "'":
+			return []*ContentStreamOperation{{Operand: "T*"}}, nil
+		case "\"":
+			if len(op.Params) != 3 {
+				common.Log.Debug("Invalid \" command, skipping over")
+				return nil, nil
+			}
+			return []*ContentStreamOperation{
+				{Operand: "Tw", Params: op.Params[:1]},
+				{Operand: "Tc", Params: op.Params[1:2]},
+				{Operand: "T*"},
+			}, nil
+		}
+		return []*ContentStreamOperation{op}, nil
+	}
+}
+
+// ReplaceColor returns a RewriteFunc that replaces the device color `from` (a *PdfColorDeviceGray,
+// *PdfColorDeviceRGB or *PdfColorDeviceCMYK) by device color `to` wherever it is set as stroking or
+// non-stroking color, by the device color operators (G, g, RG, rg, K, k) or the SC, SCN, sc and scn
+// operators in the corresponding device color space. Colors are matched with a tolerance of 0.001
+// per component. Colors set by SC, SCN, sc and scn are only replaced by colors of the same color
+// space, as changing the color space would change the meaning of the operations that follow.
+func ReplaceColor(from, to PdfColor) RewriteFunc {
+	fromVals, fromOK := deviceColorComponents(from)
+	toVals, toOK := deviceColorComponents(to)
+	if !fromOK || !toOK {
+		common.Log.Debug("ReplaceColor: not a device color (%T, %T)", from, to)
+	}
+
+	return func(op *ContentStreamOperation, gs GraphicsState,
+		resources *PdfPageResources) ([]*ContentStreamOperation, error) {
+		keep := []*ContentStreamOperation{op}
+		if !fromOK || !toOK {
+			return keep, nil
+		}
+
+		var color PdfColor
+		stroking := false
+		switch op.Operand {
+		case "G", "RG", "K", "SC", "SCN":
+			color, stroking = gs.ColorStroking, true
+		case "g", "rg", "k", "sc", "scn":
+			color = gs.ColorNonStroking
+		default:
+			return keep, nil
+		}
+		vals, ok := deviceColorComponents(color)
+		if !ok || len(vals) != len(fromVals) {
+			return keep, nil
+		}
+		for i := range vals {
+			if math.Abs(vals[i]-fromVals[i]) > 0.001 {
+				return keep, nil
+			}
+		}
+
+		operand := map[int]string{1: "g", 3: "rg", 4: "k"}[len(toVals)]
+		switch op.Operand {
+		case "SC", "SCN", "sc", "scn":
+			if len(toVals) != len(vals) {
+				return keep, nil
+			}
+			operand = op.Operand
+		default:
+			if stroking {
+				operand = map[int]string{1: "G", 3: "RG", 4: "K"}[len(toVals)]
+			}
+		}
+		return []*ContentStreamOperation{{Operand: operand, Params: makeParamsFromFloats(toVals)}}, nil
+	}
+}
+
+// deviceColorComponents returns the components of device color `color`. The bool return flag is false
+// if `color` is not a device color.
+func deviceColorComponents(color PdfColor) ([]float64, bool) {
+	switch c := color.(type) {
+	case *PdfColorDeviceGray:
+		return []float64{c.Val()}, true
+	case *PdfColorDeviceRGB:
+		return []float64{c.R(), c.G(), c.B()}, true
+	case *PdfColorDeviceCMYK:
+		return []float64{c.C(), c.M(), c.Y(), c.K()}, true
+	}
+	return nil, false
+}
+
+// StripMarkedContent returns a RewriteFunc that removes the marked-content sequences with tag `tag`
+// (BMC or BDC operators and the matching EMC) together with what they paint, e.g. /Artifact for
+// headers, footers and watermarks or /OC for optional content. The operators inside the sequences that
+// set the graphics or text state are kept, as their changes remain in effect after the sequence ends
+// unless enclosed by q and Q. Path painting operators are replaced by n, so that clipping paths are
+// kept, and text showing operators are removed as by RemoveText.
+func StripMarkedContent(tag PdfObjectName) RewriteFunc {
+	removeText := RemoveText()
+	return func(op *ContentStreamOperation, gs GraphicsState,
+		resources *PdfPageResources) ([]*ContentStreamOperation, error) {
+		stripped := false
+		for _, t := range gs.MarkedContent {
+			if t == tag {
+				stripped = true
+				break
+			}
+		}
+		if !stripped {
+			return []*ContentStreamOperation{op}, nil
+		}
+
+		switch op.Operand {
+		case "BMC", "BDC", "EMC", "MP", "DP", "BI", "Do", "sh":
+			return nil, nil
+		case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*":
+			if gs.Path.Empty() {
+				return nil, nil
+			}
+			return []*ContentStreamOperation{{Operand: "n"}}, nil
+		case "Tj", "TJ", "'", "\"":
+			return removeText(op, gs, resources)
+		}
+		return []*ContentStreamOperation{op}, nil
+	}
+}
+
+// MakeTextInvisible returns a RewriteFunc that makes the text shown by a content stream invisible
+// while keeping it extractable, as in the text layer of scanned pages. The text rendering mode is set
+// to 3 (neither fill nor stroke) at the start of each text object and Tr operators are replaced by
+// mode 3, or by mode 7 for the modes that add the text to the clipping path.
+func MakeTextInvisible() RewriteFunc {
+	invisible := func(mode int) *ContentStreamOperation {
+		if mode >= 4 {
+			return &ContentStreamOperation{Operand: "Tr", Params: []PdfObject{MakeInteger(7)}}
+		}
+		return &ContentStreamOperation{Operand: "Tr", Params: []PdfObject{MakeInteger(3)}}
+	}
+
+	return func(op *ContentStreamOperation, gs GraphicsState,
+		resources *PdfPageResources) ([]*ContentStreamOperation, error) {
+		switch op.Operand {
+		case "BT":
+			return []*ContentStreamOperation{op, invisible(gs.Text.Tr)}, nil
+		case "Tr":
+			return []*ContentStreamOperation{invisible(gs.Text.Tr)}, nil
+		}
+		return []*ContentStreamOperation{op}, nil
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package contentstream
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/unidoc/unidoc/pdf/core"
+	. "github.com/unidoc/unidoc/pdf/model"
+)
+
+// rewriteTestContents rewrites `contents` with `funcs` and returns the rewritten operations, one per
+// line, after parsing them again.
+func rewriteTestContents(t *testing.T, contents string, resources *PdfPageResources, funcs ...RewriteFunc) string {
+	rewritten, err := NewContentStreamRewriter(funcs...).RewriteString(contents, resources)
+	if err != nil {
+		t.Fatalf("Error rewriting: %v", err)
+	}
+	ops, err := NewContentStreamParser(rewritten).Parse()
+	if err != nil {
+		t.Fatalf("Error parsing rewritten contents: %v", err)
+	}
+	var lines []string
+	for _, op := range *ops {
+		line := op.Operand
+		if op.Operand != "BI" {
+			for _, param := range op.Params {
+				line += " " + param.DefaultWriteString()
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRewriteFunc(t *testing.T) {
+	contents := "q 1 0 0 rg BT /F1 12 Tf (A) Tj ET Q 0 0 10 10 re f"
+	// Insert a q before every BT, drop the Tj and replace the re by a square.
+	f := func(op *ContentStreamOperation, gs GraphicsState,
+		resources *PdfPageResources) ([]*ContentStreamOperation, error) {
+		switch op.Operand {
+		case "BT":
+			if _, ok := gs.ColorNonStroking.(*PdfColorDeviceRGB); !ok {
+				t.Errorf("Invalid color at BT: %v", gs.ColorNonStroking)
+			}
+			return []*ContentStreamOperation{{Operand: "q"}, op}, nil
+		case "ET":
+			return []*ContentStreamOperation{op, {Operand: "Q"}}, nil
+		case "Tj":
+			return nil, nil
+		case "re":
+			return []*ContentStreamOperation{{Operand: "re", Params: makeParamsFromInts([]int64{0, 0, 5, 5})}}, nil
+		}
+		return []*ContentStreamOperation{op}, nil
+	}
+	got := rewriteTestContents(t, contents, NewPdfPageResources(), f)
+	expected := "q\nrg 1 0 0\nq\nBT\nTf /F1 12\nET\nQ\nQ\nre 0 0 5 5\nf"
+	if got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestRewriteBuiltins(t *testing.T) {
+	image, err := MakeStream([]byte{0}, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	image.Set("Type", MakeName("XObject"))
+	image.Set("Subtype", MakeName("Image"))
+	form, err := MakeStream([]byte{}, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	form.Set("Type", MakeName("XObject"))
+	form.Set("Subtype", MakeName("Form"))
+	resources := NewPdfPageResources()
+	resources.SetXObjectByName("Im1", image)
+	resources.SetXObjectByName("Fm1", form)
+
+	testcases := []struct {
+		name     string
+		contents string
+		f        RewriteFunc
+		expected string
+	}{
+		{"RemoveImages", "/Im1 Do /Fm1 Do BI /W 1 /H 1 ID \x00 EI f", RemoveImages(), "Do /Fm1\nf"},
+		{"RemoveText", "BT 1 0 0 rg (A) Tj [(B)] TJ (C) ' 1 2 (D) \" ET", RemoveText(),
+			"BT\nrg 1 0 0\nT*\nTw 1\nTc 2\nT*\nET"},
+		{"ReplaceColor", "1 0 0 rg 1 0 0 RG 0 1 0 rg /DeviceRGB cs 1 0 0 sc /DeviceRGB CS 1 0 0 SC 0 g",
+			ReplaceColor(NewPdfColorDeviceRGB(1, 0, 0), NewPdfColorDeviceCMYK(0, 1, 1, 0)),
+			"k 0.000000 1.000000 1.000000 0.000000\nK 0.000000 1.000000 1.000000 0.000000\n" +
+				"rg 0 1 0\ncs /DeviceRGB\nsc 1 0 0\nCS /DeviceRGB\nSC 1 0 0\ng 0"},
+		{"ReplaceColorSameSpace", "/DeviceGray cs 0.5 sc 0.5004 G",
+			ReplaceColor(NewPdfColorDeviceGray(0.5), NewPdfColorDeviceGray(0)),
+			"cs /DeviceGray\nsc 0.000000\nG 0.000000"},
+		{"StripMarkedContent",
+			"/Artifact BMC 0 g /Span <</MCID 1>> BDC f EMC EMC /P <</MCID 2>> BDC 1 g EMC /Artifact <</Type /Pagination>> BDC f EMC",
+			StripMarkedContent("Artifact"), "g 0\nBDC /P <</MCID 2>>\ng 1\nEMC"},
+		{"StripMarkedContentState",
+			"/OC /L1 BDC 2 w 0 0 5 5 re W f BT /F1 12 Tf (A) Tj (B) ' ET /Im1 Do EMC 0 0 1 1 re f",
+			StripMarkedContent("OC"), "w 2\nre 0 0 5 5\nW\nn\nBT\nTf /F1 12\nT*\nET\nre 0 0 1 1\nf"},
+		{"MakeTextInvisible", "BT (A) Tj 5 Tr (B) Tj 0 Tr ET BT ET", MakeTextInvisible(),
+			"BT\nTr 3\nTj (A)\nTr 7\nTj (B)\nTr 3\nET\nBT\nTr 3\nET"},
+	}
+	for _, tc := range testcases {
+		got := rewriteTestContents(t, tc.contents, resources, tc.f)
+		if got != tc.expected {
+			t.Errorf("%s: expected:\n%s\ngot:\n%s", tc.name, tc.expected, got)
+		}
+	}
+}
+
+// The forms painted by a stream are rewritten once, including nested forms.
+func TestRewriteForms(t *testing.T) {
+	image, err := MakeStream([]byte{0}, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	image.Set("Type", MakeName("XObject"))
+	image.Set("Subtype", MakeName("Image"))
+	makeForm := func(contents string, resources *PdfPageResources) *PdfObjectStream {
+		form, err := MakeStream([]byte(contents), nil)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		form.Set("Type", MakeName("XObject"))
+		form.Set("Subtype", MakeName("Form"))
+		form.Set("BBox", MakeArray(MakeInteger(0), MakeInteger(0), MakeInteger(10), MakeInteger(10)))
+		if resources != nil {
+			form.Set("Resources", resources.ToPdfObject())
+		}
+		return form
+	}
+	inner := makeForm("/Im1 Do 0 0 1 1 re f", nil)
+	innerResources := NewPdfPageResources()
+	innerResources.SetXObjectByName("Im1", image)
+	innerResources.SetXObjectByName("Fm2", inner)
+	outer := makeForm("/Fm2 Do /Im1 Do /Fm2 Do", innerResources)
+	resources := NewPdfPageResources()
+	resources.SetXObjectByName("Fm1", outer)
+
+	got := rewriteTestContents(t, "/Fm1 Do /Fm1 Do", resources, RemoveImages())
+	if got != "Do /Fm1\nDo /Fm1" {
+		t.Errorf("Incorrect page contents:\n%s", got)
+	}
+	for _, tc := range []struct {
+		form     *PdfObjectStream
+		expected string
+	}{
+		{outer, "/Fm2 Do\n/Fm2 Do\n"},
+		{inner, "0 0 1 1 re\nf\n"},
+	} {
+		data, err := DecodeStream(tc.form)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("Expected form contents %q, got %q", tc.expected, data)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package convert
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// ColorTarget is the device color space to which documents are converted.
+type ColorTarget int
+
+const (
+	ColorTargetGray ColorTarget = iota // DeviceGray
+	ColorTargetCMYK                    // DeviceCMYK
+)
+
+// GrayMethod is the method with which RGB colors are converted to gray levels.
+type GrayMethod int
+
+const (
+	GrayLuminance GrayMethod = iota // 0.3 R + 0.59 G + 0.11 B, as in DeviceRGB ImageToGray
+	GrayAverage                     // (R + G + B) / 3
+	GrayLightness                   // (max(R, G, B) + min(R, G, B)) / 2
+)
+
+// BlackGeneration is the method with which RGB colors are converted to CMYK.
+type BlackGeneration int
+
+const (
+	// BlackGenerationFull prints the gray component of colors with black ink only:
+	// K = 1 - max(R, G, B) and C = (1 - R - K) / (1 - K), and so on.
+	BlackGenerationFull BlackGeneration = iota
+	// BlackGenerationNone prints colors with the C, M and Y inks only: C = 1 - R, and so on, and K = 0.
+	BlackGenerationNone
+)
+
+// ColorOptions are the options of a ColorConverter.
+type ColorOptions struct {
+	Target ColorTarget
+	// Gray is the conversion method of RGB colors to gray.
+	Gray GrayMethod
+	// Black is the conversion method of RGB colors to CMYK.
+	Black BlackGeneration
+	// ConvertSpotColors converts the colors of Separation and DeviceN color spaces to the target
+	// space. By default these spaces are kept and only their alternate spaces are converted.
+	ConvertSpotColors bool
+}
+
+// tintSampleBudget is the approximate number of samples of the sampled functions that replace the
+// tint transforms of Separation and DeviceN color spaces.
+const tintSampleBudget = 4096
+
+// keepsColorspace returns true if the colors of `cs` are left unchanged by the conversion. The colors
+// of Indexed and Pattern spaces are kept as their palettes and underlying spaces are converted.
+func (c *ColorConverter) keepsColorspace(cs model.PdfColorspace) bool {
+	switch cs.(type) {
+	case *model.PdfColorspaceDeviceGray:
+		return c.opts.Target == ColorTargetGray
+	case *model.PdfColorspaceDeviceCMYK:
+		return c.opts.Target == ColorTargetCMYK
+	case *model.PdfColorspaceSpecialIndexed, *model.PdfColorspaceSpecialPattern:
+		return true
+	case *model.PdfColorspaceSpecialSeparation, *model.PdfColorspaceDeviceN:
+		return !c.opts.ConvertSpotColors
+	}
+	return false
+}
+
+// targetColorspace returns the target color space of the conversion.
+func (c *ColorConverter) targetColorspace() model.PdfColorspace {
+	if c.opts.Target == ColorTargetCMYK {
+		return model.NewPdfColorspaceDeviceCMYK()
+	}
+	return model.NewPdfColorspaceDeviceGray()
+}
+
+// targetComponents returns the number of components of the target color space.
+func (c *ColorConverter) targetComponents() int {
+	if c.opts.Target == ColorTargetCMYK {
+		return 4
+	}
+	return 1
+}
+
+// fromRGB returns the components in the target space of RGB color `r`, `g`, `b`.
+func (c *ColorConverter) fromRGB(r, g, b float64) []float64 {
+	if c.opts.Target == ColorTargetGray {
+		var gray float64
+		switch c.opts.Gray {
+		case GrayAverage:
+			gray = (r + g + b) / 3
+		case GrayLightness:
+			gray = (math.Max(r, math.Max(g, b)) + math.Min(r, math.Min(g, b))) / 2
+		default:
+			gray = 0.3*r + 0.59*g + 0.11*b
+		}
+		return []float64{clamp01(gray)}
+	}
+
+	if c.opts.Black == BlackGenerationNone {
+		return []float64{clamp01(1 - r), clamp01(1 - g), clamp01(1 - b), 0}
+	}
+	k := 1 - math.Max(r, math.Max(g, b))
+	if k >= 1 {
+		return []float64{0, 0, 0, 1}
+	}
+	return []float64{
+		clamp01((1 - r - k) / (1 - k)),
+		clamp01((1 - g - k) / (1 - k)),
+		clamp01((1 - b - k) / (1 - k)),
+		clamp01(k),
+	}
+}
+
+// convertColor returns the components in the target space of color `color` of color space `cs`.
+// Gray colors are converted to black ink and CMYK colors, including those of ICC based and spot color
+// spaces with CMYK alternates, keep their components for CMYK targets.
+func (c *ColorConverter) convertColor(cs model.PdfColorspace, color model.PdfColor) ([]float64, error) {
+	switch col := color.(type) {
+	case *model.PdfColorDeviceGray:
+		if c.opts.Target == ColorTargetCMYK {
+			return []float64{0, 0, 0, 1 - col.Val()}, nil
+		}
+		return []float64{col.Val()}, nil
+	case *model.PdfColorDeviceCMYK:
+		if c.opts.Target == ColorTargetCMYK {
+			return []float64{col.C(), col.M(), col.Y(), col.K()}, nil
+		}
+	}
+
+	rgbColor, err := cs.ColorToRGB(color)
+	if err != nil {
+		return nil, err
+	}
+	rgb, ok := rgbColor.(*model.PdfColorDeviceRGB)
+	if !ok {
+		return nil, errors.New("Type check error")
+	}
+	return c.fromRGB(rgb.R(), rgb.G(), rgb.B()), nil
+}
+
+// convertFloats returns the components in the target space of the color of color space `cs` with
+// components `vals`, which are clipped to the ranges of the components of `cs`.
+func (c *ColorConverter) convertFloats(cs model.PdfColorspace, vals []float64) ([]float64, error) {
+	decode := cs.DecodeArray()
+	clipped := make([]float64, len(vals))
+	for i, val := range vals {
+		if 2*i+1 < len(decode) {
+			val = math.Min(math.Max(val, decode[2*i]), decode[2*i+1])
+		}
+		clipped[i] = val
+	}
+	color, err := cs.ColorFromFloats(clipped)
+	if err != nil {
+		return nil, err
+	}
+	return c.convertColor(cs, color)
+}
+
+// convertColorspace returns the color space that takes the place of `cs` in the converted document:
+// `cs` itself if its colors are kept, with its palette, alternate or underlying space converted,
+// or the target space.
+func (c *ColorConverter) convertColorspace(cs model.PdfColorspace) (model.PdfColorspace, error) {
+	switch t := cs.(type) {
+	case *model.PdfColorspaceSpecialIndexed:
+		return c.convertIndexed(t)
+	case *model.PdfColorspaceSpecialPattern:
+		if t.UnderlyingCS != nil {
+			underlying, err := c.convertColorspace(t.UnderlyingCS)
+			if err != nil {
+				return nil, err
+			}
+			t.UnderlyingCS = underlying
+		}
+		return t, nil
+	case *model.PdfColorspaceSpecialSeparation:
+		if !c.keepsColorspace(t) {
+			break
+		}
+		if t.AlternateSpace == nil || c.keepsColorspace(t.AlternateSpace) {
+			return t, nil
+		}
+		tint, err := c.convertTintTransform(t)
+		if err != nil {
+			return nil, err
+		}
+		t.AlternateSpace, t.TintTransform = c.targetColorspace(), tint
+		return t, nil
+	case *model.PdfColorspaceDeviceN:
+		if !c.keepsColorspace(t) {
+			break
+		}
+		if t.AlternateSpace == nil || c.keepsColorspace(t.AlternateSpace) {
+			return t, nil
+		}
+		tint, err := c.convertTintTransform(t)
+		if err != nil {
+			return nil, err
+		}
+		t.AlternateSpace, t.TintTransform = c.targetColorspace(), tint
+		return t, nil
+	}
+	if c.keepsColorspace(cs) {
+		return cs, nil
+	}
+	return c.targetColorspace(), nil
+}
+
+// convertIndexed converts the palette of Indexed color space `cs` to the target space, or the
+// alternate spaces of its base if the colors of the base are kept.
+func (c *ColorConverter) convertIndexed(cs *model.PdfColorspaceSpecialIndexed) (model.PdfColorspace, error) {
+	base := cs.Base
+	if base == nil {
+		return nil, errors.New("Indexed base colorspace undefined")
+	}
+	if c.keepsColorspace(base) {
+		converted, err := c.convertColorspace(base)
+		if err != nil {
+			return nil, err
+		}
+		cs.Base = converted
+		return cs, nil
+	}
+
+	var lookup []byte
+	switch t := core.TraceToDirectObject(cs.Lookup).(type) {
+	case *core.PdfObjectString:
+		lookup = []byte(*t)
+	case *core.PdfObjectStream:
+		decoded, err := core.DecodeStream(t)
+		if err != nil {
+			return nil, err
+		}
+		lookup = decoded
+	default:
+		return nil, errors.New("Indexed CS: Invalid table format")
+	}
+
+	n := base.GetNumComponents()
+	decode := base.DecodeArray()
+	var palette []byte
+	for i := 0; i <= cs.HiVal && (i+1)*n <= len(lookup); i++ {
+		vals := make([]float64, n)
+		for j := range vals {
+			vals[j] = interpolate(float64(lookup[i*n+j]), 0, 255, decode[2*j], decode[2*j+1])
+		}
+		converted, err := c.convertFloats(base, vals)
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range converted {
+			palette = append(palette, byte(val*255+0.5))
+		}
+	}
+	cs.Base = c.targetColorspace()
+	cs.Lookup = core.MakeString(string(palette))
+	return cs, nil
+}
+
+// convertTintTransform returns a sampled tint transform from the components of Separation or
+// DeviceN color space `cs` to the target space.
+func (c *ColorConverter) convertTintTransform(cs model.PdfColorspace) (model.PdfFunction, error) {
+	n := cs.GetNumComponents()
+	steps := int(math.Pow(tintSampleBudget, 1/float64(n)) + 0.5)
+	if steps < 2 {
+		steps = 2
+	} else if steps > 256 {
+		steps = 256
+	}
+
+	domain := make([]float64, 0, 2*n)
+	size := make([]int, n)
+	for i := range size {
+		domain = append(domain, 0, 1)
+		size[i] = steps
+	}
+	return c.sampleFunction(domain, size, func(x []float64) ([]float64, error) {
+		return c.convertFloats(cs, x)
+	})
+}
+
+// sampleFunction returns a sampled function with inputs in `domain` and `size` samples per input of
+// function `f`, whose outputs are colors in the target space.
+func (c *ColorConverter) sampleFunction(domain []float64, size []int,
+	f func(x []float64) ([]float64, error)) (*model.PdfFunctionType0, error) {
+	count := 1
+	for _, s := range size {
+		count *= s
+	}
+	var samples []float64
+	x := make([]float64, len(size))
+	for k := 0; k < count; k++ {
+		// The first input varies fastest.
+		rem := k
+		for i, s := range size {
+			x[i] = interpolate(float64(rem%s), 0, float64(s-1), domain[2*i], domain[2*i+1])
+			rem /= s
+		}
+		out, err := f(x)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, out...)
+	}
+
+	var rang []float64
+	for i := 0; i < c.targetComponents(); i++ {
+		rang = append(rang, 0, 1)
+	}
+	return model.NewPdfFunctionType0(domain, rang, size, samples)
+}
+
+// convertArray returns color array `arr` of color space `cs` converted to the target space. It is
+// returned unchanged if it cannot be converted.
+func (c *ColorConverter) convertArray(cs model.PdfColorspace, arr *core.PdfObjectArray) *core.PdfObjectArray {
+	vals, err := arr.ToFloat64Array()
+	if err != nil {
+		common.Log.Debug("Invalid color array %s: %v", arr, err)
+		return arr
+	}
+	converted, err := c.convertFloats(cs, vals)
+	if err != nil {
+		common.Log.Debug("Unable to convert color %v: %v", vals, err)
+		return arr
+	}
+	return core.MakeArrayFromFloats(converted)
+}
+
+// interpolate maps `x` in the range `xmin` to `xmax` to the range `ymin` to `ymax`.
+func interpolate(x, xmin, xmax, ymin, ymax float64) float64 {
+	if xmax == xmin {
+		return ymin
+	}
+	return ymin + (x-xmin)*(ymax-ymin)/(xmax-xmin)
+}
+
+// clamp01 clips `x` to the range 0 to 1.
+func clamp01(x float64) float64 {
+	return math.Min(math.Max(x, 0), 1)
+}
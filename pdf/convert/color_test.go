@@ -0,0 +1,275 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package convert
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+const testColorContents = "1 0 0 rg 0 0 1 RG /CS1 cs 1 sc /DeviceRGB CS 0 1 0 SC " +
+	"BI /W 1 /H 1 /CS /RGB /BPC 8 ID \xff\x00\x00 EI /Im1 Do /Fm1 Do /Sh1 sh"
+
+func makeStream(dict *core.PdfObjectDictionary, contents string) *core.PdfObjectStream {
+	dict.Set("Length", core.MakeInteger(int64(len(contents))))
+	return &core.PdfObjectStream{PdfObjectDictionary: dict, Stream: []byte(contents)}
+}
+
+// listStreamOperations returns the operations of content stream `stream`, one per line.
+func listStreamOperations(t *testing.T, stream *core.PdfObjectStream) string {
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		t.Fatalf("Error decoding stream: %v", err)
+	}
+	return listOperations(t, string(data))
+}
+
+// listPageOperations returns the operations of the content streams of `page`, one per line.
+func listPageOperations(t *testing.T, page *model.PdfPage) string {
+	contents, err := page.GetAllContentStreams()
+	if err != nil {
+		t.Fatalf("Error decoding contents: %v", err)
+	}
+	return listOperations(t, contents)
+}
+
+// listOperations returns the operations of `contents`, one per line, with the color spaces and
+// samples of inline images.
+func listOperations(t *testing.T, contents string) string {
+	ops, err := contentstream.NewContentStreamParser(contents).Parse()
+	if err != nil {
+		t.Fatalf("Error parsing stream: %v", err)
+	}
+	var lines []string
+	for _, op := range *ops {
+		line := op.Operand
+		for _, param := range op.Params {
+			if inline, ok := param.(*contentstream.ContentStreamInlineImage); ok {
+				line += " " + inline.ColorSpace.DefaultWriteString() + " " + string(inline.GetEncodedData())
+				continue
+			}
+			line += " " + param.DefaultWriteString()
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// colorTestPage returns a page with colors set by operators and inline images, an RGB image, a form
+// with a Separation color space, an Indexed color space, an axial shading and a square annotation.
+func colorTestPage(t *testing.T) *model.PdfPage {
+	imageDict := core.MakeDict()
+	imageDict.Set("Type", core.MakeName("XObject"))
+	imageDict.Set("Subtype", core.MakeName("Image"))
+	imageDict.Set("Width", core.MakeInteger(2))
+	imageDict.Set("Height", core.MakeInteger(1))
+	imageDict.Set("ColorSpace", core.MakeName("DeviceRGB"))
+	imageDict.Set("BitsPerComponent", core.MakeInteger(8))
+	image := makeStream(imageDict, "\xff\x00\x00\xff\xff\xff")
+
+	tint := core.MakeDict()
+	tint.Set("FunctionType", core.MakeInteger(2))
+	tint.Set("Domain", core.MakeArrayFromFloats([]float64{0, 1}))
+	tint.Set("C0", core.MakeArrayFromFloats([]float64{1, 1, 1}))
+	tint.Set("C1", core.MakeArrayFromFloats([]float64{1, 0, 0}))
+	tint.Set("N", core.MakeInteger(1))
+	spot := core.MakeArray(core.MakeName("Separation"), core.MakeName("Spot"), core.MakeName("DeviceRGB"), tint)
+	formColorspaces := core.MakeDict()
+	formColorspaces.Set("CS0", spot)
+	formResources := core.MakeDict()
+	formResources.Set("ColorSpace", formColorspaces)
+	formDict := core.MakeDict()
+	formDict.Set("Type", core.MakeName("XObject"))
+	formDict.Set("Subtype", core.MakeName("Form"))
+	formDict.Set("BBox", core.MakeArrayFromFloats([]float64{0, 0, 10, 10}))
+	formDict.Set("Resources", formResources)
+	form := makeStream(formDict, "/CS0 cs 1 scn 0 0 1 RG")
+
+	function := core.MakeDict()
+	function.Set("FunctionType", core.MakeInteger(2))
+	function.Set("Domain", core.MakeArrayFromFloats([]float64{0, 1}))
+	function.Set("C0", core.MakeArrayFromFloats([]float64{0, 0, 0}))
+	function.Set("C1", core.MakeArrayFromFloats([]float64{1, 1, 1}))
+	function.Set("N", core.MakeInteger(1))
+	shading := core.MakeDict()
+	shading.Set("ShadingType", core.MakeInteger(2))
+	shading.Set("ColorSpace", core.MakeName("DeviceRGB"))
+	shading.Set("Coords", core.MakeArrayFromFloats([]float64{0, 0, 10, 0}))
+	shading.Set("Function", function)
+	shadings := core.MakeDict()
+	shadings.Set("Sh1", shading)
+
+	indexed, err := model.NewPdfColorspaceFromPdfObject(core.MakeArray(core.MakeName("Indexed"),
+		core.MakeName("DeviceRGB"), core.MakeInteger(1), core.MakeString("\xff\x00\x00\x00\x00\xff")))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	page := model.NewPdfPage()
+	page.Resources = model.NewPdfPageResources()
+	page.Resources.SetXObjectByName("Im1", image)
+	page.Resources.SetXObjectByName("Fm1", form)
+	page.Resources.SetColorspaceByName("CS1", indexed)
+	page.Resources.Shading = shadings
+	if err := page.SetContentStreams([]string{testColorContents}, nil); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	square := model.NewPdfAnnotationSquare()
+	square.C = core.MakeArrayFromFloats([]float64{1, 0, 0})
+	square.IC = core.MakeArrayFromFloats([]float64{0})
+	ap := core.MakeDict()
+	ap.Set("N", makeStream(core.MakeDict(), "0 1 0 rg"))
+	square.AP = ap
+	page.Annotations = append(page.Annotations, square.PdfAnnotation)
+	return page
+}
+
+func TestConvertGray(t *testing.T) {
+	page := colorTestPage(t)
+	if err := NewColorConverter(ColorOptions{}).ConvertPage(page); err != nil {
+		t.Fatalf("Error converting page: %v", err)
+	}
+
+	expected := "g 0.300000\nG 0.110000\ncs /CS1\nsc 1\nG 0.000000\nG 0.590000\nBI /G M\n" +
+		"Do /Im1\nDo /Fm1\nsh /Sh1"
+	if got := listPageOperations(t, page); got != expected {
+		t.Errorf("Expected:\n%q\ngot:\n%q", expected, got)
+	}
+
+	// Image.
+	image, xtype := page.Resources.GetXObjectByName("Im1")
+	if xtype != model.XObjectTypeImage {
+		t.Fatalf("Image missing")
+	}
+	data, err := core.DecodeStream(image)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if cs, ok := image.Get("ColorSpace").(*core.PdfObjectName); !ok || *cs != "DeviceGray" ||
+		!reflect.DeepEqual(data, []byte{77, 255}) {
+		t.Errorf("Invalid image %s %v", image.PdfObjectDictionary, data)
+	}
+
+	// Form: the Separation color space is kept with a gray alternate space.
+	form, _ := page.Resources.GetXObjectByName("Fm1")
+	if got := listStreamOperations(t, form); got != "cs /CS0\nscn 1\nG 0.110000" {
+		t.Errorf("Invalid form contents %q", got)
+	}
+	resources := core.TraceToDirectObject(form.Get("Resources")).(*core.PdfObjectDictionary)
+	colorspaces := core.TraceToDirectObject(resources.Get("ColorSpace")).(*core.PdfObjectDictionary)
+	spot, err := model.NewPdfColorspaceFromPdfObject(colorspaces.Get("CS0"))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	separation, ok := spot.(*model.PdfColorspaceSpecialSeparation)
+	if !ok {
+		t.Fatalf("Invalid form colorspace %s", spot)
+	}
+	color, err := separation.ColorFromFloats([]float64{1})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if gray, ok := color.(*model.PdfColorDeviceGray); !ok || math.Abs(gray.Val()-0.3) > 0.001 {
+		t.Errorf("Invalid tint transform output %v", color)
+	}
+
+	// Indexed palette.
+	cs, _ := page.Resources.GetColorspaceByName("CS1")
+	indexed, ok := cs.(*model.PdfColorspaceSpecialIndexed)
+	if !ok || indexed.Base.String() != "DeviceGray" {
+		t.Fatalf("Invalid indexed colorspace %v", cs)
+	}
+	if lookup, ok := indexed.Lookup.(*core.PdfObjectString); !ok || *lookup != "\x4d\x1c" {
+		t.Errorf("Invalid palette %v", indexed.Lookup)
+	}
+
+	// Shading.
+	shading, ok := page.Resources.GetShadingByName("Sh1")
+	if !ok || shading.ColorSpace.String() != "DeviceGray" {
+		t.Fatalf("Invalid shading %v", shading)
+	}
+	axial := shading.GetContext().(*model.PdfShadingType2)
+	if len(axial.Function) != 1 {
+		t.Fatalf("Invalid shading functions %v", axial.Function)
+	}
+	for _, x := range []float64{0, 0.5, 1} {
+		out, err := axial.Function[0].Evaluate([]float64{x})
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if len(out) != 1 || math.Abs(out[0]-x) > 0.01 {
+			t.Errorf("Invalid shading color at %g: %v", x, out)
+		}
+	}
+
+	// Annotation.
+	annot := page.Annotations[0]
+	square := annot.GetContext().(*model.PdfAnnotationSquare)
+	if got := annot.C.DefaultWriteString(); got != "[0.300000]" {
+		t.Errorf("Invalid annotation color %s", got)
+	}
+	if got := square.IC.DefaultWriteString(); got != "[0.000000]" {
+		t.Errorf("Invalid annotation interior color %s", got)
+	}
+	appearance := core.TraceToDirectObject(annot.AP).(*core.PdfObjectDictionary).Get("N").(*core.PdfObjectStream)
+	if got := listStreamOperations(t, appearance); got != "g 0.590000" {
+		t.Errorf("Invalid appearance stream %q", got)
+	}
+}
+
+func TestConvertCMYK(t *testing.T) {
+	testcases := []struct {
+		opts     ColorOptions
+		contents string
+		expected string
+	}{
+		{ColorOptions{Target: ColorTargetCMYK}, "1 0 0 rg 0.5 G 0.2 0.2 0.2 RG 0.1 0.2 0.3 0.4 k",
+			"k 0.000000 1.000000 1.000000 0.000000\nK 0.000000 0.000000 0.000000 0.500000\n" +
+				"K 0.000000 0.000000 0.000000 0.800000\nk 0.100000 0.200000 0.300000 0.400000"},
+		{ColorOptions{Target: ColorTargetCMYK, Black: BlackGenerationNone}, "0.2 0.2 0.2 RG",
+			"K 0.800000 0.800000 0.800000 0.000000"},
+		{ColorOptions{Target: ColorTargetGray, Gray: GrayAverage}, "0 0.3 0.6 rg 0.5 0.5 0.5 0.5 k",
+			"g 0.300000\ng 0.250000"},
+		{ColorOptions{Target: ColorTargetGray, Gray: GrayLightness}, "0 0.3 0.6 rg", "g 0.300000"},
+		// Images larger than their data are kept.
+		{ColorOptions{Target: ColorTargetGray},
+			"BI /W 3037000500 /H 3037000500 /CS /RGB /BPC 8 ID \x00 EI", "BI /RGB \x00 \n"},
+	}
+	for _, tc := range testcases {
+		page := model.NewPdfPage()
+		if err := page.SetContentStreams([]string{tc.contents}, nil); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if err := NewColorConverter(tc.opts).ConvertPage(page); err != nil {
+			t.Fatalf("Error converting page: %v", err)
+		}
+		if got := listPageOperations(t, page); got != tc.expected {
+			t.Errorf("%+v: expected:\n%s\ngot:\n%s", tc.opts, tc.expected, got)
+		}
+	}
+}
+
+func TestConvertMeshData(t *testing.T) {
+	// Two vertices of a free-form triangle mesh with 8 bit flags, coordinates and RGB components.
+	data := []byte{0, 1, 2, 255, 0, 0, 0, 3, 4, 0, 0, 255}
+	c := NewColorConverter(ColorOptions{})
+	converted, err := c.convertMeshData(data, 4, model.NewPdfColorspaceDeviceRGB(),
+		[]float64{0, 1, 0, 1, 0, 1, 0, 1, 0, 1}, 8, 8, core.MakeInteger(8))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	expected := []byte{0, 1, 2, 77, 0, 3, 4, 28}
+	if !reflect.DeepEqual(converted, expected) {
+		t.Errorf("Expected %v, got %v", expected, converted)
+	}
+}
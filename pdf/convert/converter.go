@@ -0,0 +1,456 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package convert
+
+import (
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// ColorConverter converts the colors of pages to DeviceGray or DeviceCMYK. The objects shared by
+// pages, such as images and forms, are converted once, so the same converter should be used for all
+// the pages of a document.
+type ColorConverter struct {
+	opts ColorOptions
+
+	// visited holds the streams and shadings that have been converted.
+	visited map[core.PdfObject]bool
+}
+
+// NewColorConverter returns a converter with options `opts`.
+func NewColorConverter(opts ColorOptions) *ColorConverter {
+	return &ColorConverter{opts: opts, visited: map[core.PdfObject]bool{}}
+}
+
+// ConvertPage converts the colors of `page`: its content streams, resources, transparency group and
+// annotations. Objects that cannot be converted are logged and left unchanged.
+func (c *ColorConverter) ConvertPage(page *model.PdfPage) error {
+	if page.Resources == nil {
+		page.Resources = model.NewPdfPageResources()
+	}
+
+	contents, err := page.GetAllContentStreams()
+	if err != nil {
+		return err
+	}
+	rewritten, err := c.rewriteContents(contents, page.Resources)
+	if err != nil {
+		return err
+	}
+	if err := page.SetContentStreams([]string{rewritten}, core.NewFlateEncoder()); err != nil {
+		return err
+	}
+	if err := c.convertResources(page.Resources); err != nil {
+		return err
+	}
+	c.convertGroup(page.Group)
+
+	for _, annot := range page.Annotations {
+		c.convertAnnotation(annot, page.Resources)
+	}
+	return nil
+}
+
+// rewriteContents returns content stream `contents` with resources `resources` with the colors set by
+// its operators converted. The forms it paints are converted with the resources.
+func (c *ColorConverter) rewriteContents(contents string, resources *model.PdfPageResources) (string, error) {
+	rw := contentstream.NewContentStreamRewriter(c.rewriteColor)
+	rw.DisableFormRecursion()
+	return rw.RewriteString(contents, resources)
+}
+
+// rewriteColor is the contentstream.RewriteFunc that converts the colors set by the color operators
+// and the inline images of content streams. Colors of color spaces that are not kept are replaced by
+// the g, G, k or K operators, including the initial colors set by the CS and cs operators.
+func (c *ColorConverter) rewriteColor(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState,
+	resources *model.PdfPageResources) ([]*contentstream.ContentStreamOperation, error) {
+	keep := []*contentstream.ContentStreamOperation{op}
+	var cs model.PdfColorspace
+	var color model.PdfColor
+	stroking := false
+	switch op.Operand {
+	case "CS", "SC", "SCN", "G", "RG", "K":
+		cs, color, stroking = gs.ColorspaceStroking, gs.ColorStroking, true
+	case "cs", "sc", "scn", "g", "rg", "k":
+		cs, color = gs.ColorspaceNonStroking, gs.ColorNonStroking
+	case "BI":
+		return c.convertInlineImage(op, resources), nil
+	default:
+		return keep, nil
+	}
+	if cs == nil || color == nil {
+		return keep, nil
+	}
+	if pattern, ok := cs.(*model.PdfColorspaceSpecialPattern); ok {
+		return c.convertPatternColor(op, pattern), nil
+	}
+	if c.keepsColorspace(cs) {
+		return keep, nil
+	}
+
+	vals, err := c.convertColor(cs, color)
+	if err != nil {
+		common.Log.Debug("Unable to convert color of %s: %v", op.Operand, err)
+		return keep, nil
+	}
+	operand := "g"
+	if len(vals) == 4 {
+		operand = "k"
+	}
+	if stroking {
+		operand = map[string]string{"g": "G", "k": "K"}[operand]
+	}
+	return []*contentstream.ContentStreamOperation{{Operand: operand, Params: makeParamsFromFloats(vals)}}, nil
+}
+
+// convertPatternColor returns SCN or scn operation `op` of Pattern color space `pattern` with the
+// components of the color of uncolored patterns converted to the target space.
+func (c *ColorConverter) convertPatternColor(op *contentstream.ContentStreamOperation,
+	pattern *model.PdfColorspaceSpecialPattern) []*contentstream.ContentStreamOperation {
+	keep := []*contentstream.ContentStreamOperation{op}
+	if (op.Operand != "SCN" && op.Operand != "scn") || len(op.Params) < 2 ||
+		pattern.UnderlyingCS == nil || c.keepsColorspace(pattern.UnderlyingCS) {
+		return keep
+	}
+
+	n := len(op.Params) - 1
+	vals, err := model.GetNumbersAsFloat(op.Params[:n])
+	if err != nil {
+		common.Log.Debug("Invalid pattern color: %v", err)
+		return keep
+	}
+	converted, err := c.convertFloats(pattern.UnderlyingCS, vals)
+	if err != nil {
+		common.Log.Debug("Unable to convert pattern color %v: %v", vals, err)
+		return keep
+	}
+	params := append(makeParamsFromFloats(converted), op.Params[n])
+	return []*contentstream.ContentStreamOperation{{Operand: op.Operand, Params: params}}
+}
+
+// convertResources converts the XObjects, patterns, Type 3 fonts, soft masks, shadings and color
+// spaces of `resources`. The color spaces are converted last, as the content streams of forms
+// without resources of their own are rewritten with the original ones.
+func (c *ColorConverter) convertResources(resources *model.PdfPageResources) error {
+	if xobjects, ok := core.TraceToDirectObject(resources.XObject).(*core.PdfObjectDictionary); ok {
+		for _, name := range xobjects.Keys() {
+			stream, ok := core.TraceToDirectObject(xobjects.Get(name)).(*core.PdfObjectStream)
+			if !ok {
+				continue
+			}
+			subtype, _ := core.TraceToDirectObject(stream.Get("Subtype")).(*core.PdfObjectName)
+			if subtype == nil {
+				continue
+			}
+			var err error
+			switch *subtype {
+			case "Image":
+				err = c.convertImage(stream)
+			case "Form":
+				err = c.convertForm(stream, resources)
+			}
+			if err != nil {
+				common.Log.Debug("Unable to convert XObject %s: %v", name, err)
+			}
+		}
+	}
+
+	if patterns, ok := core.TraceToDirectObject(resources.Pattern).(*core.PdfObjectDictionary); ok {
+		for _, name := range patterns.Keys() {
+			pattern, ok := resources.GetPatternByName(name)
+			if !ok {
+				continue
+			}
+			var err error
+			if pattern.IsTiling() {
+				stream, ok := pattern.GetContainingPdfObject().(*core.PdfObjectStream)
+				if ok {
+					err = c.convertForm(stream, resources)
+				}
+			} else if pattern.IsShading() {
+				if shading := pattern.GetAsShadingPattern().Shading; shading != nil {
+					err = c.convertShading(shading)
+				}
+			}
+			if err != nil {
+				common.Log.Debug("Unable to convert pattern %s: %v", name, err)
+			}
+		}
+	}
+
+	if fonts, ok := core.TraceToDirectObject(resources.Font).(*core.PdfObjectDictionary); ok {
+		for _, name := range fonts.Keys() {
+			if font, ok := core.TraceToDirectObject(fonts.Get(name)).(*core.PdfObjectDictionary); ok {
+				c.convertType3Font(font, resources)
+			}
+		}
+	}
+
+	if extGStates, ok := core.TraceToDirectObject(resources.ExtGState).(*core.PdfObjectDictionary); ok {
+		for _, name := range extGStates.Keys() {
+			if gs, ok := core.TraceToDirectObject(extGStates.Get(name)).(*core.PdfObjectDictionary); ok {
+				c.convertSoftMask(gs.Get("SMask"), resources)
+			}
+		}
+	}
+
+	if shadings, ok := core.TraceToDirectObject(resources.Shading).(*core.PdfObjectDictionary); ok {
+		for _, name := range shadings.Keys() {
+			shading, ok := resources.GetShadingByName(name)
+			if !ok {
+				continue
+			}
+			if err := c.convertShading(shading); err != nil {
+				common.Log.Debug("Unable to convert shading %s: %v", name, err)
+			}
+		}
+	}
+
+	if resources.ColorSpace != nil {
+		for _, name := range resources.ColorSpace.Names {
+			cs, err := c.convertColorspace(resources.ColorSpace.Colorspaces[name])
+			if err != nil {
+				common.Log.Debug("Unable to convert colorspace %s: %v", name, err)
+				continue
+			}
+			resources.ColorSpace.Colorspaces[name] = cs
+		}
+	}
+	return nil
+}
+
+// convertForm converts the content stream, resources and transparency group of form XObject,
+// tiling pattern or glyph description `stream`. `parent` are the resources of the stream painting
+// it, which are used if it has no resources of its own.
+func (c *ColorConverter) convertForm(stream *core.PdfObjectStream, parent *model.PdfPageResources) error {
+	if c.visited[stream] {
+		return nil
+	}
+	c.visited[stream] = true
+
+	resources := parent
+	resDict, hasResources := core.TraceToDirectObject(stream.Get("Resources")).(*core.PdfObjectDictionary)
+	if hasResources {
+		var err error
+		resources, err = model.NewPdfPageResourcesFromDict(resDict)
+		if err != nil {
+			return err
+		}
+	}
+
+	contents, err := core.DecodeStream(stream)
+	if err != nil {
+		return err
+	}
+	rewritten, err := c.rewriteContents(string(contents), resources)
+	if err != nil {
+		return err
+	}
+	if err := setStreamData(stream, []byte(rewritten)); err != nil {
+		return err
+	}
+	c.convertGroup(stream.Get("Group"))
+
+	if !hasResources {
+		return nil
+	}
+	if err := c.convertResources(resources); err != nil {
+		return err
+	}
+	// The resources model holds the only copy of the converted color spaces.
+	if resources.ColorSpace != nil {
+		resDict.Set("ColorSpace", resources.ColorSpace.ToPdfObject())
+	}
+	return nil
+}
+
+// convertType3Font converts the glyph descriptions of `font` if it is a Type 3 font. `parent` are
+// the resources of the stream using the font.
+func (c *ColorConverter) convertType3Font(font *core.PdfObjectDictionary, parent *model.PdfPageResources) {
+	subtype, ok := core.TraceToDirectObject(font.Get("Subtype")).(*core.PdfObjectName)
+	if !ok || *subtype != "Type3" {
+		return
+	}
+	charProcs, ok := core.TraceToDirectObject(font.Get("CharProcs")).(*core.PdfObjectDictionary)
+	if !ok {
+		return
+	}
+
+	resources := parent
+	resDict, hasResources := core.TraceToDirectObject(font.Get("Resources")).(*core.PdfObjectDictionary)
+	if hasResources {
+		var err error
+		resources, err = model.NewPdfPageResourcesFromDict(resDict)
+		if err != nil {
+			common.Log.Debug("Invalid Type 3 font resources: %v", err)
+			return
+		}
+	}
+	for _, name := range charProcs.Keys() {
+		if stream, ok := core.TraceToDirectObject(charProcs.Get(name)).(*core.PdfObjectStream); ok {
+			if err := c.convertForm(stream, resources); err != nil {
+				common.Log.Debug("Unable to convert glyph %s: %v", name, err)
+			}
+		}
+	}
+	if hasResources && !c.visited[resDict] {
+		c.visited[resDict] = true
+		if err := c.convertResources(resources); err != nil {
+			common.Log.Debug("Unable to convert Type 3 font resources: %v", err)
+		}
+		if resources.ColorSpace != nil {
+			resDict.Set("ColorSpace", resources.ColorSpace.ToPdfObject())
+		}
+	}
+}
+
+// convertSoftMask converts soft mask dictionary `obj` of a graphics state parameter dictionary: the
+// transparency group of its form and its backdrop color.
+func (c *ColorConverter) convertSoftMask(obj core.PdfObject, resources *model.PdfPageResources) {
+	smask, ok := core.TraceToDirectObject(obj).(*core.PdfObjectDictionary)
+	if !ok {
+		return
+	}
+	form, ok := core.TraceToDirectObject(smask.Get("G")).(*core.PdfObjectStream)
+	if !ok {
+		return
+	}
+
+	// The backdrop color is in the color space of the group, so it is converted with it.
+	if backdrop, ok := core.TraceToDirectObject(smask.Get("BC")).(*core.PdfObjectArray); ok && !c.visited[form] {
+		if group, ok := core.TraceToDirectObject(form.Get("Group")).(*core.PdfObjectDictionary); ok {
+			if csObj := group.Get("CS"); csObj != nil {
+				if cs, err := model.NewPdfColorspaceFromPdfObject(csObj); err == nil && !c.keepsColorspace(cs) {
+					smask.Set("BC", c.convertArray(cs, backdrop))
+				}
+			}
+		}
+	}
+	if err := c.convertForm(form, resources); err != nil {
+		common.Log.Debug("Unable to convert soft mask: %v", err)
+	}
+}
+
+// convertGroup converts the color space of transparency group dictionary `obj`.
+func (c *ColorConverter) convertGroup(obj core.PdfObject) {
+	group, ok := core.TraceToDirectObject(obj).(*core.PdfObjectDictionary)
+	if !ok {
+		return
+	}
+	csObj := group.Get("CS")
+	if csObj == nil {
+		return
+	}
+	cs, err := model.NewPdfColorspaceFromPdfObject(csObj)
+	if err != nil {
+		common.Log.Debug("Invalid group colorspace: %v", err)
+		return
+	}
+	if !c.keepsColorspace(cs) {
+		group.Set("CS", c.targetColorspace().ToPdfObject())
+	}
+}
+
+// convertAnnotation converts the appearance streams of `annot` and its colors. `resources` are the
+// resources of the page, used by appearance streams without resources of their own.
+func (c *ColorConverter) convertAnnotation(annot *model.PdfAnnotation, resources *model.PdfPageResources) {
+	if ap, ok := core.TraceToDirectObject(annot.AP).(*core.PdfObjectDictionary); ok {
+		for _, key := range []core.PdfObjectName{"N", "R", "D"} {
+			var streams []*core.PdfObjectStream
+			switch t := core.TraceToDirectObject(ap.Get(key)).(type) {
+			case *core.PdfObjectStream:
+				streams = append(streams, t)
+			case *core.PdfObjectDictionary:
+				// Appearance states.
+				for _, state := range t.Keys() {
+					if stream, ok := core.TraceToDirectObject(t.Get(state)).(*core.PdfObjectStream); ok {
+						streams = append(streams, stream)
+					}
+				}
+			}
+			for _, stream := range streams {
+				if err := c.convertForm(stream, resources); err != nil {
+					common.Log.Debug("Unable to convert appearance stream: %v", err)
+				}
+			}
+		}
+	}
+
+	annot.C = c.convertAnnotationColor(annot.C)
+	switch t := annot.GetContext().(type) {
+	case *model.PdfAnnotationLine:
+		t.IC = c.convertAnnotationColor(t.IC)
+	case *model.PdfAnnotationSquare:
+		t.IC = c.convertAnnotationColor(t.IC)
+	case *model.PdfAnnotationCircle:
+		t.IC = c.convertAnnotationColor(t.IC)
+	case *model.PdfAnnotationPolygon:
+		t.IC = c.convertAnnotationColor(t.IC)
+	case *model.PdfAnnotationPolyLine:
+		t.IC = c.convertAnnotationColor(t.IC)
+	case *model.PdfAnnotationRedact:
+		t.IC = c.convertAnnotationColor(t.IC)
+	case *model.PdfAnnotationWidget:
+		// Border and background colors of the widget's appearance characteristics.
+		if mk, ok := core.TraceToDirectObject(t.MK).(*core.PdfObjectDictionary); ok {
+			for _, key := range []core.PdfObjectName{"BC", "BG"} {
+				if color := mk.Get(key); color != nil {
+					mk.Set(key, c.convertAnnotationColor(color))
+				}
+			}
+		}
+	}
+}
+
+// convertAnnotationColor returns annotation color array `obj`, whose number of components (1, 3 or
+// 4) gives its device color space, converted to the target space.
+func (c *ColorConverter) convertAnnotationColor(obj core.PdfObject) core.PdfObject {
+	arr, ok := core.TraceToDirectObject(obj).(*core.PdfObjectArray)
+	if !ok {
+		return obj
+	}
+	var cs model.PdfColorspace
+	switch len(*arr) {
+	case 1:
+		cs = model.NewPdfColorspaceDeviceGray()
+	case 3:
+		cs = model.NewPdfColorspaceDeviceRGB()
+	case 4:
+		cs = model.NewPdfColorspaceDeviceCMYK()
+	default:
+		// Transparent.
+		return obj
+	}
+	if c.keepsColorspace(cs) {
+		return obj
+	}
+	return c.convertArray(cs, arr)
+}
+
+// setStreamData replaces the data of `stream` by `data` encoded with the FlateDecode filter.
+func setStreamData(stream *core.PdfObjectStream, data []byte) error {
+	encoder := core.NewFlateEncoder()
+	encoded, err := encoder.EncodeBytes(data)
+	if err != nil {
+		return err
+	}
+	stream.Set("Filter", core.MakeName(encoder.GetFilterName()))
+	stream.Remove("DecodeParms")
+	stream.Set("Length", core.MakeInteger(int64(len(encoded))))
+	stream.Stream = encoded
+	return nil
+}
+
+// makeParamsFromFloats returns `vals` as operands.
+func makeParamsFromFloats(vals []float64) []core.PdfObject {
+	var params []core.PdfObject
+	for _, val := range vals {
+		params = append(params, core.MakeFloat(val))
+	}
+	return params
+}
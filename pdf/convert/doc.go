@@ -0,0 +1,16 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+// Package convert rewrites the colors of PDF documents, e.g. to produce the grayscale or CMYK-only
+// files required for printing.
+//
+// A ColorConverter converts pages to DeviceGray or DeviceCMYK. The color operators of content
+// streams are rewritten, including those of the Form XObjects, tiling patterns, Type 3 glyphs, soft
+// masks and annotation appearances used by the pages. Images are converted and stored with the
+// FlateDecode filter, shading functions are replaced by sampled functions in the target space and the
+// colors of mesh shadings are converted. Indexed color spaces keep their index with a converted
+// palette. Separation and DeviceN color spaces are kept, as their colorants are printed on their own
+// plates, with their alternate spaces converted, unless ColorOptions.ConvertSpotColors is set.
+package convert
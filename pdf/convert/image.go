@@ -0,0 +1,332 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package convert
+
+import (
+	"errors"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// convertImage converts the samples of image XObject `stream` to 8 bit samples in the target space,
+// stored with the FlateDecode filter. The palettes of Indexed images and the alternate spaces of
+// kept spot color spaces are converted instead. Stencil masks are left unchanged.
+func (c *ColorConverter) convertImage(stream *core.PdfObjectStream) error {
+	if c.visited[stream] {
+		return nil
+	}
+	c.visited[stream] = true
+
+	if mask, ok := core.TraceToDirectObject(stream.Get("ImageMask")).(*core.PdfObjectBool); ok && bool(*mask) {
+		return nil
+	}
+	csObj := stream.Get("ColorSpace")
+	if csObj == nil {
+		// JPXDecode images may have their color space in the image data.
+		return nil
+	}
+	cs, err := model.NewPdfColorspaceFromPdfObject(csObj)
+	if err != nil {
+		return err
+	}
+	if c.keepsColorspace(cs) {
+		converted, err := c.convertColorspace(cs)
+		if err != nil {
+			return err
+		}
+		stream.Set("ColorSpace", converted.ToPdfObject())
+		return nil
+	}
+
+	ximg, err := model.NewXObjectImageFromStream(stream)
+	if err != nil {
+		return err
+	}
+	img, err := ximg.ToImage()
+	if err != nil {
+		return err
+	}
+	var decode []float64
+	if arr, ok := core.TraceToDirectObject(ximg.Decode).(*core.PdfObjectArray); ok {
+		decode, err = arr.ToFloat64Array()
+		if err != nil {
+			return err
+		}
+	}
+	data, err := c.convertSamples(img, cs, decode)
+	if err != nil {
+		return err
+	}
+
+	// Color key masks select samples of the original image, so they are replaced by the equivalent
+	// stencil mask.
+	if ranges, ok := core.TraceToDirectObject(stream.Get("Mask")).(*core.PdfObjectArray); ok {
+		mask, err := colorKeyMask(img, cs.GetNumComponents(), ranges)
+		if err != nil {
+			return err
+		}
+		stream.Set("Mask", mask)
+	}
+	// The matte color of a soft mask is in the color space of the image.
+	if smask, ok := core.TraceToDirectObject(stream.Get("SMask")).(*core.PdfObjectStream); ok {
+		if matte, ok := core.TraceToDirectObject(smask.Get("Matte")).(*core.PdfObjectArray); ok {
+			smask.Set("Matte", c.convertArray(cs, matte))
+		}
+	}
+
+	stream.Set("ColorSpace", c.targetColorspace().ToPdfObject())
+	stream.Set("BitsPerComponent", core.MakeInteger(8))
+	stream.Remove("Decode")
+	return setStreamData(stream, data)
+}
+
+// convertInlineImage returns BI operation `op` with its inline image converted to 8 bit samples in
+// the target space. Stencil masks and images in kept color spaces are left unchanged, apart from
+// those in Indexed color spaces given in the image, whose palettes are not converted.
+func (c *ColorConverter) convertInlineImage(op *contentstream.ContentStreamOperation,
+	resources *model.PdfPageResources) []*contentstream.ContentStreamOperation {
+	keep := []*contentstream.ContentStreamOperation{op}
+	if len(op.Params) != 1 {
+		return keep
+	}
+	inline, ok := op.Params[0].(*contentstream.ContentStreamInlineImage)
+	if !ok {
+		return keep
+	}
+	if isMask, err := inline.IsMask(); err != nil || isMask {
+		return keep
+	}
+	cs, err := inline.GetColorSpace(resources)
+	if err != nil {
+		common.Log.Debug("Unable to convert inline image: %v", err)
+		return keep
+	}
+	if _, isArray := inline.ColorSpace.(*core.PdfObjectArray); !isArray && c.keepsColorspace(cs) {
+		return keep
+	}
+
+	img, err := inline.ToImage(resources)
+	if err != nil {
+		common.Log.Debug("Unable to convert inline image: %v", err)
+		return keep
+	}
+	var decode []float64
+	if arr, ok := inline.Decode.(*core.PdfObjectArray); ok {
+		decode, err = arr.ToFloat64Array()
+		if err != nil {
+			common.Log.Debug("Invalid inline image decode: %v", err)
+			return keep
+		}
+	}
+	data, err := c.convertSamples(img, cs, decode)
+	if err != nil {
+		common.Log.Debug("Unable to convert inline image: %v", err)
+		return keep
+	}
+
+	// Inline images are parsed with abbreviated filter names only, so the samples are stored raw.
+	converted, err := contentstream.NewInlineImageFromImage(model.Image{
+		Width:            img.Width,
+		Height:           img.Height,
+		BitsPerComponent: 8,
+		ColorComponents:  c.targetComponents(),
+		Data:             data,
+	}, nil)
+	if err != nil {
+		common.Log.Debug("Unable to convert inline image: %v", err)
+		return keep
+	}
+	converted.Intent = inline.Intent
+	converted.Interpolate = inline.Interpolate
+	return []*contentstream.ContentStreamOperation{{Operand: "BI", Params: []core.PdfObject{converted}}}
+}
+
+// convertSamples returns the samples of `img` in color space `cs`, mapped with decode array `decode`
+// (the default of `cs` if nil), as 8 bit samples in the target space.
+func (c *ColorConverter) convertSamples(img *model.Image, cs model.PdfColorspace, decode []float64) ([]byte, error) {
+	n := cs.GetNumComponents()
+	bpc := int(img.BitsPerComponent)
+	if bpc < 1 || bpc > 16 {
+		return nil, errors.New("Invalid bits per component")
+	}
+	maxVal := float64(uint32(1)<<uint(bpc) - 1)
+	decode = imageDecode(cs, bpc, decode)
+
+	// The size is checked before the output is allocated.
+	if img.Width <= 0 || img.Height <= 0 || n < 1 ||
+		img.Width > int64(8*len(img.Data)/(n*bpc))/img.Height {
+		return nil, errors.New("Image size exceeds image data")
+	}
+
+	targetN := c.targetComponents()
+	data := make([]byte, 0, int(img.Width*img.Height)*targetN)
+	// Images often have few distinct colors, which are converted once.
+	cache := map[uint64][]byte{}
+	cacheable := n*bpc <= 64
+	br := newBitReader(img.Data)
+	vals := make([]float64, n)
+	for y := int64(0); y < img.Height; y++ {
+		for x := int64(0); x < img.Width; x++ {
+			var key uint64
+			for i := range vals {
+				v, ok := br.read(bpc)
+				if !ok {
+					return nil, errors.New("Too few image samples")
+				}
+				key = key<<uint(bpc) | v
+				vals[i] = interpolate(float64(v), 0, maxVal, decode[2*i], decode[2*i+1])
+			}
+			if pixel, has := cache[key]; cacheable && has {
+				data = append(data, pixel...)
+				continue
+			}
+			converted, err := c.convertFloats(cs, vals)
+			if err != nil {
+				return nil, err
+			}
+			pixel := make([]byte, targetN)
+			for i, val := range converted {
+				pixel[i] = byte(clamp01(val)*255 + 0.5)
+			}
+			if cacheable {
+				cache[key] = pixel
+			}
+			data = append(data, pixel...)
+		}
+		// Rows start at byte boundaries.
+		br.align()
+	}
+	return data, nil
+}
+
+// imageDecode returns image decode array `decode` for `bpc` bit samples in color space `cs`, or the
+// default one if `decode` is invalid.
+func imageDecode(cs model.PdfColorspace, bpc int, decode []float64) []float64 {
+	n := cs.GetNumComponents()
+	if len(decode) >= 2*n {
+		return decode
+	}
+	if _, ok := cs.(*model.PdfColorspaceSpecialIndexed); ok {
+		return []float64{0, float64(uint32(1)<<uint(bpc) - 1)}
+	}
+	decode = cs.DecodeArray()
+	if len(decode) >= 2*n {
+		return decode
+	}
+	decode = nil
+	for i := 0; i < n; i++ {
+		decode = append(decode, 0, 1)
+	}
+	return decode
+}
+
+// colorKeyMask returns the stencil mask of `img`, with `n` components per sample, that masks the
+// samples whose components are all in the ranges of color key mask `ranges`.
+func colorKeyMask(img *model.Image, n int, ranges *core.PdfObjectArray) (*core.PdfObjectStream, error) {
+	vals, err := ranges.ToIntegerArray()
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) < 2*n {
+		return nil, errors.New("Invalid color key mask")
+	}
+
+	bpc := int(img.BitsPerComponent)
+	br := newBitReader(img.Data)
+	bw := &bitWriter{}
+	for y := int64(0); y < img.Height; y++ {
+		for x := int64(0); x < img.Width; x++ {
+			masked := true
+			for i := 0; i < n; i++ {
+				v, ok := br.read(bpc)
+				if !ok {
+					return nil, errors.New("Too few image samples")
+				}
+				if int(v) < vals[2*i] || int(v) > vals[2*i+1] {
+					masked = false
+				}
+			}
+			// Samples of 1 are masked out.
+			if masked {
+				bw.write(1, 1)
+			} else {
+				bw.write(0, 1)
+			}
+		}
+		br.align()
+		bw.align()
+	}
+
+	dict := core.MakeDict()
+	dict.Set("Type", core.MakeName("XObject"))
+	dict.Set("Subtype", core.MakeName("Image"))
+	dict.Set("Width", core.MakeInteger(img.Width))
+	dict.Set("Height", core.MakeInteger(img.Height))
+	imageMask := core.PdfObjectBool(true)
+	dict.Set("ImageMask", &imageMask)
+	stream := &core.PdfObjectStream{PdfObjectDictionary: dict}
+	if err := setStreamData(stream, bw.data); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// bitReader reads big-endian values of any number of bits from packed data, such as image samples.
+type bitReader struct {
+	data []byte
+	pos  int // Position in bits.
+}
+
+// newBitReader returns a reader of `data`.
+func newBitReader(data []byte) *bitReader {
+	return &bitReader{data: data}
+}
+
+// read returns the next `n` bits of the data. The bool return flag is false at the end of the data.
+func (br *bitReader) read(n int) (uint64, bool) {
+	if br.pos+n > 8*len(br.data) {
+		return 0, false
+	}
+	var v uint64
+	for i := 0; i < n; i++ {
+		bit := br.data[br.pos/8] >> uint(7-br.pos%8) & 1
+		v = v<<1 | uint64(bit)
+		br.pos++
+	}
+	return v, true
+}
+
+// align skips to the start of the next byte.
+func (br *bitReader) align() {
+	br.pos = (br.pos + 7) / 8 * 8
+}
+
+// bitWriter writes big-endian values of any number of bits as packed data.
+type bitWriter struct {
+	data []byte
+	pos  int // Position in bits.
+}
+
+// write appends the `n` low bits of `v` to the data.
+func (bw *bitWriter) write(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if bw.pos%8 == 0 {
+			bw.data = append(bw.data, 0)
+		}
+		if v>>uint(i)&1 != 0 {
+			bw.data[bw.pos/8] |= 1 << uint(7-bw.pos%8)
+		}
+		bw.pos++
+	}
+}
+
+// align pads the data with zero bits to the next byte.
+func (bw *bitWriter) align() {
+	bw.pos = (bw.pos + 7) / 8 * 8
+}
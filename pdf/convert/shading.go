@@ -0,0 +1,256 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package convert
+
+import (
+	"errors"
+
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// Number of samples per input of the functions that replace the functions of shadings: functions of
+// one input (axial, radial and mesh shadings) and of two inputs (function-based shadings).
+const (
+	shadingSamples   = 256
+	shadingSamples2D = 64
+)
+
+// convertShading converts `shading` to the target space: its functions are replaced by sampled
+// functions in the target space, or the colors of its vertices are converted for mesh shadings
+// without functions. The alternate spaces of kept spot color spaces are converted instead.
+func (c *ColorConverter) convertShading(shading *model.PdfShading) error {
+	container := shading.GetContainingPdfObject()
+	if c.visited[container] {
+		return nil
+	}
+	c.visited[container] = true
+
+	cs := shading.ColorSpace
+	if cs == nil {
+		return errors.New("Shading colorspace undefined")
+	}
+	if c.keepsColorspace(cs) {
+		converted, err := c.convertColorspace(cs)
+		if err != nil {
+			return err
+		}
+		shading.ColorSpace = converted
+		shading.ToPdfObject()
+		return nil
+	}
+
+	if shading.Background != nil {
+		shading.Background = c.convertArray(cs, shading.Background)
+	}
+
+	var err error
+	switch t := shading.GetContext().(type) {
+	case *model.PdfShadingType1:
+		t.Function, err = c.convertShadingFunctions(cs, t.Function, t.Domain, []float64{0, 1, 0, 1})
+	case *model.PdfShadingType2:
+		t.Function, err = c.convertShadingFunctions(cs, t.Function, t.Domain, []float64{0, 1})
+	case *model.PdfShadingType3:
+		t.Function, err = c.convertShadingFunctions(cs, t.Function, t.Domain, []float64{0, 1})
+	case *model.PdfShadingType4:
+		t.Function, t.Decode, err = c.convertMesh(shading, 4, t.Function, t.Decode, t.BitsPerCoordinate,
+			t.BitsPerComponent, t.BitsPerFlag)
+	case *model.PdfShadingType5:
+		t.Function, t.Decode, err = c.convertMesh(shading, 5, t.Function, t.Decode, t.BitsPerCoordinate,
+			t.BitsPerComponent, nil)
+	case *model.PdfShadingType6:
+		t.Function, t.Decode, err = c.convertMesh(shading, 6, t.Function, t.Decode, t.BitsPerCoordinate,
+			t.BitsPerComponent, t.BitsPerFlag)
+	case *model.PdfShadingType7:
+		t.Function, t.Decode, err = c.convertMesh(shading, 7, t.Function, t.Decode, t.BitsPerCoordinate,
+			t.BitsPerComponent, t.BitsPerFlag)
+	default:
+		return errors.New("Unsupported shading type")
+	}
+	if err != nil {
+		return err
+	}
+
+	shading.ColorSpace = c.targetColorspace()
+	shading.GetContext().ToPdfObject()
+	return nil
+}
+
+// convertShadingFunctions returns a sampled function in the target space that takes the place of
+// shading functions `funcs` in color space `cs`, over domain `domainArr` (`defaultDomain` if nil).
+func (c *ColorConverter) convertShadingFunctions(cs model.PdfColorspace, funcs []model.PdfFunction,
+	domainArr *core.PdfObjectArray, defaultDomain []float64) ([]model.PdfFunction, error) {
+	if len(funcs) == 0 {
+		return nil, errors.New("Shading function missing")
+	}
+	domain := defaultDomain
+	if domainArr != nil {
+		var err error
+		domain, err = domainArr.ToFloat64Array()
+		if err != nil {
+			return nil, err
+		}
+		if len(domain) != len(defaultDomain) {
+			return nil, errors.New("Invalid shading domain")
+		}
+	}
+	return c.sampleShadingFunctions(cs, funcs, domain)
+}
+
+// sampleShadingFunctions returns a sampled function in the target space of shading functions
+// `funcs` in color space `cs`, over domain `domain`.
+func (c *ColorConverter) sampleShadingFunctions(cs model.PdfColorspace, funcs []model.PdfFunction,
+	domain []float64) ([]model.PdfFunction, error) {
+	steps := shadingSamples
+	if len(domain) == 4 {
+		steps = shadingSamples2D
+	}
+	size := make([]int, len(domain)/2)
+	for i := range size {
+		size[i] = steps
+	}
+
+	f, err := c.sampleFunction(domain, size, func(x []float64) ([]float64, error) {
+		// Either a single function with all the components, or one function per component.
+		var vals []float64
+		for _, fun := range funcs {
+			out, err := fun.Evaluate(x)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, out...)
+		}
+		return c.convertFloats(cs, vals)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []model.PdfFunction{f}, nil
+}
+
+// convertMesh converts mesh shading `shading` of type `shadingType` (4 to 7) with functions `funcs`
+// and decode array `decodeArr`. It returns the functions and decode array of the converted shading.
+// The functions are replaced by a sampled function if present, otherwise the colors of the vertices
+// are converted and the stream of the shading is rewritten.
+func (c *ColorConverter) convertMesh(shading *model.PdfShading, shadingType int, funcs []model.PdfFunction,
+	decodeArr *core.PdfObjectArray, bitsPerCoordinate, bitsPerComponent,
+	bitsPerFlag *core.PdfObjectInteger) ([]model.PdfFunction, *core.PdfObjectArray, error) {
+	if decodeArr == nil || bitsPerCoordinate == nil || bitsPerComponent == nil {
+		return nil, nil, errors.New("Mesh shading parameters missing")
+	}
+	decode, err := decodeArr.ToFloat64Array()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(funcs) > 0 {
+		// The vertices have a single parametric value, the input of the functions.
+		if len(decode) < 6 {
+			return nil, nil, errors.New("Invalid mesh shading Decode")
+		}
+		funcs, err = c.sampleShadingFunctions(shading.ColorSpace, funcs, decode[4:6])
+		return funcs, decodeArr, err
+	}
+
+	stream, ok := shading.GetContainingPdfObject().(*core.PdfObjectStream)
+	if !ok {
+		return nil, nil, errors.New("Mesh shading not a stream")
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		return nil, nil, err
+	}
+	converted, err := c.convertMeshData(data, shadingType, shading.ColorSpace, decode,
+		int(*bitsPerCoordinate), int(*bitsPerComponent), bitsPerFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := setStreamData(stream, converted); err != nil {
+		return nil, nil, err
+	}
+
+	newDecode := append([]float64{}, decode[:4]...)
+	for i := 0; i < c.targetComponents(); i++ {
+		newDecode = append(newDecode, 0, 1)
+	}
+	return nil, core.MakeArrayFromFloats(newDecode), nil
+}
+
+// convertMeshData returns the vertex data `data` of a mesh shading of type `shadingType` in color
+// space `cs` with the colors of the vertices converted to the target space, with components in the
+// range 0 to 1. The flags and coordinates are copied as they are. Incomplete vertices or patches at
+// the end of the data are dropped.
+func (c *ColorConverter) convertMeshData(data []byte, shadingType int, cs model.PdfColorspace,
+	decode []float64, bpc, bpcomp int, bitsPerFlag *core.PdfObjectInteger) ([]byte, error) {
+	bpf := 0
+	if bitsPerFlag != nil {
+		bpf = int(*bitsPerFlag)
+	}
+	n := cs.GetNumComponents()
+	if bpc < 1 || bpc > 32 || bpcomp < 1 || bpcomp > 16 || bpf < 0 || bpf > 8 {
+		return nil, errors.New("Invalid mesh shading bit sizes")
+	}
+	if len(decode) < 4+2*n {
+		return nil, errors.New("Invalid mesh shading Decode")
+	}
+	maxVal := float64(uint32(1)<<uint(bpcomp) - 1)
+
+	br := newBitReader(data)
+	var out []byte
+	for {
+		// Each vertex or patch starts at a byte boundary and is written to `elem` until complete.
+		elem := &bitWriter{}
+		numPoints, numColors := 1, 1
+		if shadingType != 5 {
+			flag, ok := br.read(bpf)
+			if !ok {
+				break
+			}
+			elem.write(flag, bpf)
+			switch shadingType {
+			case 6:
+				numPoints, numColors = 12, 4
+			case 7:
+				numPoints, numColors = 16, 4
+			}
+			if shadingType >= 6 && flag != 0 {
+				// The first edge is shared with the previous patch.
+				numPoints, numColors = numPoints-4, 2
+			}
+		}
+
+		ok := true
+		for i := 0; i < 2*numPoints && ok; i++ {
+			var v uint64
+			v, ok = br.read(bpc)
+			elem.write(v, bpc)
+		}
+		vals := make([]float64, n)
+		for i := 0; i < numColors && ok; i++ {
+			for j := 0; j < n && ok; j++ {
+				var v uint64
+				v, ok = br.read(bpcomp)
+				vals[j] = interpolate(float64(v), 0, maxVal, decode[4+2*j], decode[5+2*j])
+			}
+			if !ok {
+				break
+			}
+			converted, err := c.convertFloats(cs, vals)
+			if err != nil {
+				return nil, err
+			}
+			for _, val := range converted {
+				elem.write(uint64(clamp01(val)*maxVal+0.5), bpcomp)
+			}
+		}
+		if !ok {
+			break
+		}
+		br.align()
+		out = append(out, elem.data...)
+	}
+	return out, nil
+}
@@ -14,6 +14,7 @@ import (
 	goimage "image"
 	"io/ioutil"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/boombuler/barcode"
@@ -37,6 +38,7 @@ const testImageFile1 = "../../testfiles/logo.png"
 const testImageFile2 = "../../testfiles/signature.png"
 const testRobotoRegularTTFFile = "../../testfiles/roboto/Roboto-Regular.ttf"
 const testRobotoBoldTTFFile = "../../testfiles/roboto/Roboto-Bold.ttf"
+const testCFFOTFFile = "../../testfiles/otf/CFFTest.otf"
 
 func TestTemplate1(t *testing.T) {
 	creator := New()
@@ -524,6 +526,79 @@ func TestParagraphFonts(t *testing.T) {
 	}
 }
 
+// Test writing with an OpenType font with CFF outlines.
+func TestParagraphOTFFont(t *testing.T) {
+	creator := New()
+
+	otf, err := model.NewPdfFontFromTTFFile(testCFFOTFFile)
+	if err != nil {
+		t.Errorf("Fail: %v\n", err)
+		return
+	}
+
+	p := NewParagraph("0110 Q1 00Q 1Q0 Q01")
+	p.SetFont(otf)
+	p.SetFontSize(24)
+	p.SetLineHeight(1.2)
+
+	err = creator.Draw(p)
+	if err != nil {
+		t.Errorf("Fail: %v\n", err)
+		return
+	}
+
+	err = creator.WriteToFile("/tmp/2_pOTF.pdf")
+	if err != nil {
+		t.Errorf("Fail: %v\n", err)
+		return
+	}
+}
+
+// Test kerning and ligatures in paragraph layout.
+func TestParagraphKerning(t *testing.T) {
+	p := NewParagraph("AVA To")
+	p.SetFontSize(10)
+	unkerned := p.getTextWidth()
+	p.SetEnableKerning(true)
+	kerned := p.getTextWidth()
+	// Helvetica: A V -70, V A -80, T o -120.
+	if math.Abs(unkerned-kerned-10*(70+80+120)) > 1e-6 {
+		t.Errorf("Kerned width %v, unkerned width %v", kerned, unkerned)
+	}
+
+	// The kerning is emitted as TJ adjustments.
+	blk := NewBlock(612, 792)
+	if _, err := drawParagraphOnBlock(blk, p, DrawContext{PageWidth: 612, PageHeight: 792}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	content := string(blk.contents.Bytes())
+	if !strings.Contains(content, "[(A) 70.000000 (V) 80.000000 (A) -278.000000 (T) 120.000000 (o)] TJ") {
+		t.Errorf("Incorrect content stream: %s", content)
+	}
+
+	// Ligatures require a text encoding with the ligature glyphs.
+	p = NewParagraph("fine")
+	p.SetFont(fonts.NewFontTimesRoman())
+	p.SetEncoder(textencoding.NewStandardTextEncoder())
+	p.SetEnableLigatures(true)
+	glyphs, err := p.getGlyphs([]rune(p.Text()))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(glyphs) != 3 || glyphs[0].glyph != "fi" || string(glyphs[0].runes) != "fi" {
+		t.Errorf("Incorrect glyphs %v", glyphs)
+	}
+
+	creator := New()
+	p.SetText("fine flow. Ligatures fi fl.")
+	if err := creator.Draw(p); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if err := creator.WriteToFile("/tmp/2_p_kerning.pdf"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+}
+
 // Test writing with the 14 built in fonts.
 func TestParagraphStandardFonts(t *testing.T) {
 	creator := New()
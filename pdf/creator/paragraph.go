@@ -61,6 +61,10 @@ type Paragraph struct {
 	// Scaling factors (1 default).
 	scaleX, scaleY float64
 
+	// Kerning and standard ligature substitution (both disabled by default), if supported by the font.
+	enableKerning   bool
+	enableLigatures bool
+
 	// Text lines after wrapping to available width.
 	textLines []string
 }
@@ -132,6 +136,19 @@ func (p *Paragraph) SetEnableWrap(enableWrap bool) {
 	p.enableWrap = enableWrap
 }
 
+// SetEnableKerning sets whether the kerning of the font (if any) is applied to pairs of glyphs. Disabled
+// by default.
+func (p *Paragraph) SetEnableKerning(enableKerning bool) {
+	p.enableKerning = enableKerning
+}
+
+// SetEnableLigatures sets whether pairs of glyphs are replaced by standard ligatures of the font, e.g. "fi"
+// for "f" followed by "i". Ligatures are only used if the text encoding has a character code for them.
+// Disabled by default.
+func (p *Paragraph) SetEnableLigatures(enableLigatures bool) {
+	p.enableLigatures = enableLigatures
+}
+
 // SetColor set the color of the Paragraph text.
 //
 // Example:
@@ -204,28 +221,102 @@ func (p *Paragraph) Height() float64 {
 	return h
 }
 
-// Calculate the text width (if not wrapped).
-func (p *Paragraph) getTextWidth() float64 {
-	w := float64(0.0)
+// paragraphGlyph is a glyph of the laid out text: a single rune or a pair of runes replaced by a
+// ligature.
+type paragraphGlyph struct {
+	runes []rune
+	glyph string
+	wx    float64 // Advance width in thousandths of a unit of text space.
+	kern  float64 // Kerning with the preceding glyph in thousandths of a unit of text space.
+}
 
-	for _, rune := range p.text {
-		glyph, found := p.encoder.RuneToGlyph(rune)
+// width returns the width of the glyph including its kerning (font size * thousandths of a point).
+func (g paragraphGlyph) width(fontSize float64) float64 {
+	return fontSize * (g.wx + g.kern)
+}
+
+// getGlyphs converts `runes` to glyphs, substituting ligatures and kerning the glyph pairs if enabled.
+// Spaces are not kerned as they are drawn as position adjustments.
+func (p *Paragraph) getGlyphs(runes []rune) ([]paragraphGlyph, error) {
+	kernedFont, _ := p.textFont.(fonts.KernedFont)
+	ligatureFont, _ := p.textFont.(fonts.LigatureFont)
+
+	glyphs := []paragraphGlyph{}
+	for _, val := range runes {
+		glyph, found := p.encoder.RuneToGlyph(val)
 		if !found {
-			common.Log.Debug("Error! Glyph not found for rune: %s\n", rune)
-			return -1 // XXX/FIXME: return error.
+			common.Log.Debug("ERROR: Glyph not found for rune: %v", val)
+			return nil, errors.New("Glyph not found for rune")
+		}
+
+		n := len(glyphs)
+		if p.enableLigatures && ligatureFont != nil && n > 0 && len(glyphs[n-1].runes) == 1 {
+			prev := &glyphs[n-1]
+			if ligature, ok := ligatureFont.GetLigature(prev.glyph, glyph); ok {
+				_, encodable := p.encoder.GlyphToCharcode(ligature)
+				metrics, found := p.textFont.GetGlyphCharMetrics(ligature)
+				if encodable && found {
+					prev.runes = append(prev.runes, val)
+					prev.glyph = ligature
+					prev.wx = metrics.Wx
+					prev.kern = 0
+					if n > 1 {
+						prev.kern = p.getKerning(kernedFont, glyphs[n-2].glyph, ligature)
+					}
+					continue
+				}
+			}
 		}
 
 		metrics, found := p.textFont.GetGlyphCharMetrics(glyph)
 		if !found {
-			common.Log.Debug("Glyph char metrics not found! %s\n", glyph)
-			return -1 // XXX/FIXME: return error.
+			common.Log.Debug("ERROR: Glyph char metrics not found! %s", glyph)
+			return nil, errors.New("Glyph char metrics missing")
 		}
-		w += p.fontSize * metrics.Wx
+		g := paragraphGlyph{runes: []rune{val}, glyph: glyph, wx: metrics.Wx}
+		if n > 0 {
+			g.kern = p.getKerning(kernedFont, glyphs[n-1].glyph, glyph)
+		}
+		glyphs = append(glyphs, g)
+	}
+
+	return glyphs, nil
+}
+
+// getKerning returns the kerning of glyph `right` following glyph `left` in thousandths of a unit of
+// text space, or 0 if kerning is disabled or not supported by the font.
+func (p *Paragraph) getKerning(font fonts.KernedFont, left, right string) float64 {
+	if !p.enableKerning || font == nil || left == "space" || right == "space" {
+		return 0
+	}
+	kx, _ := font.GetKerning(left, right)
+	return kx
+}
+
+// Calculate the text width (if not wrapped).
+func (p *Paragraph) getTextWidth() float64 {
+	glyphs, err := p.getGlyphs([]rune(p.text))
+	if err != nil {
+		return -1 // XXX/FIXME: return error.
+	}
+
+	w := float64(0.0)
+	for _, g := range glyphs {
+		w += g.width(p.fontSize)
 	}
 
 	return w
 }
 
+// glyphsToString returns the text of `glyphs`.
+func glyphsToString(glyphs []paragraphGlyph) string {
+	runes := []rune{}
+	for _, g := range glyphs {
+		runes = append(runes, g.runes...)
+	}
+	return string(runes)
+}
+
 // Simple algorithm to wrap the text into lines (greedy algorithm - fill the lines).
 // XXX/TODO: Consider the Knuth/Plass algorithm or an alternative.
 func (p *Paragraph) wrapText() error {
@@ -234,71 +325,53 @@ func (p *Paragraph) wrapText() error {
 		return nil
 	}
 
-	line := []rune{}
+	glyphs, err := p.getGlyphs([]rune(p.text))
+	if err != nil {
+		return err
+	}
+
+	line := []paragraphGlyph{}
 	lineWidth := 0.0
 	p.textLines = []string{}
 
-	runes := []rune(p.text)
-	glyphs := []string{}
-	widths := []float64{}
-
-	for _, val := range runes {
-		glyph, found := p.encoder.RuneToGlyph(val)
-		if !found {
-			common.Log.Debug("ERROR: Glyph not found for rune: %v", val)
-			return errors.New("Glyph not found for rune") // XXX/FIXME: return error.
-		}
-
-		metrics, found := p.textFont.GetGlyphCharMetrics(glyph)
-		if !found {
-			common.Log.Debug("ERROR: Glyph char metrics not found! %s", glyph)
-			return errors.New("Glyph char metrics missing") // XXX/FIXME: return error.
-		}
-
-		w := p.fontSize * metrics.Wx
+	for _, g := range glyphs {
+		w := g.width(p.fontSize)
 		if lineWidth+w > p.wrapWidth*1000.0 {
 			// Goes out of bounds: Wrap.
 			// Breaks on the character.
 			// XXX/TODO: when goes outside: back up to next space, otherwise break on the character.
 			idx := -1
-			for i := len(glyphs) - 1; i >= 0; i-- {
-				if glyphs[i] == "space" {
+			for i := len(line) - 1; i >= 0; i-- {
+				if line[i].glyph == "space" {
 					idx = i
 					break
 				}
 			}
 			if idx > 0 {
-				p.textLines = append(p.textLines, string(line[0:idx+1]))
-
-				line = line[idx+1:]
-				line = append(line, val)
+				p.textLines = append(p.textLines, glyphsToString(line[0:idx+1]))
 
-				glyphs = glyphs[idx+1:]
-				glyphs = append(glyphs, glyph)
-				widths = widths[idx+1:]
-				widths = append(widths, w)
+				// Glyphs following a space are not kerned.
+				line = append(line[idx+1:], g)
 
 				lineWidth = 0
-				for _, width := range widths {
-					lineWidth += width
+				for _, lg := range line {
+					lineWidth += lg.width(p.fontSize)
 				}
 
 			} else {
-				p.textLines = append(p.textLines, string(line))
-				line = []rune{val}
-				lineWidth = w
-				widths = []float64{w}
-				glyphs = []string{glyph}
+				p.textLines = append(p.textLines, glyphsToString(line))
+				// The first glyph of a line is not kerned.
+				g.kern = 0
+				line = []paragraphGlyph{g}
+				lineWidth = g.width(p.fontSize)
 			}
 		} else {
-			line = append(line, val)
+			line = append(line, g)
 			lineWidth += w
-			glyphs = append(glyphs, glyph)
-			widths = append(widths, w)
 		}
 	}
 	if len(line) > 0 {
-		p.textLines = append(p.textLines, string(line))
+		p.textLines = append(p.textLines, glyphsToString(line))
 	}
 
 	return nil
@@ -408,28 +481,20 @@ func drawParagraphOnBlock(blk *Block, p *Paragraph, ctx DrawContext) (DrawContex
 			cc.Add_Tstar()
 		}
 
-		runes := []rune(line)
+		glyphs, err := p.getGlyphs([]rune(line))
+		if err != nil {
+			return ctx, err
+		}
 
 		// Get width of the line (excluding spaces).
 		w := float64(0)
 		spaces := 0
-		for _, runeVal := range runes {
-			glyph, found := p.encoder.RuneToGlyph(runeVal)
-			if !found {
-				common.Log.Debug("Rune 0x%x not supported by text encoder", runeVal)
-				return ctx, errors.New("Unsupported rune in text encoding")
-			}
-			if glyph == "space" {
+		for _, g := range glyphs {
+			if g.glyph == "space" {
 				spaces++
 				continue
 			}
-			metrics, found := p.textFont.GetGlyphCharMetrics(glyph)
-			if !found {
-				common.Log.Debug("Unsupported glyph %s in font\n", glyph)
-				return ctx, errors.New("Unsupported text glyph")
-			}
-
-			w += p.fontSize * metrics.Wx
+			w += g.width(p.fontSize)
 		}
 
 		objs := []core.PdfObject{}
@@ -455,27 +520,31 @@ func drawParagraphOnBlock(blk *Block, p *Paragraph, ctx DrawContext) (DrawContex
 		}
 
 		encStr := ""
-		for _, runeVal := range runes {
-			//creator.Add_Tj(core.PdfObjectString(tb.Encoder.Encode(line)))
-			glyph, found := p.encoder.RuneToGlyph(runeVal)
-			if !found {
-				common.Log.Debug("Rune 0x%x not supported by text encoder", runeVal)
-				return ctx, errors.New("Unsupported rune in text encoding")
-			}
-
-			if glyph == "space" {
-				if !found {
-					common.Log.Debug("Unsupported glyph %s in font\n", glyph)
-					return ctx, errors.New("Unsupported text glyph")
+		for _, g := range glyphs {
+			if g.glyph == "space" {
+				if len(encStr) > 0 {
+					objs = append(objs, core.MakeString(encStr))
+					encStr = ""
 				}
+				objs = append(objs, core.MakeFloat(-spaceWidth))
+				continue
+			}
 
+			if g.kern != 0 {
+				// Positive TJ adjustments move the next glyph to the left.
 				if len(encStr) > 0 {
 					objs = append(objs, core.MakeString(encStr))
 					encStr = ""
 				}
-				objs = append(objs, core.MakeFloat(-spaceWidth))
+				objs = append(objs, core.MakeFloat(-g.kern))
+			}
+
+			if len(g.runes) > 1 {
+				// Ligature.
+				code, _ := p.encoder.GlyphToCharcode(g.glyph)
+				encStr += string([]byte{code})
 			} else {
-				encStr += string(p.encoder.Encode(string(runeVal)))
+				encStr += p.encoder.Encode(string(g.runes))
 			}
 		}
 		if len(encStr) > 0 {
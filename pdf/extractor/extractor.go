@@ -9,8 +9,16 @@ import "github.com/unidoc/unidoc/pdf/model"
 
 // Extractor stores and offers functionality for extracting content from PDF pages.
 type Extractor struct {
-	contents  string
-	resources *model.PdfPageResources
+	contents    string
+	resources   *model.PdfPageResources
+	annotations []*model.PdfAnnotation
+
+	// The visible region of the page (CropBox or MediaBox) and its rotation in degrees clockwise.
+	// pageBox is nil if not known.
+	pageBox *model.PdfRectangle
+	rotate  int
+
+	includeAnnotations bool
 }
 
 // New returns an Extractor instance for extracting content from the input PDF page.
@@ -23,6 +31,22 @@ func New(page *model.PdfPage) (*Extractor, error) {
 	e := &Extractor{}
 	e.contents = contents
 	e.resources = page.Resources
+	e.annotations = page.Annotations
+	if page.CropBox != nil {
+		e.pageBox = page.CropBox
+	} else if mediaBox, err := page.GetMediaBox(); err == nil {
+		e.pageBox = mediaBox
+	}
+	if page.Rotate != nil {
+		e.rotate = (int(*page.Rotate)%360 + 360) % 360
+	}
 
 	return e, nil
 }
+
+// IncludeAnnotations sets whether the text of the appearance streams of the page's annotations, e.g.
+// the values of filled form fields, is extracted along with the text of the page's content stream.
+// Hidden annotations are skipped.
+func (e *Extractor) IncludeAnnotations(include bool) {
+	e.includeAnnotations = include
+}
@@ -0,0 +1,256 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// HOCROptions are options of the hOCR export of the text of pages.
+type HOCROptions struct {
+	// Scale is the number of pixels per point of the coordinates, e.g. 300/72.0 to compare with the
+	// output of OCR of the page rendered at 300 DPI. 0 means 1 pixel per point (72 DPI).
+	Scale float64
+}
+
+const hocrHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="ocr-system" content="unidoc">
+<meta name="ocr-capabilities" content="ocr_page ocr_carea ocr_par ocr_line ocrx_word">
+<title></title>
+</head>
+<body>
+`
+
+const hocrFooter = `</body>
+</html>
+`
+
+// WriteHOCR writes the text of the pages of `reader` to `w` as an hOCR document: an HTML document with
+// an ocr_page element per page, as returned by ExtractHOCR.
+func WriteHOCR(w io.Writer, reader *model.PdfReader, opts HOCROptions) error {
+	numPages, err := reader.GetNumPages()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(hocrHeader)
+	for pageNum := 1; pageNum <= numPages; pageNum++ {
+		page, err := reader.GetPage(pageNum)
+		if err != nil {
+			return err
+		}
+		e, err := New(page)
+		if err != nil {
+			return err
+		}
+		pageHTML, err := e.ExtractHOCR(pageNum, opts)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(pageHTML)
+	}
+	buf.WriteString(hocrFooter)
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// ExtractHOCR returns the text of the page of `e`, page number `pageNum` of its document, as an hOCR
+// page: an HTML div of class ocr_page that contains the blocks (ocr_carea), paragraphs (ocr_par),
+// lines (ocr_line) and words (ocrx_word) of the page layout in reading order. Each element has the
+// standard hOCR title with its bounding box in pixels from the top left corner of the rotated page,
+// and the words have their font and font size (x_font, x_fsize).
+// The lines and words are absolutely positioned spans with their font family and size, so the page
+// is also displayed as positioned HTML.
+func (e *Extractor) ExtractHOCR(pageNum int, opts HOCROptions) (string, error) {
+	layout, err := e.ExtractPageLayout()
+	if err != nil {
+		return "", err
+	}
+	p := e.newHOCRPage(layout, opts)
+
+	var buf bytes.Buffer
+	width, height := p.size()
+	fmt.Fprintf(&buf, "<div class=\"ocr_page\" id=\"page_%d\" title=\"bbox 0 0 %d %d; ppageno %d\" "+
+		"style=\"position:relative;width:%spx;height:%spx\">\n",
+		pageNum, pixel(width), pixel(height), pageNum-1, cssNumber(width), cssNumber(height))
+
+	var numPars, numLines, numWords int
+	for i, block := range layout.Blocks {
+		fmt.Fprintf(&buf, "<div class=\"ocr_carea\" id=\"block_%d_%d\" title=\"%s\">\n",
+			pageNum, i+1, p.bboxTitle(block.BBox))
+		for _, para := range block.Paragraphs {
+			numPars++
+			fmt.Fprintf(&buf, "<p class=\"ocr_par\" id=\"par_%d_%d\" title=\"%s\">\n",
+				pageNum, numPars, p.bboxTitle(para.BBox))
+			for _, line := range para.Lines {
+				numLines++
+				lineBox := p.toPage(line.BBox)
+				fmt.Fprintf(&buf, "<span class=\"ocr_line\" id=\"line_%d_%d\" title=\"%s; x_size %s\" "+
+					"style=\"position:absolute;left:%spx;top:%spx;width:%spx;height:%spx\">",
+					pageNum, numLines, p.bboxTitle(line.BBox), cssNumber(lineSize(line)*p.scale),
+					cssNumber(lineBox.x0), cssNumber(lineBox.y0),
+					cssNumber(lineBox.x1-lineBox.x0), cssNumber(lineBox.y1-lineBox.y0))
+				for j, word := range line.Words {
+					numWords++
+					if j > 0 {
+						buf.WriteString(" ")
+					}
+					buf.WriteString(p.wordHTML(word, lineBox, block.Rotation, pageNum, numWords))
+				}
+				buf.WriteString("</span>\n")
+			}
+			buf.WriteString("</p>\n")
+		}
+		buf.WriteString("</div>\n")
+	}
+	buf.WriteString("</div>\n")
+	return buf.String(), nil
+}
+
+// hocrPage maps the device space of a page to pixels from the top left corner of the page as
+// displayed, i.e. rotated by its Rotate entry.
+type hocrPage struct {
+	box    model.PdfRectangle
+	rotate int
+	scale  float64
+}
+
+// pixelRect is a rectangle in the pixel coordinates of a hocrPage.
+type pixelRect struct {
+	x0, y0, x1, y1 float64
+}
+
+// newHOCRPage returns the hocrPage of the page of `e`. If the page's box is not known, it extends
+// from the origin to the text of `layout`.
+func (e *Extractor) newHOCRPage(layout *PageLayout, opts HOCROptions) hocrPage {
+	p := hocrPage{rotate: e.rotate, scale: opts.Scale}
+	if p.scale <= 0 {
+		p.scale = 1
+	}
+	if e.pageBox != nil {
+		p.box = *e.pageBox
+	} else {
+		for _, block := range layout.Blocks {
+			p.box.Urx = math.Max(p.box.Urx, block.BBox.Urx)
+			p.box.Ury = math.Max(p.box.Ury, block.BBox.Ury)
+		}
+	}
+	return p
+}
+
+// size returns the width and height of the page in pixels.
+func (p hocrPage) size() (float64, float64) {
+	w, h := (p.box.Urx-p.box.Llx)*p.scale, (p.box.Ury-p.box.Lly)*p.scale
+	if p.rotate == 90 || p.rotate == 270 {
+		return h, w
+	}
+	return w, h
+}
+
+// toPage returns device space rectangle `r` in page pixel coordinates.
+func (p hocrPage) toPage(r model.PdfRectangle) pixelRect {
+	x0, y0 := p.toPixel(r.Llx, r.Lly)
+	x1, y1 := p.toPixel(r.Urx, r.Ury)
+	return pixelRect{math.Min(x0, x1), math.Min(y0, y1), math.Max(x0, x1), math.Max(y0, y1)}
+}
+
+// toPixel returns device space point (`x`, `y`) in page pixel coordinates.
+func (p hocrPage) toPixel(x, y float64) (float64, float64) {
+	b := p.box
+	var px, py float64
+	switch p.rotate {
+	case 90:
+		px, py = y-b.Lly, x-b.Llx
+	case 180:
+		px, py = b.Urx-x, y-b.Lly
+	case 270:
+		px, py = b.Ury-y, b.Urx-x
+	default:
+		px, py = x-b.Llx, b.Ury-y
+	}
+	return px * p.scale, py * p.scale
+}
+
+// bboxTitle returns the hOCR bbox property of device space rectangle `r`.
+func (p hocrPage) bboxTitle(r model.PdfRectangle) string {
+	pr := p.toPage(r)
+	return fmt.Sprintf("bbox %d %d %d %d", pixel(pr.x0), pixel(pr.y0), pixel(pr.x1), pixel(pr.y1))
+}
+
+// wordHTML returns the ocrx_word span of `word`, number `num` of page `pageNum`, positioned in the
+// line with box `lineBox`. `rotation` is the direction of the word's text in device space.
+func (p hocrPage) wordHTML(word TextWord, lineBox pixelRect, rotation, pageNum, num int) string {
+	box := p.toPage(word.BBox)
+	title := p.bboxTitle(word.BBox)
+	style := fmt.Sprintf("position:absolute;left:%spx;top:%spx;width:%spx;height:%spx",
+		cssNumber(box.x0-lineBox.x0), cssNumber(box.y0-lineBox.y0), cssNumber(box.x1-box.x0),
+		cssNumber(box.y1-box.y0))
+
+	if family := fontFamily(word.FontName); family != "" {
+		title += "; x_font " + family
+		style += ";font-family:'" + family + "'"
+	}
+	title += "; x_fsize " + cssNumber(word.FontSize)
+	style += ";font-size:" + cssNumber(word.FontSize*p.scale) + "px"
+
+	// Text that runs down or up the displayed page is written vertically.
+	switch ((rotation-p.rotate)%360 + 360) % 360 {
+	case 270:
+		style += ";writing-mode:vertical-rl"
+	case 90:
+		style += ";writing-mode:sideways-lr"
+	}
+
+	return fmt.Sprintf("<span class=\"ocrx_word\" id=\"word_%d_%d\" title=\"%s\" style=\"%s\">%s</span>",
+		pageNum, num, html.EscapeString(title), html.EscapeString(style), html.EscapeString(word.Text))
+}
+
+// lineSize returns the largest font size of the words of `line`.
+func lineSize(line TextLine) float64 {
+	size := 0.0
+	for _, w := range line.Words {
+		size = math.Max(size, w.FontSize)
+	}
+	return size
+}
+
+// fontFamily returns the font family of font `name`: the name without the tag of font subsets,
+// e.g. Helvetica-Bold for ABCDEF+Helvetica-Bold, and without characters that are not valid in hOCR
+// titles and CSS strings.
+func fontFamily(name string) string {
+	if len(name) > 7 && name[6] == '+' && strings.Trim(name[:6], "ABCDEFGHIJKLMNOPQRSTUVWXYZ") == "" {
+		name = name[7:]
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '\'' || r == '"' || r == ';' || r == '\\' || r < ' ' {
+			return -1
+		}
+		return r
+	}, name)
+}
+
+// pixel returns `v` rounded to a whole number of pixels.
+func pixel(v float64) int {
+	return int(math.Floor(v + 0.5))
+}
+
+// cssNumber returns `v` rounded to 2 decimal places and formatted without trailing zeros.
+func cssNumber(v float64) string {
+	return strconv.FormatFloat(math.Floor(v*100+0.5)/100, 'f', -1, 64)
+}
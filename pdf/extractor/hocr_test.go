@@ -0,0 +1,98 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// Helvetica widths: H 722, e 556, l 222, o 556, space 278, W 944, r 333, d 556.
+const testHOCRContents = `BT /F1 10 Tf 100 700 Td (Hello World) Tj ET`
+
+func TestHOCR(t *testing.T) {
+	e := Extractor{
+		contents:  testHOCRContents,
+		resources: helveticaResources(),
+		pageBox:   &model.PdfRectangle{Urx: 612, Ury: 792},
+	}
+	s, err := e.ExtractHOCR(1, HOCROptions{Scale: 2})
+	if err != nil {
+		t.Fatalf("Error extracting hOCR: %v", err)
+	}
+
+	// "Hello" extends from 100 to 122.78 and "World" from 125.56 to 151.67, from the descent at 698
+	// to the ascent at 708. The coordinates are scaled by 2 from the top of the page.
+	expected := []string{
+		`<div class="ocr_page" id="page_1" title="bbox 0 0 1224 1584; ppageno 0" ` +
+			`style="position:relative;width:1224px;height:1584px">`,
+		`<div class="ocr_carea" id="block_1_1" title="bbox 200 168 303 188">`,
+		`<p class="ocr_par" id="par_1_1" title="bbox 200 168 303 188">`,
+		`<span class="ocr_line" id="line_1_1" title="bbox 200 168 303 188; x_size 20" ` +
+			`style="position:absolute;left:200px;top:168px;width:103.34px;height:20px">`,
+		`<span class="ocrx_word" id="word_1_1" title="bbox 200 168 246 188; x_font Helvetica; x_fsize 10" ` +
+			`style="position:absolute;left:0px;top:0px;width:45.56px;height:20px;` +
+			`font-family:&#39;Helvetica&#39;;font-size:20px">Hello</span> `,
+		`<span class="ocrx_word" id="word_1_2" title="bbox 251 168 303 188; x_font Helvetica; x_fsize 10" ` +
+			`style="position:absolute;left:51.12px;top:0px;width:52.22px;height:20px;` +
+			`font-family:&#39;Helvetica&#39;;font-size:20px">World</span></span>`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(s, exp) {
+			t.Errorf("Missing %s in:\n%s", exp, s)
+		}
+	}
+
+	// On a page rotated by 90 degrees the left of the page is at the top.
+	e.rotate = 90
+	s, err = e.ExtractHOCR(1, HOCROptions{})
+	if err != nil {
+		t.Fatalf("Error extracting hOCR: %v", err)
+	}
+	for _, exp := range []string{`title="bbox 0 0 792 612; ppageno 0"`,
+		`title="bbox 698 100 708 123; x_font Helvetica; x_fsize 10"`, "writing-mode:vertical-rl"} {
+		if !strings.Contains(s, exp) {
+			t.Errorf("Missing %s in:\n%s", exp, s)
+		}
+	}
+
+	if family := fontFamily("ABCDEF+Times-Bold"); family != "Times-Bold" {
+		t.Errorf("Font family %q != Times-Bold", family)
+	}
+}
+
+func TestWriteHOCR(t *testing.T) {
+	data := makeTestPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 5 0 R] /Count 2 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R " +
+			"/Resources << /Font << /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> >> >> >>",
+		makeStreamObject(testHOCRContents),
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 300 400] /CropBox [0 0 200 300] >>",
+	})
+	reader, err := model.NewPdfReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error reading PDF: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHOCR(&buf, reader, HOCROptions{}); err != nil {
+		t.Fatalf("Error writing hOCR: %v", err)
+	}
+	s := buf.String()
+	if !strings.HasPrefix(s, "<!DOCTYPE html>") || !strings.HasSuffix(s, "</html>\n") {
+		t.Errorf("Not an HTML document:\n%s", s)
+	}
+	for _, exp := range []string{`title="bbox 0 0 612 792; ppageno 0"`, `title="bbox 0 0 200 300; ppageno 1"`,
+		`title="bbox 100 84 123 94; x_font Helvetica; x_fsize 10"`, ">Hello</span>"} {
+		if !strings.Contains(s, exp) {
+			t.Errorf("Missing %s in:\n%s", exp, s)
+		}
+	}
+}
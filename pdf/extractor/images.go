@@ -0,0 +1,287 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// ImageMarkType is the role of an image drawn on a page.
+type ImageMarkType int
+
+const (
+	// ImageMarkImage is an image painted with its own colors.
+	ImageMarkImage ImageMarkType = iota
+	// ImageMarkStencil is an image mask (ImageMask true) that paints the current fill color.
+	ImageMarkStencil
+	// ImageMarkMask is the stencil mask (Mask entry) of an image.
+	ImageMarkMask
+	// ImageMarkSoftMask is the soft mask (SMask entry) of an image.
+	ImageMarkSoftMask
+)
+
+// ImageOptions control how the images of a page are returned by ExtractImages.
+type ImageOptions struct {
+	// Decode decodes the data of each image into ImageMark.Image.
+	Decode bool
+}
+
+// ImageMark is an image drawn on a page, either an image XObject, possibly painted by a form XObject,
+// or an inline image.
+type ImageMark struct {
+	Type ImageMarkType
+	// Name is the resource name of the image XObject, or of the image whose mask the mark is. It is
+	// empty for inline images.
+	Name   string
+	Inline bool
+	// CTM is the current transformation matrix when the image is drawn. It maps the unit square, in
+	// which images are drawn, to the page's default user space.
+	CTM contentstream.Matrix
+	// BBox is the bounding box of the image on the page in the page's default user space.
+	BBox model.PdfRectangle
+	// Width and Height are the size of the image in samples.
+	Width  int64
+	Height int64
+	// DPIX and DPIY are the effective resolutions of the image in samples per inch along its width and
+	// height as drawn on the page. They are 0 if the image is drawn with no extent.
+	DPIX float64
+	DPIY float64
+	// ColorSpace is the color space of the image. It is nil for stencil masks and if the color space
+	// could not be loaded.
+	ColorSpace       model.PdfColorspace
+	BitsPerComponent int64
+	// Filters are the names of the filters of the image data in the order they are applied when
+	// decoding. The abbreviated names of inline images are expanded, e.g. Fl to FlateDecode.
+	Filters []string
+	// Size is the length in bytes of the encoded image data.
+	Size int
+	// XObject is the image XObject of the mark and InlineImage the inline image. Only one of them is
+	// set.
+	XObject     *model.XObjectImage
+	InlineImage *contentstream.ContentStreamInlineImage
+	// Image is the decoded image. It is only set if ImageOptions.Decode is set and the image data
+	// could be decoded.
+	Image *model.Image
+}
+
+// inlineFilterNames are the full names of the abbreviated filter names of inline images
+// (Table 94 "Additional Abbreviations in an Inline Image Object").
+var inlineFilterNames = map[string]string{
+	"AHx": "ASCIIHexDecode",
+	"A85": "ASCII85Decode",
+	"LZW": "LZWDecode",
+	"Fl":  "FlateDecode",
+	"RL":  "RunLengthDecode",
+	"CCF": "CCITTFaxDecode",
+	"DCT": "DCTDecode",
+}
+
+// ExtractImages returns the images drawn by the content stream of `e` in the order they are drawn,
+// including those drawn by form XObjects. An image drawn several times has a mark for each time it
+// is drawn. The stencil and soft masks of an image XObject follow its mark. Images that cannot be
+// read are skipped.
+func (e *Extractor) ExtractImages(opts ImageOptions) ([]ImageMark, error) {
+	operations, err := contentstream.NewContentStreamParser(e.contents).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var marks []ImageMark
+	processor := contentstream.NewContentStreamProcessor(*operations)
+	processor.EnableFormRecursion()
+	processor.AddHandler(contentstream.HandlerConditionEnumAllOperands, "",
+		func(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState,
+			resources *model.PdfPageResources) error {
+			if len(op.Params) != 1 {
+				return nil
+			}
+			switch op.Operand {
+			case "Do":
+				name, ok := op.Params[0].(*core.PdfObjectName)
+				if !ok || resources == nil {
+					return nil
+				}
+				stream, xtype := resources.GetXObjectByName(*name)
+				if xtype != model.XObjectTypeImage {
+					return nil
+				}
+				mark, ok := newXObjectImageMark(stream, string(*name), ImageMarkImage, gs.CTM, opts)
+				if !ok {
+					return nil
+				}
+				marks = append(marks, mark)
+				if mask, ok := core.TraceToDirectObject(mark.XObject.Mask).(*core.PdfObjectStream); ok {
+					if maskMark, ok := newXObjectImageMark(mask, mark.Name, ImageMarkMask, gs.CTM, opts); ok {
+						marks = append(marks, maskMark)
+					}
+				}
+				if smask, ok := core.TraceToDirectObject(mark.XObject.SMask).(*core.PdfObjectStream); ok {
+					if maskMark, ok := newXObjectImageMark(smask, mark.Name, ImageMarkSoftMask, gs.CTM, opts); ok {
+						marks = append(marks, maskMark)
+					}
+				}
+			case "BI":
+				inline, ok := op.Params[0].(*contentstream.ContentStreamInlineImage)
+				if !ok {
+					return nil
+				}
+				if mark, ok := newInlineImageMark(inline, resources, gs.CTM, opts); ok {
+					marks = append(marks, mark)
+				}
+			}
+			return nil
+		})
+
+	if err := processor.Process(e.resources); err != nil {
+		return nil, err
+	}
+	return marks, nil
+}
+
+// newXObjectImageMark returns the mark of type `markType` for image XObject `stream` with resource
+// name `name` drawn with CTM `ctm`. false is returned if the image cannot be read.
+func newXObjectImageMark(stream *core.PdfObjectStream, name string, markType ImageMarkType,
+	ctm contentstream.Matrix, opts ImageOptions) (ImageMark, bool) {
+	ximg, err := model.NewXObjectImageFromStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to read image %s: %v", name, err)
+		return ImageMark{}, false
+	}
+
+	mark := newImageMark(markType, ctm, *ximg.Width, *ximg.Height)
+	mark.Name = name
+	mark.XObject = ximg
+	mark.Filters = filterNames(stream.Get("Filter"), nil)
+	mark.Size = len(stream.Stream)
+	if isStencil, ok := core.TraceToDirectObject(ximg.ImageMask).(*core.PdfObjectBool); ok && bool(*isStencil) {
+		if markType == ImageMarkImage {
+			mark.Type = ImageMarkStencil
+		}
+		if ximg.BitsPerComponent == nil {
+			// Image masks have 1 bit per component and BitsPerComponent is optional.
+			bpc := int64(1)
+			ximg.BitsPerComponent = &bpc
+		}
+	} else {
+		mark.ColorSpace = ximg.ColorSpace
+	}
+	if ximg.BitsPerComponent != nil {
+		mark.BitsPerComponent = *ximg.BitsPerComponent
+	}
+
+	if opts.Decode {
+		img, err := ximg.ToImage()
+		if err != nil {
+			common.Log.Debug("Unable to decode image %s: %v", name, err)
+		}
+		mark.Image = img
+	}
+	return mark, true
+}
+
+// newInlineImageMark returns the mark for inline image `inline` of a content stream with resources
+// `resources` drawn with CTM `ctm`. false is returned if the image cannot be read.
+func newInlineImageMark(inline *contentstream.ContentStreamInlineImage, resources *model.PdfPageResources,
+	ctm contentstream.Matrix, opts ImageOptions) (ImageMark, bool) {
+	width, ok := core.TraceToDirectObject(inline.Width).(*core.PdfObjectInteger)
+	if !ok {
+		common.Log.Debug("Invalid inline image width %v", inline.Width)
+		return ImageMark{}, false
+	}
+	height, ok := core.TraceToDirectObject(inline.Height).(*core.PdfObjectInteger)
+	if !ok {
+		common.Log.Debug("Invalid inline image height %v", inline.Height)
+		return ImageMark{}, false
+	}
+
+	mark := newImageMark(ImageMarkImage, ctm, int64(*width), int64(*height))
+	mark.Inline = true
+	mark.InlineImage = inline
+	mark.Filters = filterNames(inline.Filter, inlineFilterNames)
+	mark.Size = len(inline.GetEncodedData())
+	if isStencil, _ := inline.IsMask(); isStencil {
+		mark.Type = ImageMarkStencil
+		mark.BitsPerComponent = 1
+	} else {
+		if resources != nil {
+			cs, err := inline.GetColorSpace(resources)
+			if err != nil {
+				common.Log.Debug("Unable to load inline image colorspace: %v", err)
+			}
+			mark.ColorSpace = cs
+		}
+		mark.BitsPerComponent = 8
+		if bpc, ok := core.TraceToDirectObject(inline.BitsPerComponent).(*core.PdfObjectInteger); ok {
+			mark.BitsPerComponent = int64(*bpc)
+		}
+	}
+
+	if opts.Decode {
+		img, err := inline.ToImage(resources)
+		if err != nil {
+			common.Log.Debug("Unable to decode inline image: %v", err)
+		}
+		mark.Image = img
+	}
+	return mark, true
+}
+
+// newImageMark returns a mark of type `markType` for an image of `width` x `height` samples drawn
+// with CTM `ctm`, with its bounding box and resolution.
+func newImageMark(markType ImageMarkType, ctm contentstream.Matrix, width, height int64) ImageMark {
+	mark := ImageMark{Type: markType, CTM: ctm, Width: width, Height: height}
+
+	x0, y0 := ctm.Transform(0, 0)
+	mark.BBox = model.PdfRectangle{Llx: x0, Lly: y0, Urx: x0, Ury: y0}
+	for _, corner := range [][2]float64{{1, 0}, {0, 1}, {1, 1}} {
+		x, y := ctm.Transform(corner[0], corner[1])
+		mark.BBox.Llx = math.Min(mark.BBox.Llx, x)
+		mark.BBox.Lly = math.Min(mark.BBox.Lly, y)
+		mark.BBox.Urx = math.Max(mark.BBox.Urx, x)
+		mark.BBox.Ury = math.Max(mark.BBox.Ury, y)
+	}
+
+	// The width and height of the image are the lengths of the images of the unit square's sides.
+	if w := math.Hypot(ctm[0], ctm[1]); w > 0 {
+		mark.DPIX = float64(width) * 72 / w
+	}
+	if h := math.Hypot(ctm[3], ctm[4]); h > 0 {
+		mark.DPIY = float64(height) * 72 / h
+	}
+	return mark
+}
+
+// filterNames returns the names of the filters in Filter entry `obj`, a name or an array of names,
+// with the names in `abbreviations` replaced by their full names.
+func filterNames(obj core.PdfObject, abbreviations map[string]string) []string {
+	var names []core.PdfObject
+	switch t := core.TraceToDirectObject(obj).(type) {
+	case *core.PdfObjectName:
+		names = []core.PdfObject{t}
+	case *core.PdfObjectArray:
+		names = *t
+	}
+
+	var filters []string
+	for _, obj := range names {
+		name, ok := core.TraceToDirectObject(obj).(*core.PdfObjectName)
+		if !ok {
+			common.Log.Debug("Invalid filter name %v", obj)
+			continue
+		}
+		filter := string(*name)
+		if full, ok := abbreviations[filter]; ok {
+			filter = full
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
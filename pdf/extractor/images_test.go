@@ -0,0 +1,129 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+const testImagesContents = `
+q 144 0 0 72 100 200 cm /Im1 Do Q
+q 2 0 0 2 0 0 cm /Fm1 Do Q
+q 36 0 0 18 10 10 cm BI /W 2 /H 1 /CS /RGB /BPC 8 /F /AHx ID FF000000FF00> EI Q
+q 0 36 -36 0 300 300 cm BI /W 8 /H 1 /IM true /F [/AHx] ID AA> EI Q
+`
+
+func TestExtractImages(t *testing.T) {
+	smaskDict := core.MakeDict()
+	smaskDict.Set("Subtype", core.MakeName("Image"))
+	smaskDict.Set("Width", core.MakeInteger(2))
+	smaskDict.Set("Height", core.MakeInteger(2))
+	smaskDict.Set("ColorSpace", core.MakeName("DeviceGray"))
+	smaskDict.Set("BitsPerComponent", core.MakeInteger(8))
+	smask := makeStream(smaskDict, "\x00\x40\x80\xff")
+
+	imageDict := core.MakeDict()
+	imageDict.Set("Subtype", core.MakeName("Image"))
+	imageDict.Set("Width", core.MakeInteger(2))
+	imageDict.Set("Height", core.MakeInteger(2))
+	imageDict.Set("ColorSpace", core.MakeName("DeviceRGB"))
+	imageDict.Set("BitsPerComponent", core.MakeInteger(8))
+	imageDict.Set("Filter", core.MakeName("ASCIIHexDecode"))
+	imageDict.Set("SMask", smask)
+	image := makeStream(imageDict, "FF0000 00FF00 0000FF FFFFFF>")
+
+	// The form draws the image of the page with a different name.
+	formDict := core.MakeDict()
+	formDict.Set("Subtype", core.MakeName("Form"))
+	formDict.Set("BBox", core.MakeArrayFromFloats([]float64{0, 0, 100, 100}))
+	formDict.Set("Matrix", core.MakeArrayFromFloats([]float64{1, 0, 0, 1, 10, 0}))
+	form := makeStream(formDict, "20 0 0 10 0 0 cm /Img Do")
+	xobjects := core.MakeDict()
+	xobjects.Set("Img", image)
+	formResources := core.MakeDict()
+	formResources.Set("XObject", xobjects)
+	formDict.Set("Resources", formResources)
+
+	resources := model.NewPdfPageResources()
+	resources.SetXObjectByName("Im1", image)
+	resources.SetXObjectByName("Fm1", form)
+	e := &Extractor{contents: testImagesContents, resources: resources}
+
+	marks, err := e.ExtractImages(ImageOptions{Decode: true})
+	if err != nil {
+		t.Fatalf("Error extracting images: %v", err)
+	}
+
+	type expectedMark struct {
+		markType   ImageMarkType
+		name       string
+		inline     bool
+		bbox       model.PdfRectangle
+		dpiX, dpiY float64
+		colorspace string
+		bpc        int64
+		filters    []string
+		size       int
+		data       []byte
+	}
+	rgb := []byte{0xff, 0, 0, 0, 0xff, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}
+	alpha := []byte{0, 0x40, 0x80, 0xff}
+	expected := []expectedMark{
+		{ImageMarkImage, "Im1", false, model.PdfRectangle{Llx: 100, Lly: 200, Urx: 244, Ury: 272}, 1, 2,
+			"DeviceRGB", 8, []string{"ASCIIHexDecode"}, 28, rgb},
+		{ImageMarkSoftMask, "Im1", false, model.PdfRectangle{Llx: 100, Lly: 200, Urx: 244, Ury: 272}, 1, 2,
+			"DeviceGray", 8, nil, 4, alpha},
+		{ImageMarkImage, "Img", false, model.PdfRectangle{Llx: 20, Lly: 0, Urx: 60, Ury: 20}, 3.6, 7.2,
+			"DeviceRGB", 8, []string{"ASCIIHexDecode"}, 28, rgb},
+		{ImageMarkSoftMask, "Img", false, model.PdfRectangle{Llx: 20, Lly: 0, Urx: 60, Ury: 20}, 3.6, 7.2,
+			"DeviceGray", 8, nil, 4, alpha},
+		{ImageMarkImage, "", true, model.PdfRectangle{Llx: 10, Lly: 10, Urx: 46, Ury: 28}, 4, 4,
+			"DeviceRGB", 8, []string{"ASCIIHexDecode"}, 13, []byte{0xff, 0, 0, 0, 0xff, 0}},
+		{ImageMarkStencil, "", true, model.PdfRectangle{Llx: 264, Lly: 300, Urx: 300, Ury: 336}, 16, 2,
+			"", 1, []string{"ASCIIHexDecode"}, 3, []byte{0xaa}},
+	}
+
+	if len(marks) != len(expected) {
+		t.Fatalf("Expected %d images, got %d: %+v", len(expected), len(marks), marks)
+	}
+	for i, exp := range expected {
+		mark := marks[i]
+		bboxOK := math.Abs(mark.BBox.Llx-exp.bbox.Llx) < 1e-6 && math.Abs(mark.BBox.Lly-exp.bbox.Lly) < 1e-6 &&
+			math.Abs(mark.BBox.Urx-exp.bbox.Urx) < 1e-6 && math.Abs(mark.BBox.Ury-exp.bbox.Ury) < 1e-6
+		if mark.Type != exp.markType || mark.Name != exp.name || mark.Inline != exp.inline || !bboxOK ||
+			math.Abs(mark.DPIX-exp.dpiX) > 1e-6 || math.Abs(mark.DPIY-exp.dpiY) > 1e-6 ||
+			mark.BitsPerComponent != exp.bpc || !reflect.DeepEqual(mark.Filters, exp.filters) ||
+			mark.Size != exp.size {
+			t.Errorf("Image %d: expected %+v, got %+v", i, exp, mark)
+		}
+		colorspace := ""
+		if mark.ColorSpace != nil {
+			colorspace = mark.ColorSpace.String()
+		}
+		if colorspace != exp.colorspace {
+			t.Errorf("Image %d: expected colorspace %q, got %q", i, exp.colorspace, colorspace)
+		}
+		if mark.Image == nil || !reflect.DeepEqual(mark.Image.Data, exp.data) {
+			t.Errorf("Image %d: expected data %v, got %+v", i, exp.data, mark.Image)
+		}
+	}
+
+	// Without decoding, no image data is returned.
+	marks, err = e.ExtractImages(ImageOptions{})
+	if err != nil {
+		t.Fatalf("Error extracting images: %v", err)
+	}
+	for i, mark := range marks {
+		if mark.Image != nil {
+			t.Errorf("Image %d decoded", i)
+		}
+	}
+}
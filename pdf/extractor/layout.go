@@ -0,0 +1,626 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// PageLayout is the text of a page organized in blocks, paragraphs, lines and words.
+// The blocks are in reading order: text in the dominant direction of the page comes first, and
+// within each direction blocks are ordered top to bottom and columns left to right.
+type PageLayout struct {
+	Blocks []TextBlock
+}
+
+// TextBlock is a region of text that is separated from the text around it by whitespace, e.g. a
+// column, a heading or a group of paragraphs.
+type TextBlock struct {
+	Paragraphs []TextParagraph
+	BBox       model.PdfRectangle
+	// Rotation is the direction of the text in degrees counterclockwise from the x axis of the page.
+	Rotation int
+
+	// Lines of the block in the block's coordinate system, used for the physical layout.
+	lines []*layoutLine
+}
+
+// TextParagraph is a paragraph of text in a TextBlock.
+type TextParagraph struct {
+	Lines []TextLine
+	BBox  model.PdfRectangle
+}
+
+// TextLine is a line of text in a TextParagraph.
+type TextLine struct {
+	Words []TextWord
+	BBox  model.PdfRectangle
+}
+
+// TextWord is a word in a TextLine.
+type TextWord struct {
+	Text     string
+	BBox     model.PdfRectangle
+	FontSize float64
+	// FontName is the BaseFont of the font of the word's first character, "" if it is not known.
+	FontName string
+}
+
+// The following parameters of the layout analysis are in units of font size.
+const (
+	// ascentRatio and descentRatio give the extent of glyphs above and below the baseline.
+	ascentRatio  = 0.8
+	descentRatio = 0.2
+	// lineTolerance is the maximum baseline offset of words on the same line.
+	lineTolerance = 0.5
+	// blockGapY is the minimum vertical whitespace between blocks.
+	blockGapY = 0.5
+	// blockGapX is the minimum horizontal whitespace between columns.
+	blockGapX = 1.0
+	// indentThreshold is the minimum indentation of the first line of a paragraph.
+	indentThreshold = 1.0
+)
+
+// paragraphSpacing is the ratio of the spacing between lines of different paragraphs of a block to
+// the typical spacing between its lines.
+const paragraphSpacing = 1.4
+
+// ExtractPageLayout returns the text of `e` organized in blocks, paragraphs, lines and words in
+// reading order.
+func (e *Extractor) ExtractPageLayout() (*PageLayout, error) {
+	textList, err := e.ExtractXYText()
+	if err != nil {
+		return nil, err
+	}
+	return textList.Layout(), nil
+}
+
+// Layout analyzes the positions of the characters in `tl` and returns its text organized in blocks,
+// paragraphs, lines and words in reading order.
+// Characters are grouped by the direction of their baselines, so rotated text is handled. For each
+// direction, characters are joined into words and the words are split into blocks by recursive
+// XY-cuts along the widest whitespace gaps. The lines of each block are then grouped into paragraphs
+// by their spacing and indentation.
+func (tl *TextList) Layout() *PageLayout {
+	groups := map[int][]layoutChar{}
+	for _, t := range *tl {
+		for _, c := range t.layoutChars() {
+			groups[c.rotation] = append(groups[c.rotation], c)
+		}
+	}
+
+	rotations := make([]int, 0, len(groups))
+	for rotation := range groups {
+		rotations = append(rotations, rotation)
+	}
+	// The dominant direction first.
+	sort.Slice(rotations, func(i, j int) bool {
+		ni, nj := len(groups[rotations[i]]), len(groups[rotations[j]])
+		if ni != nj {
+			return ni > nj
+		}
+		return rotations[i] < rotations[j]
+	})
+
+	layout := &PageLayout{}
+	for _, rotation := range rotations {
+		f := newLayoutFrame(rotation)
+		words := f.makeWords(groups[rotation])
+		for _, blockWords := range xyCut(words) {
+			layout.Blocks = append(layout.Blocks, f.makeBlock(blockWords))
+		}
+	}
+	return layout
+}
+
+// ToText returns the text of the layout in reading order. Lines are separated by newlines and
+// paragraphs and blocks by empty lines.
+func (l *PageLayout) ToText() string {
+	var buf bytes.Buffer
+	for i, block := range l.Blocks {
+		if i > 0 {
+			buf.WriteString("\n\n")
+		}
+		for j, para := range block.Paragraphs {
+			if j > 0 {
+				buf.WriteString("\n\n")
+			}
+			for k, line := range para.Lines {
+				if k > 0 {
+					buf.WriteString("\n")
+				}
+				buf.WriteString(line.Text())
+			}
+		}
+	}
+	procBuf(&buf)
+	return buf.String()
+}
+
+// ToPhysicalText returns the text of the layout with its physical layout preserved, similar to
+// pdftotext -layout. Words are placed in a grid of characters at positions proportional to their
+// positions on the page, and empty lines are inserted for vertical whitespace. Text of different
+// directions is output separately, the dominant direction first.
+func (l *PageLayout) ToPhysicalText() string {
+	var rotations []int
+	linesByRotation := map[int][]*layoutLine{}
+	for _, block := range l.Blocks {
+		if _, has := linesByRotation[block.Rotation]; !has {
+			rotations = append(rotations, block.Rotation)
+		}
+		linesByRotation[block.Rotation] = append(linesByRotation[block.Rotation], block.lines...)
+	}
+
+	var buf bytes.Buffer
+	for i, rotation := range rotations {
+		if i > 0 {
+			buf.WriteString("\n\n")
+		}
+		buf.WriteString(physicalText(linesByRotation[rotation]))
+	}
+	procBuf(&buf)
+	return buf.String()
+}
+
+// Text returns the words of the line separated by spaces.
+func (line TextLine) Text() string {
+	words := make([]string, len(line.Words))
+	for i, w := range line.Words {
+		words[i] = w.Text
+	}
+	return strings.Join(words, " ")
+}
+
+// layoutChar is a character in device space with the direction of its baseline.
+type layoutChar struct {
+	textChar
+	size     float64
+	rotation int
+	// The appearance of the text of the character. nil if not known.
+	style *textStyle
+}
+
+// layoutChars returns the characters of `t`. If the positions of its characters are not known, the
+// text is divided evenly along its baseline.
+func (t XYText) layoutChars() []layoutChar {
+	rotation := baselineRotation(t.X, t.Y, t.EndX, t.EndY, 0)
+
+	chars := t.chars
+	if chars == nil {
+		n := utf8.RuneCountInString(t.Text)
+		i := 0
+		for _, r := range t.Text {
+			a, b := float64(i)/float64(n), float64(i+1)/float64(n)
+			chars = append(chars, textChar{
+				text: string(r),
+				x:    t.X + a*(t.EndX-t.X), y: t.Y + a*(t.EndY-t.Y),
+				endX: t.X + b*(t.EndX-t.X), endY: t.Y + b*(t.EndY-t.Y),
+			})
+			i++
+		}
+	}
+
+	out := make([]layoutChar, len(chars))
+	for i, c := range chars {
+		out[i] = layoutChar{
+			textChar: c,
+			size:     t.FontSize,
+			rotation: baselineRotation(c.x, c.y, c.endX, c.endY, rotation),
+			style:    t.style,
+		}
+	}
+	return out
+}
+
+// baselineRotation returns the direction from (`x0`, `y0`) to (`x1`, `y1`) in whole degrees
+// counterclockwise from the x axis, or `def` if the points coincide.
+func baselineRotation(x0, y0, x1, y1 float64, def int) int {
+	if x0 == x1 && y0 == y1 {
+		return def
+	}
+	deg := int(math.Floor(math.Atan2(y1-y0, x1-x0)*180/math.Pi + 0.5))
+	return (deg%360 + 360) % 360
+}
+
+// layoutFrame is a coordinate system in which text of direction `rotation` runs left to right, i.e.
+// device space rotated by -`rotation` degrees.
+type layoutFrame struct {
+	rotation int
+	cos, sin float64
+}
+
+func newLayoutFrame(rotation int) layoutFrame {
+	theta := float64(rotation) * math.Pi / 180
+	return layoutFrame{rotation: rotation, cos: math.Cos(theta), sin: math.Sin(theta)}
+}
+
+// toFrame converts device space point (`x`, `y`) to the frame.
+func (f layoutFrame) toFrame(x, y float64) (float64, float64) {
+	return x*f.cos + y*f.sin, -x*f.sin + y*f.cos
+}
+
+// toDevice converts frame point (`u`, `v`) to device space.
+func (f layoutFrame) toDevice(u, v float64) (float64, float64) {
+	return u*f.cos - v*f.sin, u*f.sin + v*f.cos
+}
+
+// deviceRect returns the bounding box in device space of the frame rectangle (`x0`, `y0`, `x1`,
+// `y1`).
+func (f layoutFrame) deviceRect(x0, y0, x1, y1 float64) model.PdfRectangle {
+	r := model.PdfRectangle{Llx: math.Inf(1), Lly: math.Inf(1), Urx: math.Inf(-1), Ury: math.Inf(-1)}
+	for _, p := range [][2]float64{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y1}} {
+		x, y := f.toDevice(p[0], p[1])
+		r.Llx, r.Lly = math.Min(r.Llx, x), math.Min(r.Lly, y)
+		r.Urx, r.Ury = math.Max(r.Urx, x), math.Max(r.Ury, y)
+	}
+	return r
+}
+
+// layoutWord is a word in frame coordinates. It extends from `x0` to `x1` along baseline `base`.
+type layoutWord struct {
+	text   string
+	x0, x1 float64
+	base   float64
+	size   float64
+	chars  []layoutChar
+}
+
+// fontName returns the name of the font of the first character of `w`.
+func (w *layoutWord) fontName() string {
+	if len(w.chars) == 0 || w.chars[0].style == nil {
+		return ""
+	}
+	return w.chars[0].style.fontName
+}
+
+func (w *layoutWord) y0() float64 { return w.base - descentRatio*w.size }
+func (w *layoutWord) y1() float64 { return w.base + ascentRatio*w.size }
+
+// layoutLine is a line of words in frame coordinates, ordered left to right.
+type layoutLine struct {
+	words []*layoutWord
+}
+
+func (l *layoutLine) x0() float64 { return l.words[0].x0 }
+
+func (l *layoutLine) x1() float64 {
+	x1 := l.words[0].x1
+	for _, w := range l.words {
+		x1 = math.Max(x1, w.x1)
+	}
+	return x1
+}
+
+// base returns the baseline of the line: that of its largest word.
+func (l *layoutLine) base() float64 {
+	largest := l.words[0]
+	for _, w := range l.words {
+		if w.size > largest.size {
+			largest = w
+		}
+	}
+	return largest.base
+}
+
+func (l *layoutLine) size() float64 {
+	size := 0.0
+	for _, w := range l.words {
+		size = math.Max(size, w.size)
+	}
+	return size
+}
+
+// makeWords joins `chars`, in content stream order, into words. Words are separated by whitespace
+// characters, gaps along the baseline and changes of baseline.
+func (f layoutFrame) makeWords(chars []layoutChar) []*layoutWord {
+	var words []*layoutWord
+	var w *layoutWord
+	for _, c := range chars {
+		if strings.TrimFunc(c.text, unicode.IsSpace) == "" && c.text != "" {
+			w = nil
+			continue
+		}
+		x0, base := f.toFrame(c.x, c.y)
+		x1, _ := f.toFrame(c.endX, c.endY)
+		if x1 < x0 {
+			x0, x1 = x1, x0
+		}
+
+		if w != nil {
+			size := math.Max(w.size, c.size)
+			gap := x0 - w.x1
+			if math.Abs(base-w.base) > lineTolerance*size || gap > spaceThreshold*size || gap < -lineTolerance*size {
+				w = nil
+			}
+		}
+		if w == nil {
+			w = &layoutWord{x0: x0, x1: x1, base: base, size: c.size}
+			words = append(words, w)
+		}
+		w.text += c.text
+		w.chars = append(w.chars, c)
+		w.x0 = math.Min(w.x0, x0)
+		w.x1 = math.Max(w.x1, x1)
+		w.size = math.Max(w.size, c.size)
+	}
+	return words
+}
+
+// xyCut splits `words` into blocks in reading order by recursively cutting along whitespace gaps:
+// horizontal gaps separate blocks from top to bottom and vertical gaps separate columns from left to
+// right. At each step the axis with the relatively widest gap is cut.
+func xyCut(words []*layoutWord) [][]*layoutWord {
+	if len(words) <= 1 {
+		return [][]*layoutWord{words}
+	}
+	size := medianSize(words)
+
+	yCuts, yGap := findCuts(words, func(w *layoutWord) (float64, float64) { return w.y0(), w.y1() },
+		blockGapY*size)
+	xCuts, xGap := findCuts(words, func(w *layoutWord) (float64, float64) { return w.x0, w.x1 },
+		blockGapX*size)
+	// Gaps between the words of a single line do not separate columns.
+	if len(xCuts) > 0 && len(makeLines(words)) < 2 {
+		xCuts = nil
+	}
+
+	var parts [][]*layoutWord
+	switch {
+	case len(yCuts) > 0 && (len(xCuts) == 0 || yGap/blockGapY >= xGap/blockGapX):
+		parts = splitWords(words, yCuts, func(w *layoutWord) float64 { return (w.y0() + w.y1()) / 2 })
+		// Top to bottom.
+		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+			parts[i], parts[j] = parts[j], parts[i]
+		}
+	case len(xCuts) > 0:
+		parts = splitWords(words, xCuts, func(w *layoutWord) float64 { return (w.x0 + w.x1) / 2 })
+	default:
+		return [][]*layoutWord{words}
+	}
+
+	var blocks [][]*layoutWord
+	for _, part := range parts {
+		if len(part) == len(words) {
+			// The cuts do not split the words, e.g. words with a positional extent of zero.
+			return [][]*layoutWord{words}
+		}
+		blocks = append(blocks, xyCut(part)...)
+	}
+	return blocks
+}
+
+// findCuts returns the positions of the gaps of at least `minGap` between the extents of `words`
+// given by `extent`, in increasing order, and the width of the widest gap. Gaps have a positive width.
+func findCuts(words []*layoutWord, extent func(*layoutWord) (float64, float64),
+	minGap float64) ([]float64, float64) {
+	type interval struct{ lo, hi float64 }
+	intervals := make([]interval, len(words))
+	for i, w := range words {
+		intervals[i].lo, intervals[i].hi = extent(w)
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].lo < intervals[j].lo })
+
+	var cuts []float64
+	maxGap := 0.0
+	hi := intervals[0].hi
+	for _, iv := range intervals[1:] {
+		if gap := iv.lo - hi; gap > 0 && gap >= minGap {
+			cuts = append(cuts, (iv.lo+hi)/2)
+			maxGap = math.Max(maxGap, gap)
+		}
+		hi = math.Max(hi, iv.hi)
+	}
+	return cuts, maxGap
+}
+
+// splitWords splits `words` at `cuts`, in increasing order, by the positions given by `pos`.
+func splitWords(words []*layoutWord, cuts []float64, pos func(*layoutWord) float64) [][]*layoutWord {
+	parts := make([][]*layoutWord, len(cuts)+1)
+	for _, w := range words {
+		i := sort.SearchFloat64s(cuts, pos(w))
+		parts[i] = append(parts[i], w)
+	}
+	return parts
+}
+
+// medianSize returns the median font size of `words`.
+func medianSize(words []*layoutWord) float64 {
+	sizes := make([]float64, len(words))
+	for i, w := range words {
+		sizes[i] = w.size
+	}
+	return median(sizes)
+}
+
+// median returns the lower median of `vals`, which is modified, or 0 if `vals` is empty.
+func median(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	sort.Float64s(vals)
+	return vals[(len(vals)-1)/2]
+}
+
+// makeLines groups `words` into lines ordered from top to bottom.
+func makeLines(words []*layoutWord) []*layoutLine {
+	sorted := make([]*layoutWord, len(words))
+	copy(sorted, words)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].base > sorted[j].base })
+
+	var lines []*layoutLine
+	var line *layoutLine
+	for _, w := range sorted {
+		if line != nil && math.Abs(line.base()-w.base) > lineTolerance*math.Max(line.size(), w.size) {
+			line = nil
+		}
+		if line == nil {
+			line = &layoutLine{}
+			lines = append(lines, line)
+		}
+		line.words = append(line.words, w)
+	}
+	for _, line := range lines {
+		sort.SliceStable(line.words, func(i, j int) bool { return line.words[i].x0 < line.words[j].x0 })
+	}
+	return lines
+}
+
+// makeBlock returns the block of `words`. A new paragraph starts at lines that follow a larger than
+// usual vertical space and at indented lines that follow an unindented line.
+func (f layoutFrame) makeBlock(words []*layoutWord) TextBlock {
+	lines := makeLines(words)
+	block := TextBlock{Rotation: f.rotation, lines: lines}
+
+	blockX0, blockX1 := math.Inf(1), math.Inf(-1)
+	var spacings []float64
+	for i, line := range lines {
+		blockX0 = math.Min(blockX0, line.x0())
+		blockX1 = math.Max(blockX1, line.x1())
+		if i > 0 {
+			spacings = append(spacings, lines[i-1].base()-line.base())
+		}
+	}
+	spacing := median(spacings)
+
+	var paraLines []*layoutLine
+	for i, line := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			size := line.size()
+			spaced := prev.base()-line.base() > paragraphSpacing*spacing
+			indented := line.x0()-blockX0 > indentThreshold*size &&
+				prev.x0()-blockX0 < lineTolerance*size &&
+				line.x1() > blockX1-indentThreshold*size
+			if spaced || indented {
+				block.Paragraphs = append(block.Paragraphs, f.makeParagraph(paraLines))
+				paraLines = nil
+			}
+		}
+		paraLines = append(paraLines, line)
+	}
+	block.Paragraphs = append(block.Paragraphs, f.makeParagraph(paraLines))
+
+	block.BBox = block.Paragraphs[0].BBox
+	for _, para := range block.Paragraphs[1:] {
+		block.BBox = unionRect(block.BBox, para.BBox)
+	}
+	return block
+}
+
+// makeParagraph returns the paragraph of `lines`.
+func (f layoutFrame) makeParagraph(lines []*layoutLine) TextParagraph {
+	var para TextParagraph
+	for i, l := range lines {
+		var line TextLine
+		for j, w := range l.words {
+			word := TextWord{
+				Text:     w.text,
+				BBox:     f.deviceRect(w.x0, w.y0(), w.x1, w.y1()),
+				FontSize: w.size,
+				FontName: w.fontName(),
+			}
+			line.Words = append(line.Words, word)
+			if j == 0 {
+				line.BBox = word.BBox
+			} else {
+				line.BBox = unionRect(line.BBox, word.BBox)
+			}
+		}
+		para.Lines = append(para.Lines, line)
+		if i == 0 {
+			para.BBox = line.BBox
+		} else {
+			para.BBox = unionRect(para.BBox, line.BBox)
+		}
+	}
+	return para
+}
+
+// unionRect returns the smallest rectangle that contains `a` and `b`.
+func unionRect(a, b model.PdfRectangle) model.PdfRectangle {
+	return model.PdfRectangle{
+		Llx: math.Min(a.Llx, b.Llx),
+		Lly: math.Min(a.Lly, b.Lly),
+		Urx: math.Max(a.Urx, b.Urx),
+		Ury: math.Max(a.Ury, b.Ury),
+	}
+}
+
+// The largest number of blank lines between rows and of spaces before a word in physicalText, which
+// bound the output when the row spacing or character width is tiny compared to the page.
+const (
+	maxPhysicalBlankLines = 10
+	maxPhysicalPadding    = 200
+)
+
+// physicalText returns `lines` laid out in a grid of characters. Lines with the same baseline are
+// put on the same row, so columns are kept side by side.
+func physicalText(lines []*layoutLine) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	var words []*layoutWord
+	var charWidths []float64
+	for _, line := range lines {
+		words = append(words, line.words...)
+		for _, w := range line.words {
+			if n := utf8.RuneCountInString(w.text); n > 0 && w.x1 > w.x0 {
+				charWidths = append(charWidths, (w.x1-w.x0)/float64(n))
+			}
+		}
+	}
+	charWidth := median(charWidths)
+	if charWidth <= 0 {
+		charWidth = 0.5 * medianSize(words)
+	}
+	if charWidth <= 0 {
+		charWidth = 1
+	}
+	minX := math.Inf(1)
+	for _, w := range words {
+		minX = math.Min(minX, w.x0)
+	}
+
+	rows := makeLines(words)
+	var gaps []float64
+	for i := 1; i < len(rows); i++ {
+		gaps = append(gaps, rows[i-1].base()-rows[i].base())
+	}
+	rowSpacing := median(gaps)
+
+	var buf bytes.Buffer
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString("\n")
+			if rowSpacing > 0 {
+				gap := rows[i-1].base() - row.base()
+				blank := math.Min(math.Floor(gap/rowSpacing+0.5)-1, maxPhysicalBlankLines)
+				for n := int(blank); n > 0; n-- {
+					buf.WriteString("\n")
+				}
+			}
+		}
+		col := 0
+		for j, w := range row.words {
+			target := col + int(math.Min(math.Floor((w.x0-minX)/charWidth+0.5)-float64(col),
+				maxPhysicalPadding))
+			if j > 0 && target <= col {
+				target = col + 1
+			}
+			buf.WriteString(strings.Repeat(" ", target-col))
+			buf.WriteString(w.text)
+			col = target + utf8.RuneCountInString(w.text)
+		}
+	}
+	return buf.String()
+}
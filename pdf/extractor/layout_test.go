@@ -0,0 +1,120 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// Two columns with a heading, drawn line by line across the columns, and rotated text in the margin.
+const testLayoutContents = `
+BT
+/F1 14 Tf
+50 740 Td
+(Heading)Tj
+ET
+BT
+/F1 10 Tf
+1 0 0 1 50 700 Tm
+(Left one)Tj
+1 0 0 1 300 700 Tm
+(Right one)Tj
+1 0 0 1 50 688 Tm
+(Left two)Tj
+1 0 0 1 300 688 Tm
+(Right two)Tj
+1 0 0 1 65 676 Tm
+(Indented)Tj
+1 0 0 1 50 664 Tm
+(Left four)Tj
+0 1 -1 0 20 600 Tm
+(Rotated)Tj
+ET
+`
+
+func TestPageLayout(t *testing.T) {
+	e := Extractor{contents: testLayoutContents, resources: helveticaResources()}
+	layout, err := e.ExtractPageLayout()
+	if err != nil {
+		t.Fatalf("Error extracting layout: %v", err)
+	}
+
+	expected := "Heading\n\nLeft one\nLeft two\n\nIndented\nLeft four\n\nRight one\nRight two\n\nRotated"
+	if s := layout.ToText(); s != expected {
+		t.Errorf("Text mismatch:\n%s\n!=\n%s", s, expected)
+	}
+
+	if n := len(layout.Blocks); n != 4 {
+		t.Fatalf("Expected 4 blocks, got %d", n)
+	}
+	rotated := layout.Blocks[3]
+	if rotated.Rotation != 90 {
+		t.Errorf("Rotation %d != 90", rotated.Rotation)
+	}
+	// Helvetica: R 722, o 556, t 278, a 556, t 278, e 556, d 556 => 35.02 at size 10. The ascent is
+	// to the left of the baseline at x = 20.
+	bbox := rotated.BBox
+	if math.Abs(bbox.Llx-12) > 1e-6 || math.Abs(bbox.Urx-22) > 1e-6 ||
+		math.Abs(bbox.Lly-600) > 1e-6 || math.Abs(bbox.Ury-635.02) > 1e-6 {
+		t.Errorf("Incorrect bbox of rotated text %+v", bbox)
+	}
+
+	words := layout.Blocks[1].Paragraphs[0].Lines[0].Words
+	if len(words) != 2 || words[0].Text != "Left" || words[1].Text != "one" || words[1].FontSize != 10 {
+		t.Errorf("Incorrect words %+v", words)
+	}
+}
+
+func TestPhysicalLayout(t *testing.T) {
+	e := Extractor{contents: testLayoutContents, resources: helveticaResources()}
+	layout, err := e.ExtractPageLayout()
+	if err != nil {
+		t.Fatalf("Error extracting layout: %v", err)
+	}
+	// The columns are side by side and the vertical whitespace below the heading is kept.
+	expected := "Heading\n\n\n" +
+		"Left one                                              Right one\n" +
+		"Left two                                              Right two\n" +
+		"   Indented\n" +
+		"Left four\n\n" +
+		"Rotated"
+	if s := layout.ToPhysicalText(); s != expected {
+		t.Errorf("Text mismatch:\n%s\n!=\n%s", s, expected)
+	}
+}
+
+// Text with a font size of zero is laid out without endless xy-cut recursion.
+func TestLayoutZeroFontSize(t *testing.T) {
+	e := Extractor{contents: `BT /F1 0 Tf 100 200 Td (A B)Tj ET`, resources: helveticaResources()}
+	if _, err := e.ExtractPageLayout(); err != nil {
+		t.Fatalf("Error extracting layout: %v", err)
+	}
+}
+
+// The whitespace of the physical layout of tiny text spread over a page is bounded.
+func TestPhysicalLayoutBounds(t *testing.T) {
+	contents := `BT /F1 0.01 Tf
+		1 0 0 1 10 700 Tm (A) Tj 1 0 0 1 10 699.99 Tm (B) Tj 1 0 0 1 10 699.98 Tm (C) Tj
+		1 0 0 1 10 100 Tm (D) Tj 1 0 0 1 500 100 Tm (E) Tj ET`
+	e := Extractor{contents: contents, resources: helveticaResources()}
+	layout, err := e.ExtractPageLayout()
+	if err != nil {
+		t.Fatalf("Error extracting layout: %v", err)
+	}
+	s := layout.ToPhysicalText()
+	if n := strings.Count(s, "\n"); n > 3+maxPhysicalBlankLines {
+		t.Errorf("%d lines in physical text", n)
+	}
+	if n := strings.Count(s, " "); n > maxPhysicalPadding {
+		t.Errorf("%d spaces in physical text", n)
+	}
+	if strings.Count(s, "A")+strings.Count(s, "B")+strings.Count(s, "C")+strings.Count(s, "D")+
+		strings.Count(s, "E") != 5 {
+		t.Errorf("Incorrect physical text %q", s)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// markedContent is a marked-content sequence (BMC or BDC ... EMC) of a content stream
+// (PDF 32000-1:2008 14.6).
+type markedContent struct {
+	tag string
+	// mcid is the marked-content identifier of the sequence, or -1 if it has none.
+	mcid            int
+	stmObjectNumber int64
+	// actualText replaces the text shown in the sequence if hasActualText is true.
+	actualText    string
+	hasActualText bool
+	// start is the number of texts extracted before the sequence began.
+	start int
+}
+
+// beginMarkedContent begins the marked-content sequence of BMC or BDC operation `op` in graphics state
+// `gs`. The property list of BDC is either inline or a name in the Properties of `resources`.
+func (x *textExtraction) beginMarkedContent(op *contentstream.ContentStreamOperation,
+	gs contentstream.GraphicsState, resources *model.PdfPageResources) {
+	mc := markedContent{mcid: -1, start: len(*x.textList)}
+	if gs.Form != nil {
+		mc.stmObjectNumber = gs.Form.ObjectNumber
+	}
+	if len(op.Params) > 0 {
+		if tag, ok := op.Params[0].(*core.PdfObjectName); ok {
+			mc.tag = string(*tag)
+		}
+	}
+	if op.Operand == "BDC" && len(op.Params) == 2 {
+		props := markedContentProperties(op.Params[1], resources)
+		if props != nil {
+			if mcid, ok := core.TraceToDirectObject(props.Get("MCID")).(*core.PdfObjectInteger); ok {
+				mc.mcid = int(*mcid)
+			}
+			mc.actualText, mc.hasActualText = model.DecodeTextString(props.Get("ActualText"))
+		}
+	}
+	x.marked = append(x.marked, mc)
+}
+
+// markedContentProperties returns the property list `obj` of a BDC operation, which is either a
+// dictionary or the name of a property list in the Properties of `resources`.
+func markedContentProperties(obj core.PdfObject, resources *model.PdfPageResources) *core.PdfObjectDictionary {
+	switch v := obj.(type) {
+	case *core.PdfObjectDictionary:
+		return v
+	case *core.PdfObjectName:
+		if resources == nil {
+			return nil
+		}
+		properties, ok := core.TraceToDirectObject(resources.Properties).(*core.PdfObjectDictionary)
+		if !ok {
+			common.Log.Debug("Property list %s not found: no Properties in resources", *v)
+			return nil
+		}
+		props, ok := core.TraceToDirectObject(properties.Get(*v)).(*core.PdfObjectDictionary)
+		if !ok {
+			common.Log.Debug("Property list %s not found", *v)
+			return nil
+		}
+		return props
+	}
+	return nil
+}
+
+// endMarkedContent ends the marked-content sequences that follow the first `n`, innermost first.
+func (x *textExtraction) endMarkedContent(n int) {
+	for len(x.marked) > n {
+		mc := x.marked[len(x.marked)-1]
+		x.marked = x.marked[:len(x.marked)-1]
+		x.replaceActualText(mc)
+	}
+}
+
+// replaceActualText replaces the texts shown in marked-content sequence `mc` by its ActualText, if it
+// has one, positioned from the start of the first text to the end of the last.
+func (x *textExtraction) replaceActualText(mc markedContent) {
+	if !mc.hasActualText || mc.start >= len(*x.textList) {
+		return
+	}
+
+	texts := (*x.textList)[mc.start:]
+	first, last := texts[0], texts[len(texts)-1]
+	replacement := XYText{
+		X:        first.X,
+		Y:        first.Y,
+		EndX:     last.EndX,
+		EndY:     last.EndY,
+		FontSize: first.FontSize,
+		Orient:   first.Orient,
+		Text:     mc.actualText,
+		style:    first.style,
+	}
+	*x.textList = append((*x.textList)[:mc.start], replacement)
+}
+
+// markedContentID returns the MCID of the innermost marked-content sequence with an MCID and the
+// object number of the stream that contains it, or -1 if the current text is not in such a sequence.
+func (x *textExtraction) markedContentID() (int, int64) {
+	for i := len(x.marked) - 1; i >= 0; i-- {
+		if mc := x.marked[i]; mc.mcid >= 0 {
+			return mc.mcid, mc.stmObjectNumber
+		}
+	}
+	return -1, 0
+}
@@ -0,0 +1,200 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// TextMark is a character shown on a page, with its position and appearance.
+type TextMark struct {
+	// Text is the unicode text of the character.
+	Text string `json:"text"`
+	// BBox is the bounding box of the glyph in device space: it extends over the glyph's width and
+	// from the font's descent to its ascent.
+	BBox model.PdfRectangle `json:"bbox"`
+	// X, Y is the origin of the glyph on the baseline in device space.
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	// FontName is the BaseFont of the font. It is empty if the font could not be loaded.
+	FontName string `json:"font"`
+	// FontSize is the font size in device space.
+	FontSize float64 `json:"size"`
+	// FillColor and StrokeColor are the non-stroking and stroking colors as RGB components in the
+	// range 0 to 1. They are nil if the color cannot be converted to RGB, e.g. for patterns.
+	FillColor   []float64 `json:"fill_color"`
+	StrokeColor []float64 `json:"stroke_color"`
+	// RenderMode is the text rendering mode (Tr), e.g. 3 for invisible text.
+	RenderMode int `json:"render_mode"`
+	// OpIndex is the index in the content stream of the operation that showed the character.
+	OpIndex int `json:"op_index"`
+	// MCID is the marked-content identifier of the innermost marked-content sequence with an MCID
+	// that contains the character, or -1 if there is none.
+	MCID int `json:"mcid"`
+}
+
+// IsInvisible returns true if the character is neither filled nor stroked, as is the case for the
+// text layer of scanned pages.
+func (m TextMark) IsInvisible() bool {
+	return m.RenderMode == 3 || m.RenderMode == 7
+}
+
+// TextMarkList is a list of the characters shown on a page.
+type TextMarkList []TextMark
+
+// ToJSON returns the marks as a JSON array.
+func (ml TextMarkList) ToJSON() ([]byte, error) {
+	return json.Marshal(ml)
+}
+
+// ExtractTextMarks returns the characters shown by the content stream of `e` in content stream
+// order, with their bounding boxes and appearance.
+func (e *Extractor) ExtractTextMarks() (TextMarkList, error) {
+	textList, err := e.ExtractXYText()
+	if err != nil {
+		return nil, err
+	}
+	return textList.Marks(), nil
+}
+
+// Marks returns the characters of `tl`. Text that was not extracted from a content stream, e.g. that
+// was added to the list by the caller, has default appearance values and its characters are spread
+// evenly along its baseline.
+func (tl *TextList) Marks() TextMarkList {
+	var marks TextMarkList
+	for _, t := range *tl {
+		style := t.style
+		if style == nil {
+			style = &textStyle{ascent: ascentRatio, descent: -descentRatio, opIndex: -1, mcid: -1}
+		}
+		for _, c := range t.layoutChars() {
+			bbox := c.bbox
+			if t.chars == nil {
+				bbox = baselineRect(c.x, c.y, c.endX, c.endY, c.size, style.ascent, style.descent)
+			}
+			marks = append(marks, TextMark{
+				Text:        c.text,
+				BBox:        bbox,
+				X:           c.x,
+				Y:           c.y,
+				FontName:    style.fontName,
+				FontSize:    t.FontSize,
+				FillColor:   style.fillColor,
+				StrokeColor: style.strokeColor,
+				RenderMode:  style.renderMode,
+				OpIndex:     style.opIndex,
+				MCID:        style.mcid,
+			})
+		}
+	}
+	return marks
+}
+
+// textStyle is the appearance and origin of the text shown by a text showing operation.
+type textStyle struct {
+	fontName    string
+	fillColor   []float64
+	strokeColor []float64
+	renderMode  int
+	opIndex     int
+	// The marked-content identifier of the text and the object number of the stream that contains it
+	// (0 for the page's content stream). mcid is -1 for text outside marked content with an MCID.
+	mcid            int
+	stmObjectNumber int64
+	// The ascent and descent of the font in units of font size.
+	ascent, descent float64
+}
+
+// newTextStyle returns the appearance of text shown with text state `state` and graphics state `gs`
+// by operation `opIndex`.
+func newTextStyle(state *contentstream.TextState, gs contentstream.GraphicsState, opIndex int) *textStyle {
+	style := &textStyle{
+		fillColor:   colorToRGB(gs.ColorspaceNonStroking, gs.ColorNonStroking),
+		strokeColor: colorToRGB(gs.ColorspaceStroking, gs.ColorStroking),
+		renderMode:  state.Tr,
+		opIndex:     opIndex,
+		mcid:        -1,
+		ascent:      ascentRatio,
+		descent:     -descentRatio,
+	}
+	if state.Font != nil {
+		style.fontName = state.Font.BaseFont()
+		style.ascent, style.descent = fontExtent(state.Font)
+	}
+	return style
+}
+
+// colorToRGB returns `color` of colorspace `cs` as RGB components, or nil if it cannot be converted.
+func colorToRGB(cs model.PdfColorspace, color model.PdfColor) []float64 {
+	if cs == nil || color == nil {
+		return nil
+	}
+	rgbColor, err := cs.ColorToRGB(color)
+	if err != nil {
+		common.Log.Trace("Unable to convert color to RGB: %v", err)
+		return nil
+	}
+	rgb, ok := rgbColor.(*model.PdfColorDeviceRGB)
+	if !ok {
+		return nil
+	}
+	return []float64{rgb.R(), rgb.G(), rgb.B()}
+}
+
+// fontExtent returns the ascent and (negative) descent of `font` in units of font size, from the
+// Ascent and Descent or the FontBBox of its font descriptor. Default values are returned for fonts
+// without these metrics, e.g. the standard 14 fonts.
+func fontExtent(font *model.PdfFont) (float64, float64) {
+	ascent, descent := ascentRatio, -descentRatio
+	descriptor := font.GetFontDescriptor()
+	if descriptor == nil || font.Subtype() == "Type3" {
+		// The metrics of Type 3 fonts are in glyph space.
+		return ascent, descent
+	}
+
+	a, errA := getNumberAsFloat(core.TraceToDirectObject(descriptor.Ascent))
+	d, errD := getNumberAsFloat(core.TraceToDirectObject(descriptor.Descent))
+	if errA == nil && errD == nil && a > d {
+		return a / 1000, d / 1000
+	}
+	if arr, ok := core.TraceToDirectObject(descriptor.FontBBox).(*core.PdfObjectArray); ok {
+		if bbox, err := arr.GetAsFloat64Slice(); err == nil && len(bbox) == 4 && bbox[3] > bbox[1] {
+			return bbox[3] / 1000, bbox[1] / 1000
+		}
+	}
+	return ascent, descent
+}
+
+// transformRect returns the bounding box of rectangle (`x0`, `y0`, `x1`, `y1`) transformed by `m`.
+func transformRect(m contentstream.Matrix, x0, y0, x1, y1 float64) model.PdfRectangle {
+	r := model.PdfRectangle{Llx: math.Inf(1), Lly: math.Inf(1), Urx: math.Inf(-1), Ury: math.Inf(-1)}
+	for _, p := range [][2]float64{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y1}} {
+		x, y := m.Transform(p[0], p[1])
+		r.Llx, r.Lly = math.Min(r.Llx, x), math.Min(r.Lly, y)
+		r.Urx, r.Ury = math.Max(r.Urx, x), math.Max(r.Ury, y)
+	}
+	return r
+}
+
+// baselineRect returns the bounding box of a glyph of font size `size` that extends from (`x0`, `y0`)
+// to (`x1`, `y1`) on its baseline, and from `descent` to `ascent` (in units of font size) across it.
+func baselineRect(x0, y0, x1, y1, size, ascent, descent float64) model.PdfRectangle {
+	// Unit vector perpendicular to the baseline.
+	nx, ny := -(y1 - y0), x1-x0
+	if l := math.Hypot(nx, ny); l > 0 {
+		nx, ny = nx/l, ny/l
+	} else {
+		nx, ny = 0, 1
+	}
+	m := contentstream.NewMatrix(x1-x0, y1-y0, nx*size, ny*size, x0, y0)
+	return transformRect(m, 0, descent, 1, ascent)
+}
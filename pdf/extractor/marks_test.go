@@ -0,0 +1,65 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+const testMarksContents = `
+1 0 0 rg
+BT
+/F1 10 Tf
+100 200 Td
+3 Tr
+(Hi)Tj
+ET
+`
+
+func TestTextMarks(t *testing.T) {
+	e := Extractor{contents: testMarksContents, resources: helveticaResources()}
+	marks, err := e.ExtractTextMarks()
+	if err != nil {
+		t.Fatalf("Error extracting marks: %v", err)
+	}
+	if len(marks) != 2 {
+		t.Fatalf("Expected 2 marks, got %d", len(marks))
+	}
+
+	// Helvetica has no font descriptor, so the default ascent and descent are used. H is 722 wide.
+	h := marks[0]
+	if h.Text != "H" || h.X != 100 || h.Y != 200 || h.FontName != "Helvetica" || h.FontSize != 10 {
+		t.Errorf("Incorrect mark %+v", h)
+	}
+	bbox := h.BBox
+	if math.Abs(bbox.Llx-100) > 1e-6 || math.Abs(bbox.Lly-198) > 1e-6 ||
+		math.Abs(bbox.Urx-107.22) > 1e-6 || math.Abs(bbox.Ury-208) > 1e-6 {
+		t.Errorf("Incorrect bbox %+v", bbox)
+	}
+	if len(h.FillColor) != 3 || h.FillColor[0] != 1 || h.FillColor[1] != 0 || h.FillColor[2] != 0 {
+		t.Errorf("Incorrect fill color %v", h.FillColor)
+	}
+	if !h.IsInvisible() || h.OpIndex != 5 {
+		t.Errorf("Incorrect render mode %d or op index %d", h.RenderMode, h.OpIndex)
+	}
+	if i := marks[1]; i.Text != "i" || math.Abs(i.X-107.22) > 1e-6 {
+		t.Errorf("Incorrect mark %+v", i)
+	}
+
+	data, err := marks.ToJSON()
+	if err != nil {
+		t.Fatalf("Error exporting JSON: %v", err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(decoded) != 2 || decoded[1]["text"] != "i" || decoded[1]["render_mode"] != 3.0 {
+		t.Errorf("Incorrect JSON %s", data)
+	}
+}
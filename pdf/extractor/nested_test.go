@@ -0,0 +1,131 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+const testNestedContents = `
+BT /F1 10 Tf 100 700 Td (Page) Tj ET
+q 2 0 0 2 0 0 cm /Fm1 Do Q
+BT /T3 10 Tf 100 500 Td (A) Tj ET
+`
+
+// makeStream returns a stream with dictionary `dict` and contents `contents`.
+func makeStream(dict *core.PdfObjectDictionary, contents string) *core.PdfObjectStream {
+	dict.Set("Length", core.MakeInteger(int64(len(contents))))
+	return &core.PdfObjectStream{PdfObjectDictionary: dict, Stream: []byte(contents)}
+}
+
+// nestedTestPage returns an extractor for a page that shows text directly, in a form XObject that
+// invokes itself, with a Type 3 font whose glyph is drawn with text and in an annotation appearance.
+func nestedTestPage() *Extractor {
+	helvetica := core.MakeDict()
+	helvetica.Set("Type", core.MakeName("Font"))
+	helvetica.Set("Subtype", core.MakeName("Type1"))
+	helvetica.Set("BaseFont", core.MakeName("Helvetica"))
+	fonts := core.MakeDict()
+	fonts.Set("F1", helvetica)
+	fontResources := core.MakeDict()
+	fontResources.Set("Font", fonts)
+
+	formDict := core.MakeDict()
+	formDict.Set("Subtype", core.MakeName("Form"))
+	formDict.Set("Matrix", core.MakeArrayFromFloats([]float64{1, 0, 0, 1, 50, 0}))
+	form := makeStream(formDict, "BT /F1 10 Tf 0 300 Td (Form) Tj ET /Fm1 Do")
+	xobjects := core.MakeDict()
+	xobjects.Set("Fm1", form)
+	formResources := core.MakeDict()
+	formResources.Set("Font", fonts)
+	formResources.Set("XObject", xobjects)
+	formDict.Set("Resources", formResources)
+
+	charProcs := core.MakeDict()
+	charProcs.Set("glyph0", makeStream(core.MakeDict(), "1000 0 d0 BT /F1 1000 Tf (Z) Tj ET"))
+	encoding := core.MakeDict()
+	encoding.Set("Differences", core.MakeArray(core.MakeInteger(65), core.MakeName("glyph0")))
+	type3 := core.MakeDict()
+	type3.Set("Type", core.MakeName("Font"))
+	type3.Set("Subtype", core.MakeName("Type3"))
+	type3.Set("FontBBox", core.MakeArrayFromFloats([]float64{0, 0, 1000, 1000}))
+	type3.Set("FontMatrix", core.MakeArrayFromFloats([]float64{0.001, 0, 0, 0.001, 0, 0}))
+	type3.Set("CharProcs", charProcs)
+	type3.Set("Encoding", encoding)
+	type3.Set("FirstChar", core.MakeInteger(65))
+	type3.Set("LastChar", core.MakeInteger(65))
+	type3.Set("Widths", core.MakeArrayFromFloats([]float64{1000}))
+	type3.Set("Resources", fontResources)
+
+	resources := model.NewPdfPageResources()
+	resources.SetFontByName("F1", helvetica)
+	resources.SetFontByName("T3", type3)
+	resources.SetXObjectByName("Fm1", form)
+
+	apDict := core.MakeDict()
+	apDict.Set("Subtype", core.MakeName("Form"))
+	apDict.Set("BBox", core.MakeArrayFromFloats([]float64{0, 0, 100, 20}))
+	apDict.Set("Resources", fontResources)
+	ap := core.MakeDict()
+	ap.Set("N", makeStream(apDict, "BT /F1 10 Tf 2 5 Td (Field) Tj ET"))
+	annot := model.NewPdfAnnotation()
+	annot.Rect = core.MakeArrayFromFloats([]float64{200, 100, 400, 140})
+	annot.AP = ap
+
+	return &Extractor{
+		contents:    testNestedContents,
+		resources:   resources,
+		annotations: []*model.PdfAnnotation{annot},
+	}
+}
+
+func TestNestedText(t *testing.T) {
+	e := nestedTestPage()
+
+	type expectedText struct {
+		text       string
+		x, y, size float64
+		opIndex    int
+	}
+	testcases := []struct {
+		includeAnnotations bool
+		expected           []expectedText
+	}{
+		{false, []expectedText{
+			{"Page", 100, 700, 10, 3},
+			{"Form", 100, 600, 20, 7},
+			{"Z", 100, 500, 10, 12},
+		}},
+		{true, []expectedText{
+			{"Page", 100, 700, 10, 3},
+			{"Form", 100, 600, 20, 7},
+			{"Z", 100, 500, 10, 12},
+			{"Field", 204, 110, 20, -1},
+		}},
+	}
+	for _, tc := range testcases {
+		e.IncludeAnnotations(tc.includeAnnotations)
+		textList, err := e.ExtractXYText()
+		if err != nil {
+			t.Fatalf("Error extracting text: %v", err)
+		}
+		if len(*textList) != len(tc.expected) {
+			t.Fatalf("Expected %d texts, got %d: %+v", len(tc.expected), len(*textList), *textList)
+		}
+		for i, exp := range tc.expected {
+			got := (*textList)[i]
+			if got.Text != exp.text || math.Abs(got.X-exp.x) > 1e-6 || math.Abs(got.Y-exp.y) > 1e-6 ||
+				math.Abs(got.FontSize-exp.size) > 1e-6 || got.style.opIndex != exp.opIndex {
+				t.Errorf("Expected %+v, got %q at %g,%g size %g op %d", exp, got.Text, got.X, got.Y,
+					got.FontSize, got.style.opIndex)
+			}
+		}
+	}
+}
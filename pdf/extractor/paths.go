@@ -0,0 +1,327 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/contentstream/draw"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// axisTolerance is the largest difference in page coordinates between the ends of a line for it to be
+// considered horizontal or vertical.
+const axisTolerance = 0.01
+
+// PathShape is the shape of a path.
+type PathShape int
+
+const (
+	// PathShapeOther is any path that is not an axis-aligned rectangle or line.
+	PathShapeOther PathShape = iota
+	// PathShapeRect is a single subpath that is a rectangle with horizontal and vertical sides.
+	PathShapeRect
+	// PathShapeLine is a single subpath that is a horizontal or vertical line.
+	PathShapeLine
+)
+
+// PathOptions control which paths are returned by ExtractPaths.
+type PathOptions struct {
+	// AxisAligned only returns the axis-aligned rectangles and lines of the painted paths, e.g. the
+	// ruling lines and cell backgrounds of table grids. Each is returned as a path of its own with
+	// Shape PathShapeRect or PathShapeLine and the style of the path it is part of. Curves, other
+	// lines and paths that are only used for clipping are skipped.
+	AxisAligned bool
+}
+
+// Subpath is a subpath of a path in the page's default user space.
+type Subpath struct {
+	// Curves are the segments of the subpath as cubic Bézier curves. Straight segments are curves
+	// whose control points are at their ends. A closed subpath ends with the line that closes it,
+	// unless it already ends at its start.
+	Curves draw.CubicBezierPath
+	// Points are the start point of the subpath and the end points of its segments, excluding the
+	// line that closes it. They describe the subpath exactly if it has no curves.
+	Points draw.Path
+	// Closed is true if the subpath is closed (h, s, b, b* or re).
+	Closed bool
+	// Curved is true if the subpath has curved segments (c, v or y).
+	Curved bool
+}
+
+// PathMark is a path painted on a page or used as a clipping path.
+type PathMark struct {
+	Subpaths []Subpath
+	Shape    PathShape
+	// BBox is the bounding box of the subpaths, not including the width of strokes.
+	BBox model.PdfRectangle
+	// Stroke and Fill are true if the path is stroked and filled. EvenOdd is true if it is filled by
+	// the even-odd rule rather than the nonzero winding number rule.
+	Stroke  bool
+	Fill    bool
+	EvenOdd bool
+	// Clip is true if the path also becomes part of the clipping path (W or W*), which is the only
+	// role of paths that are neither stroked nor filled. ClipEvenOdd is true if it clips by the
+	// even-odd rule (W*).
+	Clip        bool
+	ClipEvenOdd bool
+	// StrokeColor and FillColor are the stroking and non-stroking colors as RGB components in the
+	// range 0 to 1. They are nil if the color cannot be converted to RGB, e.g. for patterns.
+	StrokeColor []float64
+	FillColor   []float64
+	// LineWidth, DashArray and DashPhase are the line width and dash pattern in page units. Their
+	// scale is the geometric mean of the scales of the CTM along its axes.
+	LineWidth float64
+	DashArray []float64
+	DashPhase float64
+	// ClipBox is the bounding box of the clipping region in which the path is painted, or nil if
+	// painting is not clipped.
+	ClipBox *model.PdfRectangle
+}
+
+// ExtractPaths returns the paths painted by the content stream of `e`, including those painted by
+// form XObjects, in the order they are painted, with coordinates in the page's default user space.
+// Paths that are only used for clipping are included with Clip set.
+func (e *Extractor) ExtractPaths(opts PathOptions) ([]PathMark, error) {
+	operations, err := contentstream.NewContentStreamParser(e.contents).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var marks []PathMark
+	clip, clipEvenOdd := false, false
+	processor := contentstream.NewContentStreamProcessor(*operations)
+	processor.EnableFormRecursion()
+	processor.AddHandler(contentstream.HandlerConditionEnumAllOperands, "",
+		func(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState,
+			resources *model.PdfPageResources) error {
+			switch op.Operand {
+			case "W", "W*":
+				clip, clipEvenOdd = true, op.Operand == "W*"
+			case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*", "n":
+				mark := newPathMark(op.Operand, gs)
+				mark.Clip, mark.ClipEvenOdd = clip, clipEvenOdd
+				clip, clipEvenOdd = false, false
+				if len(mark.Subpaths) == 0 || (op.Operand == "n" && !mark.Clip) {
+					return nil
+				}
+				if !opts.AxisAligned {
+					marks = append(marks, mark)
+				} else if mark.Stroke || mark.Fill {
+					marks = append(marks, mark.axisAligned()...)
+				}
+			}
+			return nil
+		})
+
+	if err := processor.Process(e.resources); err != nil {
+		common.Log.Debug("Error processing: %v", err)
+		return nil, err
+	}
+	return marks, nil
+}
+
+// newPathMark returns the mark of the current path of `gs` painted by operator `operand`.
+func newPathMark(operand string, gs contentstream.GraphicsState) PathMark {
+	mark := PathMark{
+		Stroke:      operand != "f" && operand != "F" && operand != "f*" && operand != "n",
+		Fill:        operand != "S" && operand != "s" && operand != "n",
+		EvenOdd:     operand == "f*" || operand == "B*" || operand == "b*",
+		StrokeColor: colorToRGB(gs.ColorspaceStroking, gs.ColorStroking),
+		FillColor:   colorToRGB(gs.ColorspaceNonStroking, gs.ColorNonStroking),
+	}
+
+	scale := math.Sqrt(math.Abs(gs.CTM[0]*gs.CTM[4] - gs.CTM[1]*gs.CTM[3]))
+	mark.LineWidth = gs.LineWidth * scale
+	for _, d := range gs.DashArray {
+		mark.DashArray = append(mark.DashArray, d*scale)
+	}
+	mark.DashPhase = gs.DashPhase * scale
+
+	// Closing operators have closed the path.
+	path := gs.Path.Transform(gs.CTM)
+	mark.Subpaths = subpaths(path)
+	mark.BBox = subpathsBBox(mark.Subpaths)
+	mark.Shape = subpathsShape(mark.Subpaths)
+
+	for i, c := range gs.Clip {
+		bbox := pathBBox(c.Path)
+		if i == 0 {
+			mark.ClipBox = &bbox
+			continue
+		}
+		mark.ClipBox.Llx = math.Max(mark.ClipBox.Llx, bbox.Llx)
+		mark.ClipBox.Lly = math.Max(mark.ClipBox.Lly, bbox.Lly)
+		mark.ClipBox.Urx = math.Min(mark.ClipBox.Urx, bbox.Urx)
+		mark.ClipBox.Ury = math.Min(mark.ClipBox.Ury, bbox.Ury)
+	}
+	return mark
+}
+
+// subpaths returns the subpaths of `path`. Subpaths without segments are omitted.
+func subpaths(path contentstream.Path) []Subpath {
+	var subs []Subpath
+	var sub *Subpath
+	var start, current draw.Point
+	flush := func() {
+		if sub != nil && len(sub.Curves.Curves) > 0 {
+			subs = append(subs, *sub)
+		}
+		sub = nil
+	}
+	for _, seg := range path.Segments {
+		if seg.Type == contentstream.PathMoveTo {
+			flush()
+			start = draw.NewPoint(seg.Points[0].X, seg.Points[0].Y)
+			current = start
+			sub = &Subpath{Curves: draw.NewCubicBezierPath(), Points: draw.NewPath().AppendPoint(start)}
+			continue
+		}
+		if sub == nil {
+			common.Log.Debug("Path segment without current point")
+			continue
+		}
+		switch seg.Type {
+		case contentstream.PathLineTo:
+			p := draw.NewPoint(seg.Points[0].X, seg.Points[0].Y)
+			sub.Curves = sub.Curves.AppendCurve(lineCurve(current, p))
+			sub.Points = sub.Points.AppendPoint(p)
+			current = p
+		case contentstream.PathCurveTo:
+			p1, p2, p3 := seg.Points[0], seg.Points[1], seg.Points[2]
+			sub.Curves = sub.Curves.AppendCurve(draw.NewCubicBezierCurve(current.X, current.Y,
+				p1.X, p1.Y, p2.X, p2.Y, p3.X, p3.Y))
+			current = draw.NewPoint(p3.X, p3.Y)
+			sub.Points = sub.Points.AppendPoint(current)
+			sub.Curved = true
+		case contentstream.PathClose:
+			if current != start {
+				sub.Curves = sub.Curves.AppendCurve(lineCurve(current, start))
+			}
+			sub.Closed = true
+			current = start
+			// Segments that follow without a move start a new subpath at the same point.
+			flush()
+			sub = &Subpath{Curves: draw.NewCubicBezierPath(), Points: draw.NewPath().AppendPoint(start)}
+		}
+	}
+	flush()
+	return subs
+}
+
+// lineCurve returns the line from `p0` to `p1` as a cubic Bézier curve.
+func lineCurve(p0, p1 draw.Point) draw.CubicBezierCurve {
+	return draw.NewCubicBezierCurve(p0.X, p0.Y, p0.X, p0.Y, p1.X, p1.Y, p1.X, p1.Y)
+}
+
+// subpathsBBox returns the bounding box of `subs`.
+func subpathsBBox(subs []Subpath) model.PdfRectangle {
+	bbox := model.PdfRectangle{Llx: math.Inf(1), Lly: math.Inf(1), Urx: math.Inf(-1), Ury: math.Inf(-1)}
+	extend := func(r model.PdfRectangle) {
+		bbox.Llx, bbox.Lly = math.Min(bbox.Llx, r.Llx), math.Min(bbox.Lly, r.Lly)
+		bbox.Urx, bbox.Ury = math.Max(bbox.Urx, r.Urx), math.Max(bbox.Ury, r.Ury)
+	}
+	for _, sub := range subs {
+		if !sub.Curved {
+			for _, p := range sub.Points.Points {
+				extend(model.PdfRectangle{Llx: p.X, Lly: p.Y, Urx: p.X, Ury: p.Y})
+			}
+			continue
+		}
+		for _, c := range sub.Curves.Curves {
+			extend(c.GetBounds())
+		}
+	}
+	return bbox
+}
+
+// pathBBox returns the bounding box of the points of `path`, including control points.
+func pathBBox(path contentstream.Path) model.PdfRectangle {
+	bbox := model.PdfRectangle{Llx: math.Inf(1), Lly: math.Inf(1), Urx: math.Inf(-1), Ury: math.Inf(-1)}
+	for _, seg := range path.Segments {
+		for _, p := range seg.Points {
+			bbox.Llx, bbox.Lly = math.Min(bbox.Llx, p.X), math.Min(bbox.Lly, p.Y)
+			bbox.Urx, bbox.Ury = math.Max(bbox.Urx, p.X), math.Max(bbox.Ury, p.Y)
+		}
+	}
+	return bbox
+}
+
+// subpathsShape returns the shape of a path with subpaths `subs`.
+func subpathsShape(subs []Subpath) PathShape {
+	if len(subs) != 1 || subs[0].Curved {
+		return PathShapeOther
+	}
+	points := subs[0].Points.Points
+	switch {
+	case len(points) == 2 && !subs[0].Closed && isAxisAligned(points[0], points[1]):
+		return PathShapeLine
+	case isAxisRect(points):
+		return PathShapeRect
+	}
+	return PathShapeOther
+}
+
+// isAxisAligned returns true if the line from `p0` to `p1` is horizontal or vertical.
+func isAxisAligned(p0, p1 draw.Point) bool {
+	return math.Abs(p0.X-p1.X) <= axisTolerance || math.Abs(p0.Y-p1.Y) <= axisTolerance
+}
+
+// isAxisRect returns true if `points` are the corners of a rectangle with horizontal and vertical
+// sides, in order and possibly repeating the first corner at the end.
+func isAxisRect(points []draw.Point) bool {
+	if len(points) == 5 && math.Abs(points[0].X-points[4].X) <= axisTolerance &&
+		math.Abs(points[0].Y-points[4].Y) <= axisTolerance {
+		points = points[:4]
+	}
+	if len(points) != 4 {
+		return false
+	}
+	for i := range points {
+		p0, p1, p2 := points[i], points[(i+1)%4], points[(i+2)%4]
+		// Consecutive sides are a horizontal and a vertical one.
+		horizontal := math.Abs(p0.Y-p1.Y) <= axisTolerance
+		if horizontal == (math.Abs(p1.Y-p2.Y) <= axisTolerance) || !isAxisAligned(p0, p1) {
+			return false
+		}
+	}
+	return true
+}
+
+// axisAligned returns the axis-aligned rectangles and lines of `mark` as marks of their own. Lines
+// are the horizontal and vertical segments of the subpaths that are not rectangles.
+func (mark PathMark) axisAligned() []PathMark {
+	var marks []PathMark
+	add := func(sub Subpath, shape PathShape) {
+		m := mark
+		m.Subpaths = []Subpath{sub}
+		m.Shape = shape
+		m.BBox = subpathsBBox(m.Subpaths)
+		marks = append(marks, m)
+	}
+	for _, sub := range mark.Subpaths {
+		if sub.Curved {
+			continue
+		}
+		points := sub.Points.Points
+		if isAxisRect(points) {
+			add(sub, PathShapeRect)
+			continue
+		}
+		for _, c := range sub.Curves.Curves {
+			if c.P0 == c.P3 || !isAxisAligned(c.P0, c.P3) {
+				continue
+			}
+			add(Subpath{
+				Curves: draw.NewCubicBezierPath().AppendCurve(c),
+				Points: draw.NewPath().AppendPoint(c.P0).AppendPoint(c.P3),
+			}, PathShapeLine)
+		}
+	}
+	return marks
+}
@@ -0,0 +1,102 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/contentstream/draw"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+const testPathsContents = `
+1 0 0 RG 2 w [3 1] 0 d 10 10 m 100 10 l 100 50 l S
+0 0 1 rg 20 20 30 40 re f
+q 2 0 0 2 0 0 cm 0 0 50 50 re W n
+0 0 m 10 0 10 10 0 10 c h B*
+Q
+`
+
+func rectsEqual(a, b model.PdfRectangle) bool {
+	return math.Abs(a.Llx-b.Llx) < 1e-6 && math.Abs(a.Lly-b.Lly) < 1e-6 &&
+		math.Abs(a.Urx-b.Urx) < 1e-6 && math.Abs(a.Ury-b.Ury) < 1e-6
+}
+
+func TestExtractPaths(t *testing.T) {
+	e := &Extractor{contents: testPathsContents, resources: model.NewPdfPageResources()}
+	marks, err := e.ExtractPaths(PathOptions{})
+	if err != nil {
+		t.Fatalf("Error extracting paths: %v", err)
+	}
+	if len(marks) != 4 {
+		t.Fatalf("Expected 4 paths, got %d: %+v", len(marks), marks)
+	}
+
+	// Stroked polyline.
+	m := marks[0]
+	if len(m.Subpaths) != 1 || m.Subpaths[0].Closed || m.Subpaths[0].Curved || m.Shape != PathShapeOther ||
+		!m.Stroke || m.Fill || m.Clip || m.ClipBox != nil || !reflect.DeepEqual(m.StrokeColor, []float64{1, 0, 0}) ||
+		m.LineWidth != 2 || !reflect.DeepEqual(m.DashArray, []float64{3, 1}) ||
+		!rectsEqual(m.BBox, model.PdfRectangle{Llx: 10, Lly: 10, Urx: 100, Ury: 50}) {
+		t.Errorf("Invalid polyline %+v", m)
+	}
+	expectedPoints := []draw.Point{{X: 10, Y: 10}, {X: 100, Y: 10}, {X: 100, Y: 50}}
+	if !reflect.DeepEqual(m.Subpaths[0].Points.Points, expectedPoints) || len(m.Subpaths[0].Curves.Curves) != 2 {
+		t.Errorf("Invalid polyline subpath %+v", m.Subpaths[0])
+	}
+
+	// Filled rectangle.
+	m = marks[1]
+	if len(m.Subpaths) != 1 || !m.Subpaths[0].Closed || len(m.Subpaths[0].Curves.Curves) != 4 ||
+		m.Shape != PathShapeRect || m.Stroke || !m.Fill || !reflect.DeepEqual(m.FillColor, []float64{0, 0, 1}) ||
+		!rectsEqual(m.BBox, model.PdfRectangle{Llx: 20, Lly: 20, Urx: 50, Ury: 60}) {
+		t.Errorf("Invalid rectangle %+v", m)
+	}
+
+	// Clipping path in scaled user space.
+	m = marks[2]
+	if !m.Clip || m.ClipEvenOdd || m.Stroke || m.Fill || m.Shape != PathShapeRect || m.ClipBox != nil ||
+		!rectsEqual(m.BBox, model.PdfRectangle{Llx: 0, Lly: 0, Urx: 100, Ury: 100}) {
+		t.Errorf("Invalid clipping path %+v", m)
+	}
+
+	// Clipped curve with the line width and dash pattern scaled by the CTM.
+	m = marks[3]
+	if len(m.Subpaths) != 1 || !m.Subpaths[0].Closed || !m.Subpaths[0].Curved ||
+		len(m.Subpaths[0].Curves.Curves) != 2 || m.Shape != PathShapeOther || !m.Stroke || !m.Fill ||
+		!m.EvenOdd || m.LineWidth != 4 || !reflect.DeepEqual(m.DashArray, []float64{6, 2}) ||
+		m.ClipBox == nil || !rectsEqual(*m.ClipBox, model.PdfRectangle{Llx: 0, Lly: 0, Urx: 100, Ury: 100}) ||
+		!rectsEqual(m.BBox, model.PdfRectangle{Llx: 0, Lly: 0, Urx: 15, Ury: 20}) {
+		t.Errorf("Invalid curve %+v", m)
+	}
+
+	// Only the rectangle and the lines of the polyline are axis-aligned.
+	marks, err = e.ExtractPaths(PathOptions{AxisAligned: true})
+	if err != nil {
+		t.Fatalf("Error extracting paths: %v", err)
+	}
+	expected := []struct {
+		shape PathShape
+		bbox  model.PdfRectangle
+	}{
+		{PathShapeLine, model.PdfRectangle{Llx: 10, Lly: 10, Urx: 100, Ury: 10}},
+		{PathShapeLine, model.PdfRectangle{Llx: 100, Lly: 10, Urx: 100, Ury: 50}},
+		{PathShapeRect, model.PdfRectangle{Llx: 20, Lly: 20, Urx: 50, Ury: 60}},
+	}
+	if len(marks) != len(expected) {
+		t.Fatalf("Expected %d axis-aligned paths, got %d: %+v", len(expected), len(marks), marks)
+	}
+	for i, exp := range expected {
+		if marks[i].Shape != exp.shape || !rectsEqual(marks[i].BBox, exp.bbox) || len(marks[i].Subpaths) != 1 {
+			t.Errorf("Path %d: expected %+v, got %+v", i, exp, marks[i])
+		}
+	}
+	if marks[0].LineWidth != 2 || !reflect.DeepEqual(marks[0].StrokeColor, []float64{1, 0, 0}) {
+		t.Errorf("Invalid line style %+v", marks[0])
+	}
+}
@@ -0,0 +1,343 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/annotator"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// SearchOptions control how the text of a page is matched by Search.
+type SearchOptions struct {
+	// IgnoreCase matches letters regardless of their case.
+	IgnoreCase bool
+	// IgnoreDiacritics matches accented Latin letters as their base letters, e.g. é as e.
+	IgnoreDiacritics bool
+	// WholeWord only accepts matches that neither start nor end inside a word.
+	WholeWord bool
+	// Regexp treats the pattern as a regular expression (RE2 syntax). Otherwise the pattern is
+	// literal text in which any run of whitespace matches any run of whitespace.
+	Regexp bool
+}
+
+// SearchMatch is an occurrence of a search pattern on a page.
+type SearchMatch struct {
+	// PageNum is the number of the page (starting from 1) for matches found by SearchDocument and
+	// 0 otherwise.
+	PageNum int
+	// Text is the matched text as shown on the page. Words are separated by spaces.
+	Text string
+	// Quads are the quadrilaterals that cover the match, one per line of text, in the order of the
+	// QuadPoints entry of annotations: the x, y coordinates of the upper left, upper right, lower
+	// left and lower right corners relative to the text direction.
+	Quads [][8]float64
+	// BBox is the bounding box of the quadrilaterals.
+	BBox model.PdfRectangle
+}
+
+// Highlight returns a highlight annotation of color `color` and opacity `opacity` that covers `m`,
+// with an appearance stream. It can be added to the Annotations of the page of the match. A nil
+// `color` is yellow and an `opacity` of 0 is opaque.
+func (m SearchMatch) Highlight(color *model.PdfColorDeviceRGB, opacity float64) (*model.PdfAnnotation, error) {
+	return annotator.CreateHighlightAnnotation(annotator.HighlightAnnotationDef{
+		Quads:   m.Quads,
+		Color:   color,
+		Opacity: opacity,
+	})
+}
+
+// SearchDocument returns the matches of `pattern` on all pages of `reader` with options `opts`.
+func SearchDocument(reader *model.PdfReader, pattern string, opts SearchOptions) ([]SearchMatch, error) {
+	re, err := compileSearchPattern(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+	numPages, err := reader.GetNumPages()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []SearchMatch
+	for pageNum := 1; pageNum <= numPages; pageNum++ {
+		page, err := reader.GetPage(pageNum)
+		if err != nil {
+			return nil, err
+		}
+		e, err := New(page)
+		if err != nil {
+			return nil, err
+		}
+		textList, err := e.ExtractXYText()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range textList.search(re, opts) {
+			m.PageNum = pageNum
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+// Search returns the matches of `pattern` in the text of `e` with options `opts`, in reading order.
+// The text is searched in the reading order of its layout, so matches may extend over several lines
+// of a block of text. Words broken by a hyphen at the end of a line are matched as a whole word.
+func (e *Extractor) Search(pattern string, opts SearchOptions) ([]SearchMatch, error) {
+	re, err := compileSearchPattern(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+	textList, err := e.ExtractXYText()
+	if err != nil {
+		return nil, err
+	}
+	return textList.search(re, opts), nil
+}
+
+// compileSearchPattern returns the regular expression that matches `pattern` with options `opts` in
+// the text built by newSearchText.
+func compileSearchPattern(pattern string, opts SearchOptions) (*regexp.Regexp, error) {
+	if opts.IgnoreDiacritics {
+		pattern = foldText(pattern, true)
+	}
+	if !opts.Regexp {
+		fields := strings.Fields(pattern)
+		for i, field := range fields {
+			fields[i] = regexp.QuoteMeta(field)
+		}
+		pattern = strings.Join(fields, `\s+`)
+	}
+	if opts.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		common.Log.Debug("Invalid search pattern: %v", err)
+		return nil, err
+	}
+	return re, nil
+}
+
+// search returns the matches of `re` in the blocks of the layout of `tl`. Matches do not extend over
+// several blocks.
+func (tl *TextList) search(re *regexp.Regexp, opts SearchOptions) []SearchMatch {
+	var matches []SearchMatch
+	for _, block := range tl.Layout().Blocks {
+		st := newSearchText(block, opts.IgnoreDiacritics)
+		for start := 0; start <= len(st.text); {
+			loc := re.FindStringIndex(st.text[start:])
+			if loc == nil {
+				break
+			}
+			s, e := start+loc[0], start+loc[1]
+			if s == e || (opts.WholeWord && !st.isWordBoundary(s, e)) {
+				// Retry from the next character.
+				_, n := utf8.DecodeRuneInString(st.text[s:])
+				if n == 0 {
+					n = 1
+				}
+				start = s + n
+				continue
+			}
+			matches = append(matches, st.match(s, e))
+			start = e
+		}
+	}
+	return matches
+}
+
+// searchUnit is a character of a block, or a separator between its words and lines, in a searchText.
+type searchUnit struct {
+	// The text of the unit in the searchable text is text[fs:fe] and in the original text
+	// original[os:oe].
+	fs, fe int
+	os, oe int
+	// char is the character of the unit. It is nil for separators.
+	char *layoutChar
+	line int
+}
+
+// searchText is the searchable text of a block: the text of its words in reading order separated by
+// single spaces, with characters folded for searching.
+type searchText struct {
+	frame    layoutFrame
+	text     string
+	original string
+	units    []searchUnit
+	// owner is the index of the unit of each byte of text.
+	owner []int
+}
+
+// newSearchText returns the searchable text of `block`. Ligatures are expanded and, if
+// `ignoreDiacritics` is true, accented letters are replaced by their base letters. A hyphen at the end
+// of a line that breaks a word is omitted from the text.
+func newSearchText(block TextBlock, ignoreDiacritics bool) *searchText {
+	st := &searchText{frame: newLayoutFrame(block.Rotation)}
+	var text, original bytes.Buffer
+	add := func(folded, orig string, char *layoutChar, line int) {
+		u := searchUnit{fs: text.Len(), os: original.Len(), char: char, line: line}
+		text.WriteString(folded)
+		original.WriteString(orig)
+		u.fe, u.oe = text.Len(), original.Len()
+		for i := u.fs; i < u.fe; i++ {
+			st.owner = append(st.owner, len(st.units))
+		}
+		st.units = append(st.units, u)
+	}
+
+	for i, line := range block.lines {
+		// Lines are joined by a space, except after a hyphen that breaks a word.
+		broken := i+1 < len(block.lines) && isHyphenated(line, block.lines[i+1])
+		if i > 0 && !isHyphenated(block.lines[i-1], line) {
+			add(" ", " ", nil, i)
+		}
+		for j, w := range line.words {
+			if j > 0 {
+				add(" ", " ", nil, i)
+			}
+			for k := range w.chars {
+				c := &w.chars[k]
+				folded := foldText(c.text, ignoreDiacritics)
+				if broken && j == len(line.words)-1 && k == len(w.chars)-1 {
+					folded = ""
+				}
+				add(folded, c.text, c, i)
+			}
+		}
+	}
+	st.text, st.original = text.String(), original.String()
+	return st
+}
+
+// isHyphenated returns true if the last word of `line` is broken by a hyphen and continued on `next`:
+// it ends with a letter followed by a hyphen and `next` starts with a lower case letter.
+func isHyphenated(line, next *layoutLine) bool {
+	last := line.words[len(line.words)-1].text
+	r, n := utf8.DecodeLastRuneInString(last)
+	if r != '-' && r != '\u00ad' {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(last[:len(last)-n])
+	first, _ := utf8.DecodeRuneInString(next.words[0].text)
+	return unicode.IsLetter(prev) && unicode.IsLower(first)
+}
+
+// isWordBoundary returns true if text[s:e] neither starts nor ends inside a word.
+func (st *searchText) isWordBoundary(s, e int) bool {
+	isWordRune := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	first, _ := utf8.DecodeRuneInString(st.text[s:])
+	before, _ := utf8.DecodeLastRuneInString(st.text[:s])
+	if s > 0 && isWordRune(first) && isWordRune(before) {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(st.text[:e])
+	after, _ := utf8.DecodeRuneInString(st.text[e:])
+	if e < len(st.text) && isWordRune(last) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+// match returns the match of text[s:e].
+func (st *searchText) match(s, e int) SearchMatch {
+	first, last := st.owner[s], st.owner[e-1]
+	m := SearchMatch{Text: st.original[st.units[first].os:st.units[last].oe]}
+
+	// One quadrilateral per line, from the extent of the line's characters in the frame of the block.
+	line := -1
+	var u0, u1, v0, v1 float64
+	flush := func() {
+		if line < 0 {
+			return
+		}
+		f := st.frame
+		var quad [8]float64
+		quad[0], quad[1] = f.toDevice(u0, v1)
+		quad[2], quad[3] = f.toDevice(u1, v1)
+		quad[4], quad[5] = f.toDevice(u0, v0)
+		quad[6], quad[7] = f.toDevice(u1, v0)
+		m.Quads = append(m.Quads, quad)
+		bbox := f.deviceRect(u0, v0, u1, v1)
+		if len(m.Quads) == 1 {
+			m.BBox = bbox
+		} else {
+			m.BBox = unionRect(m.BBox, bbox)
+		}
+	}
+	for _, u := range st.units[first : last+1] {
+		if u.char == nil {
+			continue
+		}
+		c := u.char
+		x0, base := st.frame.toFrame(c.x, c.y)
+		x1, _ := st.frame.toFrame(c.endX, c.endY)
+		if x1 < x0 {
+			x0, x1 = x1, x0
+		}
+		lo, hi := base-descentRatio*c.size, base+ascentRatio*c.size
+		if u.line != line {
+			flush()
+			line = u.line
+			u0, u1, v0, v1 = x0, x1, lo, hi
+			continue
+		}
+		u0, u1 = math.Min(u0, x0), math.Max(u1, x1)
+		v0, v1 = math.Min(v0, lo), math.Max(v1, hi)
+	}
+	flush()
+	return m
+}
+
+// ligatures maps ligature characters to the letters they are composed of.
+var ligatures = map[rune]string{
+	'ﬀ': "ff", 'ﬁ': "fi", 'ﬂ': "fl", 'ﬃ': "ffi", 'ﬄ': "ffl", 'ﬅ': "st", 'ﬆ': "st",
+}
+
+// diacriticGroups lists accented Latin letters, each group preceded by its base letter.
+var diacriticGroups = []string{
+	"AÀÁÂÃÄÅĀĂĄ", "aàáâãäåāăą", "CÇĆĈĊČ", "cçćĉċč", "DĎĐ", "dďđ", "EÈÉÊËĒĔĖĘĚ", "eèéêëēĕėęě",
+	"GĜĞĠĢ", "gĝğġģ", "HĤĦ", "hĥħ", "IÌÍÎÏĨĪĬĮİ", "iìíîïĩīĭįı", "JĴ", "jĵ", "KĶ", "kķ",
+	"LĹĻĽĿŁ", "lĺļľŀł", "NÑŃŅŇ", "nñńņň", "OÒÓÔÕÖØŌŎŐ", "oòóôõöøōŏő", "RŔŖŘ", "rŕŗř",
+	"SŚŜŞŠ", "sśŝşš", "TŢŤŦ", "tţťŧ", "UÙÚÛÜŨŪŬŮŰŲ", "uùúûüũūŭůűų", "WŴ", "wŵ", "YÝŶŸ", "yýÿŷ",
+	"ZŹŻŽ", "zźżž",
+}
+
+// diacriticBase maps accented Latin letters to their base letters.
+var diacriticBase = map[rune]rune{}
+
+func init() {
+	for _, group := range diacriticGroups {
+		runes := []rune(group)
+		for _, r := range runes[1:] {
+			diacriticBase[r] = runes[0]
+		}
+	}
+}
+
+// foldText returns `s` with ligatures expanded and, if `ignoreDiacritics` is true, accented letters
+// replaced by their base letters.
+func foldText(s string, ignoreDiacritics bool) string {
+	var buf bytes.Buffer
+	for _, r := range s {
+		if lig, ok := ligatures[r]; ok {
+			buf.WriteString(lig)
+			continue
+		}
+		if base, ok := diacriticBase[r]; ok && ignoreDiacritics {
+			r = base
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
@@ -0,0 +1,134 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// \351 is é in WinAnsiEncoding.
+const testSearchContents = `
+BT
+/F1 10 Tf
+12 TL
+100 700 Td
+(This Agreement is made between the) Tj
+T* (Parties. The con-) Tj
+T* (tract is effective within 30 days at the Caf\351.) Tj
+ET
+`
+
+func TestSearch(t *testing.T) {
+	font := core.MakeDict()
+	font.Set("Type", core.MakeName("Font"))
+	font.Set("Subtype", core.MakeName("Type1"))
+	font.Set("BaseFont", core.MakeName("Helvetica"))
+	font.Set("Encoding", core.MakeName("WinAnsiEncoding"))
+	resources := model.NewPdfPageResources()
+	resources.SetFontByName("F1", font)
+	e := Extractor{contents: testSearchContents, resources: resources}
+
+	testcases := []struct {
+		pattern string
+		opts    SearchOptions
+		matches []string
+	}{
+		{"the parties", SearchOptions{}, nil},
+		{"the  parties", SearchOptions{IgnoreCase: true}, []string{"the Parties"}},
+		{"contract", SearchOptions{}, []string{"con-tract"}},
+		{"tract", SearchOptions{WholeWord: true}, nil},
+		{"the", SearchOptions{IgnoreCase: true}, []string{"the", "The", "the"}},
+		{"the", SearchOptions{IgnoreCase: true, WholeWord: true}, []string{"the", "The", "the"}},
+		{"is", SearchOptions{WholeWord: true}, []string{"is", "is"}},
+		{"cafe", SearchOptions{IgnoreCase: true}, nil},
+		{"cafe", SearchOptions{IgnoreCase: true, IgnoreDiacritics: true}, []string{"Café"}},
+		{`\d+ days`, SearchOptions{Regexp: true}, []string{"30 days"}},
+	}
+	for _, tc := range testcases {
+		matches, err := e.Search(tc.pattern, tc.opts)
+		if err != nil {
+			t.Fatalf("Error searching %q: %v", tc.pattern, err)
+		}
+		var texts []string
+		for _, m := range matches {
+			texts = append(texts, m.Text)
+		}
+		if len(texts) != len(tc.matches) {
+			t.Errorf("%q %+v: expected %q, got %q", tc.pattern, tc.opts, tc.matches, texts)
+			continue
+		}
+		for i := range texts {
+			if texts[i] != tc.matches[i] {
+				t.Errorf("%q %+v: expected %q, got %q", tc.pattern, tc.opts, tc.matches, texts)
+				break
+			}
+		}
+	}
+
+	if _, err := e.Search("(", SearchOptions{Regexp: true}); err == nil {
+		t.Errorf("Expected an error for an invalid regular expression")
+	}
+}
+
+// TestSearchHighlight checks the quadrilaterals of a match over a line break and the highlight
+// annotation made from it.
+func TestSearchHighlight(t *testing.T) {
+	e := Extractor{contents: testSearchContents, resources: helveticaResources()}
+	matches, err := e.Search("between the parties", SearchOptions{IgnoreCase: true})
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d (%v)", len(matches), err)
+	}
+	m := matches[0]
+	if len(m.Quads) != 2 {
+		t.Fatalf("Expected 2 quads, got %d", len(m.Quads))
+	}
+	// "Parties" starts the second line at 100, 688. The default ascent and descent are used.
+	quad := m.Quads[1]
+	expected := [8]float64{100, 696, 0, 696, 100, 686, 0, 686}
+	expected[2] = 100 + 10*(0.667+0.556+0.333+0.278+0.222+0.556+0.5)
+	expected[6] = expected[2]
+	for i := range quad {
+		if math.Abs(quad[i]-expected[i]) > 1e-6 {
+			t.Fatalf("Incorrect quad %v, expected %v", quad, expected)
+		}
+	}
+	if m.BBox.Lly != 686 || m.BBox.Ury != 708 {
+		t.Errorf("Incorrect bbox %+v", m.BBox)
+	}
+
+	annotation, err := m.Highlight(model.NewPdfColorDeviceRGB(1, 1, 0), 0.5)
+	if err != nil {
+		t.Fatalf("Error creating highlight: %v", err)
+	}
+	highlight, ok := annotation.GetContext().(*model.PdfAnnotationHighlight)
+	if !ok {
+		t.Fatalf("Expected a highlight annotation, got %T", annotation.GetContext())
+	}
+	quadPoints, ok := highlight.QuadPoints.(*core.PdfObjectArray)
+	if !ok || len(*quadPoints) != 16 {
+		t.Errorf("Incorrect QuadPoints %v", highlight.QuadPoints)
+	}
+	ap, ok := annotation.AP.(*core.PdfObjectDictionary)
+	if !ok || ap.Get("N") == nil {
+		t.Errorf("Missing appearance stream")
+	}
+
+	// The default highlight is opaque yellow.
+	annotation, err = m.Highlight(nil, 0)
+	if err != nil {
+		t.Fatalf("Error creating highlight: %v", err)
+	}
+	if got := annotation.C.DefaultWriteString(); got != "[1.000000 1.000000 0.000000]" {
+		t.Errorf("Incorrect default color %s", got)
+	}
+	if highlight := annotation.GetContext().(*model.PdfAnnotationHighlight); highlight.CA != nil {
+		t.Errorf("Incorrect default opacity %v", highlight.CA)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// StructuredText is the text of a tagged PDF organized by its logical structure.
+type StructuredText struct {
+	// Elements are the top level structure elements in logical order.
+	Elements []StructuredElement
+}
+
+// StructuredElement is a structure element of a tagged PDF with its text.
+type StructuredElement struct {
+	// Type is the standard structure type of the element, e.g. P, H1, LI or TD, and Role is its type
+	// as used in the document.
+	Type string
+	Role string
+	// Text is the text of the element and its descendants in logical order. It is the element's
+	// ActualText if it has one, and its Alt if it has no text, e.g. for figures.
+	Text    string
+	Lang    string
+	PageNum int
+	Kids    []StructuredElement
+}
+
+// LabelledText is the text of a block level structure element, e.g. a paragraph, heading, list item
+// or table cell, labelled with its structure type.
+type LabelledText struct {
+	Label   string
+	Text    string
+	PageNum int
+}
+
+// blockTypes are the standard structure types of block level elements (PDF 32000-1:2008 14.8.4).
+// Their text is separated from the text around them by a newline.
+var blockTypes = map[string]bool{
+	"Document": true, "Part": true, "Art": true, "Sect": true, "Div": true, "BlockQuote": true,
+	"Caption": true, "TOC": true, "TOCI": true, "Index": true, "P": true, "H": true, "H1": true,
+	"H2": true, "H3": true, "H4": true, "H5": true, "H6": true, "L": true, "LI": true,
+	"Table": true, "TR": true, "TH": true, "TD": true, "THead": true, "TBody": true, "TFoot": true,
+	"Figure": true, "Formula": true, "Form": true,
+}
+
+// ExtractStructuredText returns the text of the tagged document of `reader` organized by its
+// structure tree. The text of each structure element is that of the marked-content sequences it
+// references by MCID, so text that is not part of the logical structure, e.g. page headers marked as
+// artifacts, is omitted. An error is returned if the document has no structure tree.
+func ExtractStructuredText(reader *model.PdfReader) (*StructuredText, error) {
+	root, err := reader.GetStructTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errors.New("Document has no structure tree")
+	}
+
+	b := &structuredTextBuilder{root: root, pages: map[int]map[markedContentKey]*TextList{}}
+	var pageNums []int
+	b.collectPages(root.K, &pageNums)
+	for _, pageNum := range pageNums {
+		page, err := reader.GetPage(pageNum)
+		if err != nil {
+			common.Log.Debug("Unable to load page %d: %v", pageNum, err)
+			continue
+		}
+		e, err := New(page)
+		if err != nil {
+			return nil, err
+		}
+		textList, err := e.ExtractXYText()
+		if err != nil {
+			return nil, err
+		}
+		b.addPage(pageNum, textList)
+	}
+
+	st := &StructuredText{}
+	for _, e := range root.K {
+		elem, _ := b.element(e)
+		st.Elements = append(st.Elements, elem)
+	}
+	return st, nil
+}
+
+// Blocks returns the lowest level block elements of `st`, e.g. paragraphs, headings, list items and
+// table cells, in logical order.
+func (st *StructuredText) Blocks() []LabelledText {
+	var blocks []LabelledText
+	var walk func(elems []StructuredElement)
+	walk = func(elems []StructuredElement) {
+		for _, e := range elems {
+			if hasBlockKids(e) {
+				walk(e.Kids)
+			} else if e.Text != "" {
+				blocks = append(blocks, LabelledText{Label: e.Type, Text: e.Text, PageNum: e.PageNum})
+			}
+		}
+	}
+	walk(st.Elements)
+	return blocks
+}
+
+// ToText returns the text of `st` in logical order with its blocks on separate lines.
+func (st *StructuredText) ToText() string {
+	var lines []string
+	for _, block := range st.Blocks() {
+		lines = append(lines, block.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasBlockKids returns true if `e` has a descendant that is a block level element.
+func hasBlockKids(e StructuredElement) bool {
+	for _, kid := range e.Kids {
+		if blockTypes[kid.Type] || hasBlockKids(kid) {
+			return true
+		}
+	}
+	return false
+}
+
+// markedContentKey identifies a marked-content sequence of a page.
+type markedContentKey struct {
+	mcid            int
+	stmObjectNumber int64
+}
+
+// structuredTextBuilder builds the StructuredElements of a structure tree.
+type structuredTextBuilder struct {
+	root *model.PdfStructTreeRoot
+	// pages maps page numbers to the texts of the marked-content sequences of the page.
+	pages map[int]map[markedContentKey]*TextList
+}
+
+// collectPages appends the numbers of the pages with marked content referenced by `elems` and their
+// descendants to `pageNums`.
+func (b *structuredTextBuilder) collectPages(elems []*model.PdfStructElement, pageNums *[]int) {
+	for _, e := range elems {
+		for _, kid := range e.Kids {
+			if kid.Element != nil {
+				b.collectPages([]*model.PdfStructElement{kid.Element}, pageNums)
+				continue
+			}
+			if kid.MCID < 0 || kid.PageNum <= 0 {
+				continue
+			}
+			if _, has := b.pages[kid.PageNum]; !has {
+				b.pages[kid.PageNum] = map[markedContentKey]*TextList{}
+				*pageNums = append(*pageNums, kid.PageNum)
+			}
+		}
+	}
+}
+
+// addPage groups the texts `textList` of page `pageNum` by marked-content sequence.
+func (b *structuredTextBuilder) addPage(pageNum int, textList *TextList) {
+	groups := b.pages[pageNum]
+	for _, t := range *textList {
+		if t.style == nil || t.style.mcid < 0 {
+			continue
+		}
+		key := markedContentKey{t.style.mcid, t.style.stmObjectNumber}
+		if groups[key] == nil {
+			groups[key] = &TextList{}
+		}
+		groups[key].add(t)
+	}
+}
+
+// element returns the StructuredElement of `e`. It also returns the texts of `e` for inclusion in
+// the text of its parent, or nil if the text of `e` is its ActualText or Alt.
+func (b *structuredTextBuilder) element(e *model.PdfStructElement) (StructuredElement, TextList) {
+	elem := StructuredElement{
+		Type:    b.root.StandardType(e),
+		Role:    e.S,
+		Lang:    e.Lang,
+		PageNum: e.PageNum,
+	}
+
+	// Consecutive inline content is joined as one list of texts, so its text is spaced by position.
+	// Block level kids are on separate lines.
+	var all, inline TextList
+	var parts []string
+	flush := func() {
+		if len(inline) > 0 {
+			parts = append(parts, inline.ToText())
+			inline = nil
+		}
+	}
+	for _, kid := range e.Kids {
+		if kid.Element != nil {
+			child, texts := b.element(kid.Element)
+			elem.Kids = append(elem.Kids, child)
+			if texts != nil && !blockTypes[child.Type] {
+				inline = append(inline, texts...)
+				all = append(all, texts...)
+				continue
+			}
+			flush()
+			parts = append(parts, child.Text)
+			all = append(all, texts...)
+			continue
+		}
+		if kid.MCID < 0 {
+			continue
+		}
+		if texts := b.pages[kid.PageNum][markedContentKey{kid.MCID, kid.StmObjectNumber}]; texts != nil {
+			inline = append(inline, *texts...)
+			all = append(all, *texts...)
+		}
+	}
+	flush()
+
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	elem.Text = strings.Join(nonEmpty, "\n")
+
+	switch {
+	case e.ActualText != "":
+		elem.Text = e.ActualText
+		return elem, nil
+	case elem.Text == "" && e.Alt != "":
+		elem.Text = e.Alt
+		return elem, nil
+	}
+	return elem, all
+}
@@ -0,0 +1,154 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// makeTestPDF returns a PDF file with objects `objects`, numbered from 1. Object 1 is the catalog.
+func makeTestPDF(objects []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	var offsets []int
+	for i, obj := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// makeStreamObject returns a stream object with contents `contents`.
+func makeStreamObject(contents string) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(contents), contents)
+}
+
+// The paragraph is shown before the heading and the page number is an artifact.
+const testTaggedContents = `/Artifact BMC BT /F1 10 Tf 50 750 Td (Page 1) Tj ET EMC
+/P << /MCID 1 >> BDC BT /F1 10 Tf 50 650 Td (First line) Tj 0 -12 Td (second line) Tj ET EMC
+/H1 /MC0 BDC BT /F1 20 Tf 50 700 Td (Title) Tj ET EMC
+/LI << /MCID 2 >> BDC BT /F1 10 Tf 50 600 Td (Item) Tj
+/Span << /ActualText (one) >> BDC 30 0 Td (1) Tj EMC ET EMC`
+
+func TestStructuredText(t *testing.T) {
+	data := makeTestPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R /StructTreeRoot 5 0 R /MarkInfo << /Marked true >> >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R /StructParents 0 " +
+			"/Resources << /Font << /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> >> " +
+			"/Properties << /MC0 << /MCID 0 >> >> >> >>",
+		makeStreamObject(testTaggedContents),
+		"<< /Type /StructTreeRoot /K 6 0 R /RoleMap << /Heading /H1 >> >>",
+		"<< /Type /StructElem /S /Document /P 5 0 R /K [7 0 R 8 0 R 9 0 R 10 0 R] >>",
+		"<< /Type /StructElem /S /Heading /P 6 0 R /Pg 3 0 R /K 0 >>",
+		"<< /Type /StructElem /S /P /P 6 0 R /Pg 3 0 R /K [1] >>",
+		"<< /Type /StructElem /S /L /P 6 0 R /K << /Type /StructElem /S /LI /Pg 3 0 R " +
+			"/K << /Type /MCR /MCID 2 >> >> >>",
+		"<< /Type /StructElem /S /Figure /P 6 0 R /Pg 3 0 R /Alt (A chart) >>",
+	})
+	reader, err := model.NewPdfReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error reading PDF: %v", err)
+	}
+
+	root, err := reader.GetStructTreeRoot()
+	if err != nil || root == nil {
+		t.Fatalf("Error loading structure tree: %v", err)
+	}
+	if len(root.K) != 1 || len(root.K[0].Kids) != 4 {
+		t.Fatalf("Incorrect structure tree %+v", root.K)
+	}
+	heading := root.K[0].Kids[0].Element
+	if heading.S != "Heading" || root.StandardType(heading) != "H1" || heading.PageNum != 1 {
+		t.Errorf("Incorrect heading element %+v", heading)
+	}
+
+	st, err := ExtractStructuredText(reader)
+	if err != nil {
+		t.Fatalf("Error extracting structured text: %v", err)
+	}
+	expected := []LabelledText{
+		{"H1", "Title", 1},
+		{"P", "First line\nsecond line", 1},
+		{"LI", "Item one", 1},
+		{"Figure", "A chart", 1},
+	}
+	blocks := st.Blocks()
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected %d blocks, got %+v", len(expected), blocks)
+	}
+	for i := range blocks {
+		if blocks[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], blocks[i])
+		}
+	}
+	if list := st.Elements[0].Kids[2]; list.Type != "L" || list.Text != "Item one" {
+		t.Errorf("Incorrect list element %+v", list)
+	}
+}
+
+// A structure tree whose K array contains itself is loaded without endless recursion.
+func TestStructTreeArrayCycle(t *testing.T) {
+	data := makeTestPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R /StructTreeRoot 4 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+		"<< /Type /StructTreeRoot /K 5 0 R >>",
+		"[5 0 R << /Type /StructElem /S /P /K 5 0 R >>]",
+	})
+	reader, err := model.NewPdfReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error reading PDF: %v", err)
+	}
+	root, err := reader.GetStructTreeRoot()
+	if err != nil || root == nil {
+		t.Fatalf("Error loading structure tree: %v", err)
+	}
+	if len(root.K) != 1 || root.K[0].S != "P" || len(root.K[0].Kids) != 0 {
+		t.Errorf("Incorrect structure tree %+v", root.K)
+	}
+}
+
+// TestMarkedContent checks that text in marked-content sequences has their MCIDs and that ActualText
+// replaces the text it spans.
+func TestMarkedContent(t *testing.T) {
+	contents := `
+BT /F1 10 Tf 100 700 Td
+/Span << /MCID 4 >> BDC (Hello) Tj EMC
+/Span << /ActualText <FEFF00660069> >> BDC (xx) Tj ( yy) Tj EMC
+(!) Tj
+ET`
+	e := Extractor{contents: contents, resources: helveticaResources()}
+	marks, err := e.ExtractTextMarks()
+	if err != nil {
+		t.Fatalf("Error extracting marks: %v", err)
+	}
+	var text string
+	for _, m := range marks {
+		text += m.Text
+	}
+	if text != "Hellofi!" {
+		t.Errorf("Incorrect text %q", text)
+	}
+	if marks[0].MCID != 4 || marks[5].MCID != -1 || marks[7].MCID != -1 {
+		t.Errorf("Incorrect MCIDs %d %d %d", marks[0].MCID, marks[5].MCID, marks[7].MCID)
+	}
+	// The ActualText starts after "Hello" (2278 wide) and spans "xx yy": x 500, space 278, y 500.
+	if f := marks[5]; math.Abs(f.X-122.78) > 1e-6 || math.Abs(marks[7].X-f.X-22.78) > 1e-6 {
+		t.Errorf("Incorrect ActualText position %+v", f)
+	}
+}
@@ -0,0 +1,644 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// TableMode selects how tables are detected by ExtractTables.
+type TableMode int
+
+const (
+	// TableModeAuto detects ruled tables and then tables separated by whitespace in the remaining text.
+	TableModeAuto TableMode = iota
+	// TableModeLattice detects tables whose cells are separated by ruling lines.
+	TableModeLattice
+	// TableModeStream detects tables whose columns are separated by whitespace.
+	TableModeStream
+)
+
+// Table is a table detected on a page.
+type Table struct {
+	BBox model.PdfRectangle
+	// Cells is the grid of cells by row, top to bottom, and column, left to right. A cell that spans
+	// several rows or columns is stored at its top left position of the grid. The other positions it
+	// covers have zero RowSpan and ColSpan and no text.
+	Cells [][]TableCell
+}
+
+// TableCell is a cell of a Table.
+type TableCell struct {
+	Text    string
+	BBox    model.PdfRectangle
+	RowSpan int
+	ColSpan int
+}
+
+// Rows returns the number of rows of the table.
+func (t *Table) Rows() int {
+	return len(t.Cells)
+}
+
+// Cols returns the number of columns of the table.
+func (t *Table) Cols() int {
+	if len(t.Cells) == 0 {
+		return 0
+	}
+	return len(t.Cells[0])
+}
+
+// WriteCSV writes the cell texts of the table to `w` in CSV format, one record per row. Positions
+// covered by spanning cells are empty.
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	for _, row := range t.Cells {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = cell.Text
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ToCSV returns the cell texts of the table in CSV format.
+func (t *Table) ToCSV() (string, error) {
+	var buf bytes.Buffer
+	if err := t.WriteCSV(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// The following parameters of table detection are in points.
+const (
+	// rulingTolerance is the maximum offset of ruling lines that are considered to be aligned or
+	// connected.
+	rulingTolerance = 2.0
+	// rulingThickness is the maximum thickness of filled rectangles that are ruling lines.
+	rulingThickness = 2.0
+)
+
+// minTableRows is the minimum number of rows of a table detected by whitespace.
+const minTableRows = 2
+
+// ExtractTables detects the tables of `e` with method `mode` and returns them from top to bottom.
+// Ruled (lattice) tables are found from the horizontal and vertical ruling lines drawn by the
+// content stream: stroked lines and thin filled rectangles. Connected ruling lines form a table grid
+// and cells whose shared borders are missing are merged into spanning cells. Whitespace (stream)
+// tables are found from consecutive lines of text that are split into aligned columns by wide gaps.
+func (e *Extractor) ExtractTables(mode TableMode) ([]Table, error) {
+	textList, err := e.ExtractXYText()
+	if err != nil {
+		return nil, err
+	}
+	var chars []layoutChar
+	for _, t := range *textList {
+		for _, c := range t.layoutChars() {
+			// Tables are detected in unrotated text only.
+			if c.rotation == 0 {
+				chars = append(chars, c)
+			}
+		}
+	}
+
+	var tables []Table
+	if mode == TableModeAuto || mode == TableModeLattice {
+		rulings, err := e.extractRulings()
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, latticeTables(rulings, chars)...)
+	}
+	if mode == TableModeAuto || mode == TableModeStream {
+		// Text in ruled tables is not considered again.
+		var remaining []layoutChar
+		for _, c := range chars {
+			inTable := false
+			for _, t := range tables {
+				if rectContains(t.BBox, c.centerX(), c.centerY()) {
+					inTable = true
+					break
+				}
+			}
+			if !inTable {
+				remaining = append(remaining, c)
+			}
+		}
+		tables = append(tables, streamTables(remaining)...)
+	}
+
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].BBox.Ury > tables[j].BBox.Ury })
+	return tables, nil
+}
+
+// ruling is a horizontal or vertical line in device space at `pos` (y for horizontal and x for
+// vertical lines) that extends from `lo` to `hi`.
+type ruling struct {
+	horizontal bool
+	pos        float64
+	lo, hi     float64
+}
+
+// pathBuilder holds the subpaths of a path of a content stream in device space.
+type pathBuilder struct {
+	subpaths [][][2]float64
+	// Whether each subpath is made of straight lines only.
+	straight []bool
+	closed   []bool
+}
+
+// newPathBuilder returns the subpaths of path `path` in device space.
+func newPathBuilder(path contentstream.Path) *pathBuilder {
+	pb := &pathBuilder{}
+	var start [2]float64
+	for _, seg := range path.Segments {
+		if seg.Type == contentstream.PathMoveTo {
+			start = [2]float64{seg.Points[0].X, seg.Points[0].Y}
+			pb.moveTo(start)
+			continue
+		}
+		if len(pb.subpaths) == 0 {
+			if seg.Type == contentstream.PathClose {
+				continue
+			}
+			// Paths without a move start at their first point.
+			start = [2]float64{seg.Points[0].X, seg.Points[0].Y}
+			pb.moveTo(start)
+		} else if pb.closed[len(pb.closed)-1] {
+			// Segments that follow a closed subpath without a move start a new subpath at its start.
+			pb.moveTo(start)
+		}
+		i := len(pb.subpaths) - 1
+		switch seg.Type {
+		case contentstream.PathLineTo:
+			pb.subpaths[i] = append(pb.subpaths[i], [2]float64{seg.Points[0].X, seg.Points[0].Y})
+		case contentstream.PathCurveTo:
+			// Curves end at their last point. They are not rulings.
+			pb.subpaths[i] = append(pb.subpaths[i], [2]float64{seg.Points[2].X, seg.Points[2].Y})
+			pb.straight[i] = false
+		case contentstream.PathClose:
+			pb.closed[i] = true
+		}
+	}
+	return pb
+}
+
+func (pb *pathBuilder) moveTo(pt [2]float64) {
+	pb.subpaths = append(pb.subpaths, [][2]float64{pt})
+	pb.straight = append(pb.straight, true)
+	pb.closed = append(pb.closed, false)
+}
+
+// rulings returns the ruling lines of the path when it is stroked (`stroke`) and/or filled (`fill`).
+func (pb *pathBuilder) rulings(stroke, fill bool) []ruling {
+	var rulings []ruling
+	for i, points := range pb.subpaths {
+		if stroke {
+			for j := 1; j < len(points); j++ {
+				if r, ok := segmentRuling(points[j-1], points[j]); ok {
+					rulings = append(rulings, r)
+				}
+			}
+			if pb.closed[i] && len(points) > 2 {
+				if r, ok := segmentRuling(points[len(points)-1], points[0]); ok {
+					rulings = append(rulings, r)
+				}
+			}
+		}
+		if fill && pb.straight[i] {
+			if r, ok := thinRectRuling(points); ok {
+				rulings = append(rulings, r)
+			}
+		}
+	}
+	return rulings
+}
+
+// segmentRuling returns the ruling of the line segment from `p0` to `p1` if it is horizontal or
+// vertical.
+func segmentRuling(p0, p1 [2]float64) (ruling, bool) {
+	dx, dy := math.Abs(p1[0]-p0[0]), math.Abs(p1[1]-p0[1])
+	switch {
+	case dy <= rulingTolerance/2 && dx > rulingTolerance:
+		return ruling{horizontal: true, pos: (p0[1] + p1[1]) / 2,
+			lo: math.Min(p0[0], p1[0]), hi: math.Max(p0[0], p1[0])}, true
+	case dx <= rulingTolerance/2 && dy > rulingTolerance:
+		return ruling{pos: (p0[0] + p1[0]) / 2,
+			lo: math.Min(p0[1], p1[1]), hi: math.Max(p0[1], p1[1])}, true
+	}
+	return ruling{}, false
+}
+
+// thinRectRuling returns the ruling of the subpath with `points` if it is a thin axis aligned
+// rectangle.
+func thinRectRuling(points [][2]float64) (ruling, bool) {
+	if len(points) == 5 && points[4] == points[0] {
+		points = points[:4]
+	}
+	if len(points) != 4 {
+		return ruling{}, false
+	}
+	x0, y0, x1, y1 := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		x0, y0 = math.Min(x0, p[0]), math.Min(y0, p[1])
+		x1, y1 = math.Max(x1, p[0]), math.Max(y1, p[1])
+	}
+	// All corners must be on the bounding box.
+	for _, p := range points {
+		onX := math.Abs(p[0]-x0) < 1e-3 || math.Abs(p[0]-x1) < 1e-3
+		onY := math.Abs(p[1]-y0) < 1e-3 || math.Abs(p[1]-y1) < 1e-3
+		if !onX || !onY {
+			return ruling{}, false
+		}
+	}
+	w, h := x1-x0, y1-y0
+	switch {
+	case h <= rulingThickness && w > h:
+		return ruling{horizontal: true, pos: (y0 + y1) / 2, lo: x0, hi: x1}, true
+	case w <= rulingThickness && h > w:
+		return ruling{pos: (x0 + x1) / 2, lo: y0, hi: y1}, true
+	}
+	return ruling{}, false
+}
+
+// extractRulings returns the horizontal and vertical ruling lines drawn by the content stream of
+// `e`.
+func (e *Extractor) extractRulings() ([]ruling, error) {
+	cstreamParser := contentstream.NewContentStreamParser(e.contents)
+	operations, err := cstreamParser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	processor := contentstream.NewContentStreamProcessor(*operations)
+
+	var rulings []ruling
+	processor.AddHandler(contentstream.HandlerConditionEnumAllOperands, "",
+		func(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState,
+			resources *model.PdfPageResources) error {
+			switch op.Operand {
+			case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*":
+				// Closing operators have closed the path.
+				pb := newPathBuilder(gs.Path.Transform(gs.CTM))
+				stroke := strings.ContainsAny(op.Operand, "SsBb")
+				fill := op.Operand != "S" && op.Operand != "s"
+				rulings = append(rulings, pb.rulings(stroke, fill)...)
+			}
+			return nil
+		})
+
+	err = processor.Process(e.resources)
+	if err != nil {
+		common.Log.Error("Error processing: %v", err)
+		return nil, err
+	}
+	return mergeRulings(rulings), nil
+}
+
+// mergeRulings merges aligned rulings that overlap or touch.
+func mergeRulings(rulings []ruling) []ruling {
+	sort.Slice(rulings, func(i, j int) bool {
+		a, b := rulings[i], rulings[j]
+		if a.horizontal != b.horizontal {
+			return a.horizontal
+		}
+		if a.pos != b.pos {
+			return a.pos < b.pos
+		}
+		return a.lo < b.lo
+	})
+
+	var merged []ruling
+	for _, r := range rulings {
+		found := false
+		for i := len(merged) - 1; i >= 0; i-- {
+			m := &merged[i]
+			if m.horizontal != r.horizontal || r.pos-m.pos > rulingTolerance {
+				break
+			}
+			if r.lo <= m.hi+rulingTolerance && r.hi >= m.lo-rulingTolerance {
+				m.lo, m.hi = math.Min(m.lo, r.lo), math.Max(m.hi, r.hi)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+// intersects returns true if horizontal ruling `h` and vertical ruling `v` cross or touch.
+func intersects(h, v ruling) bool {
+	return v.pos >= h.lo-rulingTolerance && v.pos <= h.hi+rulingTolerance &&
+		h.pos >= v.lo-rulingTolerance && h.pos <= v.hi+rulingTolerance
+}
+
+// unionFind is a disjoint set forest over integers.
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	uf := make(unionFind, n)
+	for i := range uf {
+		uf[i] = i
+	}
+	return uf
+}
+
+func (uf unionFind) find(i int) int {
+	for uf[i] != i {
+		uf[i] = uf[uf[i]]
+		i = uf[i]
+	}
+	return i
+}
+
+func (uf unionFind) union(i, j int) {
+	uf[uf.find(i)] = uf.find(j)
+}
+
+// latticeTables returns the tables formed by connected `rulings` and fills their cells with `chars`.
+func latticeTables(rulings []ruling, chars []layoutChar) []Table {
+	uf := newUnionFind(len(rulings))
+	for i, a := range rulings {
+		for j, b := range rulings {
+			if a.horizontal && !b.horizontal && intersects(a, b) {
+				uf.union(i, j)
+			}
+		}
+	}
+	components := map[int][]ruling{}
+	var roots []int
+	for i, r := range rulings {
+		root := uf.find(i)
+		if _, has := components[root]; !has {
+			roots = append(roots, root)
+		}
+		components[root] = append(components[root], r)
+	}
+
+	var tables []Table
+	for _, root := range roots {
+		if t, ok := latticeTable(components[root], chars); ok {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// latticeTable returns the table formed by the connected rulings `rulings`, if they form a grid of
+// at least one cell.
+func latticeTable(rulings []ruling, chars []layoutChar) (Table, bool) {
+	var hs, vs []ruling
+	var ys, xs []float64
+	for _, r := range rulings {
+		if r.horizontal {
+			hs = append(hs, r)
+			ys = append(ys, r.pos)
+		} else {
+			vs = append(vs, r)
+			xs = append(xs, r.pos)
+		}
+	}
+	xs = clusterPositions(xs)
+	ys = clusterPositions(ys)
+	if len(xs) < 2 || len(ys) < 2 {
+		return Table{}, false
+	}
+	// Rows from top to bottom.
+	for i, j := 0, len(ys)-1; i < j; i, j = i+1, j-1 {
+		ys[i], ys[j] = ys[j], ys[i]
+	}
+	nRows, nCols := len(ys)-1, len(xs)-1
+
+	// covered returns true if a ruling in `rs` at `pos` covers the interval from `lo` to `hi`.
+	covered := func(rs []ruling, pos, lo, hi float64) bool {
+		mid := (lo + hi) / 2
+		for _, r := range rs {
+			if math.Abs(r.pos-pos) <= rulingTolerance && r.lo <= mid && r.hi >= mid {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Merge the grid cells that are not separated by rulings.
+	uf := newUnionFind(nRows * nCols)
+	for r := 0; r < nRows; r++ {
+		for c := 0; c < nCols; c++ {
+			if c+1 < nCols && !covered(vs, xs[c+1], ys[r+1], ys[r]) {
+				uf.union(r*nCols+c, r*nCols+c+1)
+			}
+			if r+1 < nRows && !covered(hs, ys[r+1], xs[c], xs[c+1]) {
+				uf.union(r*nCols+c, (r+1)*nCols+c)
+			}
+		}
+	}
+
+	// The extent of each merged cell in the grid.
+	type span struct{ r0, c0, r1, c1 int }
+	spans := map[int]*span{}
+	for r := 0; r < nRows; r++ {
+		for c := 0; c < nCols; c++ {
+			root := uf.find(r*nCols + c)
+			s, has := spans[root]
+			if !has {
+				spans[root] = &span{r, c, r, c}
+				continue
+			}
+			if r < s.r0 {
+				s.r0 = r
+			}
+			if c < s.c0 {
+				s.c0 = c
+			}
+			if r > s.r1 {
+				s.r1 = r
+			}
+			if c > s.c1 {
+				s.c1 = c
+			}
+		}
+	}
+
+	table := Table{
+		BBox:  model.PdfRectangle{Llx: xs[0], Lly: ys[nRows], Urx: xs[nCols], Ury: ys[0]},
+		Cells: make([][]TableCell, nRows),
+	}
+	for r := range table.Cells {
+		table.Cells[r] = make([]TableCell, nCols)
+	}
+	for _, s := range spans {
+		bbox := model.PdfRectangle{Llx: xs[s.c0], Lly: ys[s.r1+1], Urx: xs[s.c1+1], Ury: ys[s.r0]}
+		var cellChars []layoutChar
+		for _, c := range chars {
+			if rectContains(bbox, c.centerX(), c.centerY()) {
+				cellChars = append(cellChars, c)
+			}
+		}
+		table.Cells[s.r0][s.c0] = TableCell{
+			Text:    charsText(cellChars),
+			BBox:    bbox,
+			RowSpan: s.r1 - s.r0 + 1,
+			ColSpan: s.c1 - s.c0 + 1,
+		}
+	}
+	return table, true
+}
+
+// clusterPositions returns the sorted distinct values of `vals`, treating values within
+// rulingTolerance of each other as equal.
+func clusterPositions(vals []float64) []float64 {
+	sort.Float64s(vals)
+	var out []float64
+	for _, v := range vals {
+		if len(out) > 0 && v-out[len(out)-1] <= rulingTolerance {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// streamTables returns the tables formed by consecutive lines of `chars` that are split into
+// columns by gaps of at least blockGapX font sizes.
+func streamTables(chars []layoutChar) []Table {
+	f := newLayoutFrame(0)
+	lines := makeLines(f.makeWords(chars))
+
+	// The cells of each line: groups of words separated by wide gaps.
+	cells := make([][][]*layoutWord, len(lines))
+	for i, line := range lines {
+		var cell []*layoutWord
+		for _, w := range line.words {
+			if len(cell) > 0 && w.x0-cell[len(cell)-1].x1 >= blockGapX*math.Max(w.size, cell[0].size) {
+				cells[i] = append(cells[i], cell)
+				cell = nil
+			}
+			cell = append(cell, w)
+		}
+		cells[i] = append(cells[i], cell)
+	}
+
+	var tables []Table
+	for start := 0; start < len(lines); {
+		end := start
+		for end < len(lines) && len(cells[end]) >= 2 {
+			if end > start {
+				size := math.Max(lines[end-1].size(), lines[end].size())
+				if lines[end-1].base()-lines[end].base() > 3*size {
+					break
+				}
+			}
+			end++
+		}
+		if end-start >= minTableRows {
+			if t, ok := streamTable(lines[start:end], cells[start:end]); ok {
+				tables = append(tables, t)
+			}
+		}
+		if end == start {
+			end++
+		}
+		start = end
+	}
+	return tables
+}
+
+// streamTable returns the table of `lines` with cells `cells`. Its columns are the union of the
+// horizontal extents of the cells.
+func streamTable(lines []*layoutLine, cells [][][]*layoutWord) (Table, bool) {
+	type interval struct{ lo, hi float64 }
+	var extents []interval
+	for _, row := range cells {
+		for _, cell := range row {
+			extents = append(extents, interval{cell[0].x0, cell[len(cell)-1].x1})
+		}
+	}
+	sort.Slice(extents, func(i, j int) bool { return extents[i].lo < extents[j].lo })
+	var cols []interval
+	for _, e := range extents {
+		if n := len(cols); n > 0 && e.lo <= cols[n-1].hi {
+			cols[n-1].hi = math.Max(cols[n-1].hi, e.hi)
+			continue
+		}
+		cols = append(cols, e)
+	}
+	if len(cols) < 2 {
+		return Table{}, false
+	}
+
+	table := Table{Cells: make([][]TableCell, len(lines))}
+	for r, line := range lines {
+		top := line.base() + ascentRatio*line.size()
+		bottom := line.base() - descentRatio*line.size()
+		table.Cells[r] = make([]TableCell, len(cols))
+		for c, col := range cols {
+			var texts []string
+			for _, cell := range cells[r] {
+				if cell[0].x0 >= col.lo && cell[0].x0 <= col.hi {
+					for _, w := range cell {
+						texts = append(texts, w.text)
+					}
+				}
+			}
+			table.Cells[r][c] = TableCell{
+				Text:    strings.Join(texts, " "),
+				BBox:    model.PdfRectangle{Llx: col.lo, Lly: bottom, Urx: col.hi, Ury: top},
+				RowSpan: 1,
+				ColSpan: 1,
+			}
+		}
+	}
+
+	table.BBox = table.Cells[0][0].BBox
+	for _, row := range table.Cells {
+		for _, cell := range row {
+			table.BBox = unionRect(table.BBox, cell.BBox)
+		}
+	}
+	return table, true
+}
+
+// charsText returns the text of `chars` as words separated by spaces, in reading order.
+func charsText(chars []layoutChar) string {
+	f := newLayoutFrame(0)
+	var lines []string
+	for _, line := range makeLines(f.makeWords(chars)) {
+		var words []string
+		for _, w := range line.words {
+			words = append(words, w.text)
+		}
+		lines = append(lines, strings.Join(words, " "))
+	}
+	return strings.Join(lines, " ")
+}
+
+// centerX and centerY return the center of the glyph of `c` on its baseline.
+func (c layoutChar) centerX() float64 { return (c.x + c.endX) / 2 }
+func (c layoutChar) centerY() float64 {
+	return (c.y+c.endY)/2 + (ascentRatio-descentRatio)/2*c.size
+}
+
+// rectContains returns true if point (`x`, `y`) is inside `r`.
+func rectContains(r model.PdfRectangle, x, y float64) bool {
+	return x >= r.Llx && x <= r.Urx && y >= r.Lly && y <= r.Ury
+}
@@ -0,0 +1,88 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package extractor
+
+import (
+	"testing"
+)
+
+// testTablesContents has a ruled table with a header cell that spans both columns, drawn with
+// stroked lines and a thin filled rectangle, and a table separated by whitespace below it.
+const testTablesContents = `
+1 w
+100 700 m 300 700 l 100 680 m 300 680 l 100 660 m 300 660 l 100 640 m 300 640 l S
+100 640 m 100 700 l 300 640 m 300 700 l S
+199.5 640 1 40 re f
+BT
+/F1 10 Tf
+1 0 0 1 105 686 Tm (Fruit prices) Tj
+1 0 0 1 105 666 Tm (Apple) Tj
+1 0 0 1 205 666 Tm (1.20) Tj
+1 0 0 1 105 646 Tm (Pear) Tj
+1 0 0 1 205 646 Tm (0.90, each) Tj
+1 0 0 1 100 500 Tm (Item) Tj
+1 0 0 1 200 500 Tm (Qty) Tj
+1 0 0 1 100 485 Tm (Bolts) Tj
+1 0 0 1 200 485 Tm (12) Tj
+1 0 0 1 100 470 Tm (Nuts) Tj
+1 0 0 1 200 470 Tm (7) Tj
+1 0 0 1 100 400 Tm (Not a table) Tj
+ET
+`
+
+func TestExtractTables(t *testing.T) {
+	e := Extractor{contents: testTablesContents, resources: helveticaResources()}
+	tables, err := e.ExtractTables(TableModeAuto)
+	if err != nil {
+		t.Fatalf("Error extracting tables: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("Expected 2 tables, got %d", len(tables))
+	}
+
+	ruled := tables[0]
+	if ruled.Rows() != 3 || ruled.Cols() != 2 {
+		t.Fatalf("Expected a 3 x 2 ruled table, got %d x %d", ruled.Rows(), ruled.Cols())
+	}
+	bbox := ruled.BBox
+	if bbox.Llx != 100 || bbox.Lly != 640 || bbox.Urx != 300 || bbox.Ury != 700 {
+		t.Errorf("Incorrect table bbox %+v", bbox)
+	}
+	header := ruled.Cells[0][0]
+	if header.Text != "Fruit prices" || header.ColSpan != 2 || header.RowSpan != 1 || header.BBox.Urx != 300 {
+		t.Errorf("Incorrect header cell %+v", header)
+	}
+	if covered := ruled.Cells[0][1]; covered.ColSpan != 0 || covered.Text != "" {
+		t.Errorf("Incorrect covered cell %+v", covered)
+	}
+	csv, err := ruled.ToCSV()
+	if err != nil {
+		t.Fatalf("Error exporting CSV: %v", err)
+	}
+	expected := "Fruit prices,\nApple,1.20\nPear,\"0.90, each\"\n"
+	if csv != expected {
+		t.Errorf("Incorrect CSV\n%q\nexpected\n%q", csv, expected)
+	}
+
+	stream := tables[1]
+	if stream.Rows() != 3 || stream.Cols() != 2 {
+		t.Fatalf("Expected a 3 x 2 stream table, got %d x %d", stream.Rows(), stream.Cols())
+	}
+	csv, _ = stream.ToCSV()
+	if expected := "Item,Qty\nBolts,12\nNuts,7\n"; csv != expected {
+		t.Errorf("Incorrect CSV\n%q\nexpected\n%q", csv, expected)
+	}
+
+	tables, err = e.ExtractTables(TableModeLattice)
+	if err != nil || len(tables) != 1 {
+		t.Errorf("Expected 1 lattice table, got %d (%v)", len(tables), err)
+	}
+	// Without ruling lines, the rows of the ruled table below its header form a whitespace table.
+	tables, err = e.ExtractTables(TableModeStream)
+	if err != nil || len(tables) != 2 || tables[0].Rows() != 2 {
+		t.Errorf("Expected 2 stream tables, got %d (%v)", len(tables), err)
+	}
+}
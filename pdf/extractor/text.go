@@ -7,43 +7,104 @@ package extractor
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/unidoc/unidoc/common"
 	"github.com/unidoc/unidoc/pdf/contentstream"
 	"github.com/unidoc/unidoc/pdf/core"
-	"github.com/unidoc/unidoc/pdf/internal/cmap"
 	"github.com/unidoc/unidoc/pdf/model"
 )
 
-// XYText represents text and its position on a page
+// XYText represents text and its position on a page.
+// (X, Y) is the start of the text on its baseline and (EndX, EndY) is where the text ends, i.e. where
+// the next glyph would be drawn. All coordinates are in device space (the default user space of
+// the page). FontSize is the font size in device space.
 type XYText struct {
-	X, Y   float64
-	Orient contentstream.Orientation
-	Text   string
+	X, Y       float64
+	EndX, EndY float64
+	FontSize   float64
+	Orient     contentstream.Orientation
+	Text       string
+
+	// The characters of the text. nil if the font of the text could not be loaded.
+	chars []textChar
+	// The appearance of the text. nil for text that was not extracted from a content stream.
+	style *textStyle
+}
+
+// textChar is a character of an XYText: the text of a character code, the start and end of its
+// glyph on the baseline and the bounding box of the glyph, in device space.
+type textChar struct {
+	text       string
+	x, y       float64
+	endX, endY float64
+	bbox       model.PdfRectangle
 }
 
 // TextList is a list of text and its position on a pdf page
 type TextList []XYText
 
 // add appends the location and position of text to a text list
-func (tl *TextList) add(x, y float64, o contentstream.Orientation, text string) {
-	*tl = append(*tl, XYText{x, y, o, text})
+func (tl *TextList) add(t XYText) {
+	*tl = append(*tl, t)
 }
 
-// ToText returns the contents of tl as a single string
+// ToText returns the contents of tl as a single string. Spaces and newlines are inserted between
+// text fragments based on their positions.
 func (tl *TextList) ToText() string {
 	var buf bytes.Buffer
-	for _, t := range *tl {
-		// fmt.Printf("---- %4d: %4.1f %4.1f %q\n", i, t.X, t.Y, t.Text)
+	for i, t := range *tl {
+		if i > 0 {
+			buf.WriteString(separator((*tl)[i-1], t))
+		}
 		buf.WriteString(t.Text)
 	}
 	procBuf(&buf)
 	return buf.String()
 }
 
+// separator returns the whitespace that should be inserted between text fragment `a` and the text
+// fragment `b` that follows it: "\n" if `b` is not on the baseline of `a`, " " if there is a gap
+// between them and "" otherwise.
+func separator(a, b XYText) string {
+	// Unit vector along the baseline of `a`.
+	ux, uy := a.EndX-a.X, a.EndY-a.Y
+	if l := math.Hypot(ux, uy); l > 0 {
+		ux, uy = ux/l, uy/l
+	} else {
+		ux, uy = 1, 0
+	}
+	dx, dy := b.X-a.EndX, b.Y-a.EndY
+	along := dx*ux + dy*uy
+	across := dy*ux - dx*uy
+
+	size := math.Max(a.FontSize, b.FontSize)
+	if math.Abs(across) > lineThreshold*size {
+		return "\n"
+	}
+	if along > spaceThreshold*size || along < -size {
+		last, _ := utf8.DecodeLastRuneInString(a.Text)
+		first, _ := utf8.DecodeRuneInString(b.Text)
+		if unicode.IsSpace(last) || unicode.IsSpace(first) {
+			return ""
+		}
+		return " "
+	}
+	return ""
+}
+
+const (
+	// lineThreshold is the minimum offset, in units of font size, perpendicular to the baseline
+	// between text fragments on different lines.
+	lineThreshold = 0.4
+	// spaceThreshold is the minimum gap, in units of font size, along the baseline between words.
+	spaceThreshold = 0.15
+)
+
 // SortPosition sorts a text list by its elements position on a page. Top to bottom, left to right.
 func (tl *TextList) SortPosition() {
 	sort.SliceStable(*tl, func(i, j int) bool {
@@ -77,11 +138,24 @@ func (tl *TextList) Transform(a, b, c, d, tx, ty float64) *TextList {
 	// fmt.Println("^^^^^^^^^^^$$$$$$$$$^^^^^^^^^^^^^^^^")
 	for _, t := range *tl {
 		x, y := m.Transform(t.X, t.Y)
+		endX, endY := m.Transform(t.EndX, t.EndY)
+		var chars []textChar
+		for _, c := range t.chars {
+			c.x, c.y = m.Transform(c.x, c.y)
+			c.endX, c.endY = m.Transform(c.endX, c.endY)
+			c.bbox = transformRect(m, c.bbox.Llx, c.bbox.Lly, c.bbox.Urx, c.bbox.Ury)
+			chars = append(chars, c)
+		}
 		o := XYText{
-			X:      x,
-			Y:      y,
-			Orient: t.Orient,
-			Text:   t.Text,
+			X:        x,
+			Y:        y,
+			EndX:     endX,
+			EndY:     endY,
+			FontSize: t.FontSize * math.Sqrt(math.Abs(a*d-b*c)),
+			Orient:   t.Orient,
+			Text:     t.Text,
+			chars:    chars,
+			style:    t.style,
 		}
 		// fmt.Printf("%4d: %5.1f,%5.1f->%5.1f,%5.1f %q\n", i, t.X, t.Y, x, y, t.Text)
 		out = append(out, o)
@@ -92,8 +166,8 @@ func (tl *TextList) Transform(a, b, c, d, tx, ty float64) *TextList {
 
 // ExtractText processes and extracts all text data in content streams and returns as a string. Takes into
 // account character encoding via CMaps in the PDF file.
-// The text is processed linearly e.g. in the order in which it appears. A best effort is done to add
-// spaces and newlines.
+// The text is processed linearly e.g. in the order in which it appears. Spaces and newlines are added
+// based on the positions of the text.
 func (e *Extractor) ExtractText() (string, error) {
 	textList, err := e.ExtractXYText()
 	if err != nil {
@@ -103,164 +177,89 @@ func (e *Extractor) ExtractText() (string, error) {
 }
 
 // ExtractXYText returns the text contents of `e` as a TextList.
+// Each string shown by a text showing operator (Tj, TJ, ' and ") is a separate element of the list.
+// Its position is computed from the text state parameters (PDF 32000-1:2008 9.3), the text and
+// graphics matrices and the glyph widths of the font. Text in fonts with vertical writing mode
+// advances by the glyphs' vertical displacements.
+// Text drawn by form XObjects (Do) and by the glyph procedures of Type 3 fonts without unicode
+// mappings is included, as is the text of annotation appearances if IncludeAnnotations was set.
 func (e *Extractor) ExtractXYText() (*TextList, error) {
 	textList := &TextList{}
+	x := &textExtraction{
+		textList: textList,
+		active:   map[*core.PdfObjectStream]bool{},
+		opIndex:  -1,
+	}
+	err := x.extract(e.contents, e.resources, nil, 0)
+	if err != nil {
+		return textList, err
+	}
+
+	if e.includeAnnotations {
+		// Annotation text is not shown by an operation of the page's content stream.
+		x.opIndex = -1
+		for _, annot := range e.annotations {
+			x.extractAnnotation(annot, e.resources)
+		}
+	}
+	return textList, nil
+}
+
+// maxNestingDepth is the maximum depth of nested annotation appearances and Type 3 glyph procedures
+// that are processed. The depth of nested form XObjects is limited by the content stream processor.
+const maxNestingDepth = 16
+
+// textExtraction holds the state of the text extraction of a page.
+type textExtraction struct {
+	textList *TextList
+	// active holds the annotation appearances and glyph procedures being processed, to detect streams
+	// that invoke themselves.
+	active map[*core.PdfObjectStream]bool
+	// opIndex is the index of the current operation of the page's content stream. Text in nested
+	// streams has the index of the operation that invoked them.
+	opIndex int
+	// marked holds the open marked-content sequences, innermost last. They are those of the graphics
+	// state of the content stream processor.
+	marked []markedContent
+}
 
-	cstreamParser := contentstream.NewContentStreamParser(e.contents)
+// extract extracts the text of content stream `contents` with resources `resources`, starting in
+// graphics state `gs` (the initial page state if nil), including the text of the form XObjects it
+// paints. `depth` is the nesting depth of the stream: 0 for the page's content stream.
+func (x *textExtraction) extract(contents string, resources *model.PdfPageResources,
+	gs *contentstream.GraphicsState, depth int) error {
+	cstreamParser := contentstream.NewContentStreamParser(contents)
 	operations, err := cstreamParser.Parse()
 	if err != nil {
-		return textList, err
+		return err
 	}
 
 	processor := contentstream.NewContentStreamProcessor(*operations)
+	processor.EnableFormRecursion()
+	numMarked := 0
+	if gs != nil {
+		processor.SetInitialGraphicsState(*gs)
+		numMarked = len(gs.MarkedContent)
+	}
 
-	var codemap *cmap.CMap
-	inText := false
-	xPos, yPos := float64(-1), float64(-1)
-	orientation := contentstream.OrientationLandscape
-
+	textList := x.textList
 	processor.AddHandler(contentstream.HandlerConditionEnumAllOperands, "",
 		func(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState,
 			resources *model.PdfPageResources) error {
+			if depth == 0 && gs.Form == nil {
+				x.opIndex++
+			}
 			operand := op.Operand
-			switch operand {
-			case "BT":
-				inText = true
-			case "ET":
-				inText = false
-			case "Tf":
-				if !inText {
-					common.Log.Debug("Tf operand outside text")
-					return nil
-				}
-
-				if len(op.Params) != 2 {
-					common.Log.Debug("Error Tf should only get 2 input params, got %d", len(op.Params))
-					return errors.New("Incorrect parameter count")
-				}
-
-				codemap = nil
-
-				fontName, ok := op.Params[0].(*core.PdfObjectName)
-				if !ok {
-					common.Log.Debug("Error Tf font input not a name")
-					return errors.New("Tf range error")
-				}
-
-				if resources == nil {
-					return nil
-				}
-
-				fontObj, found := resources.GetFontByName(*fontName)
-				if !found {
-					common.Log.Debug("Font not found...")
-					return errors.New("Font not in resources")
-				}
-
-				fontObj = core.TraceToDirectObject(fontObj)
-				if fontDict, isDict := fontObj.(*core.PdfObjectDictionary); isDict {
-					toUnicode := fontDict.Get("ToUnicode")
-					if toUnicode != nil {
-						toUnicode = core.TraceToDirectObject(toUnicode)
-						toUnicodeStream, ok := toUnicode.(*core.PdfObjectStream)
-						if !ok {
-							return errors.New("Invalid ToUnicode entry - not a stream")
-						}
-						decoded, err := core.DecodeStream(toUnicodeStream)
-						if err != nil {
-							return err
-						}
-
-						codemap, err = cmap.LoadCmapFromData(decoded)
-						if err != nil {
-							return err
-						}
-					}
-				}
-			case "T*":
-				if !inText {
-					common.Log.Debug("T* operand outside text")
-					return nil
-				}
-				textList.add(xPos, yPos, orientation, "\n")
-			case "Td", "TD":
-				if !inText {
-					common.Log.Debug("Td/TD operand outside text")
-					return nil
-				}
-
-				// Params: [tx ty], corresponds to Tm=Tlm=[1 0 0;0 1 0;tx ty 1]*Tm
-				if len(op.Params) != 2 {
-					common.Log.Debug("Td/TD invalid arguments")
-					return nil
-				}
-				tx, err := getNumberAsFloat(op.Params[0])
-				if err != nil {
-					common.Log.Debug("Td Float parse error")
-					return nil
-				}
-				ty, err := getNumberAsFloat(op.Params[1])
-				if err != nil {
-					common.Log.Debug("Td Float parse error")
-					return nil
-				}
-
-				if tx > 0 {
-					textList.add(xPos, yPos, orientation, " ")
-				}
-				if ty < 0 {
-					// TODO: More flexible space characters?
-					textList.add(xPos, yPos, orientation, "\n")
-				}
-			case "Tm":
-				if !inText {
-					common.Log.Debug("Tm operand outside text")
-					return nil
-				}
+			// The marked-content sequences ended by the processor, by EMC operations and at the end of
+			// form XObjects, are ended before the operation.
+			if operand == "BMC" || operand == "BDC" {
+				x.endMarkedContent(len(gs.MarkedContent) - 1)
+			} else {
+				x.endMarkedContent(len(gs.MarkedContent))
+			}
 
-				// Params: a,b,c,d,e,f as in Tm = [a b 0; c d 0; e f 1].
-				// The last two (e,f) represent translation.
-				if len(op.Params) != 6 {
-					return errors.New("Tm: Invalid number of inputs")
-				}
-				xfloat, ok := op.Params[4].(*core.PdfObjectFloat)
-				if !ok {
-					xint, ok := op.Params[4].(*core.PdfObjectInteger)
-					if !ok {
-						return nil
-					}
-					xfloat = core.MakeFloat(float64(*xint))
-				}
-				yfloat, ok := op.Params[5].(*core.PdfObjectFloat)
-				if !ok {
-					yint, ok := op.Params[5].(*core.PdfObjectInteger)
-					if !ok {
-						return nil
-					}
-					yfloat = core.MakeFloat(float64(*yint))
-				}
-				xx, yy := gs.Transform(float64(*xfloat), float64(*yfloat))
-				xfloat, yfloat = core.MakeFloat(xx), core.MakeFloat(yy)
-				orientation = gs.PageOrientation()
-				if yPos == -1 {
-					yPos = float64(*yfloat)
-				} else if yPos > float64(*yfloat) {
-					textList.add(xPos, yPos, orientation, "\n")
-					xPos = float64(*xfloat)
-					yPos = float64(*yfloat)
-					return nil
-				}
-				if xPos == -1 {
-					xPos = float64(*xfloat)
-				} else if xPos < float64(*xfloat) {
-					textList.add(xPos, yPos, orientation, "\t")
-					xPos = float64(*xfloat)
-				}
+			switch operand {
 			case "TJ":
-				if !inText {
-					common.Log.Debug("TJ operand outside text")
-					return nil
-				}
 				if len(op.Params) < 1 {
 					return nil
 				}
@@ -271,48 +270,235 @@ func (e *Extractor) ExtractXYText() (*TextList, error) {
 				for _, obj := range *paramList {
 					switch v := obj.(type) {
 					case *core.PdfObjectString:
-						if codemap != nil {
-							textList.add(xPos, yPos, orientation, codemap.CharcodeBytesToUnicode([]byte(*v)))
-						} else {
-							textList.add(xPos, yPos, orientation, string(*v))
-						}
-					case *core.PdfObjectFloat:
-						if *v < -100 {
-							textList.add(xPos, yPos, orientation, " ")
-						}
-					case *core.PdfObjectInteger:
-						if *v < -100 {
-							textList.add(xPos, yPos, orientation, " ")
-						}
+						textList.add(x.showText(&gs.Text, gs, resources, []byte(*v), depth))
+					case *core.PdfObjectFloat, *core.PdfObjectInteger:
+						n, _ := getNumberAsFloat(v)
+						gs.Text.Adjust(n)
 					}
 				}
-			case "Tj":
-				if !inText {
-					common.Log.Debug("Tj operand outside text")
-					return nil
-				}
+			case "Tj", "'", "\"":
+				// The processor has moved to the next line and set the spacing of ' and ".
 				if len(op.Params) < 1 {
 					return nil
 				}
-				param, ok := op.Params[0].(*core.PdfObjectString)
+				param, ok := op.Params[len(op.Params)-1].(*core.PdfObjectString)
 				if !ok {
 					return fmt.Errorf("Invalid parameter type, not string (%T)", op.Params[0])
 				}
-				if codemap != nil {
-					textList.add(xPos, yPos, orientation, codemap.CharcodeBytesToUnicode([]byte(*param)))
-				} else {
-					textList.add(xPos, yPos, orientation, string(*param))
-				}
+				textList.add(x.showText(&gs.Text, gs, resources, []byte(*param), depth))
+			case "BMC", "BDC":
+				x.beginMarkedContent(op, gs, resources)
 			}
 
 			return nil
 		})
 
-	err = processor.Process(e.resources)
+	err = processor.Process(resources)
+	// Marked-content sequences do not extend beyond the stream that begins them.
+	x.endMarkedContent(numMarked)
 	if err != nil {
 		common.Log.Error("Error processing: %v", err)
-		return textList, err
+		return err
 	}
+	return nil
+}
 
-	return textList, nil
+// extractStream extracts the text of `stream`, an annotation appearance or Type 3 glyph procedure,
+// unless it is already being processed or `depth` exceeds maxNestingDepth.
+func (x *textExtraction) extractStream(stream *core.PdfObjectStream, resources *model.PdfPageResources,
+	gs contentstream.GraphicsState, depth int) {
+	if depth > maxNestingDepth {
+		common.Log.Debug("Streams nested too deeply, skipping")
+		return
+	}
+	if x.active[stream] {
+		common.Log.Debug("Stream invokes itself, skipping")
+		return
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to decode stream: %v", err)
+		return
+	}
+
+	x.active[stream] = true
+	defer delete(x.active, stream)
+	err = x.extract(string(data), resources, &gs, depth)
+	if err != nil {
+		// The text of the page is extracted even if a nested stream is invalid.
+		common.Log.Debug("Error extracting text of nested stream: %v", err)
+	}
+}
+
+// extractAnnotation extracts the text of the normal appearance of `annot` unless it is hidden.
+// `resources` are the resources of the page, used by appearance streams without resources.
+func (x *textExtraction) extractAnnotation(annot *model.PdfAnnotation, resources *model.PdfPageResources) {
+	const hiddenFlag = 1 << 1
+	if flags, ok := core.TraceToDirectObject(annot.F).(*core.PdfObjectInteger); ok && *flags&hiddenFlag != 0 {
+		return
+	}
+	stream := annotationAppearance(annot)
+	if stream == nil {
+		return
+	}
+	rect, ok := core.TraceToDirectObject(annot.Rect).(*core.PdfObjectArray)
+	if !ok {
+		return
+	}
+	r, err := model.NewPdfRectangle(*rect)
+	if err != nil {
+		common.Log.Debug("Invalid annotation Rect: %v", err)
+		return
+	}
+	form, err := model.NewXObjectFormFromStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to load annotation appearance: %v", err)
+		return
+	}
+	formResources := form.Resources
+	if formResources == nil {
+		formResources = resources
+	}
+
+	// The appearance's bounding box, transformed by its matrix, is mapped to the annotation's
+	// rectangle (PDF 32000-1:2008 12.5.5).
+	matrix := getMatrix(form.Matrix)
+	bbox := model.PdfRectangle{Urx: r.Urx - r.Llx, Ury: r.Ury - r.Lly}
+	if arr, ok := core.TraceToDirectObject(form.BBox).(*core.PdfObjectArray); ok {
+		if b, err := model.NewPdfRectangle(*arr); err == nil {
+			bbox = *b
+		}
+	}
+	box := transformRect(matrix, bbox.Llx, bbox.Lly, bbox.Urx, bbox.Ury)
+	sx, sy := 1.0, 1.0
+	if w := box.Urx - box.Llx; w > 0 {
+		sx = (r.Urx - r.Llx) / w
+	}
+	if h := box.Ury - box.Lly; h > 0 {
+		sy = (r.Ury - r.Lly) / h
+	}
+	a := contentstream.NewMatrix(sx, 0, 0, sy, r.Llx-sx*box.Llx, r.Lly-sy*box.Lly)
+
+	gs := contentstream.NewGraphicsState()
+	gs.CTM = matrix.Mult(a)
+	gs.Form = stream
+	x.extractStream(stream, formResources, gs, 1)
+}
+
+// annotationAppearance returns the normal appearance stream of `annot`: the N entry of its
+// appearance dictionary or, for annotations with several appearance states, the one selected by AS.
+func annotationAppearance(annot *model.PdfAnnotation) *core.PdfObjectStream {
+	ap, ok := core.TraceToDirectObject(annot.AP).(*core.PdfObjectDictionary)
+	if !ok {
+		return nil
+	}
+	switch n := core.TraceToDirectObject(ap.Get("N")).(type) {
+	case *core.PdfObjectStream:
+		return n
+	case *core.PdfObjectDictionary:
+		state, ok := core.TraceToDirectObject(annot.AS).(*core.PdfObjectName)
+		if !ok {
+			return nil
+		}
+		stream, _ := core.TraceToDirectObject(n.Get(*state)).(*core.PdfObjectStream)
+		return stream
+	}
+	return nil
+}
+
+// getMatrix returns the matrix of array `obj` [a b c d e f], or the identity matrix if `obj` is not a
+// valid matrix, e.g. if it is nil.
+func getMatrix(obj core.PdfObject) contentstream.Matrix {
+	if arr, ok := core.TraceToDirectObject(obj).(*core.PdfObjectArray); ok {
+		if f, err := arr.GetAsFloat64Slice(); err == nil && len(f) == 6 {
+			return contentstream.NewMatrix(f[0], f[1], f[2], f[3], f[4], f[5])
+		}
+		common.Log.Debug("Invalid matrix %s", obj)
+	}
+	return contentstream.IdentityMatrix()
+}
+
+// showText shows string `data` with text state `ts` in graphics state `gs`, advancing the text
+// matrix of `ts` past it, and returns the text and its position. `resources` and `depth` are the
+// resources and nesting depth of the content stream.
+func (x *textExtraction) showText(ts *contentstream.TextState, gs contentstream.GraphicsState,
+	resources *model.PdfPageResources, data []byte, depth int) XYText {
+	m := ts.Tm.Mult(gs.CTM)
+	startX, startY := m.Transform(0, ts.Ts)
+	style := newTextStyle(ts, gs, x.opIndex)
+	style.mcid, style.stmObjectNumber = x.markedContentID()
+
+	var text string
+	var chars []textChar
+	if font := ts.Font; font == nil {
+		text = string(data)
+	} else {
+		var buf bytes.Buffer
+		for _, g := range ts.Glyphs(data) {
+			s, ok := font.CharcodeToUnicode(g.Code)
+			if !ok {
+				s, ok = x.type3GlyphText(font, g, gs, resources, depth)
+			}
+			if !ok {
+				common.Log.Trace("No unicode mapping for code 0x%04x", g.Code)
+				s = string(utf8.RuneError)
+			}
+			buf.WriteString(s)
+
+			// The glyph extends over its width, the character and word spacing follow it.
+			c := textChar{text: s}
+			c.x, c.y = g.Tm.Mult(gs.CTM).Transform(0, ts.Ts)
+			c.endX, c.endY = g.End.Mult(gs.CTM).Transform(0, ts.Ts)
+			c.bbox = transformRect(g.Matrix.Mult(gs.CTM), 0, style.descent, g.Width, style.ascent)
+			chars = append(chars, c)
+		}
+		text = buf.String()
+	}
+
+	end := ts.Tm.Mult(gs.CTM)
+	endX, endY := end.Transform(0, ts.Ts)
+	return XYText{
+		X:        startX,
+		Y:        startY,
+		EndX:     endX,
+		EndY:     endY,
+		FontSize: ts.Tfs * math.Hypot(m[3], m[4]),
+		Orient:   gs.PageOrientation(),
+		Text:     text,
+		chars:    chars,
+		style:    style,
+	}
+}
+
+// type3GlyphText returns the text drawn by the glyph procedure of glyph `g` of Type 3 font `font`.
+// Some Type 3 fonts draw their glyphs with text in other fonts. The bool return flag is false if no
+// text is drawn.
+func (x *textExtraction) type3GlyphText(font *model.PdfFont, g contentstream.TextGlyph,
+	gs contentstream.GraphicsState, resources *model.PdfPageResources, depth int) (string, bool) {
+	charProc, ok := font.GetType3CharProc(g.Code)
+	if !ok {
+		return "", false
+	}
+	fm, ok := font.GetType3FontMatrix()
+	if !ok || len(fm) != 6 {
+		return "", false
+	}
+	if glyphResources := font.GetType3Resources(); glyphResources != nil {
+		resources = glyphResources
+	}
+
+	// Glyph space is mapped to text space by the FontMatrix.
+	gs.CTM = contentstream.NewMatrix(fm[0], fm[1], fm[2], fm[3], fm[4], fm[5]).Mult(g.Matrix).Mult(gs.CTM)
+	gs.Text = contentstream.NewGraphicsState().Text
+	gs.Path = contentstream.Path{}
+
+	glyphText := &TextList{}
+	nested := *x
+	nested.textList = glyphText
+	nested.extractStream(charProc, resources, gs, depth+1)
+	var buf bytes.Buffer
+	for _, t := range *glyphText {
+		buf.WriteString(t.Text)
+	}
+	return buf.String(), buf.Len() > 0
 }
@@ -7,13 +7,21 @@ package extractor
 
 import (
 	"flag"
+	"math"
+	"os"
 	"testing"
+
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
 )
 
-func init() {
+// TestMain sets isTesting when run by go test. The test flags are registered by testing.Init after the
+// package init functions have run, so they are looked up here.
+func TestMain(m *testing.M) {
 	if flag.Lookup("test.v") != nil {
 		isTesting = true
 	}
+	os.Exit(m.Run())
 }
 
 const testContents1 = `
@@ -41,3 +49,131 @@ func TestTextExtraction1(t *testing.T) {
 		return
 	}
 }
+
+// helveticaResources returns resources with the Helvetica font named F1.
+func helveticaResources() *model.PdfPageResources {
+	font := core.MakeDict()
+	font.Set("Type", core.MakeName("Font"))
+	font.Set("Subtype", core.MakeName("Type1"))
+	font.Set("BaseFont", core.MakeName("Helvetica"))
+	resources := model.NewPdfPageResources()
+	resources.SetFontByName("F1", font)
+	return resources
+}
+
+// Helvetica widths: H 722, e 556, l 222, o 556, space 278, W 944, r 333, d 556, A 667, B 667.
+const testContents2 = `
+2 0 0 2 10 20 cm
+BT
+/F1 10 Tf
+5 Tw 1 Tc
+100 200 Td
+(Hello World)Tj
+0 -12 Td
+[(A) -300 (B)]TJ
+ET
+`
+
+// TestTextPositions checks that the positions of text fragments are computed from the font widths
+// and the text state.
+func TestTextPositions(t *testing.T) {
+	e := Extractor{contents: testContents2, resources: helveticaResources()}
+	textList, err := e.ExtractXYText()
+	if err != nil {
+		t.Fatalf("Error extracting text: %v", err)
+	}
+
+	expected := []XYText{
+		// 11 glyphs: 51.67 + 11 Tc + 1 Tw = 67.67 text space units, scaled by 2.
+		{X: 210, Y: 420, EndX: 345.34, EndY: 420, FontSize: 20, Text: "Hello World"},
+		{X: 210, Y: 396, EndX: 225.34, EndY: 396, FontSize: 20, Text: "A"},
+		{X: 231.34, Y: 396, EndX: 246.68, EndY: 396, FontSize: 20, Text: "B"},
+	}
+	if len(*textList) != len(expected) {
+		t.Fatalf("Expected %d fragments, got %d: %+v", len(expected), len(*textList), *textList)
+	}
+	for i, exp := range expected {
+		got := (*textList)[i]
+		if got.Text != exp.Text ||
+			math.Abs(got.X-exp.X) > 0.01 || math.Abs(got.Y-exp.Y) > 0.01 ||
+			math.Abs(got.EndX-exp.EndX) > 0.01 || math.Abs(got.EndY-exp.EndY) > 0.01 ||
+			math.Abs(got.FontSize-exp.FontSize) > 0.01 {
+			t.Errorf("Fragment %d: expected %+v, got %+v", i, exp, got)
+		}
+	}
+
+	if s := separator(expected[0], expected[1]); s != "\n" {
+		t.Errorf("Expected newline between lines, got %q", s)
+	}
+	if s := separator(expected[1], expected[2]); s != " " {
+		t.Errorf("Expected space between words, got %q", s)
+	}
+}
+
+// Two columns of vertical Japanese text, the left one drawn first. The codes of UniJIS-UCS2-V are
+// UCS-2 and the glyphs have the default vertical metrics: w1_y -1000 and v_y 880.
+const testVerticalContents = `
+BT
+/F1 10 Tf
+1 0 0 1 188 700 Tm
+<30673059> Tj
+1 0 0 1 200 700 Tm
+[<65e5> 100 <672c8a9e>] TJ
+ET
+`
+
+func TestVerticalText(t *testing.T) {
+	font := core.MakeDict()
+	font.Set("Type", core.MakeName("Font"))
+	font.Set("Subtype", core.MakeName("Type0"))
+	font.Set("BaseFont", core.MakeName("KozMinPro-Regular"))
+	font.Set("Encoding", core.MakeName("UniJIS-UCS2-V"))
+	cidFont := core.MakeDict()
+	cidFont.Set("Type", core.MakeName("Font"))
+	cidFont.Set("Subtype", core.MakeName("CIDFontType0"))
+	cidFont.Set("BaseFont", core.MakeName("KozMinPro-Regular"))
+	info := core.MakeDict()
+	info.Set("Registry", core.MakeString("Adobe"))
+	info.Set("Ordering", core.MakeString("Japan1"))
+	info.Set("Supplement", core.MakeInteger(4))
+	cidFont.Set("CIDSystemInfo", info)
+	font.Set("DescendantFonts", core.MakeArray(cidFont))
+	resources := model.NewPdfPageResources()
+	resources.SetFontByName("F1", font)
+
+	e := Extractor{contents: testVerticalContents, resources: resources}
+	textList, err := e.ExtractXYText()
+	if err != nil {
+		t.Fatalf("Error extracting text: %v", err)
+	}
+	expected := []XYText{
+		{X: 188, Y: 700, EndX: 188, EndY: 680, Text: "です"},
+		{X: 200, Y: 700, EndX: 200, EndY: 690, Text: "日"},
+		// The TJ adjustment moves down the column.
+		{X: 200, Y: 689, EndX: 200, EndY: 669, Text: "本語"},
+	}
+	if len(*textList) != len(expected) {
+		t.Fatalf("Expected %d fragments, got %d: %+v", len(expected), len(*textList), *textList)
+	}
+	for i, exp := range expected {
+		got := (*textList)[i]
+		if got.Text != exp.Text ||
+			math.Abs(got.X-exp.X) > 0.01 || math.Abs(got.Y-exp.Y) > 0.01 ||
+			math.Abs(got.EndX-exp.EndX) > 0.01 || math.Abs(got.EndY-exp.EndY) > 0.01 {
+			t.Errorf("Fragment %d: expected %+v, got %+v", i, exp, got)
+		}
+	}
+
+	// The glyph boxes are centered on the column, below the vertical origins.
+	bbox := (*textList)[1].chars[0].bbox
+	if math.Abs(bbox.Llx-195) > 0.01 || math.Abs(bbox.Urx-205) > 0.01 ||
+		math.Abs(bbox.Lly-689.2) > 0.01 || math.Abs(bbox.Ury-699.2) > 0.01 {
+		t.Errorf("Incorrect glyph bbox %+v", bbox)
+	}
+
+	// Columns are read top to bottom, from right to left.
+	expectedText := "日本語\nです"
+	if s := textList.Layout().ToText(); s != expectedText {
+		t.Errorf("Text mismatch: %+q != %+q", s, expectedText)
+	}
+}
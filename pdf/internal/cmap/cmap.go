@@ -23,15 +23,27 @@ type CMap struct {
 
 	codeMap map[uint64]string
 
+	// Character code to CID mappings (encoding CMaps used by Type0 fonts).
+	cidMap map[uint64]uint16
+
+	// Decodes the character codes of predefined CMaps whose codes are in Unicode or a legacy CJK
+	// encoding. nil for other CMaps.
+	decode func(code uint64) (string, bool)
+
 	name       string
 	ctype      int
+	wmode      int
 	codespaces []codespace
+
+	// The usecmap nesting depth of predefined CMaps loaded from files, 0 for other CMaps.
+	useDepth int
 }
 
 // codespace represents a single codespace range used in the CMap.
 type codespace struct {
-	low  uint64
-	high uint64
+	numBytes int
+	low      uint64
+	high     uint64
 }
 
 // Name returns the name of the CMap.
@@ -44,12 +56,17 @@ func (cmap *CMap) Type() int {
 	return cmap.ctype
 }
 
+// Vertical returns true if the CMap is for vertical writing mode (WMode 1).
+func (cmap *CMap) Vertical() bool {
+	return cmap.wmode == 1
+}
+
 // CharcodeBytesToUnicode converts a byte array of charcodes to a unicode string representation.
 func (cmap *CMap) CharcodeBytesToUnicode(src []byte) string {
 	var buf bytes.Buffer
 
 	// Maximum number of possible bytes per code.
-	maxLen := 4
+	maxLen := maxCodeLen
 
 	i := 0
 	for i < len(src) {
@@ -76,6 +93,7 @@ func (cmap *CMap) CharcodeBytesToUnicode(src []byte) string {
 }
 
 // CharcodeToUnicode converts a single character code to unicode string.
+// Returns "?" if the code is not mapped.
 func (cmap *CMap) CharcodeToUnicode(srcCode uint64) string {
 	if c, has := cmap.codeMap[srcCode]; has {
 		return c
@@ -85,11 +103,96 @@ func (cmap *CMap) CharcodeToUnicode(srcCode uint64) string {
 	return "?"
 }
 
+// Lookup returns the unicode string that `code` maps to. The bool return flag is true if there was
+// a match, and false otherwise.
+func (cmap *CMap) Lookup(code uint64) (string, bool) {
+	s, has := cmap.codeMap[code]
+	return s, has
+}
+
+// DecodeCharcode returns the unicode string of character code `code` of a predefined CMap whose
+// codes are in Unicode or a legacy CJK encoding, e.g. UniJIS-UCS2-H or GBK-EUC-H.
+// The bool return flag is false if the CMap has no such encoding or `code` is not valid in it.
+func (cmap *CMap) DecodeCharcode(code uint64) (string, bool) {
+	if cmap.decode == nil {
+		return "", false
+	}
+	return cmap.decode(code)
+}
+
+// HasCIDMappings returns true if the CMap maps character codes to CIDs (i.e. it is an encoding
+// CMap as used by Type0 fonts rather than a ToUnicode CMap).
+func (cmap *CMap) HasCIDMappings() bool {
+	return len(cmap.cidMap) > 0
+}
+
+// CharcodeToCID returns the CID that character code `code` maps to.
+// The bool return flag is true if there was a match, and false otherwise.
+func (cmap *CMap) CharcodeToCID(code uint64) (uint16, bool) {
+	cid, has := cmap.cidMap[code]
+	return cid, has
+}
+
+// BytesToCharcodes splits `data` into character codes using the codespace ranges of the CMap.
+// Each code is matched against the codespace ranges from the shortest to the longest byte length as
+// described in section 9.7.6.2 "CMap Mapping" (PDF32000_2008). If the CMap has no codespace ranges,
+// 1 byte codes are assumed.
+func (cmap *CMap) BytesToCharcodes(data []byte) []uint64 {
+	codes := []uint64{}
+	if len(cmap.codespaces) == 0 {
+		for _, b := range data {
+			codes = append(codes, uint64(b))
+		}
+		return codes
+	}
+
+	for i := 0; i < len(data); {
+		code, n, matched := cmap.matchCode(data[i:])
+		if !matched {
+			common.Log.Debug("No codespace match for byte 0x%02x. Using %d bytes", data[i], n)
+		}
+		codes = append(codes, code)
+		i += n
+	}
+	return codes
+}
+
+// matchCode returns the first character code in `data` and its length in bytes.
+// The bool return flag is false if no codespace range matched, in which case the code length is that
+// of the shortest codespace range.
+func (cmap *CMap) matchCode(data []byte) (uint64, int, bool) {
+	var code uint64
+	for j := 0; j < maxCodeLen && j < len(data); j++ {
+		code = code<<8 | uint64(data[j])
+		for _, cs := range cmap.codespaces {
+			if cs.numBytes == j+1 && code >= cs.low && code <= cs.high {
+				return code, j + 1, true
+			}
+		}
+	}
+
+	n := maxCodeLen
+	for _, cs := range cmap.codespaces {
+		if cs.numBytes < n {
+			n = cs.numBytes
+		}
+	}
+	if n > len(data) {
+		n = len(data)
+	}
+	code = 0
+	for j := 0; j < n; j++ {
+		code = code<<8 | uint64(data[j])
+	}
+	return code, n, false
+}
+
 // newCMap returns an initialized CMap.
 func newCMap() *CMap {
 	cmap := &CMap{}
 	cmap.codespaces = []codespace{}
 	cmap.codeMap = map[uint64]string{}
+	cmap.cidMap = map[uint64]uint16{}
 	return cmap
 }
 
@@ -109,6 +212,7 @@ func LoadCmapFromData(data []byte) (*CMap, error) {
 
 // parse parses the CMap file and loads into the CMap structure.
 func (cmap *CMap) parse() error {
+	var prevName string
 	for {
 		o, err := cmap.parseObject()
 		if err != nil {
@@ -138,8 +242,22 @@ func (cmap *CMap) parse() error {
 				if err != nil {
 					return err
 				}
+			} else if op.Operand == begincidchar {
+				err := cmap.parseCidchar()
+				if err != nil {
+					return err
+				}
+			} else if op.Operand == begincidrange {
+				err := cmap.parseCidrange()
+				if err != nil {
+					return err
+				}
+			} else if op.Operand == usecmap && prevName != "" {
+				cmap.useCMap(prevName)
 			}
+			prevName = ""
 		} else if n, isName := o.(cmapName); isName {
+			prevName = n.Name
 			if n.Name == cmapname {
 				o, err := cmap.parseObject()
 				if err != nil {
@@ -166,8 +284,22 @@ func (cmap *CMap) parse() error {
 					return errors.New("CMap type not an integer")
 				}
 				cmap.ctype = int(typeInt.val)
+			} else if n.Name == cmapwmode {
+				o, err := cmap.parseObject()
+				if err != nil {
+					if err == io.EOF {
+						break
+					}
+					return err
+				}
+				modeInt, ok := o.(cmapInt)
+				if !ok {
+					return errors.New("CMap WMode not an integer")
+				}
+				cmap.wmode = int(modeInt.val)
 			}
 		} else {
+			prevName = ""
 			common.Log.Trace("Unhandled object: %T %#v", o, o)
 		}
 	}
@@ -211,7 +343,7 @@ func (cmap *CMap) parseCodespaceRange() error {
 		low := hexToUint64(hexLow)
 		high := hexToUint64(hexHigh)
 
-		cspace := codespace{low, high}
+		cspace := codespace{len(hexLow.b), low, high}
 		cmap.codespaces = append(cmap.codespaces, cspace)
 
 		common.Log.Trace("Codespace low: 0x%X, high: 0x%X", low, high)
@@ -365,7 +497,7 @@ func (cmap *CMap) parseBfrange() error {
 			i := uint64(0)
 			for sc := srcCodeFrom; sc <= srcCodeTo; sc++ {
 				r := target + i
-				cmap.codeMap[sc] = string(r)
+				cmap.codeMap[sc] = string(rune(r))
 				i++
 			}
 		default:
@@ -375,3 +507,104 @@ func (cmap *CMap) parseBfrange() error {
 
 	return nil
 }
+
+// parseCidchar parses a cidchar section of a CMap file.
+// <srcCode> CID
+func (cmap *CMap) parseCidchar() error {
+	for {
+		o, err := cmap.parseObject()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		var srcCode uint64
+
+		switch v := o.(type) {
+		case cmapOperand:
+			if v.Operand == endcidchar {
+				return nil
+			}
+			return errors.New("Unexpected operand")
+		case cmapHexString:
+			srcCode = hexToUint64(v)
+		default:
+			return errors.New("Unexpected type")
+		}
+
+		o, err = cmap.parseObject()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		cid, ok := o.(cmapInt)
+		if !ok {
+			return errors.New("CID not an integer")
+		}
+
+		cmap.cidMap[srcCode] = uint16(cid.val)
+	}
+
+	return nil
+}
+
+// parseCidrange parses a cidrange section of a CMap file.
+// <srcCodeFrom> <srcCodeTo> CID, maps [from,to] to [CID,CID+to-from].
+func (cmap *CMap) parseCidrange() error {
+	for {
+		o, err := cmap.parseObject()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		var srcCodeFrom uint64
+
+		switch v := o.(type) {
+		case cmapOperand:
+			if v.Operand == endcidrange {
+				return nil
+			}
+			return errors.New("Unexpected operand")
+		case cmapHexString:
+			srcCodeFrom = hexToUint64(v)
+		default:
+			return errors.New("Unexpected type")
+		}
+
+		o, err = cmap.parseObject()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		hexTo, ok := o.(cmapHexString)
+		if !ok {
+			return errors.New("Non-hex high")
+		}
+		srcCodeTo := hexToUint64(hexTo)
+
+		o, err = cmap.parseObject()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		cid, ok := o.(cmapInt)
+		if !ok {
+			return errors.New("CID not an integer")
+		}
+
+		for sc := srcCodeFrom; sc <= srcCodeTo; sc++ {
+			cmap.cidMap[sc] = uint16(uint64(cid.val) + sc - srcCodeFrom)
+		}
+	}
+
+	return nil
+}
@@ -122,3 +122,22 @@ func TestCMapParser1(t *testing.T) {
 		return
 	}
 }
+
+// Test that a generated ToUnicode CMap parses back to the same mappings.
+func TestMakeToUnicodeCMap(t *testing.T) {
+	codeToRune := map[uint16]rune{}
+	for code := uint16(1); code <= 250; code++ {
+		codeToRune[code] = 'A' + rune(code)
+	}
+	codeToRune[0x1234] = 0x4E2D
+
+	cmap, err := LoadCmapFromData(MakeToUnicodeCMap(codeToRune))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	for code, r := range codeToRune {
+		if v, ok := cmap.Lookup(uint64(code)); !ok || v != string(r) {
+			t.Errorf("Incorrect mapping 0x%04X -> %q (%t), expected %q", code, v, ok, string(r))
+		}
+	}
+}
@@ -15,9 +15,18 @@ const (
 	endbfchar           = "endbfchar"
 	beginbfrange        = "beginbfrange"
 	endbfrange          = "endbfrange"
+	begincidchar        = "begincidchar"
+	endcidchar          = "endcidchar"
+	begincidrange       = "begincidrange"
+	endcidrange         = "endcidrange"
+	usecmap             = "usecmap"
 
-	cmapname = "CMapName"
-	cmaptype = "CMapType"
+	cmapname  = "CMapName"
+	cmaptype  = "CMapType"
+	cmapwmode = "WMode"
 )
 
+// maxCodeLen is the maximum number of bytes in a character code.
+const maxCodeLen = 4
+
 var reNumeric = regexp.MustCompile(`^[\+-.]*([0-9.]+)`)
@@ -0,0 +1,353 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package cmap
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/unidoc/unidoc/common"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// The predefined CMaps (PDF32000_2008 9.7.5.2 "Predefined CMaps") are loaded from the CMap files of
+// the directory set by SetPredefinedDir, e.g. a copy of Adobe's cmap-resources. The files give the
+// character code to CID mappings of the CMaps and the CID to unicode mappings of the Adobe CJK
+// character collections (e.g. Adobe-Japan1-UCS2).
+// The character codes of the predefined CMaps are in Unicode or a legacy CJK encoding, e.g. UCS-2 for
+// UniJIS-UCS2-H and GBK for GBK-EUC-H, so they are decoded to unicode even without the files.
+
+var (
+	predefinedDir   string
+	predefinedMutex sync.Mutex
+	predefinedCache = map[string]*CMap{}
+)
+
+// SetPredefinedDir sets the directory of the predefined CMap files. The files are looked up by CMap
+// name in `dir` and in its subdirectories */CMap, the layout of Adobe's cmap-resources.
+func SetPredefinedDir(dir string) {
+	predefinedMutex.Lock()
+	defer predefinedMutex.Unlock()
+	predefinedDir = dir
+	predefinedCache = map[string]*CMap{}
+}
+
+// LoadPredefinedCMap returns the predefined CMap `name`, e.g. UniJIS-UCS2-H or Adobe-GB1-UCS2.
+// If there is no CMap file for `name`, a CMap with the codespace ranges and unicode decoding of its
+// encoding is returned, and an error if the encoding is not known.
+func LoadPredefinedCMap(name string) (*CMap, error) {
+	predefinedMutex.Lock()
+	defer predefinedMutex.Unlock()
+	return loadPredefinedCMap(name, 0)
+}
+
+// maxUseCMapDepth is the maximum length of chains of CMaps that use other CMaps (usecmap).
+const maxUseCMapDepth = 8
+
+// loadPredefinedCMap loads predefined CMap `name`, which is used by a CMap at usecmap nesting depth
+// `depth`. predefinedMutex must be held.
+func loadPredefinedCMap(name string, depth int) (*CMap, error) {
+	if cmap, has := predefinedCache[name]; has {
+		if cmap == nil {
+			return nil, errors.New("Predefined CMap not available")
+		}
+		return cmap, nil
+	}
+	if depth > maxUseCMapDepth {
+		return nil, errors.New("CMaps nested too deeply")
+	}
+
+	cmap, err := loadPredefinedFile(name, depth)
+	if err != nil {
+		common.Log.Debug("Unable to load CMap file %s: %v", name, err)
+		cmap = nil
+	}
+	enc, known := predefinedEncoding(name)
+	if cmap == nil && known {
+		cmap = newCMap()
+		cmap.name = name
+		cmap.codespaces = enc.codespaces
+	}
+	if cmap != nil {
+		if known {
+			cmap.decode = enc.decode
+		}
+		if isVerticalName(name) {
+			cmap.wmode = 1
+		}
+	}
+
+	predefinedCache[name] = cmap
+	if cmap == nil {
+		return nil, errors.New("Predefined CMap not available")
+	}
+	return cmap, nil
+}
+
+// loadPredefinedFile loads the CMap file of predefined CMap `name`. Returns nil and no error if there
+// is no file.
+func loadPredefinedFile(name string, depth int) (*CMap, error) {
+	if predefinedDir == "" || strings.ContainsAny(name, `/\`) {
+		return nil, nil
+	}
+	paths := []string{filepath.Join(predefinedDir, name)}
+	matches, _ := filepath.Glob(filepath.Join(predefinedDir, "*", "CMap", name))
+	paths = append(paths, matches...)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		cmap := newCMap()
+		cmap.cMapParser = newCMapParser(data)
+		cmap.useDepth = depth + 1
+		if err := cmap.parse(); err != nil {
+			return nil, err
+		}
+		cmap.cMapParser = nil
+		return cmap, nil
+	}
+	return nil, nil
+}
+
+// useCMap merges the mappings of predefined CMap `name` into `cmap` (usecmap operator).
+func (cmap *CMap) useCMap(name string) {
+	var parent *CMap
+	var err error
+	if cmap.useDepth > 0 {
+		// Loading a predefined CMap file, the lock is already held.
+		parent, err = loadPredefinedCMap(name, cmap.useDepth)
+	} else {
+		parent, err = LoadPredefinedCMap(name)
+	}
+	if err != nil {
+		common.Log.Debug("Unable to use CMap %s: %v", name, err)
+		return
+	}
+	cmap.codespaces = append(cmap.codespaces, parent.codespaces...)
+	for code, cid := range parent.cidMap {
+		if _, has := cmap.cidMap[code]; !has {
+			cmap.cidMap[code] = cid
+		}
+	}
+	for code, s := range parent.codeMap {
+		if _, has := cmap.codeMap[code]; !has {
+			cmap.codeMap[code] = s
+		}
+	}
+	if cmap.decode == nil {
+		cmap.decode = parent.decode
+	}
+}
+
+// isVerticalName returns true if `name` is the name of a predefined CMap for vertical writing.
+func isVerticalName(name string) bool {
+	return name == "V" || strings.HasSuffix(name, "-V")
+}
+
+// charcodeEncoding is the encoding of the character codes of predefined CMaps.
+type charcodeEncoding struct {
+	codespaces []codespace
+	decode     func(code uint64) (string, bool)
+}
+
+var (
+	ucs2Encoding = charcodeEncoding{
+		codespaces: []codespace{{2, 0x0000, 0xffff}},
+		decode:     decodeUCS2,
+	}
+	utf16Encoding = charcodeEncoding{
+		codespaces: []codespace{{2, 0x0000, 0xd7ff}, {2, 0xe000, 0xffff}, {4, 0xd800dc00, 0xdbffdfff}},
+		decode:     decodeUTF16,
+	}
+	utf32Encoding = charcodeEncoding{
+		codespaces: []codespace{{4, 0x00000000, 0x0010ffff}},
+		decode:     decodeUCS2,
+	}
+	utf8Encoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x7f}, {2, 0xc080, 0xdfbf}, {3, 0xe08080, 0xefbfbf},
+			{4, 0xf0808080, 0xf7bfbfbf}},
+		decode: decodeUTF8,
+	}
+	sjisEncoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x80}, {1, 0xa0, 0xdf}, {1, 0xfd, 0xff},
+			{2, 0x8140, 0x9ffc}, {2, 0xe040, 0xfcfc}},
+		decode: byteDecoder(japanese.ShiftJIS, 0),
+	}
+	eucJPEncoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x80}, {2, 0x8ea0, 0x8edf}, {2, 0xa1a1, 0xfefe}},
+		decode:     byteDecoder(japanese.EUCJP, 0),
+	}
+	jisEncoding = charcodeEncoding{
+		codespaces: []codespace{{2, 0x2121, 0x7e7e}},
+		decode:     byteDecoder(japanese.EUCJP, 0x8080),
+	}
+	gbkEncoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x80}, {2, 0x8140, 0xfefe}},
+		decode:     byteDecoder(simplifiedchinese.GBK, 0),
+	}
+	gb18030Encoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x80}, {2, 0x8140, 0xfefe}, {4, 0x81308130, 0xfe39fe39}},
+		decode:     byteDecoder(simplifiedchinese.GB18030, 0),
+	}
+	gbEncoding = charcodeEncoding{
+		codespaces: []codespace{{2, 0x2121, 0x7e7e}},
+		decode:     byteDecoder(simplifiedchinese.GBK, 0x8080),
+	}
+	big5Encoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x80}, {2, 0x8140, 0xfefe}},
+		decode:     byteDecoder(traditionalchinese.Big5, 0),
+	}
+	uhcEncoding = charcodeEncoding{
+		codespaces: []codespace{{1, 0x00, 0x80}, {2, 0x8141, 0xfefe}},
+		decode:     byteDecoder(korean.EUCKR, 0),
+	}
+	kscEncoding = charcodeEncoding{
+		codespaces: []codespace{{2, 0x2121, 0x7e7e}},
+		decode:     byteDecoder(korean.EUCKR, 0x8080),
+	}
+)
+
+// predefinedEncodings maps the names of the predefined CMaps, without the writing mode suffix -H or
+// -V, to the encodings of their character codes (Table 118 PDF32000_2008).
+var predefinedEncodings = map[string]charcodeEncoding{
+	// Chinese (Simplified).
+	"GB-EUC":      gbkEncoding,
+	"GBpc-EUC":    gbkEncoding,
+	"GBK-EUC":     gbkEncoding,
+	"GBKp-EUC":    gbkEncoding,
+	"GBK2K":       gb18030Encoding,
+	"GB":          gbEncoding,
+	"UniGB-UCS2":  ucs2Encoding,
+	"UniGB-UTF16": utf16Encoding,
+	"UniGB-UTF32": utf32Encoding,
+	"UniGB-UTF8":  utf8Encoding,
+
+	// Chinese (Traditional).
+	"B5pc":         big5Encoding,
+	"B5":           big5Encoding,
+	"HKscs-B5":     big5Encoding,
+	"ETen-B5":      big5Encoding,
+	"ETenms-B5":    big5Encoding,
+	"UniCNS-UCS2":  ucs2Encoding,
+	"UniCNS-UTF16": utf16Encoding,
+	"UniCNS-UTF32": utf32Encoding,
+	"UniCNS-UTF8":  utf8Encoding,
+
+	// Japanese.
+	"83pv-RKSJ":        sjisEncoding,
+	"90ms-RKSJ":        sjisEncoding,
+	"90msp-RKSJ":       sjisEncoding,
+	"90pv-RKSJ":        sjisEncoding,
+	"Add-RKSJ":         sjisEncoding,
+	"Ext-RKSJ":         sjisEncoding,
+	"EUC":              eucJPEncoding,
+	"":                 jisEncoding, // H and V.
+	"UniJIS-UCS2":      ucs2Encoding,
+	"UniJIS-UCS2-HW":   ucs2Encoding,
+	"UniJIS-UTF16":     utf16Encoding,
+	"UniJIS-UTF32":     utf32Encoding,
+	"UniJIS-UTF8":      utf8Encoding,
+	"UniJIS2004-UTF16": utf16Encoding,
+	"UniJIS2004-UTF32": utf32Encoding,
+	"UniJIS2004-UTF8":  utf8Encoding,
+
+	// Korean.
+	"KSC-EUC":      uhcEncoding,
+	"KSCpc-EUC":    uhcEncoding,
+	"KSCms-UHC":    uhcEncoding,
+	"KSCms-UHC-HW": uhcEncoding,
+	"KSC":          kscEncoding,
+	"UniKS-UCS2":   ucs2Encoding,
+	"UniKS-UTF16":  utf16Encoding,
+	"UniKS-UTF32":  utf32Encoding,
+	"UniKS-UTF8":   utf8Encoding,
+}
+
+// predefinedEncoding returns the encoding of the character codes of predefined CMap `name`.
+func predefinedEncoding(name string) (charcodeEncoding, bool) {
+	base := name
+	if strings.HasSuffix(name, "-H") || strings.HasSuffix(name, "-V") {
+		base = name[:len(name)-2]
+	} else if name == "H" || name == "V" {
+		base = ""
+	} else {
+		return charcodeEncoding{}, false
+	}
+	enc, has := predefinedEncodings[base]
+	return enc, has
+}
+
+// decodeUCS2 decodes a UCS-2 or UTF-32 character code.
+func decodeUCS2(code uint64) (string, bool) {
+	if code > utf8.MaxRune || (code >= 0xd800 && code <= 0xdfff) {
+		return "", false
+	}
+	return string(rune(code)), true
+}
+
+// decodeUTF16 decodes a UTF-16 character code, a 2 byte code or a 4 byte surrogate pair.
+func decodeUTF16(code uint64) (string, bool) {
+	if code <= 0xffff {
+		return decodeUCS2(code)
+	}
+	r := utf16.DecodeRune(rune(code>>16), rune(code&0xffff))
+	if r == utf8.RuneError {
+		return "", false
+	}
+	return string(r), true
+}
+
+// decodeUTF8 decodes a UTF-8 character code.
+func decodeUTF8(code uint64) (string, bool) {
+	r, _ := utf8.DecodeRune(codeBytes(code))
+	if r == utf8.RuneError {
+		return "", false
+	}
+	return string(r), true
+}
+
+// byteDecoder returns a function that decodes character codes in encoding `enc`. `offset` is added to
+// the codes first, e.g. 0x8080 for the ISO-2022 forms of EUC encodings.
+func byteDecoder(enc encoding.Encoding, offset uint64) func(code uint64) (string, bool) {
+	return func(code uint64) (string, bool) {
+		if code < 0x80 {
+			return string(rune(code)), true
+		}
+		b, err := enc.NewDecoder().Bytes(codeBytes(code + offset))
+		if err != nil || len(b) == 0 {
+			return "", false
+		}
+		s := string(b)
+		if strings.ContainsRune(s, utf8.RuneError) {
+			return "", false
+		}
+		return s, true
+	}
+}
+
+// codeBytes returns the bytes of character code `code`, most significant first.
+func codeBytes(code uint64) []byte {
+	n := 1
+	for c := code >> 8; c > 0; c >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(code)
+		code >>= 8
+	}
+	return b
+}
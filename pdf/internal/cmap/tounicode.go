@@ -0,0 +1,57 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package cmap
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"unicode/utf16"
+)
+
+// maxBfEntries is the maximum number of entries in a beginbfchar block (PDF Reference 5.9.2).
+const maxBfEntries = 100
+
+// MakeToUnicodeCMap returns the data of a ToUnicode CMap with 2 byte character codes that maps the
+// codes in `codeToRune` to their runes.
+func MakeToUnicodeCMap(codeToRune map[uint16]rune) []byte {
+	codes := make([]int, 0, len(codeToRune))
+	for code := range codeToRune {
+		codes = append(codes, int(code))
+	}
+	sort.Ints(codes)
+
+	var buf bytes.Buffer
+	buf.WriteString("/CIDInit /ProcSet findresource begin\n")
+	buf.WriteString("12 dict begin\n")
+	buf.WriteString("begincmap\n")
+	buf.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	buf.WriteString("/CMapName /Adobe-Identity-UCS def\n")
+	buf.WriteString("/CMapType 2 def\n")
+	buf.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	for len(codes) > 0 {
+		n := len(codes)
+		if n > maxBfEntries {
+			n = maxBfEntries
+		}
+		fmt.Fprintf(&buf, "%d beginbfchar\n", n)
+		for _, code := range codes[:n] {
+			fmt.Fprintf(&buf, "<%04X> <", code)
+			for _, u := range utf16.Encode([]rune{codeToRune[uint16(code)]}) {
+				fmt.Fprintf(&buf, "%04X", u)
+			}
+			buf.WriteString(">\n")
+		}
+		buf.WriteString("endbfchar\n")
+		codes = codes[n:]
+	}
+
+	buf.WriteString("endcmap\n")
+	buf.WriteString("CMapName currentdict /CMap defineresource pop\n")
+	buf.WriteString("end\nend\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,266 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/unidoc/unidoc/pdf/model/icc"
+)
+
+// ColorToProfile returns the components of `color` of colorspace `cs` converted to the device space
+// of ICC profile `profile` with rendering intent `intent`. The components are in the range 0 to 1,
+// with L*a*b* components encoded as L*/100, (a*+128)/255 and (b*+128)/255.
+//
+// The colors of ICCBased colorspaces with valid profiles and of CalGray, CalRGB and Lab colorspaces
+// are color managed. The colors of other colorspaces are converted to DeviceRGB, which is taken to
+// be sRGB. Indexed, Separation, DeviceN and Pattern colors are converted via the colors of their
+// base, alternate or underlying colorspaces.
+func ColorToProfile(cs PdfColorspace, color PdfColor, profile *icc.Profile, intent icc.RenderingIntent) ([]float64, error) {
+	src, err := newColorSource(cs)
+	if err != nil {
+		return nil, err
+	}
+	t, err := icc.NewTransform(src.profile, profile, intent)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := src.components(color)
+	if err != nil {
+		return nil, err
+	}
+	return t.Apply(vals)
+}
+
+// ImageToProfile returns `img`, with samples in colorspace `cs`, converted to the device space of ICC
+// profile `profile` with rendering intent `intent`. The colors are converted as by ColorToProfile
+// and the bits per component are kept.
+func ImageToProfile(cs PdfColorspace, img Image, profile *icc.Profile, intent icc.RenderingIntent) (Image, error) {
+	src, err := newColorSource(cs)
+	if err != nil {
+		return img, err
+	}
+	t, err := icc.NewTransform(src.profile, profile, intent)
+	if err != nil {
+		return img, err
+	}
+
+	n := cs.GetNumComponents()
+	samples := img.GetSamples()
+	if len(samples)%n != 0 {
+		return img, fmt.Errorf("Image data not a multiple of %d", n)
+	}
+	maxVal := math.Pow(2, float64(img.BitsPerComponent)) - 1
+	decode := img.decode
+	if len(decode) != 2*n {
+		decode = cs.DecodeArray()
+		if _, isIndexed := cs.(*PdfColorspaceSpecialIndexed); isIndexed {
+			decode = []float64{0, maxVal}
+		}
+	}
+	if len(decode) != 2*n {
+		decode = nil
+		for i := 0; i < n; i++ {
+			decode = append(decode, 0, 1)
+		}
+	}
+
+	// Images often have few distinct colors, which are converted once.
+	cache := map[uint64][]uint32{}
+	cacheable := n*int(img.BitsPerComponent) <= 64
+	outSamples := []uint32{}
+	vals := make([]float64, n)
+	for i := 0; i < len(samples); i += n {
+		var key uint64
+		for j := range vals {
+			key = key<<uint(img.BitsPerComponent) | uint64(samples[i+j])
+			vals[j] = interpolate(float64(samples[i+j]), 0, maxVal, decode[2*j], decode[2*j+1])
+		}
+		if pixel, has := cache[key]; cacheable && has {
+			outSamples = append(outSamples, pixel...)
+			continue
+		}
+
+		color, err := cs.ColorFromFloats(vals)
+		if err != nil {
+			return img, err
+		}
+		comps, err := src.components(color)
+		if err != nil {
+			return img, err
+		}
+		converted, err := t.Apply(comps)
+		if err != nil {
+			return img, err
+		}
+		pixel := make([]uint32, len(converted))
+		for j, val := range converted {
+			pixel[j] = uint32(val*maxVal + 0.5)
+		}
+		if cacheable {
+			cache[key] = pixel
+		}
+		outSamples = append(outSamples, pixel...)
+	}
+
+	outImg := img
+	outImg.ColorComponents = profile.NumComponents()
+	outImg.decode = nil
+	outImg.SetSamples(outSamples)
+	return outImg, nil
+}
+
+// colorSource is the source of color managed conversions of the colors of a colorspace.
+type colorSource struct {
+	cs      PdfColorspace // Colorspace of the colors, the base or alternate colorspace of special colorspaces.
+	profile *icc.Profile  // Profile of the colors.
+	viaRGB  bool          // The colors are converted to DeviceRGB, taken as sRGB.
+}
+
+// newColorSource returns the source of conversions of the colors of `cs`.
+func newColorSource(cs PdfColorspace) (*colorSource, error) {
+	var err error
+	src := &colorSource{cs: cs}
+	switch t := cs.(type) {
+	case *PdfColorspaceSpecialIndexed:
+		if t.Base == nil {
+			return nil, errors.New("Indexed base colorspace undefined")
+		}
+		return newColorSource(t.Base)
+	case *PdfColorspaceSpecialSeparation:
+		if t.AlternateSpace == nil {
+			return nil, errors.New("Alternate colorspace undefined")
+		}
+		return newColorSource(t.AlternateSpace)
+	case *PdfColorspaceDeviceN:
+		if t.AlternateSpace == nil {
+			return nil, errors.New("Alternate colorspace undefined")
+		}
+		return newColorSource(t.AlternateSpace)
+	case *PdfColorspaceSpecialPattern:
+		if t.UnderlyingCS == nil {
+			return nil, errors.New("Pattern colors without underlying colorspace")
+		}
+		return newColorSource(t.UnderlyingCS)
+	case *PdfColorspaceICCBased:
+		if src.profile, err = t.GetProfile(); err != nil {
+			src.profile, src.viaRGB = icc.SRGB(), true
+		}
+	case *PdfColorspaceCalGray:
+		var white [3]float64
+		if white, err = whitePoint(t.WhitePoint); err == nil {
+			src.profile, err = icc.NewCalGrayProfile(white, t.Gamma)
+		}
+	case *PdfColorspaceCalRGB:
+		var white [3]float64
+		if white, err = whitePoint(t.WhitePoint); err == nil {
+			if len(t.Gamma) != 3 || len(t.Matrix) != 9 {
+				return nil, errors.New("Invalid CalRGB parameters")
+			}
+			var gamma [3]float64
+			var matrix [9]float64
+			copy(gamma[:], t.Gamma)
+			copy(matrix[:], t.Matrix)
+			src.profile, err = icc.NewCalRGBProfile(white, gamma, matrix)
+		}
+	case *PdfColorspaceLab:
+		var white [3]float64
+		if white, err = whitePoint(t.WhitePoint); err == nil {
+			src.profile, err = icc.NewLabProfile(white)
+		}
+	default:
+		src.profile, src.viaRGB = icc.SRGB(), true
+	}
+	if err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// components returns the components of `color` in the device space of the profile of the source.
+func (src *colorSource) components(color PdfColor) ([]float64, error) {
+	if pattern, ok := color.(*PdfColorPattern); ok {
+		if pattern.Color == nil {
+			return nil, errors.New("Pattern color without underlying color")
+		}
+		color = pattern.Color
+	}
+
+	if src.viaRGB {
+		rgbColor, err := src.cs.ColorToRGB(color)
+		if err != nil {
+			return nil, err
+		}
+		rgb, ok := rgbColor.(*PdfColorDeviceRGB)
+		if !ok {
+			return nil, errors.New("Type check error")
+		}
+		return []float64{rgb.R(), rgb.G(), rgb.B()}, nil
+	}
+
+	switch t := color.(type) {
+	case *PdfColorLab:
+		return []float64{t.L() / 100, (t.A() + 128) / 255, (t.B() + 128) / 255}, nil
+	case *PdfColorCalGray:
+		return []float64{t.Val()}, nil
+	case *PdfColorCalRGB:
+		return []float64{t.A(), t.B(), t.C()}, nil
+	}
+
+	// The colors of ICCBased colorspaces are colors of their alternate colorspaces with the components
+	// of the ICC colors, which are normalized to the range 0 to 1.
+	vals, err := colorComponents(color)
+	if err != nil {
+		return nil, err
+	}
+	if iccCS, ok := src.cs.(*PdfColorspaceICCBased); ok && len(iccCS.Range) == 2*len(vals) {
+		for i := range vals {
+			vals[i] = interpolate(vals[i], iccCS.Range[2*i], iccCS.Range[2*i+1], 0, 1)
+		}
+	}
+	return vals, nil
+}
+
+// colorComponents returns the components of `color`.
+func colorComponents(color PdfColor) ([]float64, error) {
+	switch t := color.(type) {
+	case *PdfColorDeviceGray:
+		return []float64{t.Val()}, nil
+	case *PdfColorDeviceRGB:
+		return []float64{t.R(), t.G(), t.B()}, nil
+	case *PdfColorDeviceCMYK:
+		return []float64{t.C(), t.M(), t.Y(), t.K()}, nil
+	case *PdfColorCalGray:
+		return []float64{t.Val()}, nil
+	case *PdfColorCalRGB:
+		return []float64{t.A(), t.B(), t.C()}, nil
+	case *PdfColorLab:
+		return []float64{t.L(), t.A(), t.B()}, nil
+	}
+	return nil, fmt.Errorf("Unsupported color type %T", color)
+}
+
+// whitePoint returns white point `wp` [XW YW ZW] of a CIE-based colorspace.
+func whitePoint(wp []float64) ([3]float64, error) {
+	var white [3]float64
+	if len(wp) != 3 {
+		return white, errors.New("Invalid white point")
+	}
+	copy(white[:], wp)
+	return white, nil
+}
+
+// colorToSRGB returns `color` of colorspace `cs` converted to sRGB, as a DeviceRGB color, with the
+// default relative colorimetric rendering intent.
+func colorToSRGB(cs PdfColorspace, color PdfColor) (PdfColor, error) {
+	vals, err := ColorToProfile(cs, color, icc.SRGB(), icc.RelativeColorimetric)
+	if err != nil {
+		return nil, err
+	}
+	return NewPdfColorDeviceRGB(vals[0], vals[1], vals[2]), nil
+}
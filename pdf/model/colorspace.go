@@ -12,6 +12,7 @@ import (
 
 	"github.com/unidoc/unidoc/common"
 	. "github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model/icc"
 )
 
 //
@@ -853,6 +854,11 @@ func (this *PdfColorspaceCalGray) ColorToRGB(color PdfColor) (PdfColor, error) {
 		return nil, errors.New("Type check error")
 	}
 
+	// Color managed conversion, or the approximate conversion below if the parameters are invalid.
+	if rgb, err := colorToSRGB(this, calgray); err == nil {
+		return rgb, nil
+	}
+
 	ANorm := calgray.Val()
 
 	// A -> X,Y,Z
@@ -876,6 +882,11 @@ func (this *PdfColorspaceCalGray) ColorToRGB(color PdfColor) (PdfColor, error) {
 
 // A, B, C -> X, Y, Z
 func (this *PdfColorspaceCalGray) ImageToRGB(img Image) (Image, error) {
+	// Color managed conversion, or the approximate conversion below if the parameters are invalid.
+	if converted, err := ImageToProfile(this, img, icc.SRGB(), icc.RelativeColorimetric); err == nil {
+		return converted, nil
+	}
+
 	rgbImage := img
 
 	samples := img.GetSamples()
@@ -1180,6 +1191,11 @@ func (this *PdfColorspaceCalRGB) ColorToRGB(color PdfColor) (PdfColor, error) {
 		return nil, errors.New("Type check error")
 	}
 
+	// Color managed conversion, or the approximate conversion below if the parameters are invalid.
+	if rgb, err := colorToSRGB(this, calrgb); err == nil {
+		return rgb, nil
+	}
+
 	// A, B, C in range 0.0 to 1.0
 	aVal := calrgb.A()
 	bVal := calrgb.B()
@@ -1207,6 +1223,11 @@ func (this *PdfColorspaceCalRGB) ColorToRGB(color PdfColor) (PdfColor, error) {
 }
 
 func (this *PdfColorspaceCalRGB) ImageToRGB(img Image) (Image, error) {
+	// Color managed conversion, or the approximate conversion below if the parameters are invalid.
+	if converted, err := ImageToProfile(this, img, icc.SRGB(), icc.RelativeColorimetric); err == nil {
+		return converted, nil
+	}
+
 	rgbImage := img
 
 	samples := img.GetSamples()
@@ -1521,6 +1542,11 @@ func (this *PdfColorspaceLab) ColorToRGB(color PdfColor) (PdfColor, error) {
 		return nil, errors.New("Type check error")
 	}
 
+	// Color managed conversion, or the approximate conversion below if the parameters are invalid.
+	if rgb, err := colorToSRGB(this, lab); err == nil {
+		return rgb, nil
+	}
+
 	// Get L*, a*, b* values.
 	LStar := lab.L()
 	AStar := lab.A()
@@ -1552,6 +1578,11 @@ func (this *PdfColorspaceLab) ColorToRGB(color PdfColor) (PdfColor, error) {
 }
 
 func (this *PdfColorspaceLab) ImageToRGB(img Image) (Image, error) {
+	// Color managed conversion, or the approximate conversion below if the parameters are invalid.
+	if converted, err := ImageToProfile(this, img, icc.SRGB(), icc.RelativeColorimetric); err == nil {
+		return converted, nil
+	}
+
 	g := func(x float64) float64 {
 		if x >= 6.0/29 {
 			return x * x * x
@@ -1662,7 +1693,8 @@ func (this *PdfColorICCBased) ToInteger(bits int) []uint32 {
 // A conforming reader shall support ICC.1:2004:10 as required by PDF 1.7, which will enable it
 // to properly render all embedded ICC profiles regardless of the PDF version
 //
-// In the current implementation, we rely on the alternative colormap provided.
+// Colors are converted with the embedded profile when it is valid and supported, otherwise with the
+// alternative colormap provided.
 type PdfColorspaceICCBased struct {
 	N         int           // Number of color components (Required). Can be 1,3, or 4.
 	Alternate PdfColorspace // Alternate colorspace for non-conforming readers.
@@ -1674,6 +1706,9 @@ type PdfColorspaceICCBased struct {
 
 	container *PdfIndirectObject
 	stream    *PdfObjectStream
+
+	profile    *icc.Profile // Parsed profile of Data.
+	profileErr error        // Error of parsing the profile.
 }
 
 func (this *PdfColorspaceICCBased) GetNumComponents() int {
@@ -1689,6 +1724,20 @@ func (this *PdfColorspaceICCBased) String() string {
 	return "ICCBased"
 }
 
+// GetProfile returns the ICC profile of the colorspace, which is parsed from Data on first use. An
+// error is returned if the profile is invalid or unsupported, or its colors do not have N components.
+func (this *PdfColorspaceICCBased) GetProfile() (*icc.Profile, error) {
+	if this.profile == nil && this.profileErr == nil {
+		this.profile, this.profileErr = icc.Parse(this.Data)
+		if this.profileErr == nil && this.profile.NumComponents() != this.N {
+			this.profileErr = fmt.Errorf("ICC profile has %d components, expecting %d",
+				this.profile.NumComponents(), this.N)
+			this.profile = nil
+		}
+	}
+	return this.profile, this.profileErr
+}
+
 func NewPdfColorspaceICCBased(N int) (*PdfColorspaceICCBased, error) {
 	cs := &PdfColorspaceICCBased{}
 
@@ -1883,6 +1932,12 @@ func (this *PdfColorspaceICCBased) ColorToRGB(color PdfColor) (PdfColor, error)
 		}
 	*/
 
+	_, err := this.GetProfile()
+	if err == nil {
+		return colorToSRGB(this, color)
+	}
+	common.Log.Trace("ICC Based colorspace profile not used: %v", err)
+
 	if this.Alternate == nil {
 		common.Log.Debug("ICC Based colorspace missing alternative")
 		if this.N == 1 {
@@ -1908,6 +1963,12 @@ func (this *PdfColorspaceICCBased) ColorToRGB(color PdfColor) (PdfColor, error)
 }
 
 func (this *PdfColorspaceICCBased) ImageToRGB(img Image) (Image, error) {
+	_, err := this.GetProfile()
+	if err == nil {
+		return ImageToProfile(this, img, icc.SRGB(), icc.RelativeColorimetric)
+	}
+	common.Log.Trace("ICC Based colorspace profile not used: %v", err)
+
 	if this.Alternate == nil {
 		common.Log.Debug("ICC Based colorspace missing alternative")
 		if this.N == 1 {
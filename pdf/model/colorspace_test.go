@@ -6,7 +6,9 @@
 package model
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -44,3 +46,73 @@ func TestDeviceNCS1(t *testing.T) {
 
 	//t.Errorf("Test not implemented yet")
 }
+
+// linearGrayProfile returns an ICC profile of gray levels with a linear tone reproduction curve.
+func linearGrayProfile() []byte {
+	data := make([]byte, 128, 160)
+	copy(data[12:], "mntr")
+	copy(data[16:], "GRAY")
+	copy(data[20:], "XYZ ")
+	copy(data[36:], "acsp")
+	data = append(data, 0, 0, 0, 1)
+	data = append(data, "kTRC\x00\x00\x00\x90\x00\x00\x00\x0e"...)
+	data = append(data, "curv\x00\x00\x00\x00\x00\x00\x00\x01\x01\x00"...)
+	binary.BigEndian.PutUint32(data, uint32(len(data)))
+	return data
+}
+
+func TestICCBasedColorManagement(t *testing.T) {
+	cs, err := NewPdfColorspaceICCBased(1)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	cs.Alternate = NewPdfColorspaceDeviceGray()
+
+	// Without a profile the alternate colorspace is used.
+	color, err := cs.ColorFromFloats([]float64{0.5})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	rgb, err := cs.ColorToRGB(color)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if r := rgb.(*PdfColorDeviceRGB).R(); math.Abs(r-0.5) > 0.001 {
+		t.Errorf("Alternate conversion: got %f, expected 0.5", r)
+	}
+
+	// Linear gray 0.5 is sRGB 0.7354.
+	cs, _ = NewPdfColorspaceICCBased(1)
+	cs.Alternate = NewPdfColorspaceDeviceGray()
+	cs.Data = linearGrayProfile()
+	if _, err := cs.GetProfile(); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	rgb, err = cs.ColorToRGB(color)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if r := rgb.(*PdfColorDeviceRGB).R(); math.Abs(r-0.7354) > 0.002 {
+		t.Errorf("Profile conversion: got %f, expected 0.7354", r)
+	}
+
+	img := Image{Width: 2, Height: 1, BitsPerComponent: 8, ColorComponents: 1, Data: []byte{0, 128}}
+	rgbImg, err := cs.ImageToRGB(img)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if rgbImg.ColorComponents != 3 || len(rgbImg.Data) != 6 || rgbImg.Data[0] != 0 || rgbImg.Data[3] != 188 {
+		t.Errorf("Wrong image conversion: %d components, data % x", rgbImg.ColorComponents, rgbImg.Data)
+	}
+
+	// CalGray colors are color managed too.
+	calGray := NewPdfColorspaceCalGray()
+	calGray.WhitePoint = []float64{0.9505, 1, 1.089}
+	rgb, err = calGray.ColorToRGB(NewPdfColorCalGray(0.5))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if r := rgb.(*PdfColorDeviceRGB).R(); math.Abs(r-0.7354) > 0.002 {
+		t.Errorf("CalGray conversion: got %f, expected 0.7354", r)
+	}
+}
@@ -6,12 +6,15 @@
 package model
 
 import (
+	"bytes"
 	"errors"
-
-	"io/ioutil"
+	"io"
+	"os"
+	"unicode/utf8"
 
 	"github.com/unidoc/unidoc/common"
 	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/internal/cmap"
 	"github.com/unidoc/unidoc/pdf/model/fonts"
 	"github.com/unidoc/unidoc/pdf/model/textencoding"
 )
@@ -19,27 +22,188 @@ import (
 // The PdfFont structure represents an underlying font structure which can be of type:
 // - Type0
 // - Type1
+// - MMType1
+// - Type3
 // - TrueType
-// etc.
+// The font can be used for drawing text (through the fonts.Font interface) or for decoding and measuring
+// character codes in content streams when reading.
 type PdfFont struct {
-	context interface{} // The underlying font: Type0, Type1, Truetype, etc..
+	context pdfFontContext // The underlying font: Type0, Type1, Truetype, etc..
+}
+
+// pdfFontContext is the interface implemented by the underlying font types.
+type pdfFontContext interface {
+	fonts.Font
+
+	// getFontCommon returns the fields shared by all font types.
+	getFontCommon() *fontCommon
+
+	// getCharMetrics returns the metrics of the glyph with character code `code`.
+	getCharMetrics(code uint64) (fonts.CharMetrics, bool)
+
+	// bytesToCharcodes splits a string from a content stream into character codes.
+	bytesToCharcodes(data []byte) []uint64
+
+	// charcodeToUnicode decodes `code` without a ToUnicode CMap.
+	charcodeToUnicode(code uint64) (string, bool)
+}
+
+// fontCommon represents the fields that are common to all PDF fonts.
+type fontCommon struct {
+	// All fonts have these fields.
+	basefont string
+	subtype  string
+
+	// These are optional fields in the PDF font.
+	toUnicode core.PdfObject
+
+	// These objects are computed from optional fields in the PDF font.
+	toUnicodeCmap  *cmap.CMap
+	fontDescriptor *PdfFontDescriptor
 }
 
-// Set the encoding for the underlying font.
+// SetEncoder sets the encoding for the underlying font.
 func (font PdfFont) SetEncoder(encoder textencoding.TextEncoder) {
-	switch t := font.context.(type) {
-	case *pdfFontTrueType:
-		t.SetEncoder(encoder)
+	if font.context == nil {
+		return
 	}
+	font.context.SetEncoder(encoder)
 }
 
+// GetGlyphCharMetrics returns the metrics of the glyph with name `glyph`.
 func (font PdfFont) GetGlyphCharMetrics(glyph string) (fonts.CharMetrics, bool) {
-	switch t := font.context.(type) {
-	case *pdfFontTrueType:
-		return t.GetGlyphCharMetrics(glyph)
+	if font.context == nil {
+		return fonts.CharMetrics{}, false
+	}
+	return font.context.GetGlyphCharMetrics(glyph)
+}
+
+// BaseFont returns the font's BaseFont name.
+func (font PdfFont) BaseFont() string {
+	if font.context == nil {
+		return ""
+	}
+	return font.context.getFontCommon().basefont
+}
+
+// Subtype returns the font's Subtype, e.g. "Type1", "TrueType", "Type3" or "Type0".
+func (font PdfFont) Subtype() string {
+	if font.context == nil {
+		return ""
+	}
+	return font.context.getFontCommon().subtype
+}
+
+// IsCID returns true if the font is a composite (Type0) font.
+func (font PdfFont) IsCID() bool {
+	_, isType0 := font.context.(*pdfFontType0)
+	return isType0
+}
+
+// IsVertical returns true if the font uses vertical writing mode, i.e. it is a Type0 font with the
+// Identity-V encoding or another Encoding CMap with WMode 1, e.g. UniJIS-UCS2-V.
+func (font PdfFont) IsVertical() bool {
+	t, isType0 := font.context.(*pdfFontType0)
+	return isType0 && t.vertical
+}
+
+// GetVerticalMetrics returns the vertical metrics of the glyph with character code `code` of a font
+// with vertical writing mode: the vertical displacement w1_y and the position vector (v_x, v_y) from
+// the glyph's horizontal origin to its vertical origin, in thousandths of a unit of text space
+// (9.7.4.3 "Glyph Metrics in CIDFonts").
+// The bool return flag is false if the font does not use vertical writing mode.
+func (font PdfFont) GetVerticalMetrics(code uint64) (w1y, vx, vy float64, ok bool) {
+	t, isType0 := font.context.(*pdfFontType0)
+	if !isType0 || !t.vertical || t.DescendantFont == nil {
+		return 0, 0, 0, false
+	}
+	cid, _ := t.charcodeToCID(code)
+	w1y, vx, vy = t.DescendantFont.getCIDVerticalMetrics(cid)
+	return w1y, vx, vy, true
+}
+
+// GetFontDescriptor returns the font descriptor of the font. For Type0 fonts this is the descriptor of
+// the descendant CIDFont. Returns nil if there is none, e.g. for the standard 14 fonts.
+func (font PdfFont) GetFontDescriptor() *PdfFontDescriptor {
+	if font.context == nil {
+		return nil
+	}
+	if t, isType0 := font.context.(*pdfFontType0); isType0 && t.DescendantFont != nil {
+		return t.DescendantFont.fontDescriptor
 	}
+	return font.context.getFontCommon().fontDescriptor
+}
 
-	return fonts.CharMetrics{}, false
+// GetCharMetrics returns the metrics of the glyph with character code `code`. The widths are in
+// thousandths of a unit of text space, irrespective of the font type.
+// The bool return flag is true if there was a match, and false otherwise.
+func (font PdfFont) GetCharMetrics(code uint64) (fonts.CharMetrics, bool) {
+	if font.context == nil {
+		return fonts.CharMetrics{}, false
+	}
+	return font.context.getCharMetrics(code)
+}
+
+// CharcodeBytesToCharcodes splits `data`, a string shown by a text showing operator (e.g. Tj), into
+// character codes. Simple fonts have 1 byte codes, while the code lengths of Type0 fonts are
+// determined by the font's Encoding CMap.
+func (font PdfFont) CharcodeBytesToCharcodes(data []byte) []uint64 {
+	if font.context == nil {
+		codes := make([]uint64, len(data))
+		for i, b := range data {
+			codes[i] = uint64(b)
+		}
+		return codes
+	}
+	return font.context.bytesToCharcodes(data)
+}
+
+// CharcodeToUnicode converts a single character code to its unicode string representation.
+// The font's ToUnicode CMap is used if there is one, otherwise the mapping is derived from the font's
+// encoding.
+// The bool return flag is true if there was a match, and false otherwise.
+func (font PdfFont) CharcodeToUnicode(code uint64) (string, bool) {
+	if font.context == nil {
+		return "", false
+	}
+	if codemap := font.context.getFontCommon().toUnicodeCmap; codemap != nil {
+		if s, has := codemap.Lookup(code); has {
+			return s, true
+		}
+	}
+	return font.context.charcodeToUnicode(code)
+}
+
+// CharcodeBytesToUnicode converts `data`, a string shown by a text showing operator (e.g. Tj), to a
+// unicode string. Codes that cannot be mapped are replaced by the unicode replacement character.
+func (font PdfFont) CharcodeBytesToUnicode(data []byte) string {
+	var buf bytes.Buffer
+	for _, code := range font.CharcodeBytesToCharcodes(data) {
+		s, ok := font.CharcodeToUnicode(code)
+		if !ok {
+			common.Log.Trace("No unicode mapping for code 0x%04x (%s)", code, font.BaseFont())
+			s = string(utf8.RuneError)
+		}
+		buf.WriteString(s)
+	}
+	return buf.String()
+}
+
+// SetPredefinedCMapDir sets the directory of the CMap files of the predefined CMaps, e.g. a copy of
+// Adobe's cmap-resources, either flat or with its <Registry>-<Ordering>-<Supplement>/CMap layout.
+// The files give the CIDs of character codes of fonts with predefined Encoding CMaps such as
+// UniJIS-UCS2-H, and the CID to unicode mappings of the Adobe CJK character collections (e.g.
+// Adobe-Japan1-UCS2) used for text extraction from Type0 fonts without a ToUnicode CMap.
+// Without the files, the character codes of the predefined CMaps are still decoded to unicode, as
+// they are in Unicode or a legacy CJK encoding, but the glyph metrics are the CIDFont's defaults.
+func SetPredefinedCMapDir(dir string) {
+	cmap.SetPredefinedDir(dir)
+}
+
+// NewPdfFontFromPdfObject loads a PdfFont from a font dictionary, e.g. one obtained from the Font
+// entry of a resource dictionary. Type1, MMType1, TrueType, Type3 and Type0 fonts are supported.
+func NewPdfFontFromPdfObject(obj core.PdfObject) (*PdfFont, error) {
+	return newPdfFontFromPdfObject(obj)
 }
 
 func newPdfFontFromPdfObject(obj core.PdfObject) (*PdfFont, error) {
@@ -57,7 +221,7 @@ func newPdfFontFromPdfObject(obj core.PdfObject) (*PdfFont, error) {
 	}
 
 	if obj := d.Get("Type"); obj != nil {
-		oname, is := obj.(*core.PdfObjectName)
+		oname, is := core.TraceToDirectObject(obj).(*core.PdfObjectName)
 		if !is || string(*oname) != "Font" {
 			common.Log.Debug("Incompatibility ERROR: Type (Required) defined but not Font name")
 			return nil, errors.New("Range check error")
@@ -67,27 +231,43 @@ func newPdfFontFromPdfObject(obj core.PdfObject) (*PdfFont, error) {
 		return nil, errors.New("Required attribute missing")
 	}
 
-	obj = d.Get("Subtype")
-	if obj == nil {
+	subtypeObj := d.Get("Subtype")
+	if subtypeObj == nil {
 		common.Log.Debug("Incompatibility ERROR: Subtype (Required) missing")
 		return nil, errors.New("Required attribute missing")
 	}
 
-	subtype, ok := core.TraceToDirectObject(obj).(*core.PdfObjectName)
+	subtype, ok := core.TraceToDirectObject(subtypeObj).(*core.PdfObjectName)
 	if !ok {
-		common.Log.Debug("Incompatibility ERROR: subtype not a name (%T) ", obj)
+		common.Log.Debug("Incompatibility ERROR: subtype not a name (%T) ", subtypeObj)
 		return nil, errors.New("Type check error")
 	}
 
 	switch subtype.String() {
-	case "TrueType":
-		truefont, err := newPdfFontTrueTypeFromPdfObject(obj)
+	case "TrueType", "Type1", "MMType1":
+		simplefont, err := newPdfFontSimpleFromPdfObject(obj, subtype.String())
 		if err != nil {
-			common.Log.Debug("Error loading truetype font: %v", truefont)
+			common.Log.Debug("Error loading %s font: %v", subtype.String(), err)
 			return nil, err
 		}
 
-		font.context = truefont
+		font.context = simplefont
+	case "Type3":
+		type3font, err := newPdfFontType3FromPdfObject(obj)
+		if err != nil {
+			common.Log.Debug("Error loading Type3 font: %v", err)
+			return nil, err
+		}
+
+		font.context = type3font
+	case "Type0":
+		type0font, err := newPdfFontType0FromPdfObject(obj)
+		if err != nil {
+			common.Log.Debug("Error loading Type0 font: %v", err)
+			return nil, err
+		}
+
+		font.context = type0font
 	default:
 		common.Log.Debug("Unsupported font type: %s", subtype.String())
 		return nil, errors.New("Unsupported font type")
@@ -96,155 +276,32 @@ func newPdfFontFromPdfObject(obj core.PdfObject) (*PdfFont, error) {
 	return font, nil
 }
 
+// ToPdfObject converts the font to a PDF representation.
 func (font PdfFont) ToPdfObject() core.PdfObject {
-	switch f := font.context.(type) {
-	case *pdfFontTrueType:
-		return f.ToPdfObject()
+	if font.context == nil {
+		// If not supported, return null..
+		common.Log.Debug("Unsupported font (%T) - returning null object", font.context)
+		return core.MakeNull()
 	}
-
-	// If not supported, return null..
-	common.Log.Debug("Unsupported font (%T) - returning null object", font.context)
-	return core.MakeNull()
-}
-
-type pdfFontTrueType struct {
-	Encoder textencoding.TextEncoder
-
-	firstChar  int
-	lastChar   int
-	charWidths []float64
-
-	// Subtype shall be TrueType.
-	// Encoding is subject to limitations that are described in 9.6.6, "Character Encoding".
-	// BaseFont is derived differently.
-	BaseFont       core.PdfObject
-	FirstChar      core.PdfObject
-	LastChar       core.PdfObject
-	Widths         core.PdfObject
-	FontDescriptor *PdfFontDescriptor
-	Encoding       core.PdfObject
-	ToUnicode      core.PdfObject
-
-	container *core.PdfIndirectObject
+	return font.context.ToPdfObject()
 }
 
-func (font pdfFontTrueType) SetEncoder(encoder textencoding.TextEncoder) {
-	font.Encoder = encoder
+// getFontCommon returns `base`. It lets the font types satisfy pdfFontContext by embedding fontCommon.
+func (base *fontCommon) getFontCommon() *fontCommon {
+	return base
 }
 
-func (font pdfFontTrueType) GetGlyphCharMetrics(glyph string) (fonts.CharMetrics, bool) {
-	metrics := fonts.CharMetrics{}
-
-	code, found := font.Encoder.GlyphToCharcode(glyph)
-	if !found {
-		return metrics, false
-	}
-
-	if int(code) < font.firstChar {
-		common.Log.Debug("Code lower than firstchar (%d < %d)", code, font.firstChar)
-		return metrics, false
-	}
-
-	if int(code) > font.lastChar {
-		common.Log.Debug("Code higher than lastchar (%d < %d)", code, font.lastChar)
-		return metrics, false
-	}
-
-	index := int(code) - font.firstChar
-	if index >= len(font.charWidths) {
-		common.Log.Debug("Code outside of widths range")
-		return metrics, false
-	}
-
-	width := font.charWidths[index]
-	metrics.Wx = width
-
-	return metrics, true
-}
-
-func newPdfFontTrueTypeFromPdfObject(obj core.PdfObject) (*pdfFontTrueType, error) {
-	font := &pdfFontTrueType{}
-
-	if ind, is := obj.(*core.PdfIndirectObject); is {
-		font.container = ind
-		obj = ind.PdfObject
-	}
-
-	d, ok := obj.(*core.PdfObjectDictionary)
-	if !ok {
-		common.Log.Debug("Font object invalid, not a dictionary (%T)", obj)
-		return nil, errors.New("Type check error")
-	}
-
-	if obj := d.Get("Type"); obj != nil {
-		oname, is := obj.(*core.PdfObjectName)
-		if !is || oname.String() != "Font" {
-			common.Log.Debug("Incompatibility: Type defined but not Font")
-		}
-	}
-
-	if obj := d.Get("Subtype"); obj != nil {
-		oname, is := obj.(*core.PdfObjectName)
-		if !is || oname.String() != "TrueType" {
-			common.Log.Debug("Incompatibility: Loading TrueType font but Subtype != TrueType")
-		}
-	}
-
-	font.BaseFont = d.Get("BaseFont")
-
-	if obj := d.Get("FirstChar"); obj != nil {
-		font.FirstChar = obj
-
-		intVal, ok := core.TraceToDirectObject(obj).(*core.PdfObjectInteger)
-		if !ok {
-			common.Log.Debug("Invalid FirstChar type (%T)", obj)
-			return nil, errors.New("Type check error")
-		}
-		font.firstChar = int(*intVal)
-	} else {
-		common.Log.Debug("ERROR: FirstChar attribute missing")
-		return nil, errors.New("Required attribute missing")
-	}
-
-	if obj := d.Get("LastChar"); obj != nil {
-		font.LastChar = obj
-
-		intVal, ok := core.TraceToDirectObject(obj).(*core.PdfObjectInteger)
-		if !ok {
-			common.Log.Debug("Invalid LastChar type (%T)", obj)
-			return nil, errors.New("Type check error")
-		}
-		font.lastChar = int(*intVal)
-	} else {
-		common.Log.Debug("ERROR: FirstChar attribute missing")
-		return nil, errors.New("Required attribute missing")
-	}
-
-	font.charWidths = []float64{}
-	if obj := d.Get("Widths"); obj != nil {
-		font.Widths = obj
+// newFontCommonFromDict loads the fields common to all fonts from font dictionary `d`.
+// A ToUnicode CMap that cannot be parsed is logged and ignored as it is not needed for rendering.
+func newFontCommonFromDict(d *core.PdfObjectDictionary, subtype string) (*fontCommon, error) {
+	base := &fontCommon{subtype: subtype}
 
-		arr, ok := core.TraceToDirectObject(obj).(*core.PdfObjectArray)
-		if !ok {
-			common.Log.Debug("Widths attribute != array (%T)", arr)
-			return nil, errors.New("Type check error")
+	if obj := d.Get("BaseFont"); obj != nil {
+		if name, ok := core.TraceToDirectObject(obj).(*core.PdfObjectName); ok {
+			base.basefont = string(*name)
+		} else {
+			common.Log.Debug("Incompatibility: BaseFont not a name (%T)", obj)
 		}
-
-		widths, err := arr.ToFloat64Array()
-		if err != nil {
-			common.Log.Debug("Error converting widths to array")
-			return nil, err
-		}
-
-		if len(widths) != (font.lastChar - font.firstChar + 1) {
-			common.Log.Debug("Invalid widths length != %d (%d)", font.lastChar-font.firstChar+1, len(widths))
-			return nil, errors.New("Range check error")
-		}
-
-		font.charWidths = widths
-	} else {
-		common.Log.Debug("Widths missing from font")
-		return nil, errors.New("Required attribute missing")
 	}
 
 	if obj := d.Get("FontDescriptor"); obj != nil {
@@ -253,59 +310,98 @@ func newPdfFontTrueTypeFromPdfObject(obj core.PdfObject) (*pdfFontTrueType, erro
 			common.Log.Debug("Error loading font descriptor: %v", err)
 			return nil, err
 		}
-
-		font.FontDescriptor = descriptor
+		base.fontDescriptor = descriptor
 	}
 
-	font.Encoding = d.Get("Encoding")
-	font.ToUnicode = d.Get("ToUnicode")
-
-	return font, nil
-}
-
-func (this *pdfFontTrueType) ToPdfObject() core.PdfObject {
-	if this.container == nil {
-		this.container = &core.PdfIndirectObject{}
+	if obj := d.Get("ToUnicode"); obj != nil {
+		base.toUnicode = obj
+		codemap, err := loadCmapFromStreamObject(obj)
+		if err != nil {
+			common.Log.Debug("Unable to load ToUnicode CMap: %v", err)
+		} else {
+			base.toUnicodeCmap = codemap
+		}
 	}
-	d := core.MakeDict()
-	this.container.PdfObject = d
 
-	d.Set("Type", core.MakeName("Font"))
-	d.Set("Subtype", core.MakeName("TrueType"))
+	return base, nil
+}
 
-	if this.BaseFont != nil {
-		d.Set("BaseFont", this.BaseFont)
-	}
-	if this.FirstChar != nil {
-		d.Set("FirstChar", this.FirstChar)
-	}
-	if this.LastChar != nil {
-		d.Set("LastChar", this.LastChar)
-	}
-	if this.Widths != nil {
-		d.Set("Widths", this.Widths)
+// loadCmapFromStreamObject parses the CMap contained in stream object `obj`.
+func loadCmapFromStreamObject(obj core.PdfObject) (*cmap.CMap, error) {
+	stream, ok := core.TraceToDirectObject(obj).(*core.PdfObjectStream)
+	if !ok {
+		common.Log.Debug("CMap not a stream (%T)", obj)
+		return nil, errors.New("Type check error")
 	}
-	if this.FontDescriptor != nil {
-		d.Set("FontDescriptor", this.FontDescriptor.ToPdfObject())
+	decoded, err := core.DecodeStream(stream)
+	if err != nil {
+		return nil, err
 	}
-	if this.Encoding != nil {
-		d.Set("Encoding", this.Encoding)
+	return cmap.LoadCmapFromData(decoded)
+}
+
+// getMissingWidth returns the MissingWidth entry of the font descriptor `descriptor` (0 if not set).
+func getMissingWidth(descriptor *PdfFontDescriptor) float64 {
+	if descriptor == nil || descriptor.MissingWidth == nil {
+		return 0
 	}
-	if this.ToUnicode != nil {
-		d.Set("ToUnicode", this.ToUnicode)
+	w, err := getNumberAsFloat(core.TraceToDirectObject(descriptor.MissingWidth))
+	if err != nil {
+		common.Log.Debug("Invalid MissingWidth (%T)", descriptor.MissingWidth)
+		return 0
 	}
-
-	return this.container
+	return w
 }
 
+// NewPdfFontFromTTFFile loads a TrueType font from file `filePath` and returns a simple font with
+// WinAnsiEncoding and the font program embedded.
+// OpenType fonts with CFF outlines (.otf) are loaded as Type1 fonts with the font embedded as a
+// FontFile3 of subtype OpenType. Their CFF table must not be CID-keyed, see
+// NewCompositePdfFontFromOTFFile for CID-keyed fonts.
+// WOFF and WOFF2 fonts are accepted too, see NewPdfFontFromReader.
 func NewPdfFontFromTTFFile(filePath string) (*PdfFont, error) {
-	ttf, err := fonts.TtfParse(filePath)
+	f, err := os.Open(filePath)
+	if err != nil {
+		common.Log.Debug("Unable to open font file: %v", err)
+		return nil, err
+	}
+	defer f.Close()
+	return NewPdfFontFromReader(f)
+}
+
+// NewPdfFontFromReader loads a TrueType (.ttf), OpenType (.otf), WOFF (.woff) or WOFF2 (.woff2) font
+// from `r` and returns a simple font as NewPdfFontFromTTFFile does. WOFF and WOFF2 fonts are unpacked
+// and embedded as TrueType or OpenType fonts.
+func NewPdfFontFromReader(r io.Reader) (*PdfFont, error) {
+	ttfBytes, err := fonts.ReadSfnt(r)
+	if err != nil {
+		common.Log.Debug("Unable to read font: %v", err)
+		return nil, err
+	}
+
+	ttf, err := fonts.TtfParseBytes(ttfBytes)
 	if err != nil {
 		common.Log.Debug("Error loading ttf font: %v", err)
 		return nil, err
 	}
 
-	truefont := &pdfFontTrueType{}
+	subtype := "TrueType"
+	var cff *fonts.CFFFont
+	if ttf.HasCFF {
+		cff, err = fonts.ParseOpenTypeCFF(ttfBytes)
+		if err != nil {
+			common.Log.Debug("Error loading CFF table: %v", err)
+			return nil, err
+		}
+		if cff.IsCID() {
+			common.Log.Debug("CID-keyed CFF font %s cannot be used as a simple font", cff.Name())
+			return nil, errors.New("CID-keyed OpenType font not supported as a simple font")
+		}
+		subtype = "Type1"
+	}
+
+	truefont := &pdfFontSimple{}
+	truefont.fontCommon = &fontCommon{subtype: subtype, basefont: ttf.PostScriptName}
 
 	truefont.Encoder = textencoding.NewWinAnsiTextEncoder()
 	truefont.firstChar = 32
@@ -359,6 +455,7 @@ func NewPdfFontFromTTFFile(filePath string) (*PdfFont, error) {
 	truefont.Encoding = core.MakeName("WinAnsiEncoding")
 
 	descriptor := &PdfFontDescriptor{}
+	descriptor.FontName = core.MakeName(ttf.PostScriptName)
 	descriptor.Ascent = core.MakeFloat(k * float64(ttf.TypoAscender))
 	descriptor.Descent = core.MakeFloat(k * float64(ttf.TypoDescender))
 	descriptor.CapHeight = core.MakeFloat(k * float64(ttf.CapHeight))
@@ -366,20 +463,21 @@ func NewPdfFontFromTTFFile(filePath string) (*PdfFont, error) {
 	descriptor.ItalicAngle = core.MakeFloat(float64(ttf.ItalicAngle))
 	descriptor.MissingWidth = core.MakeFloat(k * float64(ttf.Widths[0]))
 
-	ttfBytes, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		common.Log.Debug("Unable to read file contents: %v", err)
-		return nil, err
-	}
-
 	// XXX/TODO: Encode the file...
 	stream, err := core.MakeStream(ttfBytes, core.NewFlateEncoder())
 	if err != nil {
 		common.Log.Debug("Unable to make stream: %v", err)
 		return nil, err
 	}
-	stream.PdfObjectDictionary.Set("Length1", core.MakeInteger(int64(len(ttfBytes))))
-	descriptor.FontFile2 = stream
+	if cff != nil {
+		stream.PdfObjectDictionary.Set("Subtype", core.MakeName("OpenType"))
+		descriptor.FontFile3 = stream
+		descriptor.fontProgram = cff
+		descriptor.fontProgramLoaded = true
+	} else {
+		stream.PdfObjectDictionary.Set("Length1", core.MakeInteger(int64(len(ttfBytes))))
+		descriptor.FontFile2 = stream
+	}
 
 	if ttf.Bold {
 		descriptor.StemV = core.MakeInteger(120)
@@ -398,7 +496,8 @@ func NewPdfFontFromTTFFile(filePath string) (*PdfFont, error) {
 	descriptor.Flags = core.MakeInteger(int64(flags))
 
 	// Build Font.
-	truefont.FontDescriptor = descriptor
+	truefont.fontDescriptor = descriptor
+	truefont.loadSfntLayout(ttf, ttfBytes)
 
 	font := &PdfFont{}
 	font.context = truefont
@@ -436,6 +535,16 @@ type PdfFontDescriptor struct {
 	FD     core.PdfObject
 	CIDSet core.PdfObject
 
+	// The parsed FontFile or FontFile3 font program, loaded on first use.
+	fontProgram       fonts.FontProgram
+	fontProgramErr    error
+	fontProgramLoaded bool
+
+	// The parsed TrueType font program of FontFile2 (or an OpenType FontFile3 with TrueType
+	// outlines), loaded on first use.
+	trueTypeProgram *fonts.TrueTypeFont
+	trueTypeLoaded  bool
+
 	// Container.
 	container *core.PdfIndirectObject
 }
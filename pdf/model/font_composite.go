@@ -0,0 +1,649 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/internal/cmap"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// pdfFontType0 represents a Type0 (composite) font. Character codes are mapped to CIDs by the
+// Encoding CMap, and the CIDs select glyphs in the descendant CIDFont (9.7 "Composite Fonts").
+type pdfFontType0 struct {
+	*fontCommon
+
+	// The descendant CIDFont.
+	DescendantFont *pdfCIDFont
+
+	// The Encoding CMap. nil for the Identity-H and Identity-V encodings.
+	encoderCmap *cmap.CMap
+	// True if the font uses vertical writing mode (e.g. Identity-V).
+	vertical bool
+
+	BaseFont        core.PdfObject
+	Encoding        core.PdfObject
+	DescendantFonts core.PdfObject
+
+	container *core.PdfIndirectObject
+}
+
+// pdfCIDFont represents a CIDFont, the descendant of a Type0 font. The Subtype is CIDFontType0
+// (CFF based glyphs) or CIDFontType2 (TrueType based glyphs).
+type pdfCIDFont struct {
+	*fontCommon
+
+	// Registry and Ordering of the CIDSystemInfo, e.g. "Adobe" and "Japan1".
+	registry string
+	ordering string
+
+	// Horizontal metrics: default width and CID to width map from the W array.
+	dw     float64
+	widths map[uint16]float64
+
+	// Vertical metrics: default [v_y w1_y] from DW2, and CID to [w1_y v_x v_y] from the W2 array.
+	dw2             [2]float64
+	verticalMetrics map[uint16][3]float64
+
+	// CID to GID map for CIDFontType2 fonts. nil means the Identity mapping.
+	cidToGID []uint16
+
+	BaseFont      core.PdfObject
+	CIDSystemInfo core.PdfObject
+	DW            core.PdfObject
+	W             core.PdfObject
+	DW2           core.PdfObject
+	W2            core.PdfObject
+	CIDToGIDMap   core.PdfObject
+
+	container *core.PdfIndirectObject
+}
+
+// SetEncoder is not supported for Type0 fonts, the encoding is given by the Encoding CMap.
+func (font *pdfFontType0) SetEncoder(encoder textencoding.TextEncoder) {
+	common.Log.Debug("SetEncoder not supported for Type0 fonts")
+}
+
+// GetGlyphCharMetrics is not supported for Type0 fonts as CIDFonts do not have glyph names.
+func (font *pdfFontType0) GetGlyphCharMetrics(glyph string) (fonts.CharMetrics, bool) {
+	return fonts.CharMetrics{}, false
+}
+
+// charcodeToCID returns the CID that character code `code` maps to.
+// The bool return flag is false if there is no mapping, in which case CID 0 (.notdef) is returned.
+func (font *pdfFontType0) charcodeToCID(code uint64) (uint16, bool) {
+	if font.encoderCmap == nil {
+		// Identity-H / Identity-V.
+		return uint16(code), code <= 0xffff
+	}
+	return font.encoderCmap.CharcodeToCID(code)
+}
+
+// getCharMetrics returns the metrics of the glyph with character code `code`. For fonts with vertical
+// writing mode, Wy is the vertical displacement w1_y.
+func (font *pdfFontType0) getCharMetrics(code uint64) (fonts.CharMetrics, bool) {
+	if font.DescendantFont == nil {
+		return fonts.CharMetrics{}, false
+	}
+	cid, _ := font.charcodeToCID(code)
+	metrics := fonts.CharMetrics{}
+	metrics.Wx = font.DescendantFont.getCIDWidth(cid)
+	if font.vertical {
+		metrics.Wy, _, _ = font.DescendantFont.getCIDVerticalMetrics(cid)
+	}
+	return metrics, true
+}
+
+// bytesToCharcodes splits `data` into character codes using the codespace ranges of the Encoding
+// CMap. The Identity encodings have 2 byte codes.
+func (font *pdfFontType0) bytesToCharcodes(data []byte) []uint64 {
+	if font.encoderCmap != nil {
+		return font.encoderCmap.BytesToCharcodes(data)
+	}
+
+	codes := []uint64{}
+	for i := 0; i+1 < len(data); i += 2 {
+		codes = append(codes, uint64(data[i])<<8|uint64(data[i+1]))
+	}
+	if len(data)%2 == 1 {
+		common.Log.Debug("Odd number of bytes in Identity encoded string. Ignoring last byte")
+	}
+	return codes
+}
+
+// charcodeToUnicode decodes `code` without a ToUnicode CMap. The codes of predefined CMaps such as
+// UniJIS-UCS2-H or GBK-EUC-H are in a known encoding. Otherwise the CID of `code` is mapped to unicode
+// with the CID to unicode CMap of the character collection of the CIDFont, e.g. Adobe-Japan1-UCS2.
+func (font *pdfFontType0) charcodeToUnicode(code uint64) (string, bool) {
+	if font.encoderCmap != nil {
+		if s, ok := font.encoderCmap.DecodeCharcode(code); ok {
+			return s, true
+		}
+	}
+	if font.DescendantFont == nil {
+		return "", false
+	}
+	cid, ok := font.charcodeToCID(code)
+	if !ok {
+		return "", false
+	}
+	return font.DescendantFont.cidToUnicode(cid)
+}
+
+// newPdfFontType0FromPdfObject loads a Type0 font and its descendant CIDFont from font dictionary `obj`.
+func newPdfFontType0FromPdfObject(obj core.PdfObject) (*pdfFontType0, error) {
+	font := &pdfFontType0{}
+
+	if ind, is := obj.(*core.PdfIndirectObject); is {
+		font.container = ind
+		obj = ind.PdfObject
+	}
+
+	d, ok := obj.(*core.PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("Font object invalid, not a dictionary (%T)", obj)
+		return nil, errors.New("Type check error")
+	}
+
+	base, err := newFontCommonFromDict(d, "Type0")
+	if err != nil {
+		return nil, err
+	}
+	font.fontCommon = base
+	font.BaseFont = d.Get("BaseFont")
+
+	font.Encoding = d.Get("Encoding")
+	switch enc := core.TraceToDirectObject(font.Encoding).(type) {
+	case *core.PdfObjectName:
+		switch string(*enc) {
+		case "Identity-H":
+		case "Identity-V":
+			font.vertical = true
+		default:
+			font.encoderCmap, err = cmap.LoadPredefinedCMap(string(*enc))
+			if err != nil {
+				common.Log.Debug("Predefined CMap %s not supported. Assuming Identity-H", *enc)
+				break
+			}
+			font.vertical = font.encoderCmap.Vertical()
+		}
+	case *core.PdfObjectStream:
+		font.encoderCmap, err = loadCmapFromStreamObject(enc)
+		if err != nil {
+			common.Log.Debug("Unable to load Encoding CMap: %v", err)
+			return nil, err
+		}
+		font.vertical = font.encoderCmap.Vertical()
+		if wmode, ok := core.TraceToDirectObject(enc.PdfObjectDictionary.Get("WMode")).(*core.PdfObjectInteger); ok {
+			font.vertical = *wmode == 1
+		}
+	default:
+		common.Log.Debug("Encoding (Required) missing or invalid (%T)", font.Encoding)
+		return nil, errors.New("Required attribute missing")
+	}
+
+	font.DescendantFonts = d.Get("DescendantFonts")
+	arr, ok := core.TraceToDirectObject(font.DescendantFonts).(*core.PdfObjectArray)
+	if !ok || len(*arr) != 1 {
+		common.Log.Debug("DescendantFonts (Required) missing or invalid (%T)", font.DescendantFonts)
+		return nil, errors.New("Required attribute missing")
+	}
+	font.DescendantFont, err = newPdfCIDFontFromPdfObject((*arr)[0])
+	if err != nil {
+		common.Log.Debug("Error loading descendant font: %v", err)
+		return nil, err
+	}
+
+	return font, nil
+}
+
+// ToPdfObject converts the font to a PDF representation.
+func (font *pdfFontType0) ToPdfObject() core.PdfObject {
+	if font.container == nil {
+		font.container = &core.PdfIndirectObject{}
+	}
+	d := core.MakeDict()
+	font.container.PdfObject = d
+
+	d.Set("Type", core.MakeName("Font"))
+	d.Set("Subtype", core.MakeName("Type0"))
+
+	d.SetIfNotNil("BaseFont", font.BaseFont)
+	d.SetIfNotNil("Encoding", font.Encoding)
+	if font.DescendantFont != nil {
+		d.Set("DescendantFonts", core.MakeArray(font.DescendantFont.ToPdfObject()))
+	} else {
+		d.SetIfNotNil("DescendantFonts", font.DescendantFonts)
+	}
+	d.SetIfNotNil("ToUnicode", font.toUnicode)
+
+	return font.container
+}
+
+// getCIDWidth returns the horizontal width of CID `cid`, or the default width DW if not in W.
+func (font *pdfCIDFont) getCIDWidth(cid uint16) float64 {
+	if w, ok := font.widths[cid]; ok {
+		return w
+	}
+	return font.dw
+}
+
+// getCIDVerticalMetrics returns the vertical displacement w1_y and the position vector (v_x, v_y) of
+// CID `cid`. The defaults from DW2 are used if `cid` is not in W2 (9.7.4.3 "Glyph Metrics in CIDFonts").
+func (font *pdfCIDFont) getCIDVerticalMetrics(cid uint16) (w1y, vx, vy float64) {
+	if m, ok := font.verticalMetrics[cid]; ok {
+		return m[0], m[1], m[2]
+	}
+	return font.dw2[1], font.getCIDWidth(cid) / 2, font.dw2[0]
+}
+
+// cidToUnicode returns the unicode string of CID `cid` of an Adobe CJK character collection, e.g.
+// Adobe-Japan1, from the collection's predefined UCS2 CMap. These CMaps map CIDs to UCS-2 codes and
+// are loaded from the directory set by SetPredefinedCMapDir.
+func (font *pdfCIDFont) cidToUnicode(cid uint16) (string, bool) {
+	switch font.ordering {
+	case "GB1", "CNS1", "Japan1", "Korea1":
+	default:
+		return "", false
+	}
+	if font.registry != "Adobe" {
+		return "", false
+	}
+	ucs2, err := cmap.LoadPredefinedCMap("Adobe-" + font.ordering + "-UCS2")
+	if err != nil {
+		return "", false
+	}
+	if s, ok := ucs2.Lookup(uint64(cid)); ok {
+		return s, true
+	}
+	if u, ok := ucs2.CharcodeToCID(uint64(cid)); ok && u != 0 {
+		return string(rune(u)), true
+	}
+	return "", false
+}
+
+// getGID returns the glyph index of CID `cid` in the embedded font program.
+func (font *pdfCIDFont) getGID(cid uint16) uint16 {
+	if font.cidToGID == nil {
+		return cid
+	}
+	if int(cid) >= len(font.cidToGID) {
+		return 0
+	}
+	return font.cidToGID[cid]
+}
+
+// newPdfCIDFontFromPdfObject loads a CIDFont from font dictionary `obj`.
+func newPdfCIDFontFromPdfObject(obj core.PdfObject) (*pdfCIDFont, error) {
+	font := &pdfCIDFont{}
+
+	if ind, is := obj.(*core.PdfIndirectObject); is {
+		font.container = ind
+		obj = ind.PdfObject
+	}
+
+	d, ok := obj.(*core.PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("CIDFont object invalid, not a dictionary (%T)", obj)
+		return nil, errors.New("Type check error")
+	}
+
+	subtype, ok := core.TraceToDirectObject(d.Get("Subtype")).(*core.PdfObjectName)
+	if !ok || (*subtype != "CIDFontType0" && *subtype != "CIDFontType2") {
+		common.Log.Debug("Invalid CIDFont Subtype (%v)", d.Get("Subtype"))
+		return nil, errors.New("Range check error")
+	}
+
+	base, err := newFontCommonFromDict(d, string(*subtype))
+	if err != nil {
+		return nil, err
+	}
+	font.fontCommon = base
+	font.BaseFont = d.Get("BaseFont")
+
+	font.CIDSystemInfo = d.Get("CIDSystemInfo")
+	if info, ok := core.TraceToDirectObject(font.CIDSystemInfo).(*core.PdfObjectDictionary); ok {
+		if s, ok := core.TraceToDirectObject(info.Get("Registry")).(*core.PdfObjectString); ok {
+			font.registry = string(*s)
+		}
+		if s, ok := core.TraceToDirectObject(info.Get("Ordering")).(*core.PdfObjectString); ok {
+			font.ordering = string(*s)
+		}
+	} else {
+		common.Log.Debug("Incompatibility: CIDSystemInfo (Required) missing or invalid")
+	}
+
+	font.DW = d.Get("DW")
+	font.dw = 1000
+	if font.DW != nil {
+		font.dw, err = getNumberAsFloat(core.TraceToDirectObject(font.DW))
+		if err != nil {
+			common.Log.Debug("Invalid DW (%T)", font.DW)
+			return nil, err
+		}
+	}
+
+	font.W = d.Get("W")
+	font.widths = map[uint16]float64{}
+	if arr, ok := core.TraceToDirectObject(font.W).(*core.PdfObjectArray); ok {
+		err := parseCIDMetricsArray(arr, 1, func(cid uint16, vals []float64) {
+			font.widths[cid] = vals[0]
+		})
+		if err != nil {
+			common.Log.Debug("Invalid W array: %v", err)
+			return nil, err
+		}
+	}
+
+	font.DW2 = d.Get("DW2")
+	font.dw2 = [2]float64{880, -1000}
+	if arr, ok := core.TraceToDirectObject(font.DW2).(*core.PdfObjectArray); ok {
+		vals, err := arr.GetAsFloat64Slice()
+		if err != nil || len(vals) != 2 {
+			common.Log.Debug("Invalid DW2 array (%v)", font.DW2)
+			return nil, errors.New("Range check error")
+		}
+		font.dw2 = [2]float64{vals[0], vals[1]}
+	}
+
+	font.W2 = d.Get("W2")
+	font.verticalMetrics = map[uint16][3]float64{}
+	if arr, ok := core.TraceToDirectObject(font.W2).(*core.PdfObjectArray); ok {
+		err := parseCIDMetricsArray(arr, 3, func(cid uint16, vals []float64) {
+			font.verticalMetrics[cid] = [3]float64{vals[0], vals[1], vals[2]}
+		})
+		if err != nil {
+			common.Log.Debug("Invalid W2 array: %v", err)
+			return nil, err
+		}
+	}
+
+	font.CIDToGIDMap = d.Get("CIDToGIDMap")
+	if stream, ok := core.TraceToDirectObject(font.CIDToGIDMap).(*core.PdfObjectStream); ok {
+		data, err := core.DecodeStream(stream)
+		if err != nil {
+			return nil, err
+		}
+		font.cidToGID = make([]uint16, len(data)/2)
+		for i := range font.cidToGID {
+			font.cidToGID[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		}
+	}
+
+	return font, nil
+}
+
+// maxCID is the largest CID (CIDs are 2-byte values).
+const maxCID = 0xFFFF
+
+// parseCIDMetricsArray parses a CIDFont W or W2 array, where each CID has `n` metric values, and
+// calls `set` for each CID. The arrays consist of entries in either of the forms
+//
+//	c [v1 v2 ... vn  v1 v2 ... vn ...]  - metrics of consecutive CIDs starting from c
+//	cfirst clast v1 v2 ... vn           - the same metrics for all CIDs in the range [cfirst, clast]
+func parseCIDMetricsArray(arr *core.PdfObjectArray, n int, set func(cid uint16, vals []float64)) error {
+	objs := *arr
+	for i := 0; i < len(objs); {
+		first, err := getNumberAsInt64(core.TraceToDirectObject(objs[i]))
+		if err != nil || i+1 >= len(objs) {
+			return errors.New("Invalid CID metrics array")
+		}
+
+		if sub, ok := core.TraceToDirectObject(objs[i+1]).(*core.PdfObjectArray); ok {
+			vals, err := sub.GetAsFloat64Slice()
+			if err != nil {
+				return err
+			}
+			for j := 0; j+n <= len(vals); j += n {
+				cid := first + int64(j/n)
+				if cid < 0 || cid > maxCID {
+					break
+				}
+				set(uint16(cid), vals[j:j+n])
+			}
+			i += 2
+			continue
+		}
+
+		if i+2+n > len(objs) {
+			return errors.New("Invalid CID metrics array range")
+		}
+		last, err := getNumberAsInt64(core.TraceToDirectObject(objs[i+1]))
+		if err != nil {
+			return err
+		}
+		vals, err := getNumbersAsFloat(traceObjects(objs[i+2 : i+2+n]))
+		if err != nil {
+			return err
+		}
+		// CIDs are 16 bit values. Ranges are clamped to them, so that they cannot be unbounded.
+		if first < 0 {
+			first = 0
+		}
+		if last > maxCID {
+			last = maxCID
+		}
+		for cid := first; cid <= last; cid++ {
+			set(uint16(cid), vals)
+		}
+		i += 2 + n
+	}
+	return nil
+}
+
+// traceObjects returns the direct objects of `objs`.
+func traceObjects(objs []core.PdfObject) []core.PdfObject {
+	direct := make([]core.PdfObject, len(objs))
+	for i, o := range objs {
+		direct[i] = core.TraceToDirectObject(o)
+	}
+	return direct
+}
+
+// ToPdfObject converts the CIDFont to a PDF representation.
+func (font *pdfCIDFont) ToPdfObject() core.PdfObject {
+	if font.container == nil {
+		font.container = &core.PdfIndirectObject{}
+	}
+	d := core.MakeDict()
+	font.container.PdfObject = d
+
+	d.Set("Type", core.MakeName("Font"))
+	d.Set("Subtype", core.MakeName(font.subtype))
+
+	d.SetIfNotNil("BaseFont", font.BaseFont)
+	d.SetIfNotNil("CIDSystemInfo", font.CIDSystemInfo)
+	if font.fontDescriptor != nil {
+		d.Set("FontDescriptor", font.fontDescriptor.ToPdfObject())
+	}
+	d.SetIfNotNil("DW", font.DW)
+	d.SetIfNotNil("W", font.W)
+	d.SetIfNotNil("DW2", font.DW2)
+	d.SetIfNotNil("W2", font.W2)
+	d.SetIfNotNil("CIDToGIDMap", font.CIDToGIDMap)
+
+	return font.container
+}
+
+// NewCompositePdfFontFromOTFFile loads an OpenType font with CFF outlines from file `filePath` and
+// returns a Type0 font with Identity-H encoding and a CIDFontType0 descendant font. The glyph widths
+// are taken from the hmtx table and a ToUnicode CMap is generated from the cmap table.
+// A CID-keyed CFF table is embedded as a FontFile3 of subtype CIDFontType0C, other fonts are embedded
+// whole as a FontFile3 of subtype OpenType and their CIDs are glyph indexes.
+// Strings shown with the font are sequences of 2 byte CIDs. WOFF and WOFF2 fonts are accepted too.
+func NewCompositePdfFontFromOTFFile(filePath string) (*PdfFont, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		common.Log.Debug("Unable to open font file: %v", err)
+		return nil, err
+	}
+	defer f.Close()
+	otfBytes, err := fonts.ReadSfnt(f)
+	if err != nil {
+		common.Log.Debug("Unable to read font: %v", err)
+		return nil, err
+	}
+	ttf, err := fonts.TtfParseBytes(otfBytes)
+	if err != nil {
+		common.Log.Debug("Error loading otf font: %v", err)
+		return nil, err
+	}
+	if !ttf.HasCFF {
+		common.Log.Debug("Font %s has no CFF outlines", filePath)
+		return nil, errors.New("not an OpenType font with CFF outlines")
+	}
+	cffTable, err := fonts.GetSfntTable(otfBytes, "CFF ")
+	if err != nil {
+		return nil, err
+	}
+	cff, err := fonts.ParseCFF(cffTable)
+	if err != nil {
+		common.Log.Debug("Error loading CFF table: %v", err)
+		return nil, err
+	}
+
+	k := 1000.0 / float64(ttf.UnitsPerEm)
+
+	cidfont := &pdfCIDFont{}
+	cidfont.fontCommon = &fontCommon{subtype: "CIDFontType0", basefont: ttf.PostScriptName}
+	cidfont.BaseFont = core.MakeName(ttf.PostScriptName)
+	cidfont.registry, cidfont.ordering = "Adobe", "Identity"
+	if cff.IsCID() {
+		cidfont.registry, cidfont.ordering = cff.CIDSystemInfo()
+	}
+	cidSystemInfo := core.MakeDict()
+	cidSystemInfo.Set("Registry", core.MakeString(cidfont.registry))
+	cidSystemInfo.Set("Ordering", core.MakeString(cidfont.ordering))
+	cidSystemInfo.Set("Supplement", core.MakeInteger(0))
+	cidfont.CIDSystemInfo = cidSystemInfo
+	cidfont.dw2 = [2]float64{880, -1000}
+
+	// Widths by CID from hmtx.
+	cidfont.widths = map[uint16]float64{}
+	gidToCID := map[uint16]uint16{}
+	for gid := 0; gid < cff.NumGlyphs() && gid < len(ttf.Widths); gid++ {
+		cid, ok := cff.GIDToCID(gid)
+		if !ok {
+			continue
+		}
+		gidToCID[uint16(gid)] = cid
+		cidfont.widths[cid] = k * float64(ttf.Widths[gid])
+	}
+	cidfont.dw = 1000
+	cidfont.DW = core.MakeInteger(1000)
+	cidfont.W = makeCIDWidthsArray(cidfont.widths)
+
+	descriptor := &PdfFontDescriptor{}
+	descriptor.FontName = core.MakeName(ttf.PostScriptName)
+	descriptor.Ascent = core.MakeFloat(k * float64(ttf.TypoAscender))
+	descriptor.Descent = core.MakeFloat(k * float64(ttf.TypoDescender))
+	descriptor.CapHeight = core.MakeFloat(k * float64(ttf.CapHeight))
+	descriptor.FontBBox = core.MakeArrayFromFloats([]float64{k * float64(ttf.Xmin), k * float64(ttf.Ymin), k * float64(ttf.Xmax), k * float64(ttf.Ymax)})
+	descriptor.ItalicAngle = core.MakeFloat(float64(ttf.ItalicAngle))
+	if ttf.Bold {
+		descriptor.StemV = core.MakeInteger(120)
+	} else {
+		descriptor.StemV = core.MakeInteger(70)
+	}
+	// Flags: symbolic as the glyphs are not accessed by a standard Latin character set.
+	flags := 1 << 2
+	if ttf.IsFixedPitch {
+		flags |= 1
+	}
+	if ttf.ItalicAngle != 0 {
+		flags |= 1 << 6
+	}
+	descriptor.Flags = core.MakeInteger(int64(flags))
+
+	var stream *core.PdfObjectStream
+	if cff.IsCID() {
+		stream, err = core.MakeStream(cffTable, core.NewFlateEncoder())
+		if err == nil {
+			stream.PdfObjectDictionary.Set("Subtype", core.MakeName("CIDFontType0C"))
+		}
+	} else {
+		stream, err = core.MakeStream(otfBytes, core.NewFlateEncoder())
+		if err == nil {
+			stream.PdfObjectDictionary.Set("Subtype", core.MakeName("OpenType"))
+		}
+	}
+	if err != nil {
+		common.Log.Debug("Unable to make stream: %v", err)
+		return nil, err
+	}
+	descriptor.FontFile3 = stream
+	descriptor.fontProgram = cff
+	descriptor.fontProgramLoaded = true
+	cidfont.fontDescriptor = descriptor
+
+	// ToUnicode CMap from the font's unicode cmap subtable.
+	cidToRune := map[uint16]rune{}
+	for r, gid := range ttf.Chars {
+		if cid, ok := gidToCID[gid]; ok {
+			if prev, ok := cidToRune[cid]; !ok || rune(r) < prev {
+				cidToRune[cid] = rune(r)
+			}
+		}
+	}
+	toUnicodeData := cmap.MakeToUnicodeCMap(cidToRune)
+	toUnicode, err := core.MakeStream(toUnicodeData, core.NewFlateEncoder())
+	if err != nil {
+		common.Log.Debug("Unable to make stream: %v", err)
+		return nil, err
+	}
+	toUnicodeCmap, err := cmap.LoadCmapFromData(toUnicodeData)
+	if err != nil {
+		return nil, err
+	}
+
+	type0 := &pdfFontType0{}
+	type0.fontCommon = &fontCommon{
+		subtype:       "Type0",
+		basefont:      ttf.PostScriptName + "-Identity-H",
+		toUnicode:     toUnicode,
+		toUnicodeCmap: toUnicodeCmap,
+	}
+	type0.BaseFont = core.MakeName(type0.basefont)
+	type0.Encoding = core.MakeName("Identity-H")
+	type0.DescendantFont = cidfont
+
+	font := &PdfFont{}
+	font.context = type0
+
+	return font, nil
+}
+
+// makeCIDWidthsArray returns a W array for the CID to width map `widths`. Consecutive CIDs are
+// grouped in entries of the form c [w1 w2 ...].
+func makeCIDWidthsArray(widths map[uint16]float64) *core.PdfObjectArray {
+	cids := make([]int, 0, len(widths))
+	for cid := range widths {
+		cids = append(cids, int(cid))
+	}
+	sort.Ints(cids)
+
+	arr := core.MakeArray()
+	for i := 0; i < len(cids); {
+		j := i + 1
+		for j < len(cids) && cids[j] == cids[j-1]+1 {
+			j++
+		}
+		run := make([]float64, 0, j-i)
+		for _, cid := range cids[i:j] {
+			run = append(run, widths[uint16(cid)])
+		}
+		arr.Append(core.MakeInteger(int64(cids[i])))
+		arr.Append(core.MakeArrayFromFloats(run))
+		i = j
+	}
+	return arr
+}
@@ -0,0 +1,111 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+)
+
+// GetKerning returns the adjustment of the horizontal position of glyph `right` when it follows
+// glyph `left`, in thousandths of a unit of text space. Negative values move the glyphs closer.
+// Kerning is available for the standard 14 fonts (from their AFM metrics) and for simple fonts loaded
+// with NewPdfFontFromTTFFile (from the GPOS or kern tables).
+// The bool return flag is true if the font has kerning for the pair.
+func (font PdfFont) GetKerning(left, right string) (float64, bool) {
+	t, ok := font.context.(*pdfFontSimple)
+	if !ok {
+		return 0, false
+	}
+	if t.kerning != nil {
+		kx, has := t.kerning[fonts.GlyphPair{Left: left, Right: right}]
+		return kx, has
+	}
+	if kf, ok := t.std14.(fonts.KernedFont); ok {
+		return kf.GetKerning(left, right)
+	}
+	return 0, false
+}
+
+// GetLigature returns the name of the glyph that replaces glyph `left` followed by glyph `right` in
+// standard ligature substitution, e.g. "fi" for "f" followed by "i".
+// The bool return flag is true if the font has a ligature for the pair.
+func (font PdfFont) GetLigature(left, right string) (string, bool) {
+	t, ok := font.context.(*pdfFontSimple)
+	if !ok {
+		return "", false
+	}
+	if t.ligatures != nil {
+		ligature, has := t.ligatures[fonts.GlyphPair{Left: left, Right: right}]
+		return ligature, has
+	}
+	if lf, ok := t.std14.(fonts.LigatureFont); ok {
+		return lf.GetLigature(left, right)
+	}
+	return "", false
+}
+
+// loadSfntLayout loads the kerning and ligatures of the glyphs of the encoding of simple font `font`
+// from TrueType or OpenType font `data`. `ttf` holds the metrics of the font.
+func (font *pdfFontSimple) loadSfntLayout(ttf fonts.TtfType, data []byte) {
+	// Glyph names of the glyph indices of the encoded characters.
+	gidToGlyph := map[uint16]string{}
+	for charcode := font.firstChar; charcode <= font.lastChar; charcode++ {
+		glyph, ok := font.Encoder.CharcodeToGlyph(byte(charcode))
+		if !ok {
+			continue
+		}
+		r, ok := font.Encoder.CharcodeToRune(byte(charcode))
+		if !ok {
+			continue
+		}
+		if gid, ok := ttf.Chars[uint16(r)]; ok && gid != 0 {
+			gidToGlyph[gid] = glyph
+		}
+	}
+
+	kerning, err := fonts.ParseSfntKerning(data)
+	if err != nil {
+		common.Log.Debug("Unable to load kerning: %v", err)
+	}
+	if kerning != nil {
+		k := 1000.0 / float64(ttf.UnitsPerEm)
+		font.kerning = map[fonts.GlyphPair]float64{}
+		for left, leftGlyph := range gidToGlyph {
+			for right, rightGlyph := range gidToGlyph {
+				if kx, ok := kerning.GetKerning(left, right); ok && kx != 0 {
+					font.kerning[fonts.GlyphPair{Left: leftGlyph, Right: rightGlyph}] = k * float64(kx)
+				}
+			}
+		}
+	}
+
+	ligatures, err := fonts.ParseSfntLigatures(data)
+	if err != nil {
+		common.Log.Debug("Unable to load ligatures: %v", err)
+	}
+	if len(ligatures) > 0 {
+		// Ligature glyphs are named by the glyph names of the runes they are mapped to in the cmap.
+		gidToRune := map[uint16]rune{}
+		for r, gid := range ttf.Chars {
+			if old, has := gidToRune[gid]; !has || rune(r) < old {
+				gidToRune[gid] = rune(r)
+			}
+		}
+		font.ligatures = map[fonts.GlyphPair]string{}
+		for pair, gid := range ligatures {
+			left, ok1 := gidToGlyph[pair.Left]
+			right, ok2 := gidToGlyph[pair.Right]
+			r, ok3 := gidToRune[gid]
+			if !ok1 || !ok2 || !ok3 {
+				continue
+			}
+			if glyph, ok := font.Encoder.RuneToGlyph(r); ok {
+				font.ligatures[fonts.GlyphPair{Left: left, Right: right}] = glyph
+			}
+		}
+	}
+}
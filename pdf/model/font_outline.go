@@ -0,0 +1,166 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// GetGlyphOutline returns the outline of the glyph of character code `code` in the font program
+// embedded in the font: Type 1 (FontFile), CFF (FontFile3) or TrueType (FontFile2). The outline is in
+// unscaled text space units, i.e. glyph space mapped by the font matrix, so it is scaled by the font
+// size when shown.
+// The bool return flag is false if the font has no embedded font program or the glyph is not in it.
+func (font PdfFont) GetGlyphOutline(code uint64) (*fonts.GlyphOutline, bool) {
+	descriptor := font.GetFontDescriptor()
+	if descriptor == nil {
+		return nil, false
+	}
+
+	var outline *fonts.GlyphOutline
+	var matrix [6]float64
+	var err error
+	program, _ := descriptor.getFontProgram()
+	trueType := descriptor.getTrueTypeProgram()
+
+	switch t := font.context.(type) {
+	case *pdfFontSimple:
+		switch {
+		case program != nil:
+			glyph, ok := t.charcodeToGlyph(code)
+			if !ok {
+				glyph, ok = program.BuiltinEncoding()[byte(code)]
+			}
+			if !ok {
+				return nil, false
+			}
+			outline, err = program.GlyphOutline(glyph)
+			matrix = program.FontMatrix()
+		case trueType != nil:
+			outline, err = trueType.GIDOutline(t.trueTypeGID(trueType, code))
+			matrix = trueTypeMatrix(trueType)
+		default:
+			return nil, false
+		}
+	case *pdfFontType0:
+		if t.DescendantFont == nil {
+			return nil, false
+		}
+		cid, _ := t.charcodeToCID(code)
+		switch {
+		case program != nil:
+			cff, ok := program.(*fonts.CFFFont)
+			if !ok {
+				return nil, false
+			}
+			gid, ok := cff.CIDToGID(cid)
+			if !ok {
+				return nil, false
+			}
+			outline, err = cff.GIDOutline(gid)
+			matrix = cff.FontMatrix()
+		case trueType != nil:
+			outline, err = trueType.GIDOutline(int(t.DescendantFont.getGID(cid)))
+			matrix = trueTypeMatrix(trueType)
+		default:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	if err != nil {
+		common.Log.Debug("Unable to load outline of code 0x%04x (%s): %v", code, font.BaseFont(), err)
+		return nil, false
+	}
+
+	// The outlines are cached by the font programs, so a copy is transformed.
+	transformed := &fonts.GlyphOutline{Segments: make([]fonts.OutlineSegment, len(outline.Segments))}
+	for i, seg := range outline.Segments {
+		transformed.Segments[i].Op = seg.Op
+		for j := 0; j < seg.NumPoints(); j++ {
+			p := seg.Points[j]
+			transformed.Segments[i].Points[j] = fonts.OutlinePoint{
+				X: matrix[0]*p.X + matrix[2]*p.Y + matrix[4],
+				Y: matrix[1]*p.X + matrix[3]*p.Y + matrix[5],
+			}
+		}
+	}
+	return transformed, true
+}
+
+// trueTypeGID returns the glyph index of character code `code` of a simple font in its TrueType font
+// program (9.6.6.4 "Encodings for TrueType Fonts"). The glyph name of the code is mapped to unicode
+// for the (3, 1) cmap subtable, symbolic fonts map the code by the (3, 0) or (1, 0) subtables. If
+// there is no mapping, the code is used as the glyph index.
+func (font *pdfFontSimple) trueTypeGID(program *fonts.TrueTypeFont, code uint64) int {
+	if glyph, ok := font.charcodeToGlyph(code); ok {
+		if r, ok := textencoding.GlyphToRune(glyph); ok {
+			if gid, ok := program.CmapLookup(3, 1, uint32(r)); ok {
+				return gid
+			}
+		}
+	}
+	for _, c := range []uint32{0xf000 + uint32(code), uint32(code), 0xf100 + uint32(code), 0xf200 + uint32(code)} {
+		if gid, ok := program.CmapLookup(3, 0, c); ok {
+			return gid
+		}
+	}
+	if gid, ok := program.CmapLookup(1, 0, uint32(code)); ok {
+		return gid
+	}
+	return int(code)
+}
+
+// trueTypeMatrix returns the font matrix of TrueType font `program`.
+func trueTypeMatrix(program *fonts.TrueTypeFont) [6]float64 {
+	scale := 1.0 / float64(program.UnitsPerEm())
+	return [6]float64{scale, 0, 0, scale, 0, 0}
+}
+
+// getTrueTypeProgram returns the parsed TrueType font program of the FontFile2 entry of the
+// descriptor, or of a FontFile3 entry with an OpenType font with TrueType outlines. The result is
+// cached. Returns nil if there is no such program or it is invalid.
+func (this *PdfFontDescriptor) getTrueTypeProgram() *fonts.TrueTypeFont {
+	if this.trueTypeLoaded {
+		return this.trueTypeProgram
+	}
+	this.trueTypeLoaded = true
+
+	obj := this.FontFile2
+	if obj == nil && this.FontFile3 != nil {
+		if stream, ok := core.TraceToDirectObject(this.FontFile3).(*core.PdfObjectStream); ok {
+			if subtype, ok := core.TraceToDirectObject(stream.Get("Subtype")).(*core.PdfObjectName); ok &&
+				*subtype == "OpenType" {
+				obj = stream
+			}
+		}
+	}
+	if obj == nil {
+		return nil
+	}
+	stream, ok := core.TraceToDirectObject(obj).(*core.PdfObjectStream)
+	if !ok {
+		common.Log.Debug("TrueType font program not a stream (%T)", obj)
+		return nil
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to decode TrueType font program: %v", err)
+		return nil
+	}
+	if _, err := fonts.GetSfntTable(data, "glyf"); err != nil {
+		// OpenType fonts with CFF outlines are loaded by getFontProgram.
+		return nil
+	}
+	this.trueTypeProgram, err = fonts.ParseTrueType(data)
+	if err != nil {
+		common.Log.Debug("Unable to load TrueType font program: %v", err)
+	}
+	return this.trueTypeProgram
+}
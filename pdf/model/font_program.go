@@ -0,0 +1,273 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// GetFontProgram returns the parsed Type 1 (FontFile) or CFF (FontFile3 of subtype Type1C,
+// CIDFontType0C or OpenType with a CFF table) font program embedded in the font. The program is
+// parsed on first use.
+// Returns nil and no error if the font has no embedded font program of these types.
+func (font PdfFont) GetFontProgram() (fonts.FontProgram, error) {
+	descriptor := font.GetFontDescriptor()
+	if descriptor == nil {
+		return nil, nil
+	}
+	return descriptor.getFontProgram()
+}
+
+// getFontProgram returns the parsed font program of the FontFile or FontFile3 entry of the
+// descriptor. The result is cached.
+func (this *PdfFontDescriptor) getFontProgram() (fonts.FontProgram, error) {
+	if !this.fontProgramLoaded {
+		this.fontProgram, this.fontProgramErr = this.loadFontProgram()
+		this.fontProgramLoaded = true
+		if this.fontProgramErr != nil {
+			common.Log.Debug("Unable to load font program: %v", this.fontProgramErr)
+		}
+	}
+	return this.fontProgram, this.fontProgramErr
+}
+
+// loadFontProgram parses the font program of the FontFile or FontFile3 entry of the descriptor.
+func (this *PdfFontDescriptor) loadFontProgram() (fonts.FontProgram, error) {
+	if this.FontFile != nil {
+		stream, ok := core.TraceToDirectObject(this.FontFile).(*core.PdfObjectStream)
+		if !ok {
+			common.Log.Debug("FontFile not a stream (%T)", this.FontFile)
+			return nil, errors.New("Type check error")
+		}
+		data, err := core.DecodeStream(stream)
+		if err != nil {
+			return nil, err
+		}
+
+		// Length1 and Length2 give the lengths of the clear text and encrypted portions.
+		length1, ok1 := core.TraceToDirectObject(stream.Get("Length1")).(*core.PdfObjectInteger)
+		length2, ok2 := core.TraceToDirectObject(stream.Get("Length2")).(*core.PdfObjectInteger)
+		if ok1 && ok2 {
+			l1, l2 := int(*length1), int(*length2)
+			if l1 > 0 && l2 > 0 && l1+l2 <= len(data) {
+				font, err := fonts.ParseType1FontSegments(data[:l1], data[l1:l1+l2])
+				if err == nil {
+					return font, nil
+				}
+				common.Log.Debug("Invalid FontFile Length1/Length2 (%d %d): %v", l1, l2, err)
+			}
+		}
+		return fonts.ParseType1Font(data)
+	}
+
+	if this.FontFile3 != nil {
+		stream, ok := core.TraceToDirectObject(this.FontFile3).(*core.PdfObjectStream)
+		if !ok {
+			common.Log.Debug("FontFile3 not a stream (%T)", this.FontFile3)
+			return nil, errors.New("Type check error")
+		}
+		subtype, _ := core.TraceToDirectObject(stream.Get("Subtype")).(*core.PdfObjectName)
+		if subtype == nil {
+			common.Log.Debug("FontFile3 Subtype missing")
+			return nil, nil
+		}
+		data, err := core.DecodeStream(stream)
+		if err != nil {
+			return nil, err
+		}
+		switch *subtype {
+		case "Type1C", "CIDFontType0C":
+			return fonts.ParseCFF(data)
+		case "OpenType":
+			// OpenType fonts with TrueType outlines have no CFF table.
+			if _, err := fonts.GetSfntTable(data, "CFF "); err != nil {
+				return nil, nil
+			}
+			return fonts.ParseOpenTypeCFF(data)
+		}
+		common.Log.Debug("FontFile3 subtype %s not supported", *subtype)
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+// getProgramAdvance returns the advance width of glyph `glyph` in the embedded font program in
+// thousandths of a unit of text space.
+func (this *PdfFontDescriptor) getProgramAdvance(glyph string) (float64, bool) {
+	if this == nil {
+		return 0, false
+	}
+	program, err := this.getFontProgram()
+	if program == nil || err != nil {
+		return 0, false
+	}
+	w, ok := program.GlyphAdvance(glyph)
+	if !ok {
+		return 0, false
+	}
+	return w * program.FontMatrix()[0] * 1000.0, true
+}
+
+// WidthMismatch describes a character code whose width in the font dictionary differs from the
+// advance width of its glyph in the embedded font program.
+type WidthMismatch struct {
+	Code         uint64
+	GlyphName    string
+	Width        float64 // Width in the font dictionary.
+	ProgramWidth float64 // Advance width in the font program.
+}
+
+// ValidateWidths compares the glyph widths in the font dictionary (Widths for simple fonts, W for
+// CIDFontType0 fonts) with the advance widths in the embedded Type 1 or CFF font program. Widths that
+// differ by more than `tolerance` thousandths of a text space unit are returned.
+func (font PdfFont) ValidateWidths(tolerance float64) ([]WidthMismatch, error) {
+	descriptor := font.GetFontDescriptor()
+	if descriptor == nil {
+		return nil, errors.New("font has no font descriptor")
+	}
+	program, err := descriptor.getFontProgram()
+	if err != nil {
+		return nil, err
+	}
+	if program == nil {
+		return nil, errors.New("font has no Type 1 or CFF font program")
+	}
+	scale := program.FontMatrix()[0] * 1000.0
+
+	var mismatches []WidthMismatch
+	check := func(code uint64, glyph string, width float64) {
+		w, ok := program.GlyphAdvance(glyph)
+		if !ok {
+			return
+		}
+		if w *= scale; math.Abs(w-width) > tolerance {
+			mismatches = append(mismatches, WidthMismatch{Code: code, GlyphName: glyph, Width: width, ProgramWidth: w})
+		}
+	}
+
+	switch t := font.context.(type) {
+	case *pdfFontSimple:
+		for i, width := range t.charWidths {
+			code := uint64(t.firstChar + i)
+			if glyph, ok := t.charcodeToGlyph(code); ok {
+				check(code, glyph, width)
+			}
+		}
+	case *pdfFontType0:
+		cff, ok := program.(*fonts.CFFFont)
+		if !ok || t.DescendantFont == nil {
+			return nil, errors.New("widths validation not supported for font")
+		}
+		names := cff.GlyphNames()
+		for cid, width := range t.DescendantFont.widths {
+			if gid, ok := cff.CIDToGID(cid); ok {
+				check(uint64(cid), names[gid], width)
+			}
+		}
+	default:
+		return nil, errors.New("widths validation not supported for font")
+	}
+	return mismatches, nil
+}
+
+// NewPdfFontFromType1File loads a Type 1 font from .pfb or .pfa file `filePath` and returns a simple
+// font with WinAnsiEncoding and the font program embedded.
+func NewPdfFontFromType1File(filePath string) (*PdfFont, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		common.Log.Debug("Unable to read file contents: %v", err)
+		return nil, err
+	}
+	t1, err := fonts.ParseType1Font(data)
+	if err != nil {
+		common.Log.Debug("Error loading type1 font: %v", err)
+		return nil, err
+	}
+
+	type1font := &pdfFontSimple{}
+	type1font.fontCommon = &fontCommon{subtype: "Type1", basefont: t1.Name()}
+	type1font.Encoder = textencoding.NewWinAnsiTextEncoder()
+	type1font.firstChar = 32
+	type1font.lastChar = 255
+
+	type1font.BaseFont = core.MakeName(t1.Name())
+	type1font.FirstChar = core.MakeInteger(32)
+	type1font.LastChar = core.MakeInteger(255)
+	type1font.Encoding = core.MakeName("WinAnsiEncoding")
+
+	k := t1.FontMatrix()[0] * 1000.0
+	missingWidth, _ := t1.GlyphAdvance(".notdef")
+	missingWidth *= k
+
+	vals := []float64{}
+	for charcode := 32; charcode <= 255; charcode++ {
+		w := missingWidth
+		if glyph, ok := type1font.Encoder.CharcodeToGlyph(byte(charcode)); ok {
+			if adv, ok := t1.GlyphAdvance(glyph); ok {
+				w = k * adv
+			} else {
+				common.Log.Debug("Glyph %s not in font %s", glyph, t1.Name())
+			}
+		}
+		vals = append(vals, w)
+	}
+	type1font.Widths = &core.PdfIndirectObject{PdfObject: core.MakeArrayFromFloats(vals)}
+	type1font.charWidths = vals
+
+	bbox := t1.FontBBox()
+	descriptor := &PdfFontDescriptor{}
+	descriptor.FontName = core.MakeName(t1.Name())
+	descriptor.FontBBox = core.MakeArrayFromFloats([]float64{k * bbox[0], k * bbox[1], k * bbox[2], k * bbox[3]})
+	descriptor.ItalicAngle = core.MakeFloat(t1.ItalicAngle())
+	descriptor.Ascent = core.MakeFloat(k * bbox[3])
+	descriptor.Descent = core.MakeFloat(k * bbox[1])
+	descriptor.CapHeight = core.MakeFloat(k * bbox[3])
+	if outline, err := t1.GlyphOutline("H"); err == nil && len(outline.Segments) > 0 {
+		_, _, _, ury := outline.Bounds()
+		descriptor.CapHeight = core.MakeFloat(k * ury)
+	}
+	descriptor.StemV = core.MakeInteger(80)
+	descriptor.MissingWidth = core.MakeFloat(missingWidth)
+
+	// Flags.
+	flags := 1 << 5
+	if t1.IsFixedPitch() {
+		flags |= 1
+	}
+	if t1.ItalicAngle() != 0 {
+		flags |= 1 << 6
+	}
+	descriptor.Flags = core.MakeInteger(int64(flags))
+
+	cleartext, encrypted := t1.GetFontFileSegments()
+	fontFile := append(append([]byte{}, cleartext...), encrypted...)
+	stream, err := core.MakeStream(fontFile, core.NewFlateEncoder())
+	if err != nil {
+		common.Log.Debug("Unable to make stream: %v", err)
+		return nil, err
+	}
+	stream.PdfObjectDictionary.Set("Length1", core.MakeInteger(int64(len(cleartext))))
+	stream.PdfObjectDictionary.Set("Length2", core.MakeInteger(int64(len(encrypted))))
+	stream.PdfObjectDictionary.Set("Length3", core.MakeInteger(0))
+	descriptor.FontFile = stream
+	descriptor.fontProgram = t1
+	descriptor.fontProgramLoaded = true
+
+	type1font.fontDescriptor = descriptor
+
+	font := &PdfFont{}
+	font.context = type1font
+
+	return font, nil
+}
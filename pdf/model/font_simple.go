@@ -0,0 +1,403 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// pdfFontSimple represents a simple font: Type1, MMType1 or TrueType.
+// Simple fonts have single byte character codes and their glyph widths are given by the FirstChar,
+// LastChar and Widths entries. The widths of the standard 14 fonts may be omitted, in which case the
+// built-in metrics are used.
+type pdfFontSimple struct {
+	*fontCommon
+	Encoder textencoding.TextEncoder
+
+	firstChar  int
+	lastChar   int
+	charWidths []float64
+
+	// Built-in metrics if the font is one of the standard 14 fonts, nil otherwise.
+	std14 fonts.Font
+
+	// Kerning (in thousandths of a unit of text space) and ligatures by glyph name, loaded from the
+	// font file for fonts created with NewPdfFontFromTTFFile. nil otherwise.
+	kerning   map[fonts.GlyphPair]float64
+	ligatures map[fonts.GlyphPair]string
+
+	// Subtype shall be Type1, MMType1 or TrueType.
+	// Encoding is subject to limitations that are described in 9.6.6, "Character Encoding".
+	// BaseFont is derived differently.
+	BaseFont  core.PdfObject
+	FirstChar core.PdfObject
+	LastChar  core.PdfObject
+	Widths    core.PdfObject
+	Encoding  core.PdfObject
+
+	container *core.PdfIndirectObject
+}
+
+// standard14Fonts maps the names of the standard 14 fonts to their built-in metrics.
+var standard14Fonts = map[string]fonts.Font{
+	"Courier":               fonts.NewFontCourier(),
+	"Courier-Bold":          fonts.NewFontCourierBold(),
+	"Courier-BoldOblique":   fonts.NewFontCourierBoldOblique(),
+	"Courier-Oblique":       fonts.NewFontCourierOblique(),
+	"Helvetica":             fonts.NewFontHelvetica(),
+	"Helvetica-Bold":        fonts.NewFontHelveticaBold(),
+	"Helvetica-BoldOblique": fonts.NewFontHelveticaBoldOblique(),
+	"Helvetica-Oblique":     fonts.NewFontHelveticaOblique(),
+	"Times-Roman":           fonts.NewFontTimesRoman(),
+	"Times-Bold":            fonts.NewFontTimesBold(),
+	"Times-BoldItalic":      fonts.NewFontTimesBoldItalic(),
+	"Times-Italic":          fonts.NewFontTimesItalic(),
+	"Symbol":                fonts.NewFontSymbol(),
+	"ZapfDingbats":          fonts.NewFontZapfDingbats(),
+}
+
+// standard14Aliases maps commonly used alternative names to the names of the standard 14 fonts
+// (Implementation note 62 in the PDF 1.7 Reference).
+var standard14Aliases = map[string]string{
+	"Arial":                    "Helvetica",
+	"Arial,Bold":               "Helvetica-Bold",
+	"Arial,BoldItalic":         "Helvetica-BoldOblique",
+	"Arial,Italic":             "Helvetica-Oblique",
+	"CourierNew":               "Courier",
+	"CourierNew,Bold":          "Courier-Bold",
+	"CourierNew,BoldItalic":    "Courier-BoldOblique",
+	"CourierNew,Italic":        "Courier-Oblique",
+	"TimesNewRoman":            "Times-Roman",
+	"TimesNewRoman,Bold":       "Times-Bold",
+	"TimesNewRoman,BoldItalic": "Times-BoldItalic",
+	"TimesNewRoman,Italic":     "Times-Italic",
+}
+
+// getStandard14Font returns the built-in metrics for the standard 14 font `basefont`.
+// The bool return flag is true if `basefont` is (an alias of) a standard 14 font.
+func getStandard14Font(basefont string) (fonts.Font, bool) {
+	if alias, ok := standard14Aliases[basefont]; ok {
+		basefont = alias
+	}
+	font, ok := standard14Fonts[basefont]
+	return font, ok
+}
+
+// SetEncoder sets the encoding of the font.
+func (font *pdfFontSimple) SetEncoder(encoder textencoding.TextEncoder) {
+	font.Encoder = encoder
+}
+
+// GetGlyphCharMetrics returns the metrics of the glyph with name `glyph`.
+func (font *pdfFontSimple) GetGlyphCharMetrics(glyph string) (fonts.CharMetrics, bool) {
+	if font.Encoder != nil {
+		if code, found := font.Encoder.GlyphToCharcode(glyph); found {
+			if metrics, ok := font.getWidthsMetrics(uint64(code)); ok {
+				metrics.GlyphName = glyph
+				return metrics, true
+			}
+		}
+	}
+
+	if font.std14 != nil {
+		return font.std14.GetGlyphCharMetrics(glyph)
+	}
+
+	return fonts.CharMetrics{}, false
+}
+
+// getCharMetrics returns the metrics of the glyph with character code `code`.
+// The Widths array takes precedence, then the built-in metrics of the standard 14 fonts, then the
+// advance widths of the embedded font program and lastly the MissingWidth of the font descriptor.
+func (font *pdfFontSimple) getCharMetrics(code uint64) (fonts.CharMetrics, bool) {
+	glyph, _ := font.charcodeToGlyph(code)
+
+	if metrics, ok := font.getWidthsMetrics(code); ok {
+		metrics.GlyphName = glyph
+		return metrics, true
+	}
+
+	if font.std14 != nil && glyph != "" {
+		if metrics, ok := font.std14.GetGlyphCharMetrics(glyph); ok {
+			return metrics, true
+		}
+	}
+
+	if glyph != "" {
+		if w, ok := font.fontDescriptor.getProgramAdvance(glyph); ok {
+			return fonts.CharMetrics{GlyphName: glyph, Wx: w}, true
+		}
+	}
+
+	if font.fontDescriptor != nil && font.fontDescriptor.MissingWidth != nil {
+		return fonts.CharMetrics{GlyphName: glyph, Wx: getMissingWidth(font.fontDescriptor)}, true
+	}
+
+	return fonts.CharMetrics{}, false
+}
+
+// getWidthsMetrics returns the metrics of the glyph with character code `code` from the font's
+// Widths array.
+func (font *pdfFontSimple) getWidthsMetrics(code uint64) (fonts.CharMetrics, bool) {
+	metrics := fonts.CharMetrics{}
+
+	if int(code) < font.firstChar {
+		common.Log.Trace("Code lower than firstchar (%d < %d)", code, font.firstChar)
+		return metrics, false
+	}
+
+	if int(code) > font.lastChar {
+		common.Log.Trace("Code higher than lastchar (%d > %d)", code, font.lastChar)
+		return metrics, false
+	}
+
+	index := int(code) - font.firstChar
+	if index >= len(font.charWidths) {
+		common.Log.Trace("Code outside of widths range")
+		return metrics, false
+	}
+
+	metrics.Wx = font.charWidths[index]
+	return metrics, true
+}
+
+// bytesToCharcodes returns the bytes of `data` as character codes.
+func (font *pdfFontSimple) bytesToCharcodes(data []byte) []uint64 {
+	codes := make([]uint64, len(data))
+	for i, b := range data {
+		codes[i] = uint64(b)
+	}
+	return codes
+}
+
+// charcodeToUnicode returns the unicode string for character code `code` as determined by the font's
+// encoding.
+func (font *pdfFontSimple) charcodeToUnicode(code uint64) (string, bool) {
+	if code > 0xff || font.Encoder == nil {
+		return "", false
+	}
+	r, ok := font.Encoder.CharcodeToRune(byte(code))
+	if !ok {
+		return "", false
+	}
+	return string(r), true
+}
+
+// charcodeToGlyph returns the name of the glyph that character code `code` maps to.
+func (font *pdfFontSimple) charcodeToGlyph(code uint64) (string, bool) {
+	if code > 0xff || font.Encoder == nil {
+		return "", false
+	}
+	return font.Encoder.CharcodeToGlyph(byte(code))
+}
+
+// getBuiltinEncoding returns the built-in encoding of the font's embedded Type 1 or CFF font program.
+// Returns nil if the font has no such program.
+func (font *pdfFontSimple) getBuiltinEncoding() map[byte]string {
+	if font.fontDescriptor == nil {
+		return nil
+	}
+	if font.subtype != "Type1" && font.subtype != "MMType1" {
+		return nil
+	}
+	program, err := font.fontDescriptor.getFontProgram()
+	if program == nil || err != nil {
+		return nil
+	}
+	return program.BuiltinEncoding()
+}
+
+// newPdfFontSimpleFromPdfObject loads a simple font of type `subtype` from font dictionary `obj`.
+func newPdfFontSimpleFromPdfObject(obj core.PdfObject, subtype string) (*pdfFontSimple, error) {
+	font := &pdfFontSimple{}
+
+	if ind, is := obj.(*core.PdfIndirectObject); is {
+		font.container = ind
+		obj = ind.PdfObject
+	}
+
+	d, ok := obj.(*core.PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("Font object invalid, not a dictionary (%T)", obj)
+		return nil, errors.New("Type check error")
+	}
+
+	base, err := newFontCommonFromDict(d, subtype)
+	if err != nil {
+		return nil, err
+	}
+	font.fontCommon = base
+	font.BaseFont = d.Get("BaseFont")
+
+	if std14, ok := getStandard14Font(base.basefont); ok {
+		font.std14 = std14
+	}
+
+	if err := font.loadWidths(d); err != nil {
+		return nil, err
+	}
+
+	font.Encoding = d.Get("Encoding")
+	font.Encoder = font.getEncoder()
+
+	return font, nil
+}
+
+// loadWidths loads the FirstChar, LastChar and Widths entries of font dictionary `d`.
+// The entries may be omitted for the standard 14 fonts.
+func (font *pdfFontSimple) loadWidths(d *core.PdfObjectDictionary) error {
+	font.FirstChar = d.Get("FirstChar")
+	font.LastChar = d.Get("LastChar")
+	font.Widths = d.Get("Widths")
+
+	if font.FirstChar == nil || font.LastChar == nil || font.Widths == nil {
+		if font.std14 == nil {
+			common.Log.Debug("Incompatibility: FirstChar, LastChar or Widths missing from %s font %s",
+				font.subtype, font.basefont)
+		}
+		return nil
+	}
+
+	firstChar, widths, err := parseSimpleWidths(font.FirstChar, font.LastChar, font.Widths)
+	if err != nil {
+		return err
+	}
+	font.firstChar = firstChar
+	font.lastChar = firstChar + len(widths) - 1
+	font.charWidths = widths
+	return nil
+}
+
+// parseSimpleWidths parses the FirstChar, LastChar and Widths entries of a simple font dictionary.
+// It returns the first character code and the widths of the codes starting from it.
+func parseSimpleWidths(firstCharObj, lastCharObj, widthsObj core.PdfObject) (int, []float64, error) {
+	firstChar, ok := core.TraceToDirectObject(firstCharObj).(*core.PdfObjectInteger)
+	if !ok {
+		common.Log.Debug("Invalid FirstChar type (%T)", firstCharObj)
+		return 0, nil, errors.New("Type check error")
+	}
+
+	lastChar, ok := core.TraceToDirectObject(lastCharObj).(*core.PdfObjectInteger)
+	if !ok {
+		common.Log.Debug("Invalid LastChar type (%T)", lastCharObj)
+		return 0, nil, errors.New("Type check error")
+	}
+
+	arr, ok := core.TraceToDirectObject(widthsObj).(*core.PdfObjectArray)
+	if !ok {
+		common.Log.Debug("Widths attribute != array (%T)", widthsObj)
+		return 0, nil, errors.New("Type check error")
+	}
+
+	widths, err := arr.GetAsFloat64Slice()
+	if err != nil {
+		common.Log.Debug("Error converting widths to array")
+		return 0, nil, err
+	}
+
+	n := int(*lastChar) - int(*firstChar) + 1
+	if len(widths) != n {
+		// Seen in the wild. Use the widths that are there.
+		common.Log.Debug("Invalid widths length != %d (%d)", n, len(widths))
+		if len(widths) > n && n >= 0 {
+			widths = widths[:n]
+		}
+	}
+
+	return int(*firstChar), widths, nil
+}
+
+// getEncoder returns the text encoder of the font, determined from its Encoding entry and BaseFont
+// (9.6.6 PDF32000_2008). The Encoding is either the name of a predefined encoding or an encoding
+// dictionary with an optional BaseEncoding and a Differences array. Without a base encoding, the
+// built-in encoding of the font applies: that of the embedded font program, the Symbol and
+// ZapfDingbats encodings for those fonts and StandardEncoding for other Type 1 fonts. TrueType fonts
+// without a base encoding are assumed to use WinAnsiEncoding.
+func (font *pdfFontSimple) getEncoder() textencoding.TextEncoder {
+	var baseName string
+	var differences map[byte]string
+	switch enc := core.TraceToDirectObject(font.Encoding).(type) {
+	case nil:
+	case *core.PdfObjectName:
+		baseName = string(*enc)
+	case *core.PdfObjectDictionary:
+		if name, ok := core.TraceToDirectObject(enc.Get("BaseEncoding")).(*core.PdfObjectName); ok {
+			baseName = string(*name)
+		}
+		if diffList, ok := core.TraceToDirectObject(enc.Get("Differences")).(*core.PdfObjectArray); ok {
+			var err error
+			differences, err = textencoding.FromFontDifferences(diffList)
+			if err != nil {
+				common.Log.Debug("Invalid Differences in font %s: %v", font.basefont, err)
+			}
+		}
+	default:
+		common.Log.Debug("Invalid Encoding in font %s (%T)", font.basefont, font.Encoding)
+	}
+
+	if baseName != "" {
+		encoder, err := textencoding.NewSimpleTextEncoder(baseName, differences)
+		if err == nil {
+			return encoder
+		}
+		common.Log.Debug("Encoding %s not supported, using the built-in encoding", baseName)
+	}
+
+	if builtin := font.getBuiltinEncoding(); builtin != nil {
+		return textencoding.NewCustomSimpleTextEncoder(builtin, differences)
+	}
+	switch {
+	case font.basefont == "Symbol" || font.basefont == "ZapfDingbats":
+		encoder, _ := textencoding.NewSymbolicTextEncoder(font.basefont, differences)
+		return encoder
+	case font.subtype == "TrueType":
+		encoder, _ := textencoding.NewSimpleTextEncoder("WinAnsiEncoding", differences)
+		return encoder
+	}
+	encoder, _ := textencoding.NewSimpleTextEncoder("StandardEncoding", differences)
+	return encoder
+}
+
+// ToPdfObject converts the font to a PDF representation.
+func (font *pdfFontSimple) ToPdfObject() core.PdfObject {
+	if font.container == nil {
+		font.container = &core.PdfIndirectObject{}
+	}
+	d := core.MakeDict()
+	font.container.PdfObject = d
+
+	d.Set("Type", core.MakeName("Font"))
+	d.Set("Subtype", core.MakeName(font.subtype))
+
+	if font.BaseFont != nil {
+		d.Set("BaseFont", font.BaseFont)
+	}
+	if font.FirstChar != nil {
+		d.Set("FirstChar", font.FirstChar)
+	}
+	if font.LastChar != nil {
+		d.Set("LastChar", font.LastChar)
+	}
+	if font.Widths != nil {
+		d.Set("Widths", font.Widths)
+	}
+	if font.fontDescriptor != nil {
+		d.Set("FontDescriptor", font.fontDescriptor.ToPdfObject())
+	}
+	if font.Encoding != nil {
+		d.Set("Encoding", font.Encoding)
+	}
+	if font.toUnicode != nil {
+		d.Set("ToUnicode", font.toUnicode)
+	}
+
+	return font.container
+}
@@ -0,0 +1,428 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/unidoc/unidoc/pdf/core"
+)
+
+// loadTestFont parses font dictionary `rawText` and loads it as a PdfFont.
+func loadTestFont(t *testing.T, rawText string) *PdfFont {
+	parser := NewParserFromString(rawText)
+	dict, err := parser.ParseDict()
+	if err != nil {
+		t.Fatalf("Failed to parse font dict: %v", err)
+	}
+	font, err := NewPdfFontFromPdfObject(dict)
+	if err != nil {
+		t.Fatalf("Failed to load font: %v", err)
+	}
+	return font
+}
+
+// checkWidths checks that character codes of `font` have the widths in `expected`.
+func checkWidths(t *testing.T, font *PdfFont, expected map[uint64]float64) {
+	for code, w := range expected {
+		metrics, ok := font.GetCharMetrics(code)
+		if !ok {
+			t.Errorf("%s: no metrics for code %d", font.BaseFont(), code)
+			continue
+		}
+		if metrics.Wx != w {
+			t.Errorf("%s: code %d width %.1f != %.1f", font.BaseFont(), code, metrics.Wx, w)
+		}
+	}
+}
+
+// Standard 14 font without Widths or FontDescriptor.
+func TestFontStandard14(t *testing.T) {
+	font := loadTestFont(t, `<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>`)
+
+	if font.Subtype() != "Type1" {
+		t.Errorf("Subtype %q != Type1", font.Subtype())
+	}
+	checkWidths(t, font, map[uint64]float64{'A': 667, 'i': 222, ' ': 278})
+
+	if s := font.CharcodeBytesToUnicode([]byte("Hi there")); s != "Hi there" {
+		t.Errorf("Decoded %q != %q", s, "Hi there")
+	}
+}
+
+// Simple font with Widths taking precedence over the standard 14 metrics.
+func TestFontSimpleWidths(t *testing.T) {
+	font := loadTestFont(t, `<< /Type /Font /Subtype /TrueType /BaseFont /Arial
+		/FirstChar 65 /LastChar 67 /Widths [500 600 700] >>`)
+
+	checkWidths(t, font, map[uint64]float64{'A': 500, 'B': 600, 'C': 700, 'D': 722})
+}
+
+// Simple fonts without ToUnicode CMaps are decoded with their encodings.
+func TestFontSimpleEncoding(t *testing.T) {
+	tests := []struct {
+		rawText  string
+		data     string
+		expected string
+	}{
+		{`<< /Type /Font /Subtype /Type1 /BaseFont /Times-Roman >>`,
+			"It\x27s \xaeve", "It\u2019s \ufb01ve"},
+		{`<< /Type /Font /Subtype /Type1 /BaseFont /Times-Roman /Encoding /MacRomanEncoding >>`,
+			"caf\x8e \xd2x\xd3", "caf\u00e9 \u201cx\u201d"},
+		{`<< /Type /Font /Subtype /TrueType /BaseFont /Foo
+			/Encoding << /Type /Encoding /BaseEncoding /WinAnsiEncoding
+				/Differences [1 /g48 /uni0394 /A.sc 128 /Euro] >> >>`,
+			"\x01\x02\x03\x80\x80ab", "H\u0394A\u20ac\u20acab"},
+		{`<< /Type /Font /Subtype /Type1 /BaseFont /Symbol /Encoding << /Differences [65 /beta] >> >>`,
+			"ABG", "\u03b2\u0392\u0393"},
+	}
+
+	for _, test := range tests {
+		font := loadTestFont(t, test.rawText)
+		if s := font.CharcodeBytesToUnicode([]byte(test.data)); s != test.expected {
+			t.Errorf("%s: decoded %q != %q", test.rawText, s, test.expected)
+		}
+	}
+}
+
+// Type0 font with Identity-H encoding, W array and a ToUnicode CMap.
+func TestFontType0(t *testing.T) {
+	font := loadTestFont(t, `<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /Identity-H
+		/DescendantFonts [<< /Type /Font /Subtype /CIDFontType2 /BaseFont /Foo
+			/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >>
+			/DW 500 /W [1 [600 700] 10 20 300] >>] >>`)
+
+	if !font.IsCID() {
+		t.Errorf("Type0 font not CID")
+	}
+	checkWidths(t, font, map[uint64]float64{1: 600, 2: 700, 10: 300, 15: 300, 20: 300, 50: 500})
+
+	// Ranges are clamped to 16 bit CIDs and reversed ranges are skipped.
+	font = loadTestFont(t, `<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /Identity-H
+		/DescendantFonts [<< /Type /Font /Subtype /CIDFontType2 /BaseFont /Foo
+			/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >>
+			/DW 500 /W [65534 9223372036854775807 600 -5 1 700 30 20 800 65535 [900 1000]] >>] >>`)
+	checkWidths(t, font, map[uint64]float64{0: 700, 1: 700, 2: 500, 25: 500, 65534: 600, 65535: 900})
+
+	codes := font.CharcodeBytesToCharcodes([]byte{0x00, 0x01, 0x01, 0x02})
+	if len(codes) != 2 || codes[0] != 0x0001 || codes[1] != 0x0102 {
+		t.Errorf("Incorrect codes % X", codes)
+	}
+
+	toUnicode := `
+/CIDInit /ProcSet findresource begin
+begincmap
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+1 beginbfchar
+<0001> <0048>
+endbfchar
+1 beginbfrange
+<0002> <0003> <0069>
+endbfrange
+endcmap
+`
+	stream, err := MakeStream([]byte(toUnicode), NewRawEncoder())
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	dict := font.ToPdfObject().(*PdfIndirectObject).PdfObject.(*PdfObjectDictionary)
+	dict.Set("ToUnicode", stream)
+	font, err = NewPdfFontFromPdfObject(dict)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if s := font.CharcodeBytesToUnicode([]byte{0, 1, 0, 2, 0, 3}); s != "Hij" {
+		t.Errorf("Decoded %q != %q", s, "Hij")
+	}
+}
+
+// Type0 fonts with predefined Encoding CMaps are decoded with the encodings of the CMaps without a
+// ToUnicode CMap.
+func TestFontType0Predefined(t *testing.T) {
+	tests := []struct {
+		encoding string
+		data     string
+		expected string
+		vertical bool
+	}{
+		{"GBK-EUC-H", "\xc4\xe3\xba\xc3A", "\u4f60\u597dA", false},
+		{"90ms-RKSJ-V", "\x82\xa0\x93\xfa1", "\u3042\u65e51", true},
+		{"UniJIS-UCS2-H", "\x65\xe5\x67\x2c", "\u65e5\u672c", false},
+		{"UniGB-UTF16-V", "\xd8\x40\xdc\x0b\x4e\x2d", "\U0002000b\u4e2d", true},
+		{"B5pc-H", "\xa4\xa4", "\u4e2d", false},
+		{"KSCms-UHC-H", "\xc7\xd1", "\ud55c", false},
+	}
+
+	for _, test := range tests {
+		font := loadTestFont(t, `<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /`+test.encoding+`
+			/DescendantFonts [<< /Type /Font /Subtype /CIDFontType0 /BaseFont /Foo
+				/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> >>] >>`)
+		if s := font.CharcodeBytesToUnicode([]byte(test.data)); s != test.expected {
+			t.Errorf("%s: decoded %+q != %+q", test.encoding, s, test.expected)
+		}
+		if font.IsVertical() != test.vertical {
+			t.Errorf("%s: vertical %t != %t", test.encoding, font.IsVertical(), test.vertical)
+		}
+	}
+}
+
+// Predefined CMaps are loaded from the CMap directory. They give the CIDs of character codes and the
+// unicode of the CIDs of the Adobe character collections.
+func TestFontType0PredefinedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmaps")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"UniJIS-UCS2-H": `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+/CIDSystemInfo 3 dict dup begin
+  /Registry (Adobe) def
+  /Ordering (Japan1) def
+  /Supplement 2 def
+end def
+/CMapName /UniJIS-UCS2-H def
+/CMapType 1 def
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+2 begincidrange
+<0020> <007e> 1
+<65e5> <65e5> 2756
+endcidrange
+endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`,
+		"UniJIS-UCS2-V": `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+/UniJIS-UCS2-H usecmap
+/CMapName /UniJIS-UCS2-V def
+/WMode 1 def
+1 begincidchar
+<3001> 7887
+endcidchar
+endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`,
+		"Adobe-Japan1-UCS2": `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+/CMapName /Adobe-Japan1-UCS2 def
+1 begincodespacerange
+<0000> <ffff>
+endcodespacerange
+2 begincidrange
+<0001> <005f> 32
+<0ac4> <0ac4> 26085
+endcidrange
+endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`,
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+	}
+	SetPredefinedCMapDir(dir)
+	defer SetPredefinedCMapDir("")
+
+	font := loadTestFont(t, `<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /UniJIS-UCS2-V
+		/DescendantFonts [<< /Type /Font /Subtype /CIDFontType0 /BaseFont /Foo
+			/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >>
+			/W [1 95 500] /W2 [2756 [-900 500 800] 7887 7887 -500 500 880] >>] >>`)
+	if !font.IsVertical() {
+		t.Errorf("UniJIS-UCS2-V font not vertical")
+	}
+	checkWidths(t, font, map[uint64]float64{0x41: 500, 0x65e5: 1000})
+	for code, expected := range map[uint64][3]float64{0x65e5: {-900, 500, 800}, 0x3001: {-500, 500, 880},
+		0x41: {-1000, 250, 880}} {
+		w1y, vx, vy, ok := font.GetVerticalMetrics(code)
+		if !ok || w1y != expected[0] || vx != expected[1] || vy != expected[2] {
+			t.Errorf("Code 0x%04x: vertical metrics %.0f %.0f %.0f != %v", code, w1y, vx, vy, expected)
+		}
+	}
+
+	// Identity-H encoded CIDs are mapped to unicode with the Adobe-Japan1-UCS2 CMap.
+	font = loadTestFont(t, `<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /Identity-H
+		/DescendantFonts [<< /Type /Font /Subtype /CIDFontType0 /BaseFont /Foo
+			/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> >>] >>`)
+	if s := font.CharcodeBytesToUnicode([]byte{0x0a, 0xc4, 0x00, 0x22}); s != "\u65e5A" {
+		t.Errorf("Decoded %+q != %+q", s, "\u65e5A")
+	}
+}
+
+// Type3 font with glyph widths scaled by the FontMatrix.
+func TestFontType3(t *testing.T) {
+	font := loadTestFont(t, `<< /Type /Font /Subtype /Type3 /FontBBox [0 0 750 750]
+		/FontMatrix [0.001 0 0 0.001 0 0] /CharProcs << /square << >> /triangle << >> >>
+		/Encoding << /Type /Encoding /Differences [97 /square /triangle] >>
+		/FirstChar 97 /LastChar 98 /Widths [1000 500] >>`)
+
+	checkWidths(t, font, map[uint64]float64{'a': 1000, 'b': 500})
+
+	metrics, _ := font.GetCharMetrics('b')
+	if metrics.GlyphName != "triangle" {
+		t.Errorf("Glyph name %q != triangle", metrics.GlyphName)
+	}
+
+	matrix, ok := font.GetType3FontMatrix()
+	if !ok || len(matrix) != 6 || matrix[0] != 0.001 {
+		t.Errorf("Incorrect FontMatrix %v", matrix)
+	}
+}
+
+// Type1 font embedded from a .pfb file. When reloaded without Widths or Encoding, the widths and
+// the encoding come from the embedded font program.
+func TestFontType1Embedded(t *testing.T) {
+	font, err := NewPdfFontFromType1File("../../testfiles/type1/testfont.pfb")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if font.BaseFont() != "TestFont" {
+		t.Errorf("BaseFont %q != TestFont", font.BaseFont())
+	}
+	checkWidths(t, font, map[uint64]float64{'A': 600, 0xc5: 600, 'B': 600})
+
+	mismatches, err := font.ValidateWidths(0.5)
+	if err != nil || len(mismatches) != 0 {
+		t.Errorf("Unexpected widths mismatches %v %v", mismatches, err)
+	}
+
+	dict := font.ToPdfObject().(*PdfIndirectObject).PdfObject.(*PdfObjectDictionary)
+	dict.Remove("Encoding")
+	dict.Set("Widths", MakeArrayFromFloats([]float64{500}))
+	dict.Set("FirstChar", MakeInteger(65))
+	dict.Set("LastChar", MakeInteger(65))
+	font, err = NewPdfFontFromPdfObject(dict)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	program, err := font.GetFontProgram()
+	if err != nil || program == nil || program.Name() != "TestFont" {
+		t.Fatalf("Font program not loaded: %v", err)
+	}
+	checkWidths(t, font, map[uint64]float64{'A': 500, 0xc5: 600})
+	if s := font.CharcodeBytesToUnicode([]byte{'A', 0xc5}); s != "AÅ" {
+		t.Errorf("Decoded %q != %q", s, "AÅ")
+	}
+
+	mismatches, err = font.ValidateWidths(0.5)
+	if err != nil || len(mismatches) != 1 || mismatches[0].Code != 'A' || mismatches[0].ProgramWidth != 600 {
+		t.Errorf("Incorrect widths mismatches %v %v", mismatches, err)
+	}
+}
+
+// OpenType font with CFF outlines, embedded as a simple font and as a composite font.
+func TestFontOpenTypeCFF(t *testing.T) {
+	font, err := NewPdfFontFromTTFFile("../../testfiles/otf/CFFTest.otf")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if font.Subtype() != "Type1" {
+		t.Errorf("Subtype %q != Type1", font.Subtype())
+	}
+	checkWidths(t, font, map[uint64]float64{'0': 600, '1': 400, 'Q': 1000})
+
+	descriptor := font.GetFontDescriptor()
+	stream, ok := descriptor.FontFile3.(*PdfObjectStream)
+	if !ok || stream.Get("Subtype").String() != "OpenType" {
+		t.Fatalf("Incorrect FontFile3 %v", descriptor.FontFile3)
+	}
+
+	// Reload the font and check that the embedded font program matches the widths.
+	font, err = NewPdfFontFromPdfObject(font.ToPdfObject())
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	program, err := font.GetFontProgram()
+	if err != nil || program == nil || program.Name() != "CFFTest" {
+		t.Fatalf("Font program not loaded: %v", err)
+	}
+	mismatches, err := font.ValidateWidths(0.5)
+	if err != nil || len(mismatches) != 0 {
+		t.Errorf("Unexpected widths mismatches %v %v", mismatches, err)
+	}
+
+	font, err = NewCompositePdfFontFromOTFFile("../../testfiles/otf/CFFTest.otf")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	font, err = NewPdfFontFromPdfObject(font.ToPdfObject())
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !font.IsCID() {
+		t.Errorf("Composite font not CID")
+	}
+	// The CIDs are the glyph indexes: .notdef zero one Q uni4E2D.
+	checkWidths(t, font, map[uint64]float64{1: 600, 2: 400, 3: 1000})
+	if s := font.CharcodeBytesToUnicode([]byte{0, 3, 0, 1, 0, 4}); s != "Q0中" {
+		t.Errorf("Decoded %q != %q", s, "Q0中")
+	}
+}
+
+// Kerning of the standard 14 fonts (AFM KPX pairs) and of TrueType fonts (GPOS pair adjustments).
+func TestFontKerning(t *testing.T) {
+	font := loadTestFont(t, `<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>`)
+	if kx, ok := font.GetKerning("A", "V"); !ok || kx != -70 {
+		t.Errorf("Helvetica A V kerning %v %t != -70", kx, ok)
+	}
+	if _, ok := font.GetKerning("A", "B"); ok {
+		t.Errorf("Helvetica A B kerned")
+	}
+	if ligature, ok := font.GetLigature("f", "i"); !ok || ligature != "fi" {
+		t.Errorf("Helvetica f i ligature %q %t", ligature, ok)
+	}
+
+	font, err := NewPdfFontFromTTFFile("../../testfiles/roboto/Roboto-Regular.ttf")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	for _, pair := range [][2]string{{"A", "V"}, {"T", "o"}, {"V", "A"}} {
+		if kx, ok := font.GetKerning(pair[0], pair[1]); !ok || kx >= 0 {
+			t.Errorf("Roboto %s %s kerning %v %t", pair[0], pair[1], kx, ok)
+		}
+	}
+	if kx, ok := font.GetKerning("o", "o"); ok && kx != 0 {
+		t.Errorf("Roboto o o kerning %v", kx)
+	}
+}
+
+// WOFF2 fonts are unpacked and embedded as TrueType fonts.
+func TestFontWOFF2(t *testing.T) {
+	font, err := NewPdfFontFromTTFFile("../../testfiles/woff2/SourceCodePro-Regular.ttf.woff2")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if font.Subtype() != "TrueType" || font.BaseFont() != "SourceCodePro-Regular" {
+		t.Errorf("Incorrect font %s %s", font.Subtype(), font.BaseFont())
+	}
+	checkWidths(t, font, map[uint64]float64{'A': 600, ' ': 600})
+
+	descriptor := font.GetFontDescriptor()
+	stream, ok := descriptor.FontFile2.(*PdfObjectStream)
+	if !ok {
+		t.Fatalf("Incorrect FontFile2 %v", descriptor.FontFile2)
+	}
+	data, err := DecodeStream(stream)
+	if err != nil || string(data[:4]) != "\x00\x01\x00\x00" {
+		t.Errorf("Embedded font is not a TrueType font: %v", err)
+	}
+}
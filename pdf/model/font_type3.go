@@ -0,0 +1,285 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// pdfFontType3 represents a Type3 font. The glyphs of Type3 fonts are defined by content streams
+// (CharProcs) in glyph space, which is mapped to text space by the FontMatrix.
+type pdfFontType3 struct {
+	*fontCommon
+
+	firstChar  int
+	lastChar   int
+	charWidths []float64
+	fontMatrix []float64
+
+	// Character code to glyph name map from the Differences array of the Encoding.
+	differences map[byte]string
+	charProcs   map[string]*core.PdfObjectStream
+	resources   *PdfPageResources
+
+	FontBBox   core.PdfObject
+	FontMatrix core.PdfObject
+	CharProcs  core.PdfObject
+	Encoding   core.PdfObject
+	FirstChar  core.PdfObject
+	LastChar   core.PdfObject
+	Widths     core.PdfObject
+	Resources  core.PdfObject
+
+	container *core.PdfIndirectObject
+}
+
+// SetEncoder is not supported for Type3 fonts, the encoding is given by the font's Differences.
+func (font *pdfFontType3) SetEncoder(encoder textencoding.TextEncoder) {
+	common.Log.Debug("SetEncoder not supported for Type3 fonts")
+}
+
+// GetGlyphCharMetrics returns the metrics of the glyph with name `glyph`.
+func (font *pdfFontType3) GetGlyphCharMetrics(glyph string) (fonts.CharMetrics, bool) {
+	for code, name := range font.differences {
+		if name == glyph {
+			return font.getCharMetrics(uint64(code))
+		}
+	}
+	return fonts.CharMetrics{}, false
+}
+
+// getCharMetrics returns the metrics of the glyph with character code `code`. The widths of Type3
+// fonts are in glyph space and are converted to thousandths of text space units with the FontMatrix.
+func (font *pdfFontType3) getCharMetrics(code uint64) (fonts.CharMetrics, bool) {
+	metrics := fonts.CharMetrics{}
+
+	index := int(code) - font.firstChar
+	if index < 0 || int(code) > font.lastChar || index >= len(font.charWidths) {
+		return metrics, false
+	}
+
+	metrics.GlyphName = font.differences[byte(code)]
+	metrics.Wx = font.charWidths[index] * font.fontMatrix[0] * 1000.0
+	metrics.Wy = font.charWidths[index] * font.fontMatrix[1] * 1000.0
+	return metrics, true
+}
+
+// bytesToCharcodes returns the bytes of `data` as character codes.
+func (font *pdfFontType3) bytesToCharcodes(data []byte) []uint64 {
+	codes := make([]uint64, len(data))
+	for i, b := range data {
+		codes[i] = uint64(b)
+	}
+	return codes
+}
+
+// charcodeToUnicode returns the unicode string for the glyph name that character code `code` maps to.
+func (font *pdfFontType3) charcodeToUnicode(code uint64) (string, bool) {
+	if code > 0xff {
+		return "", false
+	}
+	glyph, ok := font.differences[byte(code)]
+	if !ok {
+		return "", false
+	}
+	r, ok := textencoding.GuessGlyphRune(glyph)
+	if !ok {
+		return "", false
+	}
+	return string(r), true
+}
+
+// GetType3CharProc returns the glyph description (a content stream in glyph space) of character code
+// `code` in a Type3 font.
+// The bool return flag is false if the font is not a Type3 font or has no glyph for `code`.
+func (font PdfFont) GetType3CharProc(code uint64) (*core.PdfObjectStream, bool) {
+	t, ok := font.context.(*pdfFontType3)
+	if !ok || code > 0xff {
+		return nil, false
+	}
+	glyph, ok := t.differences[byte(code)]
+	if !ok {
+		return nil, false
+	}
+	stream, ok := t.charProcs[glyph]
+	return stream, ok
+}
+
+// GetType3FontMatrix returns the FontMatrix [a b c d e f] of a Type3 font that maps glyph space to
+// text space. The bool return flag is false if the font is not a Type3 font.
+func (font PdfFont) GetType3FontMatrix() ([]float64, bool) {
+	t, ok := font.context.(*pdfFontType3)
+	if !ok {
+		return nil, false
+	}
+	return t.fontMatrix, true
+}
+
+// GetType3Resources returns the resources used by the glyph descriptions of a Type3 font. If the
+// font has no Resources entry, nil is returned and the resources of the page or form that uses the
+// font should be used.
+func (font PdfFont) GetType3Resources() *PdfPageResources {
+	t, ok := font.context.(*pdfFontType3)
+	if !ok {
+		return nil
+	}
+	return t.resources
+}
+
+// newPdfFontType3FromPdfObject loads a Type3 font from font dictionary `obj`.
+func newPdfFontType3FromPdfObject(obj core.PdfObject) (*pdfFontType3, error) {
+	font := &pdfFontType3{}
+
+	if ind, is := obj.(*core.PdfIndirectObject); is {
+		font.container = ind
+		obj = ind.PdfObject
+	}
+
+	d, ok := obj.(*core.PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("Font object invalid, not a dictionary (%T)", obj)
+		return nil, errors.New("Type check error")
+	}
+
+	base, err := newFontCommonFromDict(d, "Type3")
+	if err != nil {
+		return nil, err
+	}
+	font.fontCommon = base
+
+	font.FontBBox = d.Get("FontBBox")
+
+	font.FontMatrix = d.Get("FontMatrix")
+	arr, ok := core.TraceToDirectObject(font.FontMatrix).(*core.PdfObjectArray)
+	if !ok {
+		common.Log.Debug("FontMatrix missing or not an array (%T)", font.FontMatrix)
+		return nil, errors.New("Required attribute missing")
+	}
+	font.fontMatrix, err = arr.GetAsFloat64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(font.fontMatrix) != 6 {
+		common.Log.Debug("Invalid FontMatrix length %d", len(font.fontMatrix))
+		return nil, errors.New("Range check error")
+	}
+
+	font.CharProcs = d.Get("CharProcs")
+	procs, ok := core.TraceToDirectObject(font.CharProcs).(*core.PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("CharProcs missing or not a dictionary (%T)", font.CharProcs)
+		return nil, errors.New("Required attribute missing")
+	}
+	font.charProcs = map[string]*core.PdfObjectStream{}
+	for _, key := range procs.Keys() {
+		stream, ok := core.TraceToDirectObject(procs.Get(key)).(*core.PdfObjectStream)
+		if !ok {
+			common.Log.Debug("CharProc %s not a stream", key)
+			continue
+		}
+		font.charProcs[string(key)] = stream
+	}
+
+	font.Encoding = d.Get("Encoding")
+	font.differences, err = getEncodingDifferences(font.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	font.FirstChar = d.Get("FirstChar")
+	font.LastChar = d.Get("LastChar")
+	font.Widths = d.Get("Widths")
+	if font.FirstChar == nil || font.LastChar == nil || font.Widths == nil {
+		common.Log.Debug("Incompatibility: FirstChar, LastChar or Widths missing from Type3 font")
+	} else {
+		firstChar, widths, err := parseSimpleWidths(font.FirstChar, font.LastChar, font.Widths)
+		if err != nil {
+			return nil, err
+		}
+		font.firstChar = firstChar
+		font.lastChar = firstChar + len(widths) - 1
+		font.charWidths = widths
+	}
+
+	font.Resources = d.Get("Resources")
+	if resDict, ok := core.TraceToDirectObject(font.Resources).(*core.PdfObjectDictionary); ok {
+		font.resources, err = NewPdfPageResourcesFromDict(resDict)
+		if err != nil {
+			common.Log.Debug("Unable to load Type3 font resources: %v", err)
+			return nil, err
+		}
+	}
+
+	return font, nil
+}
+
+// getEncodingDifferences returns the character code to glyph name map given by the Differences
+// array of encoding dictionary `obj`. An empty map is returned if there are no differences.
+func getEncodingDifferences(obj core.PdfObject) (map[byte]string, error) {
+	differences := map[byte]string{}
+
+	d, ok := core.TraceToDirectObject(obj).(*core.PdfObjectDictionary)
+	if !ok {
+		return differences, nil
+	}
+	arr, ok := core.TraceToDirectObject(d.Get("Differences")).(*core.PdfObjectArray)
+	if !ok {
+		return differences, nil
+	}
+
+	// The array is of the form [code1 /name1 /name2 ... codeN /nameN1 ...] where the glyph names
+	// following a code are assigned to consecutive codes starting from it.
+	code := -1
+	for _, o := range *arr {
+		switch v := core.TraceToDirectObject(o).(type) {
+		case *core.PdfObjectInteger:
+			code = int(*v)
+		case *core.PdfObjectName:
+			if code < 0 || code > 0xff {
+				common.Log.Debug("Invalid Differences array, code out of range (%d)", code)
+				return nil, errors.New("Range check error")
+			}
+			differences[byte(code)] = string(*v)
+			code++
+		default:
+			common.Log.Debug("Invalid Differences array entry (%T)", o)
+			return nil, errors.New("Type check error")
+		}
+	}
+	return differences, nil
+}
+
+// ToPdfObject converts the font to a PDF representation.
+func (font *pdfFontType3) ToPdfObject() core.PdfObject {
+	if font.container == nil {
+		font.container = &core.PdfIndirectObject{}
+	}
+	d := core.MakeDict()
+	font.container.PdfObject = d
+
+	d.Set("Type", core.MakeName("Font"))
+	d.Set("Subtype", core.MakeName("Type3"))
+
+	d.SetIfNotNil("FontBBox", font.FontBBox)
+	d.SetIfNotNil("FontMatrix", font.FontMatrix)
+	d.SetIfNotNil("CharProcs", font.CharProcs)
+	d.SetIfNotNil("Encoding", font.Encoding)
+	d.SetIfNotNil("FirstChar", font.FirstChar)
+	d.SetIfNotNil("LastChar", font.LastChar)
+	d.SetIfNotNil("Widths", font.Widths)
+	if font.fontDescriptor != nil {
+		d.Set("FontDescriptor", font.fontDescriptor.ToPdfObject())
+	}
+	d.SetIfNotNil("Resources", font.Resources)
+	d.SetIfNotNil("ToUnicode", font.toUnicode)
+
+	return font.container
+}
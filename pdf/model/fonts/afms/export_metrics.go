@@ -21,7 +21,7 @@ import (
 
 func main() {
 	filepath := flag.String("file", "", "AFM input file")
-	method := flag.String("method", "charmetrics", "charmetrics/charcodes/glyph-to-charcode")
+	method := flag.String("method", "charmetrics", "charmetrics/charcodes/glyph-to-charcode/kerning")
 
 	flag.Parse()
 
@@ -38,6 +38,8 @@ func main() {
 		err = runCharcodeToGlyphRetrievalOnFile(*filepath)
 	case "glyph-to-charcode":
 		err = runGlyphToCharcodeRetrievalOnFile(*filepath)
+	case "kerning":
+		err = runKerningOnFile(*filepath)
 	}
 
 	if err != nil {
@@ -114,6 +116,66 @@ func runGlyphToCharcodeRetrievalOnFile(afmpath string) error {
 	return nil
 }
 
+// Generate a glyph pair to kerning map.
+func runKerningOnFile(afmpath string) error {
+	kerning, err := GetKerningFromAfmFile(afmpath)
+	if err != nil {
+		return err
+	}
+
+	keys := []fonts.GlyphPair{}
+	for key := range kerning {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Left != keys[j].Left {
+			return keys[i].Left < keys[j].Left
+		}
+		return keys[i].Right < keys[j].Right
+	})
+
+	fmt.Printf("var xxfontKerning map[GlyphPair]float64 = map[GlyphPair]float64{\n")
+	for _, key := range keys {
+		fmt.Printf("\t{\"%s\", \"%s\"}:\t%f,\n", key.Left, key.Right, kerning[key])
+	}
+	fmt.Printf("}\n")
+	return nil
+}
+
+// GetKerningFromAfmFile loads the KPX kerning pairs of an AFM file.
+func GetKerningFromAfmFile(filename string) (map[fonts.GlyphPair]float64, error) {
+	kerning := map[fonts.GlyphPair]float64{}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 1 || parts[0] != "KPX" {
+			continue
+		}
+		if len(parts) != 4 {
+			pdfcommon.Log.Debug("Failed KPX line: %s", scanner.Text())
+			return nil, errors.New("Invalid KPX line")
+		}
+		kx, err := strconv.ParseFloat(parts[3], 64)
+		if err != nil {
+			return nil, err
+		}
+		kerning[fonts.GlyphPair{Left: parts[1], Right: parts[2]}] = kx
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return kerning, nil
+}
+
 func GetCharmetricsFromAfmFile(filename string) (map[string]fonts.CharMetrics, error) {
 	glyphMetricsMap := map[string]fonts.CharMetrics{}
 
@@ -0,0 +1,1194 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// CFFFont represents a parsed Compact Font Format font program, as found in FontFile3 streams with
+// Subtype Type1C or CIDFontType0C and in the CFF table of OpenType fonts (Adobe Technical Note #5176).
+// Only Type 2 charstrings are supported.
+// Implements the FontProgram interface.
+type CFFFont struct {
+	fontName   string
+	fontMatrix [6]float64
+	fontBBox   [4]float64
+
+	// CID-keyed fonts have a Registry-Ordering-Supplement and map glyph indexes to CIDs with the
+	// charset rather than to glyph names.
+	isCID    bool
+	registry string
+	ordering string
+
+	charstrings [][]byte
+	gsubrs      [][]byte
+	privates    []cffPrivate
+	fdSelect    []byte // Glyph index to index in `privates`. nil for non-CID fonts.
+
+	charset    []uint16 // Glyph index to SID (or CID for CID-keyed fonts).
+	glyphNames []string
+	nameToGID  map[string]int
+	cidToGID   map[uint16]int
+	encoding   map[byte]string
+
+	// Cached glyph advances by glyph index.
+	advances map[int]float64
+}
+
+// cffPrivate holds the Private DICT entries that are needed for interpreting charstrings.
+type cffPrivate struct {
+	subrs         [][]byte
+	defaultWidthX float64
+	nominalWidthX float64
+}
+
+// CFF DICT operators. Two byte operators are 1200 + the second byte.
+const (
+	cffOpFontBBox       = 5
+	cffOpCharset        = 15
+	cffOpEncoding       = 16
+	cffOpCharStrings    = 17
+	cffOpPrivate        = 18
+	cffOpSubrs          = 19
+	cffOpDefaultWidthX  = 20
+	cffOpNominalWidthX  = 21
+	cffOpCharstringType = 1206
+	cffOpFontMatrix     = 1207
+	cffOpROS            = 1230
+	cffOpFDArray        = 1236
+	cffOpFDSelect       = 1237
+)
+
+// cffDict is a parsed CFF DICT: operator to operands.
+type cffDict map[int][]float64
+
+// get returns the `i`th operand of operator `op`, or `def` if not present.
+func (d cffDict) get(op int, i int, def float64) float64 {
+	if vals, ok := d[op]; ok && i < len(vals) {
+		return vals[i]
+	}
+	return def
+}
+
+// ParseCFF parses the CFF font program `data`. Only the first font in the FontSet is loaded.
+func ParseCFF(data []byte) (*CFFFont, error) {
+	if len(data) < 4 {
+		return nil, errors.New("CFF: header too short")
+	}
+	if data[0] != 1 {
+		common.Log.Debug("CFF: unsupported major version %d", data[0])
+		return nil, errors.New("CFF: unsupported version")
+	}
+	hdrSize := int(data[2])
+
+	names, pos, err := readCFFIndex(data, hdrSize)
+	if err != nil {
+		return nil, err
+	}
+	topDicts, pos, err := readCFFIndex(data, pos)
+	if err != nil {
+		return nil, err
+	}
+	strs, pos, err := readCFFIndex(data, pos)
+	if err != nil {
+		return nil, err
+	}
+	gsubrs, _, err := readCFFIndex(data, pos)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 || len(topDicts) == 0 {
+		return nil, errors.New("CFF: empty FontSet")
+	}
+	if len(names) > 1 {
+		common.Log.Debug("CFF: FontSet has %d fonts. Using the first", len(names))
+	}
+
+	top, err := parseCFFDict(topDicts[0])
+	if err != nil {
+		return nil, err
+	}
+	if t := top.get(cffOpCharstringType, 0, 2); t != 2 {
+		common.Log.Debug("CFF: unsupported CharstringType %v", t)
+		return nil, errors.New("CFF: unsupported charstring type")
+	}
+
+	font := &CFFFont{
+		fontName: string(names[0]),
+		gsubrs:   gsubrs,
+		advances: map[int]float64{},
+	}
+	font.fontMatrix = [6]float64{0.001, 0, 0, 0.001, 0, 0}
+	if m, ok := top[cffOpFontMatrix]; ok && len(m) == 6 {
+		copy(font.fontMatrix[:], m)
+	}
+	if b, ok := top[cffOpFontBBox]; ok && len(b) == 4 {
+		copy(font.fontBBox[:], b)
+	}
+
+	sid := func(s int) string {
+		if s < len(cffStandardStrings) {
+			return cffStandardStrings[s]
+		}
+		s -= len(cffStandardStrings)
+		if s < len(strs) {
+			return string(strs[s])
+		}
+		return ""
+	}
+
+	if ros, ok := top[cffOpROS]; ok && len(ros) == 3 {
+		font.isCID = true
+		font.registry = sid(int(ros[0]))
+		font.ordering = sid(int(ros[1]))
+	}
+
+	csOffset := int(top.get(cffOpCharStrings, 0, -1))
+	if csOffset < 0 {
+		return nil, errors.New("CFF: CharStrings missing")
+	}
+	font.charstrings, _, err = readCFFIndex(data, csOffset)
+	if err != nil {
+		return nil, err
+	}
+	numGlyphs := len(font.charstrings)
+	if numGlyphs == 0 {
+		return nil, errors.New("CFF: no glyphs")
+	}
+
+	font.charset, err = parseCFFCharset(data, int(top.get(cffOpCharset, 0, 0)), numGlyphs)
+	if err != nil {
+		return nil, err
+	}
+
+	if font.isCID {
+		if err := font.loadCIDPrivates(data, top); err != nil {
+			return nil, err
+		}
+		font.cidToGID = map[uint16]int{}
+		font.glyphNames = make([]string, numGlyphs)
+		for gid, cid := range font.charset {
+			font.cidToGID[cid] = gid
+			font.glyphNames[gid] = fmt.Sprintf("cid%d", cid)
+		}
+	} else {
+		private, err := parseCFFPrivate(data, top)
+		if err != nil {
+			return nil, err
+		}
+		font.privates = []cffPrivate{private}
+		font.glyphNames = make([]string, numGlyphs)
+		for gid, s := range font.charset {
+			font.glyphNames[gid] = sid(int(s))
+		}
+		font.encoding, err = parseCFFEncoding(data, int(top.get(cffOpEncoding, 0, 0)), font.charset, sid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	font.nameToGID = map[string]int{}
+	for gid, name := range font.glyphNames {
+		if _, ok := font.nameToGID[name]; !ok {
+			font.nameToGID[name] = gid
+		}
+	}
+
+	common.Log.Trace("CFF font %s: %d glyphs CID=%t", font.fontName, numGlyphs, font.isCID)
+	return font, nil
+}
+
+// readCFFIndex reads the INDEX at `pos` in `data` and returns its entries and the position
+// following it.
+func readCFFIndex(data []byte, pos int) ([][]byte, int, error) {
+	if pos < 0 || pos+2 > len(data) {
+		return nil, 0, errors.New("CFF: INDEX out of range")
+	}
+	count := int(binary.BigEndian.Uint16(data[pos:]))
+	if count == 0 {
+		return nil, pos + 2, nil
+	}
+	if pos+3 > len(data) {
+		return nil, 0, errors.New("CFF: INDEX out of range")
+	}
+	offSize := int(data[pos+2])
+	if offSize < 1 || offSize > 4 {
+		return nil, 0, errors.New("CFF: invalid INDEX offSize")
+	}
+	offsetsStart := pos + 3
+	dataStart := offsetsStart + (count+1)*offSize - 1
+	if offsetsStart+(count+1)*offSize > len(data) {
+		return nil, 0, errors.New("CFF: INDEX out of range")
+	}
+
+	readOffset := func(i int) int {
+		off := 0
+		for _, b := range data[offsetsStart+i*offSize : offsetsStart+(i+1)*offSize] {
+			off = off<<8 | int(b)
+		}
+		return off
+	}
+
+	entries := make([][]byte, count)
+	prev := readOffset(0)
+	for i := 0; i < count; i++ {
+		next := readOffset(i + 1)
+		if prev < 1 || next < prev || dataStart+next > len(data) {
+			return nil, 0, errors.New("CFF: invalid INDEX offsets")
+		}
+		entries[i] = data[dataStart+prev : dataStart+next]
+		prev = next
+	}
+	return entries, dataStart + prev, nil
+}
+
+// parseCFFDict parses the DICT data `data`.
+func parseCFFDict(data []byte) (cffDict, error) {
+	dict := cffDict{}
+	var operands []float64
+
+	for i := 0; i < len(data); {
+		b := int(data[i])
+		switch {
+		case b <= 21:
+			op := b
+			i++
+			if b == 12 {
+				if i >= len(data) {
+					return nil, errors.New("CFF: truncated DICT operator")
+				}
+				op = 1200 + int(data[i])
+				i++
+			}
+			dict[op] = operands
+			operands = nil
+		case b == 28:
+			if i+3 > len(data) {
+				return nil, errors.New("CFF: truncated DICT operand")
+			}
+			operands = append(operands, float64(int16(binary.BigEndian.Uint16(data[i+1:]))))
+			i += 3
+		case b == 29:
+			if i+5 > len(data) {
+				return nil, errors.New("CFF: truncated DICT operand")
+			}
+			operands = append(operands, float64(int32(binary.BigEndian.Uint32(data[i+1:]))))
+			i += 5
+		case b == 30:
+			v, n, err := parseCFFReal(data[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, v)
+			i += 1 + n
+		case b >= 32 && b <= 246:
+			operands = append(operands, float64(b-139))
+			i++
+		case b >= 247 && b <= 254:
+			if i+2 > len(data) {
+				return nil, errors.New("CFF: truncated DICT operand")
+			}
+			if b <= 250 {
+				operands = append(operands, float64((b-247)*256+int(data[i+1])+108))
+			} else {
+				operands = append(operands, float64(-(b-251)*256-int(data[i+1])-108))
+			}
+			i += 2
+		default:
+			common.Log.Debug("CFF: invalid DICT byte %d", b)
+			return nil, errors.New("CFF: invalid DICT data")
+		}
+	}
+	return dict, nil
+}
+
+// parseCFFReal parses a real number operand encoded as nibbles and returns it and the number of
+// bytes consumed.
+func parseCFFReal(data []byte) (float64, int, error) {
+	s := []byte{}
+	for i, b := range data {
+		for _, nibble := range []byte{b >> 4, b & 0xf} {
+			switch {
+			case nibble <= 9:
+				s = append(s, '0'+nibble)
+			case nibble == 0xa:
+				s = append(s, '.')
+			case nibble == 0xb:
+				s = append(s, 'E')
+			case nibble == 0xc:
+				s = append(s, 'E', '-')
+			case nibble == 0xe:
+				s = append(s, '-')
+			case nibble == 0xf:
+				v, err := strconv.ParseFloat(string(s), 64)
+				if err != nil {
+					return 0, 0, err
+				}
+				return v, i + 1, nil
+			}
+		}
+	}
+	return 0, 0, errors.New("CFF: unterminated real number")
+}
+
+// parseCFFCharset returns the glyph index to SID (or CID) map of the charset at `offset`, or of
+// the predefined charset with id `offset` if it is 0, 1 or 2.
+func parseCFFCharset(data []byte, offset int, numGlyphs int) ([]uint16, error) {
+	charset := make([]uint16, numGlyphs)
+	if offset <= 2 {
+		if offset != 0 {
+			common.Log.Debug("CFF: Expert charsets not supported. Using ISOAdobe")
+		}
+		for gid := range charset {
+			charset[gid] = uint16(gid)
+		}
+		return charset, nil
+	}
+
+	if offset >= len(data) {
+		return nil, errors.New("CFF: charset out of range")
+	}
+	format := data[offset]
+	pos := offset + 1
+	read16 := func() (int, error) {
+		if pos+2 > len(data) {
+			return 0, errors.New("CFF: charset out of range")
+		}
+		v := int(binary.BigEndian.Uint16(data[pos:]))
+		pos += 2
+		return v, nil
+	}
+
+	switch format {
+	case 0:
+		for gid := 1; gid < numGlyphs; gid++ {
+			v, err := read16()
+			if err != nil {
+				return nil, err
+			}
+			charset[gid] = uint16(v)
+		}
+	case 1, 2:
+		for gid := 1; gid < numGlyphs; {
+			first, err := read16()
+			if err != nil {
+				return nil, err
+			}
+			var nLeft int
+			if format == 1 {
+				if pos >= len(data) {
+					return nil, errors.New("CFF: charset out of range")
+				}
+				nLeft = int(data[pos])
+				pos++
+			} else {
+				nLeft, err = read16()
+				if err != nil {
+					return nil, err
+				}
+			}
+			for i := 0; i <= nLeft && gid < numGlyphs; i++ {
+				charset[gid] = uint16(first + i)
+				gid++
+			}
+		}
+	default:
+		common.Log.Debug("CFF: invalid charset format %d", format)
+		return nil, errors.New("CFF: invalid charset format")
+	}
+	return charset, nil
+}
+
+// parseCFFEncoding returns the character code to glyph name map of the encoding at `offset`, or
+// of the predefined encoding with id `offset` if it is 0 (Standard) or 1 (Expert).
+func parseCFFEncoding(data []byte, offset int, charset []uint16, sid func(int) string) (map[byte]string, error) {
+	encoding := map[byte]string{}
+	inFont := map[string]bool{}
+	for _, s := range charset {
+		inFont[sid(int(s))] = true
+	}
+
+	if offset <= 1 {
+		if offset == 1 {
+			common.Log.Debug("CFF: Expert encoding not supported")
+			return encoding, nil
+		}
+		encoder := textencoding.NewStandardTextEncoder()
+		for code := 0; code <= 0xff; code++ {
+			if glyph, ok := encoder.CharcodeToGlyph(byte(code)); ok && inFont[glyph] {
+				encoding[byte(code)] = glyph
+			}
+		}
+		return encoding, nil
+	}
+
+	if offset >= len(data) {
+		return nil, errors.New("CFF: encoding out of range")
+	}
+	format := data[offset]
+	pos := offset + 1
+	glyphName := func(gid int) string {
+		if gid < len(charset) {
+			return sid(int(charset[gid]))
+		}
+		return ""
+	}
+
+	switch format & 0x7f {
+	case 0:
+		if pos >= len(data) {
+			return nil, errors.New("CFF: encoding out of range")
+		}
+		nCodes := int(data[pos])
+		pos++
+		if pos+nCodes > len(data) {
+			return nil, errors.New("CFF: encoding out of range")
+		}
+		for i := 0; i < nCodes; i++ {
+			encoding[data[pos+i]] = glyphName(i + 1)
+		}
+		pos += nCodes
+	case 1:
+		if pos >= len(data) {
+			return nil, errors.New("CFF: encoding out of range")
+		}
+		nRanges := int(data[pos])
+		pos++
+		if pos+2*nRanges > len(data) {
+			return nil, errors.New("CFF: encoding out of range")
+		}
+		gid := 1
+		for i := 0; i < nRanges; i++ {
+			first, nLeft := int(data[pos]), int(data[pos+1])
+			pos += 2
+			for code := first; code <= first+nLeft && code <= 0xff; code++ {
+				encoding[byte(code)] = glyphName(gid)
+				gid++
+			}
+		}
+	default:
+		common.Log.Debug("CFF: invalid encoding format %d", format)
+		return nil, errors.New("CFF: invalid encoding format")
+	}
+
+	// Supplements map additional codes to glyphs by SID.
+	if format&0x80 != 0 {
+		if pos >= len(data) {
+			return nil, errors.New("CFF: encoding out of range")
+		}
+		nSups := int(data[pos])
+		pos++
+		if pos+3*nSups > len(data) {
+			return nil, errors.New("CFF: encoding out of range")
+		}
+		for i := 0; i < nSups; i++ {
+			code := data[pos]
+			s := int(binary.BigEndian.Uint16(data[pos+1:]))
+			pos += 3
+			encoding[code] = sid(s)
+		}
+	}
+	return encoding, nil
+}
+
+// parseCFFPrivate reads the Private DICT referenced by the Private operator of `dict`.
+func parseCFFPrivate(data []byte, dict cffDict) (cffPrivate, error) {
+	private := cffPrivate{}
+	vals, ok := dict[cffOpPrivate]
+	if !ok || len(vals) != 2 {
+		common.Log.Debug("CFF: Private DICT missing")
+		return private, nil
+	}
+	size, offset := int(vals[0]), int(vals[1])
+	if offset < 0 || size < 0 || offset+size > len(data) {
+		return private, errors.New("CFF: Private DICT out of range")
+	}
+	pdict, err := parseCFFDict(data[offset : offset+size])
+	if err != nil {
+		return private, err
+	}
+	private.defaultWidthX = pdict.get(cffOpDefaultWidthX, 0, 0)
+	private.nominalWidthX = pdict.get(cffOpNominalWidthX, 0, 0)
+	if subrs, ok := pdict[cffOpSubrs]; ok && len(subrs) == 1 {
+		// The Subrs offset is relative to the start of the Private DICT.
+		private.subrs, _, err = readCFFIndex(data, offset+int(subrs[0]))
+		if err != nil {
+			return private, err
+		}
+	}
+	return private, nil
+}
+
+// loadCIDPrivates reads the Font DICTs and FDSelect of a CID-keyed font.
+func (font *CFFFont) loadCIDPrivates(data []byte, top cffDict) error {
+	fdArrayOffset := int(top.get(cffOpFDArray, 0, -1))
+	fdSelectOffset := int(top.get(cffOpFDSelect, 0, -1))
+	if fdArrayOffset < 0 || fdSelectOffset < 0 {
+		return errors.New("CFF: CID font without FDArray or FDSelect")
+	}
+
+	fdDicts, _, err := readCFFIndex(data, fdArrayOffset)
+	if err != nil {
+		return err
+	}
+	for _, fdData := range fdDicts {
+		fd, err := parseCFFDict(fdData)
+		if err != nil {
+			return err
+		}
+		private, err := parseCFFPrivate(data, fd)
+		if err != nil {
+			return err
+		}
+		font.privates = append(font.privates, private)
+	}
+	if len(font.privates) == 0 {
+		return errors.New("CFF: empty FDArray")
+	}
+
+	numGlyphs := len(font.charstrings)
+	font.fdSelect = make([]byte, numGlyphs)
+	if fdSelectOffset >= len(data) {
+		return errors.New("CFF: FDSelect out of range")
+	}
+	pos := fdSelectOffset + 1
+	switch data[fdSelectOffset] {
+	case 0:
+		if pos+numGlyphs > len(data) {
+			return errors.New("CFF: FDSelect out of range")
+		}
+		copy(font.fdSelect, data[pos:pos+numGlyphs])
+	case 3:
+		if pos+2 > len(data) {
+			return errors.New("CFF: FDSelect out of range")
+		}
+		nRanges := int(binary.BigEndian.Uint16(data[pos:]))
+		pos += 2
+		if pos+3*nRanges+2 > len(data) {
+			return errors.New("CFF: FDSelect out of range")
+		}
+		for i := 0; i < nRanges; i++ {
+			first := int(binary.BigEndian.Uint16(data[pos:]))
+			fd := data[pos+2]
+			end := int(binary.BigEndian.Uint16(data[pos+3:]))
+			pos += 3
+			for gid := first; gid < end && gid < numGlyphs; gid++ {
+				font.fdSelect[gid] = fd
+			}
+		}
+	default:
+		common.Log.Debug("CFF: invalid FDSelect format %d", data[fdSelectOffset])
+		return errors.New("CFF: invalid FDSelect format")
+	}
+	return nil
+}
+
+// Name returns the PostScript name of the font.
+func (font *CFFFont) Name() string {
+	return font.fontName
+}
+
+// FontMatrix returns the matrix that maps glyph space to text space.
+func (font *CFFFont) FontMatrix() [6]float64 {
+	return font.fontMatrix
+}
+
+// FontBBox returns the font bounding box [llx lly urx ury] in glyph space.
+func (font *CFFFont) FontBBox() [4]float64 {
+	return font.fontBBox
+}
+
+// IsCID returns true if the font is CID-keyed.
+func (font *CFFFont) IsCID() bool {
+	return font.isCID
+}
+
+// CIDSystemInfo returns the registry and ordering of a CID-keyed font.
+func (font *CFFFont) CIDSystemInfo() (string, string) {
+	return font.registry, font.ordering
+}
+
+// NumGlyphs returns the number of glyphs in the font.
+func (font *CFFFont) NumGlyphs() int {
+	return len(font.charstrings)
+}
+
+// BuiltinEncoding returns the font's built-in character code to glyph name map. CID-keyed fonts
+// have no encoding and return an empty map.
+func (font *CFFFont) BuiltinEncoding() map[byte]string {
+	if font.encoding == nil {
+		return map[byte]string{}
+	}
+	return font.encoding
+}
+
+// GlyphNames returns the names of the glyphs in the font in glyph index order. The glyphs of
+// CID-keyed fonts are named cidN where N is the CID.
+func (font *CFFFont) GlyphNames() []string {
+	return font.glyphNames
+}
+
+// GlyphIndex returns the glyph index of glyph `glyph`.
+func (font *CFFFont) GlyphIndex(glyph string) (int, bool) {
+	gid, ok := font.nameToGID[glyph]
+	return gid, ok
+}
+
+// CIDToGID returns the glyph index of CID `cid` in a CID-keyed font. For other fonts the CID is the
+// glyph index.
+func (font *CFFFont) CIDToGID(cid uint16) (int, bool) {
+	if !font.isCID {
+		return int(cid), int(cid) < len(font.charstrings)
+	}
+	gid, ok := font.cidToGID[cid]
+	return gid, ok
+}
+
+// GIDToCID returns the CID of the glyph with index `gid` in a CID-keyed font. For other fonts the
+// glyph index is returned.
+func (font *CFFFont) GIDToCID(gid int) (uint16, bool) {
+	if gid < 0 || gid >= len(font.charset) {
+		return 0, false
+	}
+	if !font.isCID {
+		return uint16(gid), true
+	}
+	return font.charset[gid], true
+}
+
+// GlyphAdvance returns the advance width of glyph `glyph` in glyph space.
+// The bool return flag is true if there was a match, and false otherwise.
+func (font *CFFFont) GlyphAdvance(glyph string) (float64, bool) {
+	gid, ok := font.nameToGID[glyph]
+	if !ok {
+		return 0, false
+	}
+	return font.GIDAdvance(gid)
+}
+
+// GlyphOutline returns the outline of glyph `glyph` in glyph space.
+func (font *CFFFont) GlyphOutline(glyph string) (*GlyphOutline, error) {
+	gid, ok := font.nameToGID[glyph]
+	if !ok {
+		return nil, fmt.Errorf("glyph %s not found", glyph)
+	}
+	return font.GIDOutline(gid)
+}
+
+// GIDAdvance returns the advance width of the glyph with index `gid` in glyph space.
+func (font *CFFFont) GIDAdvance(gid int) (float64, bool) {
+	if w, ok := font.advances[gid]; ok {
+		return w, true
+	}
+	if _, err := font.GIDOutline(gid); err != nil {
+		common.Log.Debug("CFF font %s: glyph %d: %v", font.fontName, gid, err)
+		return 0, false
+	}
+	w, ok := font.advances[gid]
+	return w, ok
+}
+
+// GIDOutline returns the outline of the glyph with index `gid` in glyph space.
+func (font *CFFFont) GIDOutline(gid int) (*GlyphOutline, error) {
+	if gid < 0 || gid >= len(font.charstrings) {
+		return nil, fmt.Errorf("glyph index %d out of range", gid)
+	}
+	private := font.privates[0]
+	if font.fdSelect != nil {
+		fd := int(font.fdSelect[gid])
+		if fd >= len(font.privates) {
+			return nil, fmt.Errorf("glyph %d: FD %d out of range", gid, fd)
+		}
+		private = font.privates[fd]
+	}
+
+	ctx := &type2CharstringContext{font: font, private: private, outline: &GlyphOutline{}}
+	if err := ctx.run(font.charstrings[gid], 0); err != nil {
+		return nil, err
+	}
+	if !ctx.seenWidth {
+		ctx.width = private.defaultWidthX
+	}
+	if ctx.open {
+		ctx.outline.Close()
+	}
+	font.advances[gid] = ctx.width
+	return ctx.outline, nil
+}
+
+// type2CharstringContext holds the state of the Type 2 charstring interpreter.
+type type2CharstringContext struct {
+	font    *CFFFont
+	private cffPrivate
+	outline *GlyphOutline
+
+	stack     []float64
+	transient [32]float64
+	nStems    int
+
+	x, y      float64
+	open      bool
+	width     float64
+	seenWidth bool
+	finished  bool
+}
+
+// cffSubrBias returns the bias added to subroutine numbers for a subroutine INDEX with `n` entries.
+func cffSubrBias(n int) int {
+	if n < 1240 {
+		return 107
+	} else if n < 33900 {
+		return 1131
+	}
+	return 32768
+}
+
+// run interprets Type 2 charstring `data` at subroutine nesting level `depth`.
+func (ctx *type2CharstringContext) run(data []byte, depth int) error {
+	if depth > maxSubrDepth {
+		return errors.New("charstring subroutine nesting too deep")
+	}
+
+	for i := 0; i < len(data) && !ctx.finished; {
+		v := int(data[i])
+		i++
+
+		switch {
+		case v == 28:
+			if i+2 > len(data) {
+				return errors.New("charstring: truncated number")
+			}
+			ctx.stack = append(ctx.stack, float64(int16(binary.BigEndian.Uint16(data[i:]))))
+			i += 2
+			continue
+		case v >= 32 && v <= 246:
+			ctx.stack = append(ctx.stack, float64(v-139))
+			continue
+		case v >= 247 && v <= 254:
+			if i >= len(data) {
+				return errors.New("charstring: truncated number")
+			}
+			if v <= 250 {
+				ctx.stack = append(ctx.stack, float64((v-247)*256+int(data[i])+108))
+			} else {
+				ctx.stack = append(ctx.stack, float64(-(v-251)*256-int(data[i])-108))
+			}
+			i++
+			continue
+		case v == 255:
+			if i+4 > len(data) {
+				return errors.New("charstring: truncated number")
+			}
+			ctx.stack = append(ctx.stack, float64(int32(binary.BigEndian.Uint32(data[i:])))/65536)
+			i += 4
+			continue
+		}
+
+		switch v {
+		case 10, 29: // callsubr, callgsubr
+			if len(ctx.stack) < 1 {
+				return errors.New("charstring: callsubr without operand")
+			}
+			subrs := ctx.private.subrs
+			if v == 29 {
+				subrs = ctx.font.gsubrs
+			}
+			n := int(ctx.stack[len(ctx.stack)-1]) + cffSubrBias(len(subrs))
+			ctx.stack = ctx.stack[:len(ctx.stack)-1]
+			if n < 0 || n >= len(subrs) {
+				return fmt.Errorf("charstring: invalid subr %d", n)
+			}
+			if err := ctx.run(subrs[n], depth+1); err != nil {
+				return err
+			}
+			continue
+		case 11: // return
+			return nil
+		case 19, 20: // hintmask, cntrmask
+			// Any operands are implicit vstem hints.
+			ctx.stemHints()
+			i += (ctx.nStems + 7) / 8
+			continue
+		case 12:
+			if i >= len(data) {
+				return errors.New("charstring: truncated escape")
+			}
+			v = 1200 + int(data[i])
+			i++
+		}
+
+		if err := ctx.command(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// takeWidth removes the glyph width from the bottom of the stack if the first stack clearing
+// operator has more operands than it uses. `expected` is the number of operands the operator takes,
+// or -1 if it takes an even number of operands.
+func (ctx *type2CharstringContext) takeWidth(expected int) {
+	if ctx.seenWidth {
+		return
+	}
+	ctx.seenWidth = true
+	n := len(ctx.stack)
+	if (expected < 0 && n%2 == 1) || (expected >= 0 && n > expected) {
+		ctx.width = ctx.private.nominalWidthX + ctx.stack[0]
+		ctx.stack = ctx.stack[1:]
+	} else {
+		ctx.width = ctx.private.defaultWidthX
+	}
+}
+
+// stemHints handles the operands of the hstem, vstem, hstemhm, vstemhm and hintmask operators.
+func (ctx *type2CharstringContext) stemHints() {
+	ctx.takeWidth(-1)
+	ctx.nStems += len(ctx.stack) / 2
+	ctx.stack = ctx.stack[:0]
+}
+
+// command executes operator `op` with the operands on the stack.
+func (ctx *type2CharstringContext) command(op int) error {
+	s := ctx.stack
+	clear := true
+
+	switch op {
+	case 1, 3, 18, 23: // hstem, vstem, hstemhm, vstemhm
+		ctx.stemHints()
+		return nil
+	case 21: // rmoveto
+		ctx.takeWidth(2)
+		s = ctx.stack
+		if len(s) < 2 {
+			return errors.New("charstring: rmoveto needs 2 operands")
+		}
+		ctx.moveTo(s[0], s[1])
+	case 22: // hmoveto
+		ctx.takeWidth(1)
+		s = ctx.stack
+		if len(s) < 1 {
+			return errors.New("charstring: hmoveto needs 1 operand")
+		}
+		ctx.moveTo(s[0], 0)
+	case 4: // vmoveto
+		ctx.takeWidth(1)
+		s = ctx.stack
+		if len(s) < 1 {
+			return errors.New("charstring: vmoveto needs 1 operand")
+		}
+		ctx.moveTo(0, s[0])
+	case 5: // rlineto
+		for i := 0; i+1 < len(s); i += 2 {
+			ctx.lineTo(s[i], s[i+1])
+		}
+	case 6, 7: // hlineto, vlineto: alternating horizontal and vertical lines
+		horizontal := op == 6
+		for _, d := range s {
+			if horizontal {
+				ctx.lineTo(d, 0)
+			} else {
+				ctx.lineTo(0, d)
+			}
+			horizontal = !horizontal
+		}
+	case 8: // rrcurveto
+		for i := 0; i+5 < len(s); i += 6 {
+			ctx.curveTo(s[i], s[i+1], s[i+2], s[i+3], s[i+4], s[i+5])
+		}
+	case 24: // rcurveline
+		i := 0
+		for ; i+5 < len(s)-2; i += 6 {
+			ctx.curveTo(s[i], s[i+1], s[i+2], s[i+3], s[i+4], s[i+5])
+		}
+		if i+1 < len(s) {
+			ctx.lineTo(s[i], s[i+1])
+		}
+	case 25: // rlinecurve
+		i := 0
+		for ; i+1 < len(s)-6; i += 2 {
+			ctx.lineTo(s[i], s[i+1])
+		}
+		if i+5 < len(s) {
+			ctx.curveTo(s[i], s[i+1], s[i+2], s[i+3], s[i+4], s[i+5])
+		}
+	case 26: // vvcurveto: dx1? {dya dxb dyb dyc}+
+		i := 0
+		dx1 := 0.0
+		if len(s)%4 == 1 {
+			dx1 = s[0]
+			i = 1
+		}
+		for ; i+3 < len(s); i += 4 {
+			ctx.curveTo(dx1, s[i], s[i+1], s[i+2], 0, s[i+3])
+			dx1 = 0
+		}
+	case 27: // hhcurveto: dy1? {dxa dxb dyb dxc}+
+		i := 0
+		dy1 := 0.0
+		if len(s)%4 == 1 {
+			dy1 = s[0]
+			i = 1
+		}
+		for ; i+3 < len(s); i += 4 {
+			ctx.curveTo(s[i], dy1, s[i+1], s[i+2], s[i+3], 0)
+			dy1 = 0
+		}
+	case 30, 31: // vhcurveto, hvcurveto: curves alternating between vertical and horizontal tangents
+		horizontal := op == 31
+		for i := 0; i+3 < len(s); i += 4 {
+			last := 0.0
+			if len(s)-i == 5 {
+				last = s[i+4]
+			}
+			if horizontal {
+				ctx.curveTo(s[i], 0, s[i+1], s[i+2], last, s[i+3])
+			} else {
+				ctx.curveTo(0, s[i], s[i+1], s[i+2], s[i+3], last)
+			}
+			horizontal = !horizontal
+		}
+	case 14: // endchar
+		ctx.takeWidth(0)
+		s = ctx.stack
+		if ctx.open {
+			ctx.outline.Close()
+			ctx.open = false
+		}
+		if len(s) >= 4 {
+			if err := ctx.seac(s[len(s)-4], s[len(s)-3], int(s[len(s)-2]), int(s[len(s)-1])); err != nil {
+				return err
+			}
+		}
+		ctx.finished = true
+	case 1235: // flex
+		if len(s) < 13 {
+			return errors.New("charstring: flex needs 13 operands")
+		}
+		ctx.curveTo(s[0], s[1], s[2], s[3], s[4], s[5])
+		ctx.curveTo(s[6], s[7], s[8], s[9], s[10], s[11])
+	case 1234: // hflex
+		if len(s) < 7 {
+			return errors.New("charstring: hflex needs 7 operands")
+		}
+		y := ctx.y
+		ctx.curveTo(s[0], 0, s[1], s[2], s[3], 0)
+		ctx.curveTo(s[4], 0, s[5], y-ctx.y, s[6], 0)
+	case 1236: // hflex1
+		if len(s) < 9 {
+			return errors.New("charstring: hflex1 needs 9 operands")
+		}
+		y := ctx.y
+		ctx.curveTo(s[0], s[1], s[2], s[3], s[4], 0)
+		ctx.curveTo(s[5], 0, s[6], s[7], s[8], y-ctx.y-s[7])
+	case 1237: // flex1
+		if len(s) < 11 {
+			return errors.New("charstring: flex1 needs 11 operands")
+		}
+		dx := s[0] + s[2] + s[4] + s[6] + s[8]
+		dy := s[1] + s[3] + s[5] + s[7] + s[9]
+		ctx.curveTo(s[0], s[1], s[2], s[3], s[4], s[5])
+		if math.Abs(dx) > math.Abs(dy) {
+			ctx.curveTo(s[6], s[7], s[8], s[9], s[10], -dy)
+		} else {
+			ctx.curveTo(s[6], s[7], s[8], s[9], -dx, s[10])
+		}
+	default:
+		if op >= 1200 {
+			clear = false
+			if err := ctx.arithmetic(op); err != nil {
+				return err
+			}
+		} else {
+			common.Log.Debug("Type2 charstring: unsupported operator %d", op)
+		}
+	}
+	if clear {
+		ctx.stack = ctx.stack[:0]
+	}
+	return nil
+}
+
+// arithmetic executes the arithmetic and storage operators (Type 2 charstring spec 4.4 and 4.5).
+func (ctx *type2CharstringContext) arithmetic(op int) error {
+	s := ctx.stack
+	n := len(s)
+	need := func(k int) error {
+		if n < k {
+			return fmt.Errorf("charstring: operator %d needs %d operands", op, k)
+		}
+		return nil
+	}
+	bool2float := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	switch op {
+	case 1203, 1204, 1210, 1211, 1212, 1215, 1224: // and, or, add, sub, div, eq, mul
+		if err := need(2); err != nil {
+			return err
+		}
+		a, b := s[n-2], s[n-1]
+		var r float64
+		switch op {
+		case 1203:
+			r = bool2float(a != 0 && b != 0)
+		case 1204:
+			r = bool2float(a != 0 || b != 0)
+		case 1210:
+			r = a + b
+		case 1211:
+			r = a - b
+		case 1212:
+			if b != 0 {
+				r = a / b
+			}
+		case 1215:
+			r = bool2float(a == b)
+		case 1224:
+			r = a * b
+		}
+		ctx.stack = append(s[:n-2], r)
+	case 1205, 1209, 1214, 1226, 1227: // not, abs, neg, sqrt, dup
+		if err := need(1); err != nil {
+			return err
+		}
+		a := s[n-1]
+		switch op {
+		case 1205:
+			s[n-1] = bool2float(a == 0)
+		case 1209:
+			s[n-1] = math.Abs(a)
+		case 1214:
+			s[n-1] = -a
+		case 1226:
+			s[n-1] = math.Sqrt(math.Abs(a))
+		case 1227:
+			ctx.stack = append(s, a)
+		}
+	case 1218: // drop
+		if err := need(1); err != nil {
+			return err
+		}
+		ctx.stack = s[:n-1]
+	case 1228: // exch
+		if err := need(2); err != nil {
+			return err
+		}
+		s[n-2], s[n-1] = s[n-1], s[n-2]
+	case 1229: // index
+		if err := need(1); err != nil {
+			return err
+		}
+		i := int(s[n-1])
+		if i < 0 {
+			i = 0
+		}
+		if i > n-2 {
+			return errors.New("charstring: index out of range")
+		}
+		s[n-1] = s[n-2-i]
+	case 1220: // put
+		if err := need(2); err != nil {
+			return err
+		}
+		i := int(s[n-1])
+		if i >= 0 && i < len(ctx.transient) {
+			ctx.transient[i] = s[n-2]
+		}
+		ctx.stack = s[:n-2]
+	case 1221: // get
+		if err := need(1); err != nil {
+			return err
+		}
+		i := int(s[n-1])
+		if i >= 0 && i < len(ctx.transient) {
+			s[n-1] = ctx.transient[i]
+		} else {
+			s[n-1] = 0
+		}
+	case 1222: // ifelse
+		if err := need(4); err != nil {
+			return err
+		}
+		r := s[n-4]
+		if s[n-2] > s[n-1] {
+			r = s[n-3]
+		}
+		ctx.stack = append(s[:n-4], r)
+	case 1223: // random: deterministic value in (0, 1]
+		ctx.stack = append(s, 0.5)
+	default:
+		common.Log.Debug("Type2 charstring: unsupported operator 12 %d", op-1200)
+		ctx.stack = s[:0]
+	}
+	return nil
+}
+
+// seac draws an accented character, as given by the deprecated four argument form of endchar. The
+// base and accent glyphs `bchar` and `achar` are StandardEncoding codes and the accent is offset by
+// (adx, ady).
+func (ctx *type2CharstringContext) seac(adx, ady float64, bchar, achar int) error {
+	encoder := textencoding.NewStandardTextEncoder()
+	baseName, ok1 := encoder.CharcodeToGlyph(byte(bchar))
+	accentName, ok2 := encoder.CharcodeToGlyph(byte(achar))
+	if !ok1 || !ok2 {
+		return errors.New("charstring: invalid seac character codes")
+	}
+	baseGID, ok1 := ctx.font.nameToGID[baseName]
+	accentGID, ok2 := ctx.font.nameToGID[accentName]
+	if !ok1 || !ok2 {
+		return fmt.Errorf("charstring: seac glyphs %s %s not in font", baseName, accentName)
+	}
+	width := ctx.width
+	base, err := ctx.font.GIDOutline(baseGID)
+	if err != nil {
+		return err
+	}
+	accent, err := ctx.font.GIDOutline(accentGID)
+	if err != nil {
+		return err
+	}
+	accent.Translate(adx, ady)
+	ctx.outline.Append(base)
+	ctx.outline.Append(accent)
+	ctx.width = width
+	return nil
+}
+
+func (ctx *type2CharstringContext) moveTo(dx, dy float64) {
+	if ctx.open {
+		ctx.outline.Close()
+	}
+	ctx.x += dx
+	ctx.y += dy
+	ctx.outline.MoveTo(ctx.x, ctx.y)
+	ctx.open = true
+}
+
+func (ctx *type2CharstringContext) lineTo(dx, dy float64) {
+	ctx.x += dx
+	ctx.y += dy
+	ctx.outline.LineTo(ctx.x, ctx.y)
+}
+
+func (ctx *type2CharstringContext) curveTo(dx1, dy1, dx2, dy2, dx3, dy3 float64) {
+	x1, y1 := ctx.x+dx1, ctx.y+dy1
+	x2, y2 := x1+dx2, y1+dy2
+	ctx.x, ctx.y = x2+dx3, y2+dy3
+	ctx.outline.CubeTo(x1, y1, x2, y2, ctx.x, ctx.y)
+}
@@ -39,6 +39,12 @@ func (font fontCourier) GetGlyphCharMetrics(glyph string) (CharMetrics, bool) {
 	return metrics, true
 }
 
+// GetLigature returns the ligature glyph that replaces glyph `left` followed by glyph `right`.
+func (font fontCourier) GetLigature(left, right string) (string, bool) {
+	ligature, has := standardLigatures[GlyphPair{left, right}]
+	return ligature, has
+}
+
 func (font fontCourier) ToPdfObject() core.PdfObject {
 	obj := &core.PdfIndirectObject{}
 
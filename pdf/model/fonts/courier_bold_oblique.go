@@ -39,6 +39,12 @@ func (font fontCourierBoldOblique) GetGlyphCharMetrics(glyph string) (CharMetric
 	return metrics, true
 }
 
+// GetLigature returns the ligature glyph that replaces glyph `left` followed by glyph `right`.
+func (font fontCourierBoldOblique) GetLigature(left, right string) (string, bool) {
+	ligature, has := standardLigatures[GlyphPair{left, right}]
+	return ligature, has
+}
+
 func (font fontCourierBoldOblique) ToPdfObject() core.PdfObject {
 	obj := &core.PdfIndirectObject{}
 
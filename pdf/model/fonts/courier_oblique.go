@@ -39,6 +39,12 @@ func (font fontCourierOblique) GetGlyphCharMetrics(glyph string) (CharMetrics, b
 	return metrics, true
 }
 
+// GetLigature returns the ligature glyph that replaces glyph `left` followed by glyph `right`.
+func (font fontCourierOblique) GetLigature(left, right string) (string, bool) {
+	ligature, has := standardLigatures[GlyphPair{left, right}]
+	return ligature, has
+}
+
 func (font fontCourierOblique) ToPdfObject() core.PdfObject {
 	obj := &core.PdfIndirectObject{}
 
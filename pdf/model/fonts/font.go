@@ -21,3 +21,29 @@ type CharMetrics struct {
 	Wx        float64
 	Wy        float64
 }
+
+// GlyphPair is a pair of adjacent glyphs, identified by glyph name.
+type GlyphPair struct {
+	Left  string
+	Right string
+}
+
+// KernedFont is implemented by fonts with kerning information.
+type KernedFont interface {
+	// GetKerning returns the adjustment of the horizontal position of glyph `right` when it follows
+	// glyph `left`, in thousandths of a unit of text space. Negative values move the glyphs closer.
+	GetKerning(left, right string) (float64, bool)
+}
+
+// LigatureFont is implemented by fonts with ligature information.
+type LigatureFont interface {
+	// GetLigature returns the name of the glyph that replaces glyph `left` followed by glyph `right`.
+	GetLigature(left, right string) (string, bool)
+}
+
+// standardLigatures are the ligatures of the AFM files of the standard 14 fonts that substitute
+// a ligature glyph.
+var standardLigatures = map[GlyphPair]string{
+	{"f", "i"}: "fi",
+	{"f", "l"}: "fl",
+}
@@ -39,6 +39,20 @@ func (font fontHelveticaBoldOblique) GetGlyphCharMetrics(glyph string) (CharMetr
 	return metrics, true
 }
 
+// GetKerning returns the kerning of glyph `right` following glyph `left` in thousandths of a unit
+// of text space.
+func (font fontHelveticaBoldOblique) GetKerning(left, right string) (float64, bool) {
+	// The kerning pairs of Helvetica-BoldOblique are identical to those of Helvetica-Bold.
+	kx, has := helveticaBoldKerning[GlyphPair{left, right}]
+	return kx, has
+}
+
+// GetLigature returns the ligature glyph that replaces glyph `left` followed by glyph `right`.
+func (font fontHelveticaBoldOblique) GetLigature(left, right string) (string, bool) {
+	ligature, has := standardLigatures[GlyphPair{left, right}]
+	return ligature, has
+}
+
 func (font fontHelveticaBoldOblique) ToPdfObject() core.PdfObject {
 	obj := &core.PdfIndirectObject{}
 
@@ -39,6 +39,20 @@ func (font fontHelveticaOblique) GetGlyphCharMetrics(glyph string) (CharMetrics,
 	return metrics, true
 }
 
+// GetKerning returns the kerning of glyph `right` following glyph `left` in thousandths of a unit
+// of text space.
+func (font fontHelveticaOblique) GetKerning(left, right string) (float64, bool) {
+	// The kerning pairs of Helvetica-Oblique are identical to those of Helvetica.
+	kx, has := helveticaKerning[GlyphPair{left, right}]
+	return kx, has
+}
+
+// GetLigature returns the ligature glyph that replaces glyph `left` followed by glyph `right`.
+func (font fontHelveticaOblique) GetLigature(left, right string) (string, bool) {
+	ligature, has := standardLigatures[GlyphPair{left, right}]
+	return ligature, has
+}
+
 func (font fontHelveticaOblique) ToPdfObject() core.PdfObject {
 	obj := &core.PdfIndirectObject{}
 
@@ -0,0 +1,50 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// GetSfntTable returns the contents of the table with tag `tag` (e.g. "CFF ", "head") in the
+// TrueType or OpenType font `data`.
+func GetSfntTable(data []byte, tag string) ([]byte, error) {
+	if len(data) < 12 {
+		return nil, errors.New("sfnt: header too short")
+	}
+	version := string(data[:4])
+	if version != "OTTO" && version != "\x00\x01\x00\x00" && version != "true" {
+		return nil, errors.New("sfnt: unrecognized file format")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if 12+16*numTables > len(data) {
+		return nil, errors.New("sfnt: table directory out of range")
+	}
+	for i := 0; i < numTables; i++ {
+		record := data[12+16*i:]
+		if string(record[:4]) != tag {
+			continue
+		}
+		offset := int(binary.BigEndian.Uint32(record[8:]))
+		length := int(binary.BigEndian.Uint32(record[12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("sfnt: table %q out of range", tag)
+		}
+		return data[offset : offset+length], nil
+	}
+	return nil, fmt.Errorf("sfnt: table %q not found", tag)
+}
+
+// ParseOpenTypeCFF parses the CFF table of the OpenType font `data`.
+func ParseOpenTypeCFF(data []byte) (*CFFFont, error) {
+	table, err := GetSfntTable(data, "CFF ")
+	if err != nil {
+		return nil, err
+	}
+	return ParseCFF(table)
+}
@@ -0,0 +1,618 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// GIDPair is a pair of adjacent glyphs, identified by glyph index.
+type GIDPair struct {
+	Left  uint16
+	Right uint16
+}
+
+// SfntKerning holds the pair kerning of a TrueType or OpenType font. It is loaded from the pair
+// adjustment lookups of the GPOS 'kern' feature or, if the font has none, from the kern table.
+type SfntKerning struct {
+	// lookups are applied in order and their adjustments are summed. Within a lookup the first
+	// subtable with an adjustment for a pair applies.
+	lookups [][]pairSubtable
+}
+
+// pairSubtable is a subtable that adjusts the position of pairs of glyphs.
+type pairSubtable interface {
+	kerning(left, right uint16) (int16, bool)
+}
+
+// GetKerning returns the adjustment of the advance of glyph `left` when followed by glyph `right` in
+// font units.
+func (sk *SfntKerning) GetKerning(left, right uint16) (int16, bool) {
+	if sk == nil {
+		return 0, false
+	}
+	kx, found := int16(0), false
+	for _, subtables := range sk.lookups {
+		for _, subtable := range subtables {
+			if k, ok := subtable.kerning(left, right); ok {
+				kx += k
+				found = true
+				break
+			}
+		}
+	}
+	return kx, found
+}
+
+// ParseSfntKerning loads the pair kerning of the TrueType or OpenType font `data`. Returns nil and
+// no error if the font has no kerning.
+func ParseSfntKerning(data []byte) (*SfntKerning, error) {
+	if gpos, err := GetSfntTable(data, "GPOS"); err == nil {
+		lookups, err := parseGposKerning(gpos)
+		if err != nil {
+			return nil, err
+		}
+		if len(lookups) > 0 {
+			return &SfntKerning{lookups: lookups}, nil
+		}
+	}
+	kern, err := GetSfntTable(data, "kern")
+	if err != nil {
+		return nil, nil
+	}
+	pairs, err := parseKernTable(kern)
+	if err != nil || len(pairs) == 0 {
+		return nil, err
+	}
+	return &SfntKerning{lookups: [][]pairSubtable{{pairs}}}, nil
+}
+
+// ParseSfntLigatures loads the two glyph ligatures of the GSUB 'liga' (standard ligatures) feature of
+// the TrueType or OpenType font `data`. The result maps the glyph pairs to the index of the glyph
+// that replaces them.
+func ParseSfntLigatures(data []byte) (map[GIDPair]uint16, error) {
+	gsub, err := GetSfntTable(data, "GSUB")
+	if err != nil {
+		return nil, nil
+	}
+	lookups, err := getLayoutFeatureSubtables(gsub, "liga", 4, 7)
+	if err != nil {
+		return nil, err
+	}
+
+	ligatures := map[GIDPair]uint16{}
+	for _, subtables := range lookups {
+		for _, st := range subtables {
+			if err := parseLigatureSubst(st, ligatures); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ligatures, nil
+}
+
+var errLayoutRange = errors.New("sfnt: layout table out of range")
+
+// layoutData is a bounds checked reader of OpenType layout tables.
+type layoutData []byte
+
+func (d layoutData) u16(offset int) (uint16, error) {
+	if offset < 0 || offset+2 > len(d) {
+		return 0, errLayoutRange
+	}
+	return binary.BigEndian.Uint16(d[offset:]), nil
+}
+
+func (d layoutData) u32(offset int) (uint32, error) {
+	if offset < 0 || offset+4 > len(d) {
+		return 0, errLayoutRange
+	}
+	return binary.BigEndian.Uint32(d[offset:]), nil
+}
+
+// sub returns the data from `offset`.
+func (d layoutData) sub(offset int) (layoutData, error) {
+	if offset < 0 || offset > len(d) {
+		return nil, errLayoutRange
+	}
+	return d[offset:], nil
+}
+
+// u16s reads `n` consecutive uint16 values from `offset`.
+func (d layoutData) u16s(offset, n int) ([]uint16, error) {
+	if n < 0 || offset < 0 || offset+2*n > len(d) {
+		return nil, errLayoutRange
+	}
+	vals := make([]uint16, n)
+	for i := range vals {
+		vals[i] = binary.BigEndian.Uint16(d[offset+2*i:])
+	}
+	return vals, nil
+}
+
+// getLayoutFeatureSubtables returns the subtables of the lookups of feature `tag` in GSUB or GPOS
+// table `table`, grouped by lookup in lookup list order. Only lookups of type `lookupType` are
+// returned, with extension lookups (type `extensionType`) resolved.
+func getLayoutFeatureSubtables(table []byte, tag string, lookupType, extensionType uint16) ([][]layoutData, error) {
+	d := layoutData(table)
+	featureListOffset, err := d.u16(6)
+	if err != nil {
+		return nil, err
+	}
+	lookupListOffset, err := d.u16(8)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lookups of all the features with tag `tag`, regardless of script and language.
+	featureList, err := d.sub(int(featureListOffset))
+	if err != nil {
+		return nil, err
+	}
+	featureCount, err := featureList.u16(0)
+	if err != nil {
+		return nil, err
+	}
+	lookupIndices := map[uint16]bool{}
+	for i := 0; i < int(featureCount); i++ {
+		record := 2 + 6*i
+		if record+6 > len(featureList) {
+			return nil, errLayoutRange
+		}
+		if string(featureList[record:record+4]) != tag {
+			continue
+		}
+		offset, _ := featureList.u16(record + 4)
+		feature, err := featureList.sub(int(offset))
+		if err != nil {
+			return nil, err
+		}
+		count, err := feature.u16(2)
+		if err != nil {
+			return nil, err
+		}
+		indices, err := feature.u16s(4, int(count))
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indices {
+			lookupIndices[index] = true
+		}
+	}
+	if len(lookupIndices) == 0 {
+		return nil, nil
+	}
+
+	lookupList, err := d.sub(int(lookupListOffset))
+	if err != nil {
+		return nil, err
+	}
+	lookupCount, err := lookupList.u16(0)
+	if err != nil {
+		return nil, err
+	}
+	lookupOffsets, err := lookupList.u16s(2, int(lookupCount))
+	if err != nil {
+		return nil, err
+	}
+
+	var lookups [][]layoutData
+	for index, offset := range lookupOffsets {
+		if !lookupIndices[uint16(index)] {
+			continue
+		}
+		lookup, err := lookupList.sub(int(offset))
+		if err != nil {
+			return nil, err
+		}
+		ltype, err := lookup.u16(0)
+		if err != nil {
+			return nil, err
+		}
+		count, err := lookup.u16(4)
+		if err != nil {
+			return nil, err
+		}
+		offsets, err := lookup.u16s(6, int(count))
+		if err != nil {
+			return nil, err
+		}
+
+		var subtables []layoutData
+		for _, offset := range offsets {
+			st, err := lookup.sub(int(offset))
+			if err != nil {
+				return nil, err
+			}
+			stype := ltype
+			if ltype == extensionType {
+				// Extension subtable: format, extensionLookupType, extensionOffset (32 bit).
+				if stype, err = st.u16(2); err != nil {
+					return nil, err
+				}
+				extOffset, err := st.u32(4)
+				if err != nil {
+					return nil, err
+				}
+				if st, err = st.sub(int(extOffset)); err != nil {
+					return nil, err
+				}
+			}
+			if stype == lookupType {
+				subtables = append(subtables, st)
+			}
+		}
+		if len(subtables) > 0 {
+			lookups = append(lookups, subtables)
+		}
+	}
+	return lookups, nil
+}
+
+// parseCoverage parses the coverage table at the start of `d` and returns the coverage index of each
+// glyph covered.
+func parseCoverage(d layoutData) (map[uint16]int, error) {
+	format, err := d.u16(0)
+	if err != nil {
+		return nil, err
+	}
+	count, err := d.u16(2)
+	if err != nil {
+		return nil, err
+	}
+	coverage := map[uint16]int{}
+	switch format {
+	case 1:
+		glyphs, err := d.u16s(4, int(count))
+		if err != nil {
+			return nil, err
+		}
+		for i, gid := range glyphs {
+			coverage[gid] = i
+		}
+	case 2:
+		ranges, err := d.u16s(4, 3*int(count))
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(ranges); i += 3 {
+			start, end, index := int(ranges[i]), int(ranges[i+1]), int(ranges[i+2])
+			for gid := start; gid <= end; gid++ {
+				coverage[uint16(gid)] = index + gid - start
+			}
+		}
+	default:
+		return nil, errors.New("sfnt: unsupported coverage format")
+	}
+	return coverage, nil
+}
+
+// parseClassDef parses the class definition table at the start of `d`. Glyphs not in the result are
+// in class 0.
+func parseClassDef(d layoutData) (map[uint16]uint16, error) {
+	format, err := d.u16(0)
+	if err != nil {
+		return nil, err
+	}
+	classes := map[uint16]uint16{}
+	switch format {
+	case 1:
+		start, err := d.u16(2)
+		if err != nil {
+			return nil, err
+		}
+		count, err := d.u16(4)
+		if err != nil {
+			return nil, err
+		}
+		vals, err := d.u16s(6, int(count))
+		if err != nil {
+			return nil, err
+		}
+		for i, class := range vals {
+			classes[start+uint16(i)] = class
+		}
+	case 2:
+		count, err := d.u16(2)
+		if err != nil {
+			return nil, err
+		}
+		ranges, err := d.u16s(4, 3*int(count))
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(ranges); i += 3 {
+			for gid := int(ranges[i]); gid <= int(ranges[i+1]); gid++ {
+				classes[uint16(gid)] = ranges[i+2]
+			}
+		}
+	default:
+		return nil, errors.New("sfnt: unsupported class definition format")
+	}
+	return classes, nil
+}
+
+// valueRecordSize returns the size in bytes of a GPOS value record with format `format`.
+func valueRecordSize(format uint16) int {
+	size := 0
+	for ; format != 0; format >>= 1 {
+		size += 2 * int(format&1)
+	}
+	return size
+}
+
+// valueRecordXAdvance returns the XAdvance of the value record at `offset` in `d`.
+func valueRecordXAdvance(d layoutData, offset int, format uint16) (int16, error) {
+	if format&0x0004 == 0 {
+		return 0, nil
+	}
+	// XPlacement and YPlacement precede XAdvance.
+	v, err := d.u16(offset + valueRecordSize(format&0x0003))
+	return int16(v), err
+}
+
+// parseGposKerning returns the pair adjustment subtables of the lookups of the GPOS 'kern' feature.
+func parseGposKerning(gpos []byte) ([][]pairSubtable, error) {
+	lookups, err := getLayoutFeatureSubtables(gpos, "kern", 2, 9)
+	if err != nil {
+		return nil, err
+	}
+	var kerning [][]pairSubtable
+	for _, subtables := range lookups {
+		var pairSubtables []pairSubtable
+		for _, st := range subtables {
+			pst, err := parsePairPos(st)
+			if err != nil {
+				return nil, err
+			}
+			if pst != nil {
+				pairSubtables = append(pairSubtables, pst)
+			}
+		}
+		if len(pairSubtables) > 0 {
+			kerning = append(kerning, pairSubtables)
+		}
+	}
+	return kerning, nil
+}
+
+// pairPosFormat1 is a GPOS pair adjustment subtable with individual glyph pairs.
+type pairPosFormat1 struct {
+	coverage map[uint16]int
+	pairSets []map[uint16]int16
+}
+
+func (pp *pairPosFormat1) kerning(left, right uint16) (int16, bool) {
+	index, ok := pp.coverage[left]
+	if !ok || index >= len(pp.pairSets) {
+		return 0, false
+	}
+	kx, ok := pp.pairSets[index][right]
+	return kx, ok
+}
+
+// pairPosFormat2 is a GPOS pair adjustment subtable with glyph classes.
+type pairPosFormat2 struct {
+	coverage    map[uint16]int
+	classDef1   map[uint16]uint16
+	classDef2   map[uint16]uint16
+	class1Count int
+	class2Count int
+	values      []int16 // Indexed by class1*class2Count + class2.
+}
+
+func (pp *pairPosFormat2) kerning(left, right uint16) (int16, bool) {
+	if _, ok := pp.coverage[left]; !ok {
+		return 0, false
+	}
+	class1, class2 := int(pp.classDef1[left]), int(pp.classDef2[right])
+	if class1 >= pp.class1Count || class2 >= pp.class2Count {
+		return 0, false
+	}
+	return pp.values[class1*pp.class2Count+class2], true
+}
+
+// parsePairPos parses GPOS lookup type 2 subtable `d`.
+func parsePairPos(d layoutData) (pairSubtable, error) {
+	vals, err := d.u16s(0, 5)
+	if err != nil {
+		return nil, err
+	}
+	format, coverageOffset, valueFormat1, valueFormat2 := vals[0], vals[1], vals[2], vals[3]
+	cov, err := d.sub(int(coverageOffset))
+	if err != nil {
+		return nil, err
+	}
+	coverage, err := parseCoverage(cov)
+	if err != nil {
+		return nil, err
+	}
+	size1, size2 := valueRecordSize(valueFormat1), valueRecordSize(valueFormat2)
+
+	switch format {
+	case 1:
+		pairSetCount := int(vals[4])
+		offsets, err := d.u16s(10, pairSetCount)
+		if err != nil {
+			return nil, err
+		}
+		pp := &pairPosFormat1{coverage: coverage, pairSets: make([]map[uint16]int16, pairSetCount)}
+		for i, offset := range offsets {
+			pairSet, err := d.sub(int(offset))
+			if err != nil {
+				return nil, err
+			}
+			count, err := pairSet.u16(0)
+			if err != nil {
+				return nil, err
+			}
+			pairs := map[uint16]int16{}
+			for j := 0; j < int(count); j++ {
+				record := 2 + j*(2+size1+size2)
+				second, err := pairSet.u16(record)
+				if err != nil {
+					return nil, err
+				}
+				kx, err := valueRecordXAdvance(pairSet, record+2, valueFormat1)
+				if err != nil {
+					return nil, err
+				}
+				pairs[second] = kx
+			}
+			pp.pairSets[i] = pairs
+		}
+		return pp, nil
+	case 2:
+		more, err := d.u16s(8, 4)
+		if err != nil {
+			return nil, err
+		}
+		cd1, err := d.sub(int(more[0]))
+		if err != nil {
+			return nil, err
+		}
+		cd2, err := d.sub(int(more[1]))
+		if err != nil {
+			return nil, err
+		}
+		pp := &pairPosFormat2{coverage: coverage, class1Count: int(more[2]), class2Count: int(more[3])}
+		if pp.classDef1, err = parseClassDef(cd1); err != nil {
+			return nil, err
+		}
+		if pp.classDef2, err = parseClassDef(cd2); err != nil {
+			return nil, err
+		}
+		pp.values = make([]int16, pp.class1Count*pp.class2Count)
+		for i := range pp.values {
+			kx, err := valueRecordXAdvance(d, 16+i*(size1+size2), valueFormat1)
+			if err != nil {
+				return nil, err
+			}
+			pp.values[i] = kx
+		}
+		return pp, nil
+	}
+	return nil, nil
+}
+
+// kernPairs are the glyph pairs of a kern table format 0 subtable.
+type kernPairs map[GIDPair]int16
+
+func (kp kernPairs) kerning(left, right uint16) (int16, bool) {
+	kx, ok := kp[GIDPair{left, right}]
+	return kx, ok
+}
+
+// parseKernTable parses the horizontal format 0 subtables of the (Microsoft version 0) kern table.
+func parseKernTable(kern []byte) (kernPairs, error) {
+	d := layoutData(kern)
+	version, err := d.u16(0)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 {
+		// The Apple kern table (version 1.0) is not supported.
+		return nil, nil
+	}
+	nTables, err := d.u16(2)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := kernPairs{}
+	offset := 4
+	for i := 0; i < int(nTables); i++ {
+		header, err := d.u16s(offset, 3)
+		if err != nil {
+			return nil, err
+		}
+		length, coverage := int(header[1]), header[2]
+		format := coverage >> 8
+		// Horizontal kerning values (bit 0), not minimum values (bit 1) or cross stream (bit 2).
+		if format == 0 && coverage&0x0007 == 0x0001 {
+			nPairs, err := d.u16(offset + 6)
+			if err != nil {
+				return nil, err
+			}
+			vals, err := d.u16s(offset+14, 3*int(nPairs))
+			if err != nil {
+				return nil, err
+			}
+			for j := 0; j < len(vals); j += 3 {
+				pairs[GIDPair{vals[j], vals[j+1]}] += int16(vals[j+2])
+			}
+		}
+		if length < 6 {
+			break
+		}
+		offset += length
+	}
+	return pairs, nil
+}
+
+// parseLigatureSubst adds the two glyph ligatures of GSUB lookup type 4 subtable `d` to `ligatures`.
+func parseLigatureSubst(d layoutData, ligatures map[GIDPair]uint16) error {
+	vals, err := d.u16s(0, 3)
+	if err != nil {
+		return err
+	}
+	if vals[0] != 1 {
+		return nil
+	}
+	cov, err := d.sub(int(vals[1]))
+	if err != nil {
+		return err
+	}
+	coverage, err := parseCoverage(cov)
+	if err != nil {
+		return err
+	}
+	setOffsets, err := d.u16s(6, int(vals[2]))
+	if err != nil {
+		return err
+	}
+	for first, index := range coverage {
+		if index >= len(setOffsets) {
+			continue
+		}
+		set, err := d.sub(int(setOffsets[index]))
+		if err != nil {
+			return err
+		}
+		count, err := set.u16(0)
+		if err != nil {
+			return err
+		}
+		offsets, err := set.u16s(2, int(count))
+		if err != nil {
+			return err
+		}
+		for _, offset := range offsets {
+			lig, err := set.sub(int(offset))
+			if err != nil {
+				return err
+			}
+			// ligatureGlyph, componentCount, componentGlyphIDs (excluding the first).
+			header, err := lig.u16s(0, 2)
+			if err != nil {
+				return err
+			}
+			if header[1] != 2 {
+				continue
+			}
+			second, err := lig.u16(4)
+			if err != nil {
+				return err
+			}
+			pair := GIDPair{first, second}
+			if _, has := ligatures[pair]; !has {
+				ligatures[pair] = header[0]
+			}
+		}
+	}
+	return nil
+}
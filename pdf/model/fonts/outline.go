@@ -0,0 +1,133 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import "math"
+
+// FontProgram is implemented by parsed font programs that are embedded in PDF files, such as Type 1
+// (FontFile) and CFF (FontFile3) fonts. Glyph metrics and outlines are in glyph space, which is
+// mapped to text space by the font matrix.
+type FontProgram interface {
+	// Name returns the PostScript name of the font.
+	Name() string
+
+	// FontMatrix returns the matrix [a b c d e f] that maps glyph space to text space.
+	FontMatrix() [6]float64
+
+	// BuiltinEncoding returns the font's built-in character code to glyph name map.
+	BuiltinEncoding() map[byte]string
+
+	// GlyphNames returns the names of the glyphs in the font.
+	GlyphNames() []string
+
+	// GlyphAdvance returns the advance width of glyph `glyph` in glyph space.
+	// The bool return flag is true if there was a match, and false otherwise.
+	GlyphAdvance(glyph string) (float64, bool)
+
+	// GlyphOutline returns the outline of glyph `glyph` in glyph space.
+	GlyphOutline(glyph string) (*GlyphOutline, error)
+}
+
+// OutlineOp is a path construction operation in a glyph outline.
+type OutlineOp int
+
+const (
+	OutlineMoveTo OutlineOp = iota // 1 point.
+	OutlineLineTo                  // 1 point.
+	OutlineQuadTo                  // Quadratic Bézier curve. 2 points: control point and end point.
+	OutlineCubeTo                  // Cubic Bézier curve. 3 points: 2 control points and end point.
+	OutlineClose                   // Close the current subpath. No points.
+)
+
+// OutlinePoint is a point in glyph space.
+type OutlinePoint struct {
+	X, Y float64
+}
+
+// OutlineSegment is a single path construction operation and its points.
+type OutlineSegment struct {
+	Op     OutlineOp
+	Points [3]OutlinePoint
+}
+
+// GlyphOutline represents the outline of a glyph as a path in glyph space.
+type GlyphOutline struct {
+	Segments []OutlineSegment
+}
+
+// MoveTo starts a new subpath at (x, y).
+func (o *GlyphOutline) MoveTo(x, y float64) {
+	o.Segments = append(o.Segments, OutlineSegment{Op: OutlineMoveTo, Points: [3]OutlinePoint{{x, y}}})
+}
+
+// LineTo appends a straight line to (x, y).
+func (o *GlyphOutline) LineTo(x, y float64) {
+	o.Segments = append(o.Segments, OutlineSegment{Op: OutlineLineTo, Points: [3]OutlinePoint{{x, y}}})
+}
+
+// QuadTo appends a quadratic Bézier curve with control point (x1, y1) to (x, y).
+func (o *GlyphOutline) QuadTo(x1, y1, x, y float64) {
+	o.Segments = append(o.Segments, OutlineSegment{Op: OutlineQuadTo,
+		Points: [3]OutlinePoint{{x1, y1}, {x, y}}})
+}
+
+// CubeTo appends a cubic Bézier curve with control points (x1, y1), (x2, y2) to (x, y).
+func (o *GlyphOutline) CubeTo(x1, y1, x2, y2, x, y float64) {
+	o.Segments = append(o.Segments, OutlineSegment{Op: OutlineCubeTo,
+		Points: [3]OutlinePoint{{x1, y1}, {x2, y2}, {x, y}}})
+}
+
+// Close closes the current subpath.
+func (o *GlyphOutline) Close() {
+	o.Segments = append(o.Segments, OutlineSegment{Op: OutlineClose})
+}
+
+// NumPoints returns the number of points used by the segment's operation.
+func (seg OutlineSegment) NumPoints() int {
+	switch seg.Op {
+	case OutlineMoveTo, OutlineLineTo:
+		return 1
+	case OutlineQuadTo:
+		return 2
+	case OutlineCubeTo:
+		return 3
+	}
+	return 0
+}
+
+// Translate moves all points of the outline by (dx, dy).
+func (o *GlyphOutline) Translate(dx, dy float64) {
+	for i, seg := range o.Segments {
+		for j := 0; j < seg.NumPoints(); j++ {
+			o.Segments[i].Points[j].X += dx
+			o.Segments[i].Points[j].Y += dy
+		}
+	}
+}
+
+// Append adds the segments of `other` to the outline.
+func (o *GlyphOutline) Append(other *GlyphOutline) {
+	o.Segments = append(o.Segments, other.Segments...)
+}
+
+// Bounds returns the bounding box (llx, lly, urx, ury) of the points of the outline, including the
+// control points of curves.
+func (o *GlyphOutline) Bounds() (float64, float64, float64, float64) {
+	first := true
+	var llx, lly, urx, ury float64
+	for _, seg := range o.Segments {
+		for _, p := range seg.Points[:seg.NumPoints()] {
+			if first {
+				llx, lly, urx, ury = p.X, p.Y, p.X, p.Y
+				first = false
+				continue
+			}
+			llx, lly = math.Min(llx, p.X), math.Min(lly, p.Y)
+			urx, ury = math.Max(urx, p.X), math.Max(ury, p.Y)
+		}
+	}
+	return llx, lly, urx, ury
+}
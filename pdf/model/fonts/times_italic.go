@@ -39,6 +39,19 @@ func (font fontTimesItalic) GetGlyphCharMetrics(glyph string) (CharMetrics, bool
 	return metrics, true
 }
 
+// GetKerning returns the kerning of glyph `right` following glyph `left` in thousandths of a unit
+// of text space.
+func (font fontTimesItalic) GetKerning(left, right string) (float64, bool) {
+	kx, has := timesItalicKerning[GlyphPair{left, right}]
+	return kx, has
+}
+
+// GetLigature returns the ligature glyph that replaces glyph `left` followed by glyph `right`.
+func (font fontTimesItalic) GetLigature(left, right string) (string, bool) {
+	ligature, has := standardLigatures[GlyphPair{left, right}]
+	return ligature, has
+}
+
 func (font fontTimesItalic) ToPdfObject() core.PdfObject {
 	obj := &core.PdfIndirectObject{}
 
@@ -370,3 +383,2328 @@ var timesItalicCharMetrics map[string]CharMetrics = map[string]CharMetrics{
 	"zdotaccent":     {GlyphName: "zdotaccent", Wx: 389.000000, Wy: 0.000000},
 	"zero":           {GlyphName: "zero", Wx: 500.000000, Wy: 0.000000},
 }
+
+// Times-Italic kerning pairs loaded from afms/Times-Italic.afm.  See afms/MustRead.html for license information.
+var timesItalicKerning map[GlyphPair]float64 = map[GlyphPair]float64{
+	{"A", "C"}:                        -30.000000,
+	{"A", "Cacute"}:                   -30.000000,
+	{"A", "Ccaron"}:                   -30.000000,
+	{"A", "Ccedilla"}:                 -30.000000,
+	{"A", "G"}:                        -35.000000,
+	{"A", "Gbreve"}:                   -35.000000,
+	{"A", "Gcommaaccent"}:             -35.000000,
+	{"A", "O"}:                        -40.000000,
+	{"A", "Oacute"}:                   -40.000000,
+	{"A", "Ocircumflex"}:              -40.000000,
+	{"A", "Odieresis"}:                -40.000000,
+	{"A", "Ograve"}:                   -40.000000,
+	{"A", "Ohungarumlaut"}:            -40.000000,
+	{"A", "Omacron"}:                  -40.000000,
+	{"A", "Oslash"}:                   -40.000000,
+	{"A", "Otilde"}:                   -40.000000,
+	{"A", "Q"}:                        -40.000000,
+	{"A", "T"}:                        -37.000000,
+	{"A", "Tcaron"}:                   -37.000000,
+	{"A", "Tcommaaccent"}:             -37.000000,
+	{"A", "U"}:                        -50.000000,
+	{"A", "Uacute"}:                   -50.000000,
+	{"A", "Ucircumflex"}:              -50.000000,
+	{"A", "Udieresis"}:                -50.000000,
+	{"A", "Ugrave"}:                   -50.000000,
+	{"A", "Uhungarumlaut"}:            -50.000000,
+	{"A", "Umacron"}:                  -50.000000,
+	{"A", "Uogonek"}:                  -50.000000,
+	{"A", "Uring"}:                    -50.000000,
+	{"A", "V"}:                        -105.000000,
+	{"A", "W"}:                        -95.000000,
+	{"A", "Y"}:                        -55.000000,
+	{"A", "Yacute"}:                   -55.000000,
+	{"A", "Ydieresis"}:                -55.000000,
+	{"A", "quoteright"}:               -37.000000,
+	{"A", "u"}:                        -20.000000,
+	{"A", "uacute"}:                   -20.000000,
+	{"A", "ucircumflex"}:              -20.000000,
+	{"A", "udieresis"}:                -20.000000,
+	{"A", "ugrave"}:                   -20.000000,
+	{"A", "uhungarumlaut"}:            -20.000000,
+	{"A", "umacron"}:                  -20.000000,
+	{"A", "uogonek"}:                  -20.000000,
+	{"A", "uring"}:                    -20.000000,
+	{"A", "v"}:                        -55.000000,
+	{"A", "w"}:                        -55.000000,
+	{"A", "y"}:                        -55.000000,
+	{"A", "yacute"}:                   -55.000000,
+	{"A", "ydieresis"}:                -55.000000,
+	{"Aacute", "C"}:                   -30.000000,
+	{"Aacute", "Cacute"}:              -30.000000,
+	{"Aacute", "Ccaron"}:              -30.000000,
+	{"Aacute", "Ccedilla"}:            -30.000000,
+	{"Aacute", "G"}:                   -35.000000,
+	{"Aacute", "Gbreve"}:              -35.000000,
+	{"Aacute", "Gcommaaccent"}:        -35.000000,
+	{"Aacute", "O"}:                   -40.000000,
+	{"Aacute", "Oacute"}:              -40.000000,
+	{"Aacute", "Ocircumflex"}:         -40.000000,
+	{"Aacute", "Odieresis"}:           -40.000000,
+	{"Aacute", "Ograve"}:              -40.000000,
+	{"Aacute", "Ohungarumlaut"}:       -40.000000,
+	{"Aacute", "Omacron"}:             -40.000000,
+	{"Aacute", "Oslash"}:              -40.000000,
+	{"Aacute", "Otilde"}:              -40.000000,
+	{"Aacute", "Q"}:                   -40.000000,
+	{"Aacute", "T"}:                   -37.000000,
+	{"Aacute", "Tcaron"}:              -37.000000,
+	{"Aacute", "Tcommaaccent"}:        -37.000000,
+	{"Aacute", "U"}:                   -50.000000,
+	{"Aacute", "Uacute"}:              -50.000000,
+	{"Aacute", "Ucircumflex"}:         -50.000000,
+	{"Aacute", "Udieresis"}:           -50.000000,
+	{"Aacute", "Ugrave"}:              -50.000000,
+	{"Aacute", "Uhungarumlaut"}:       -50.000000,
+	{"Aacute", "Umacron"}:             -50.000000,
+	{"Aacute", "Uogonek"}:             -50.000000,
+	{"Aacute", "Uring"}:               -50.000000,
+	{"Aacute", "V"}:                   -105.000000,
+	{"Aacute", "W"}:                   -95.000000,
+	{"Aacute", "Y"}:                   -55.000000,
+	{"Aacute", "Yacute"}:              -55.000000,
+	{"Aacute", "Ydieresis"}:           -55.000000,
+	{"Aacute", "quoteright"}:          -37.000000,
+	{"Aacute", "u"}:                   -20.000000,
+	{"Aacute", "uacute"}:              -20.000000,
+	{"Aacute", "ucircumflex"}:         -20.000000,
+	{"Aacute", "udieresis"}:           -20.000000,
+	{"Aacute", "ugrave"}:              -20.000000,
+	{"Aacute", "uhungarumlaut"}:       -20.000000,
+	{"Aacute", "umacron"}:             -20.000000,
+	{"Aacute", "uogonek"}:             -20.000000,
+	{"Aacute", "uring"}:               -20.000000,
+	{"Aacute", "v"}:                   -55.000000,
+	{"Aacute", "w"}:                   -55.000000,
+	{"Aacute", "y"}:                   -55.000000,
+	{"Aacute", "yacute"}:              -55.000000,
+	{"Aacute", "ydieresis"}:           -55.000000,
+	{"Abreve", "C"}:                   -30.000000,
+	{"Abreve", "Cacute"}:              -30.000000,
+	{"Abreve", "Ccaron"}:              -30.000000,
+	{"Abreve", "Ccedilla"}:            -30.000000,
+	{"Abreve", "G"}:                   -35.000000,
+	{"Abreve", "Gbreve"}:              -35.000000,
+	{"Abreve", "Gcommaaccent"}:        -35.000000,
+	{"Abreve", "O"}:                   -40.000000,
+	{"Abreve", "Oacute"}:              -40.000000,
+	{"Abreve", "Ocircumflex"}:         -40.000000,
+	{"Abreve", "Odieresis"}:           -40.000000,
+	{"Abreve", "Ograve"}:              -40.000000,
+	{"Abreve", "Ohungarumlaut"}:       -40.000000,
+	{"Abreve", "Omacron"}:             -40.000000,
+	{"Abreve", "Oslash"}:              -40.000000,
+	{"Abreve", "Otilde"}:              -40.000000,
+	{"Abreve", "Q"}:                   -40.000000,
+	{"Abreve", "T"}:                   -37.000000,
+	{"Abreve", "Tcaron"}:              -37.000000,
+	{"Abreve", "Tcommaaccent"}:        -37.000000,
+	{"Abreve", "U"}:                   -50.000000,
+	{"Abreve", "Uacute"}:              -50.000000,
+	{"Abreve", "Ucircumflex"}:         -50.000000,
+	{"Abreve", "Udieresis"}:           -50.000000,
+	{"Abreve", "Ugrave"}:              -50.000000,
+	{"Abreve", "Uhungarumlaut"}:       -50.000000,
+	{"Abreve", "Umacron"}:             -50.000000,
+	{"Abreve", "Uogonek"}:             -50.000000,
+	{"Abreve", "Uring"}:               -50.000000,
+	{"Abreve", "V"}:                   -105.000000,
+	{"Abreve", "W"}:                   -95.000000,
+	{"Abreve", "Y"}:                   -55.000000,
+	{"Abreve", "Yacute"}:              -55.000000,
+	{"Abreve", "Ydieresis"}:           -55.000000,
+	{"Abreve", "quoteright"}:          -37.000000,
+	{"Abreve", "u"}:                   -20.000000,
+	{"Abreve", "uacute"}:              -20.000000,
+	{"Abreve", "ucircumflex"}:         -20.000000,
+	{"Abreve", "udieresis"}:           -20.000000,
+	{"Abreve", "ugrave"}:              -20.000000,
+	{"Abreve", "uhungarumlaut"}:       -20.000000,
+	{"Abreve", "umacron"}:             -20.000000,
+	{"Abreve", "uogonek"}:             -20.000000,
+	{"Abreve", "uring"}:               -20.000000,
+	{"Abreve", "v"}:                   -55.000000,
+	{"Abreve", "w"}:                   -55.000000,
+	{"Abreve", "y"}:                   -55.000000,
+	{"Abreve", "yacute"}:              -55.000000,
+	{"Abreve", "ydieresis"}:           -55.000000,
+	{"Acircumflex", "C"}:              -30.000000,
+	{"Acircumflex", "Cacute"}:         -30.000000,
+	{"Acircumflex", "Ccaron"}:         -30.000000,
+	{"Acircumflex", "Ccedilla"}:       -30.000000,
+	{"Acircumflex", "G"}:              -35.000000,
+	{"Acircumflex", "Gbreve"}:         -35.000000,
+	{"Acircumflex", "Gcommaaccent"}:   -35.000000,
+	{"Acircumflex", "O"}:              -40.000000,
+	{"Acircumflex", "Oacute"}:         -40.000000,
+	{"Acircumflex", "Ocircumflex"}:    -40.000000,
+	{"Acircumflex", "Odieresis"}:      -40.000000,
+	{"Acircumflex", "Ograve"}:         -40.000000,
+	{"Acircumflex", "Ohungarumlaut"}:  -40.000000,
+	{"Acircumflex", "Omacron"}:        -40.000000,
+	{"Acircumflex", "Oslash"}:         -40.000000,
+	{"Acircumflex", "Otilde"}:         -40.000000,
+	{"Acircumflex", "Q"}:              -40.000000,
+	{"Acircumflex", "T"}:              -37.000000,
+	{"Acircumflex", "Tcaron"}:         -37.000000,
+	{"Acircumflex", "Tcommaaccent"}:   -37.000000,
+	{"Acircumflex", "U"}:              -50.000000,
+	{"Acircumflex", "Uacute"}:         -50.000000,
+	{"Acircumflex", "Ucircumflex"}:    -50.000000,
+	{"Acircumflex", "Udieresis"}:      -50.000000,
+	{"Acircumflex", "Ugrave"}:         -50.000000,
+	{"Acircumflex", "Uhungarumlaut"}:  -50.000000,
+	{"Acircumflex", "Umacron"}:        -50.000000,
+	{"Acircumflex", "Uogonek"}:        -50.000000,
+	{"Acircumflex", "Uring"}:          -50.000000,
+	{"Acircumflex", "V"}:              -105.000000,
+	{"Acircumflex", "W"}:              -95.000000,
+	{"Acircumflex", "Y"}:              -55.000000,
+	{"Acircumflex", "Yacute"}:         -55.000000,
+	{"Acircumflex", "Ydieresis"}:      -55.000000,
+	{"Acircumflex", "quoteright"}:     -37.000000,
+	{"Acircumflex", "u"}:              -20.000000,
+	{"Acircumflex", "uacute"}:         -20.000000,
+	{"Acircumflex", "ucircumflex"}:    -20.000000,
+	{"Acircumflex", "udieresis"}:      -20.000000,
+	{"Acircumflex", "ugrave"}:         -20.000000,
+	{"Acircumflex", "uhungarumlaut"}:  -20.000000,
+	{"Acircumflex", "umacron"}:        -20.000000,
+	{"Acircumflex", "uogonek"}:        -20.000000,
+	{"Acircumflex", "uring"}:          -20.000000,
+	{"Acircumflex", "v"}:              -55.000000,
+	{"Acircumflex", "w"}:              -55.000000,
+	{"Acircumflex", "y"}:              -55.000000,
+	{"Acircumflex", "yacute"}:         -55.000000,
+	{"Acircumflex", "ydieresis"}:      -55.000000,
+	{"Adieresis", "C"}:                -30.000000,
+	{"Adieresis", "Cacute"}:           -30.000000,
+	{"Adieresis", "Ccaron"}:           -30.000000,
+	{"Adieresis", "Ccedilla"}:         -30.000000,
+	{"Adieresis", "G"}:                -35.000000,
+	{"Adieresis", "Gbreve"}:           -35.000000,
+	{"Adieresis", "Gcommaaccent"}:     -35.000000,
+	{"Adieresis", "O"}:                -40.000000,
+	{"Adieresis", "Oacute"}:           -40.000000,
+	{"Adieresis", "Ocircumflex"}:      -40.000000,
+	{"Adieresis", "Odieresis"}:        -40.000000,
+	{"Adieresis", "Ograve"}:           -40.000000,
+	{"Adieresis", "Ohungarumlaut"}:    -40.000000,
+	{"Adieresis", "Omacron"}:          -40.000000,
+	{"Adieresis", "Oslash"}:           -40.000000,
+	{"Adieresis", "Otilde"}:           -40.000000,
+	{"Adieresis", "Q"}:                -40.000000,
+	{"Adieresis", "T"}:                -37.000000,
+	{"Adieresis", "Tcaron"}:           -37.000000,
+	{"Adieresis", "Tcommaaccent"}:     -37.000000,
+	{"Adieresis", "U"}:                -50.000000,
+	{"Adieresis", "Uacute"}:           -50.000000,
+	{"Adieresis", "Ucircumflex"}:      -50.000000,
+	{"Adieresis", "Udieresis"}:        -50.000000,
+	{"Adieresis", "Ugrave"}:           -50.000000,
+	{"Adieresis", "Uhungarumlaut"}:    -50.000000,
+	{"Adieresis", "Umacron"}:          -50.000000,
+	{"Adieresis", "Uogonek"}:          -50.000000,
+	{"Adieresis", "Uring"}:            -50.000000,
+	{"Adieresis", "V"}:                -105.000000,
+	{"Adieresis", "W"}:                -95.000000,
+	{"Adieresis", "Y"}:                -55.000000,
+	{"Adieresis", "Yacute"}:           -55.000000,
+	{"Adieresis", "Ydieresis"}:        -55.000000,
+	{"Adieresis", "quoteright"}:       -37.000000,
+	{"Adieresis", "u"}:                -20.000000,
+	{"Adieresis", "uacute"}:           -20.000000,
+	{"Adieresis", "ucircumflex"}:      -20.000000,
+	{"Adieresis", "udieresis"}:        -20.000000,
+	{"Adieresis", "ugrave"}:           -20.000000,
+	{"Adieresis", "uhungarumlaut"}:    -20.000000,
+	{"Adieresis", "umacron"}:          -20.000000,
+	{"Adieresis", "uogonek"}:          -20.000000,
+	{"Adieresis", "uring"}:            -20.000000,
+	{"Adieresis", "v"}:                -55.000000,
+	{"Adieresis", "w"}:                -55.000000,
+	{"Adieresis", "y"}:                -55.000000,
+	{"Adieresis", "yacute"}:           -55.000000,
+	{"Adieresis", "ydieresis"}:        -55.000000,
+	{"Agrave", "C"}:                   -30.000000,
+	{"Agrave", "Cacute"}:              -30.000000,
+	{"Agrave", "Ccaron"}:              -30.000000,
+	{"Agrave", "Ccedilla"}:            -30.000000,
+	{"Agrave", "G"}:                   -35.000000,
+	{"Agrave", "Gbreve"}:              -35.000000,
+	{"Agrave", "Gcommaaccent"}:        -35.000000,
+	{"Agrave", "O"}:                   -40.000000,
+	{"Agrave", "Oacute"}:              -40.000000,
+	{"Agrave", "Ocircumflex"}:         -40.000000,
+	{"Agrave", "Odieresis"}:           -40.000000,
+	{"Agrave", "Ograve"}:              -40.000000,
+	{"Agrave", "Ohungarumlaut"}:       -40.000000,
+	{"Agrave", "Omacron"}:             -40.000000,
+	{"Agrave", "Oslash"}:              -40.000000,
+	{"Agrave", "Otilde"}:              -40.000000,
+	{"Agrave", "Q"}:                   -40.000000,
+	{"Agrave", "T"}:                   -37.000000,
+	{"Agrave", "Tcaron"}:              -37.000000,
+	{"Agrave", "Tcommaaccent"}:        -37.000000,
+	{"Agrave", "U"}:                   -50.000000,
+	{"Agrave", "Uacute"}:              -50.000000,
+	{"Agrave", "Ucircumflex"}:         -50.000000,
+	{"Agrave", "Udieresis"}:           -50.000000,
+	{"Agrave", "Ugrave"}:              -50.000000,
+	{"Agrave", "Uhungarumlaut"}:       -50.000000,
+	{"Agrave", "Umacron"}:             -50.000000,
+	{"Agrave", "Uogonek"}:             -50.000000,
+	{"Agrave", "Uring"}:               -50.000000,
+	{"Agrave", "V"}:                   -105.000000,
+	{"Agrave", "W"}:                   -95.000000,
+	{"Agrave", "Y"}:                   -55.000000,
+	{"Agrave", "Yacute"}:              -55.000000,
+	{"Agrave", "Ydieresis"}:           -55.000000,
+	{"Agrave", "quoteright"}:          -37.000000,
+	{"Agrave", "u"}:                   -20.000000,
+	{"Agrave", "uacute"}:              -20.000000,
+	{"Agrave", "ucircumflex"}:         -20.000000,
+	{"Agrave", "udieresis"}:           -20.000000,
+	{"Agrave", "ugrave"}:              -20.000000,
+	{"Agrave", "uhungarumlaut"}:       -20.000000,
+	{"Agrave", "umacron"}:             -20.000000,
+	{"Agrave", "uogonek"}:             -20.000000,
+	{"Agrave", "uring"}:               -20.000000,
+	{"Agrave", "v"}:                   -55.000000,
+	{"Agrave", "w"}:                   -55.000000,
+	{"Agrave", "y"}:                   -55.000000,
+	{"Agrave", "yacute"}:              -55.000000,
+	{"Agrave", "ydieresis"}:           -55.000000,
+	{"Amacron", "C"}:                  -30.000000,
+	{"Amacron", "Cacute"}:             -30.000000,
+	{"Amacron", "Ccaron"}:             -30.000000,
+	{"Amacron", "Ccedilla"}:           -30.000000,
+	{"Amacron", "G"}:                  -35.000000,
+	{"Amacron", "Gbreve"}:             -35.000000,
+	{"Amacron", "Gcommaaccent"}:       -35.000000,
+	{"Amacron", "O"}:                  -40.000000,
+	{"Amacron", "Oacute"}:             -40.000000,
+	{"Amacron", "Ocircumflex"}:        -40.000000,
+	{"Amacron", "Odieresis"}:          -40.000000,
+	{"Amacron", "Ograve"}:             -40.000000,
+	{"Amacron", "Ohungarumlaut"}:      -40.000000,
+	{"Amacron", "Omacron"}:            -40.000000,
+	{"Amacron", "Oslash"}:             -40.000000,
+	{"Amacron", "Otilde"}:             -40.000000,
+	{"Amacron", "Q"}:                  -40.000000,
+	{"Amacron", "T"}:                  -37.000000,
+	{"Amacron", "Tcaron"}:             -37.000000,
+	{"Amacron", "Tcommaaccent"}:       -37.000000,
+	{"Amacron", "U"}:                  -50.000000,
+	{"Amacron", "Uacute"}:             -50.000000,
+	{"Amacron", "Ucircumflex"}:        -50.000000,
+	{"Amacron", "Udieresis"}:          -50.000000,
+	{"Amacron", "Ugrave"}:             -50.000000,
+	{"Amacron", "Uhungarumlaut"}:      -50.000000,
+	{"Amacron", "Umacron"}:            -50.000000,
+	{"Amacron", "Uogonek"}:            -50.000000,
+	{"Amacron", "Uring"}:              -50.000000,
+	{"Amacron", "V"}:                  -105.000000,
+	{"Amacron", "W"}:                  -95.000000,
+	{"Amacron", "Y"}:                  -55.000000,
+	{"Amacron", "Yacute"}:             -55.000000,
+	{"Amacron", "Ydieresis"}:          -55.000000,
+	{"Amacron", "quoteright"}:         -37.000000,
+	{"Amacron", "u"}:                  -20.000000,
+	{"Amacron", "uacute"}:             -20.000000,
+	{"Amacron", "ucircumflex"}:        -20.000000,
+	{"Amacron", "udieresis"}:          -20.000000,
+	{"Amacron", "ugrave"}:             -20.000000,
+	{"Amacron", "uhungarumlaut"}:      -20.000000,
+	{"Amacron", "umacron"}:            -20.000000,
+	{"Amacron", "uogonek"}:            -20.000000,
+	{"Amacron", "uring"}:              -20.000000,
+	{"Amacron", "v"}:                  -55.000000,
+	{"Amacron", "w"}:                  -55.000000,
+	{"Amacron", "y"}:                  -55.000000,
+	{"Amacron", "yacute"}:             -55.000000,
+	{"Amacron", "ydieresis"}:          -55.000000,
+	{"Aogonek", "C"}:                  -30.000000,
+	{"Aogonek", "Cacute"}:             -30.000000,
+	{"Aogonek", "Ccaron"}:             -30.000000,
+	{"Aogonek", "Ccedilla"}:           -30.000000,
+	{"Aogonek", "G"}:                  -35.000000,
+	{"Aogonek", "Gbreve"}:             -35.000000,
+	{"Aogonek", "Gcommaaccent"}:       -35.000000,
+	{"Aogonek", "O"}:                  -40.000000,
+	{"Aogonek", "Oacute"}:             -40.000000,
+	{"Aogonek", "Ocircumflex"}:        -40.000000,
+	{"Aogonek", "Odieresis"}:          -40.000000,
+	{"Aogonek", "Ograve"}:             -40.000000,
+	{"Aogonek", "Ohungarumlaut"}:      -40.000000,
+	{"Aogonek", "Omacron"}:            -40.000000,
+	{"Aogonek", "Oslash"}:             -40.000000,
+	{"Aogonek", "Otilde"}:             -40.000000,
+	{"Aogonek", "Q"}:                  -40.000000,
+	{"Aogonek", "T"}:                  -37.000000,
+	{"Aogonek", "Tcaron"}:             -37.000000,
+	{"Aogonek", "Tcommaaccent"}:       -37.000000,
+	{"Aogonek", "U"}:                  -50.000000,
+	{"Aogonek", "Uacute"}:             -50.000000,
+	{"Aogonek", "Ucircumflex"}:        -50.000000,
+	{"Aogonek", "Udieresis"}:          -50.000000,
+	{"Aogonek", "Ugrave"}:             -50.000000,
+	{"Aogonek", "Uhungarumlaut"}:      -50.000000,
+	{"Aogonek", "Umacron"}:            -50.000000,
+	{"Aogonek", "Uogonek"}:            -50.000000,
+	{"Aogonek", "Uring"}:              -50.000000,
+	{"Aogonek", "V"}:                  -105.000000,
+	{"Aogonek", "W"}:                  -95.000000,
+	{"Aogonek", "Y"}:                  -55.000000,
+	{"Aogonek", "Yacute"}:             -55.000000,
+	{"Aogonek", "Ydieresis"}:          -55.000000,
+	{"Aogonek", "quoteright"}:         -37.000000,
+	{"Aogonek", "u"}:                  -20.000000,
+	{"Aogonek", "uacute"}:             -20.000000,
+	{"Aogonek", "ucircumflex"}:        -20.000000,
+	{"Aogonek", "udieresis"}:          -20.000000,
+	{"Aogonek", "ugrave"}:             -20.000000,
+	{"Aogonek", "uhungarumlaut"}:      -20.000000,
+	{"Aogonek", "umacron"}:            -20.000000,
+	{"Aogonek", "uogonek"}:            -20.000000,
+	{"Aogonek", "uring"}:              -20.000000,
+	{"Aogonek", "v"}:                  -55.000000,
+	{"Aogonek", "w"}:                  -55.000000,
+	{"Aogonek", "y"}:                  -55.000000,
+	{"Aogonek", "yacute"}:             -55.000000,
+	{"Aogonek", "ydieresis"}:          -55.000000,
+	{"Aring", "C"}:                    -30.000000,
+	{"Aring", "Cacute"}:               -30.000000,
+	{"Aring", "Ccaron"}:               -30.000000,
+	{"Aring", "Ccedilla"}:             -30.000000,
+	{"Aring", "G"}:                    -35.000000,
+	{"Aring", "Gbreve"}:               -35.000000,
+	{"Aring", "Gcommaaccent"}:         -35.000000,
+	{"Aring", "O"}:                    -40.000000,
+	{"Aring", "Oacute"}:               -40.000000,
+	{"Aring", "Ocircumflex"}:          -40.000000,
+	{"Aring", "Odieresis"}:            -40.000000,
+	{"Aring", "Ograve"}:               -40.000000,
+	{"Aring", "Ohungarumlaut"}:        -40.000000,
+	{"Aring", "Omacron"}:              -40.000000,
+	{"Aring", "Oslash"}:               -40.000000,
+	{"Aring", "Otilde"}:               -40.000000,
+	{"Aring", "Q"}:                    -40.000000,
+	{"Aring", "T"}:                    -37.000000,
+	{"Aring", "Tcaron"}:               -37.000000,
+	{"Aring", "Tcommaaccent"}:         -37.000000,
+	{"Aring", "U"}:                    -50.000000,
+	{"Aring", "Uacute"}:               -50.000000,
+	{"Aring", "Ucircumflex"}:          -50.000000,
+	{"Aring", "Udieresis"}:            -50.000000,
+	{"Aring", "Ugrave"}:               -50.000000,
+	{"Aring", "Uhungarumlaut"}:        -50.000000,
+	{"Aring", "Umacron"}:              -50.000000,
+	{"Aring", "Uogonek"}:              -50.000000,
+	{"Aring", "Uring"}:                -50.000000,
+	{"Aring", "V"}:                    -105.000000,
+	{"Aring", "W"}:                    -95.000000,
+	{"Aring", "Y"}:                    -55.000000,
+	{"Aring", "Yacute"}:               -55.000000,
+	{"Aring", "Ydieresis"}:            -55.000000,
+	{"Aring", "quoteright"}:           -37.000000,
+	{"Aring", "u"}:                    -20.000000,
+	{"Aring", "uacute"}:               -20.000000,
+	{"Aring", "ucircumflex"}:          -20.000000,
+	{"Aring", "udieresis"}:            -20.000000,
+	{"Aring", "ugrave"}:               -20.000000,
+	{"Aring", "uhungarumlaut"}:        -20.000000,
+	{"Aring", "umacron"}:              -20.000000,
+	{"Aring", "uogonek"}:              -20.000000,
+	{"Aring", "uring"}:                -20.000000,
+	{"Aring", "v"}:                    -55.000000,
+	{"Aring", "w"}:                    -55.000000,
+	{"Aring", "y"}:                    -55.000000,
+	{"Aring", "yacute"}:               -55.000000,
+	{"Aring", "ydieresis"}:            -55.000000,
+	{"Atilde", "C"}:                   -30.000000,
+	{"Atilde", "Cacute"}:              -30.000000,
+	{"Atilde", "Ccaron"}:              -30.000000,
+	{"Atilde", "Ccedilla"}:            -30.000000,
+	{"Atilde", "G"}:                   -35.000000,
+	{"Atilde", "Gbreve"}:              -35.000000,
+	{"Atilde", "Gcommaaccent"}:        -35.000000,
+	{"Atilde", "O"}:                   -40.000000,
+	{"Atilde", "Oacute"}:              -40.000000,
+	{"Atilde", "Ocircumflex"}:         -40.000000,
+	{"Atilde", "Odieresis"}:           -40.000000,
+	{"Atilde", "Ograve"}:              -40.000000,
+	{"Atilde", "Ohungarumlaut"}:       -40.000000,
+	{"Atilde", "Omacron"}:             -40.000000,
+	{"Atilde", "Oslash"}:              -40.000000,
+	{"Atilde", "Otilde"}:              -40.000000,
+	{"Atilde", "Q"}:                   -40.000000,
+	{"Atilde", "T"}:                   -37.000000,
+	{"Atilde", "Tcaron"}:              -37.000000,
+	{"Atilde", "Tcommaaccent"}:        -37.000000,
+	{"Atilde", "U"}:                   -50.000000,
+	{"Atilde", "Uacute"}:              -50.000000,
+	{"Atilde", "Ucircumflex"}:         -50.000000,
+	{"Atilde", "Udieresis"}:           -50.000000,
+	{"Atilde", "Ugrave"}:              -50.000000,
+	{"Atilde", "Uhungarumlaut"}:       -50.000000,
+	{"Atilde", "Umacron"}:             -50.000000,
+	{"Atilde", "Uogonek"}:             -50.000000,
+	{"Atilde", "Uring"}:               -50.000000,
+	{"Atilde", "V"}:                   -105.000000,
+	{"Atilde", "W"}:                   -95.000000,
+	{"Atilde", "Y"}:                   -55.000000,
+	{"Atilde", "Yacute"}:              -55.000000,
+	{"Atilde", "Ydieresis"}:           -55.000000,
+	{"Atilde", "quoteright"}:          -37.000000,
+	{"Atilde", "u"}:                   -20.000000,
+	{"Atilde", "uacute"}:              -20.000000,
+	{"Atilde", "ucircumflex"}:         -20.000000,
+	{"Atilde", "udieresis"}:           -20.000000,
+	{"Atilde", "ugrave"}:              -20.000000,
+	{"Atilde", "uhungarumlaut"}:       -20.000000,
+	{"Atilde", "umacron"}:             -20.000000,
+	{"Atilde", "uogonek"}:             -20.000000,
+	{"Atilde", "uring"}:               -20.000000,
+	{"Atilde", "v"}:                   -55.000000,
+	{"Atilde", "w"}:                   -55.000000,
+	{"Atilde", "y"}:                   -55.000000,
+	{"Atilde", "yacute"}:              -55.000000,
+	{"Atilde", "ydieresis"}:           -55.000000,
+	{"B", "A"}:                        -25.000000,
+	{"B", "Aacute"}:                   -25.000000,
+	{"B", "Abreve"}:                   -25.000000,
+	{"B", "Acircumflex"}:              -25.000000,
+	{"B", "Adieresis"}:                -25.000000,
+	{"B", "Agrave"}:                   -25.000000,
+	{"B", "Amacron"}:                  -25.000000,
+	{"B", "Aogonek"}:                  -25.000000,
+	{"B", "Aring"}:                    -25.000000,
+	{"B", "Atilde"}:                   -25.000000,
+	{"B", "U"}:                        -10.000000,
+	{"B", "Uacute"}:                   -10.000000,
+	{"B", "Ucircumflex"}:              -10.000000,
+	{"B", "Udieresis"}:                -10.000000,
+	{"B", "Ugrave"}:                   -10.000000,
+	{"B", "Uhungarumlaut"}:            -10.000000,
+	{"B", "Umacron"}:                  -10.000000,
+	{"B", "Uogonek"}:                  -10.000000,
+	{"B", "Uring"}:                    -10.000000,
+	{"D", "A"}:                        -35.000000,
+	{"D", "Aacute"}:                   -35.000000,
+	{"D", "Abreve"}:                   -35.000000,
+	{"D", "Acircumflex"}:              -35.000000,
+	{"D", "Adieresis"}:                -35.000000,
+	{"D", "Agrave"}:                   -35.000000,
+	{"D", "Amacron"}:                  -35.000000,
+	{"D", "Aogonek"}:                  -35.000000,
+	{"D", "Aring"}:                    -35.000000,
+	{"D", "Atilde"}:                   -35.000000,
+	{"D", "V"}:                        -40.000000,
+	{"D", "W"}:                        -40.000000,
+	{"D", "Y"}:                        -40.000000,
+	{"D", "Yacute"}:                   -40.000000,
+	{"D", "Ydieresis"}:                -40.000000,
+	{"Dcaron", "A"}:                   -35.000000,
+	{"Dcaron", "Aacute"}:              -35.000000,
+	{"Dcaron", "Abreve"}:              -35.000000,
+	{"Dcaron", "Acircumflex"}:         -35.000000,
+	{"Dcaron", "Adieresis"}:           -35.000000,
+	{"Dcaron", "Agrave"}:              -35.000000,
+	{"Dcaron", "Amacron"}:             -35.000000,
+	{"Dcaron", "Aogonek"}:             -35.000000,
+	{"Dcaron", "Aring"}:               -35.000000,
+	{"Dcaron", "Atilde"}:              -35.000000,
+	{"Dcaron", "V"}:                   -40.000000,
+	{"Dcaron", "W"}:                   -40.000000,
+	{"Dcaron", "Y"}:                   -40.000000,
+	{"Dcaron", "Yacute"}:              -40.000000,
+	{"Dcaron", "Ydieresis"}:           -40.000000,
+	{"Dcroat", "A"}:                   -35.000000,
+	{"Dcroat", "Aacute"}:              -35.000000,
+	{"Dcroat", "Abreve"}:              -35.000000,
+	{"Dcroat", "Acircumflex"}:         -35.000000,
+	{"Dcroat", "Adieresis"}:           -35.000000,
+	{"Dcroat", "Agrave"}:              -35.000000,
+	{"Dcroat", "Amacron"}:             -35.000000,
+	{"Dcroat", "Aogonek"}:             -35.000000,
+	{"Dcroat", "Aring"}:               -35.000000,
+	{"Dcroat", "Atilde"}:              -35.000000,
+	{"Dcroat", "V"}:                   -40.000000,
+	{"Dcroat", "W"}:                   -40.000000,
+	{"Dcroat", "Y"}:                   -40.000000,
+	{"Dcroat", "Yacute"}:              -40.000000,
+	{"Dcroat", "Ydieresis"}:           -40.000000,
+	{"F", "A"}:                        -115.000000,
+	{"F", "Aacute"}:                   -115.000000,
+	{"F", "Abreve"}:                   -115.000000,
+	{"F", "Acircumflex"}:              -115.000000,
+	{"F", "Adieresis"}:                -115.000000,
+	{"F", "Agrave"}:                   -115.000000,
+	{"F", "Amacron"}:                  -115.000000,
+	{"F", "Aogonek"}:                  -115.000000,
+	{"F", "Aring"}:                    -115.000000,
+	{"F", "Atilde"}:                   -115.000000,
+	{"F", "a"}:                        -75.000000,
+	{"F", "aacute"}:                   -75.000000,
+	{"F", "abreve"}:                   -75.000000,
+	{"F", "acircumflex"}:              -75.000000,
+	{"F", "adieresis"}:                -75.000000,
+	{"F", "agrave"}:                   -75.000000,
+	{"F", "amacron"}:                  -75.000000,
+	{"F", "aogonek"}:                  -75.000000,
+	{"F", "aring"}:                    -75.000000,
+	{"F", "atilde"}:                   -75.000000,
+	{"F", "comma"}:                    -135.000000,
+	{"F", "e"}:                        -75.000000,
+	{"F", "eacute"}:                   -75.000000,
+	{"F", "ecaron"}:                   -75.000000,
+	{"F", "ecircumflex"}:              -75.000000,
+	{"F", "edieresis"}:                -75.000000,
+	{"F", "edotaccent"}:               -75.000000,
+	{"F", "egrave"}:                   -75.000000,
+	{"F", "emacron"}:                  -75.000000,
+	{"F", "eogonek"}:                  -75.000000,
+	{"F", "i"}:                        -45.000000,
+	{"F", "iacute"}:                   -45.000000,
+	{"F", "icircumflex"}:              -45.000000,
+	{"F", "idieresis"}:                -45.000000,
+	{"F", "igrave"}:                   -45.000000,
+	{"F", "imacron"}:                  -45.000000,
+	{"F", "iogonek"}:                  -45.000000,
+	{"F", "o"}:                        -105.000000,
+	{"F", "oacute"}:                   -105.000000,
+	{"F", "ocircumflex"}:              -105.000000,
+	{"F", "odieresis"}:                -105.000000,
+	{"F", "ograve"}:                   -105.000000,
+	{"F", "ohungarumlaut"}:            -105.000000,
+	{"F", "omacron"}:                  -105.000000,
+	{"F", "oslash"}:                   -105.000000,
+	{"F", "otilde"}:                   -105.000000,
+	{"F", "period"}:                   -135.000000,
+	{"F", "r"}:                        -55.000000,
+	{"F", "racute"}:                   -55.000000,
+	{"F", "rcaron"}:                   -55.000000,
+	{"F", "rcommaaccent"}:             -55.000000,
+	{"J", "A"}:                        -40.000000,
+	{"J", "Aacute"}:                   -40.000000,
+	{"J", "Abreve"}:                   -40.000000,
+	{"J", "Acircumflex"}:              -40.000000,
+	{"J", "Adieresis"}:                -40.000000,
+	{"J", "Agrave"}:                   -40.000000,
+	{"J", "Amacron"}:                  -40.000000,
+	{"J", "Aogonek"}:                  -40.000000,
+	{"J", "Aring"}:                    -40.000000,
+	{"J", "Atilde"}:                   -40.000000,
+	{"J", "a"}:                        -35.000000,
+	{"J", "aacute"}:                   -35.000000,
+	{"J", "abreve"}:                   -35.000000,
+	{"J", "acircumflex"}:              -35.000000,
+	{"J", "adieresis"}:                -35.000000,
+	{"J", "agrave"}:                   -35.000000,
+	{"J", "amacron"}:                  -35.000000,
+	{"J", "aogonek"}:                  -35.000000,
+	{"J", "aring"}:                    -35.000000,
+	{"J", "atilde"}:                   -35.000000,
+	{"J", "comma"}:                    -25.000000,
+	{"J", "e"}:                        -25.000000,
+	{"J", "eacute"}:                   -25.000000,
+	{"J", "ecaron"}:                   -25.000000,
+	{"J", "ecircumflex"}:              -25.000000,
+	{"J", "edieresis"}:                -25.000000,
+	{"J", "edotaccent"}:               -25.000000,
+	{"J", "egrave"}:                   -25.000000,
+	{"J", "emacron"}:                  -25.000000,
+	{"J", "eogonek"}:                  -25.000000,
+	{"J", "o"}:                        -25.000000,
+	{"J", "oacute"}:                   -25.000000,
+	{"J", "ocircumflex"}:              -25.000000,
+	{"J", "odieresis"}:                -25.000000,
+	{"J", "ograve"}:                   -25.000000,
+	{"J", "ohungarumlaut"}:            -25.000000,
+	{"J", "omacron"}:                  -25.000000,
+	{"J", "oslash"}:                   -25.000000,
+	{"J", "otilde"}:                   -25.000000,
+	{"J", "period"}:                   -25.000000,
+	{"J", "u"}:                        -35.000000,
+	{"J", "uacute"}:                   -35.000000,
+	{"J", "ucircumflex"}:              -35.000000,
+	{"J", "udieresis"}:                -35.000000,
+	{"J", "ugrave"}:                   -35.000000,
+	{"J", "uhungarumlaut"}:            -35.000000,
+	{"J", "umacron"}:                  -35.000000,
+	{"J", "uogonek"}:                  -35.000000,
+	{"J", "uring"}:                    -35.000000,
+	{"K", "O"}:                        -50.000000,
+	{"K", "Oacute"}:                   -50.000000,
+	{"K", "Ocircumflex"}:              -50.000000,
+	{"K", "Odieresis"}:                -50.000000,
+	{"K", "Ograve"}:                   -50.000000,
+	{"K", "Ohungarumlaut"}:            -50.000000,
+	{"K", "Omacron"}:                  -50.000000,
+	{"K", "Oslash"}:                   -50.000000,
+	{"K", "Otilde"}:                   -50.000000,
+	{"K", "e"}:                        -35.000000,
+	{"K", "eacute"}:                   -35.000000,
+	{"K", "ecaron"}:                   -35.000000,
+	{"K", "ecircumflex"}:              -35.000000,
+	{"K", "edieresis"}:                -35.000000,
+	{"K", "edotaccent"}:               -35.000000,
+	{"K", "egrave"}:                   -35.000000,
+	{"K", "emacron"}:                  -35.000000,
+	{"K", "eogonek"}:                  -35.000000,
+	{"K", "o"}:                        -40.000000,
+	{"K", "oacute"}:                   -40.000000,
+	{"K", "ocircumflex"}:              -40.000000,
+	{"K", "odieresis"}:                -40.000000,
+	{"K", "ograve"}:                   -40.000000,
+	{"K", "ohungarumlaut"}:            -40.000000,
+	{"K", "omacron"}:                  -40.000000,
+	{"K", "oslash"}:                   -40.000000,
+	{"K", "otilde"}:                   -40.000000,
+	{"K", "u"}:                        -40.000000,
+	{"K", "uacute"}:                   -40.000000,
+	{"K", "ucircumflex"}:              -40.000000,
+	{"K", "udieresis"}:                -40.000000,
+	{"K", "ugrave"}:                   -40.000000,
+	{"K", "uhungarumlaut"}:            -40.000000,
+	{"K", "umacron"}:                  -40.000000,
+	{"K", "uogonek"}:                  -40.000000,
+	{"K", "uring"}:                    -40.000000,
+	{"K", "y"}:                        -40.000000,
+	{"K", "yacute"}:                   -40.000000,
+	{"K", "ydieresis"}:                -40.000000,
+	{"Kcommaaccent", "O"}:             -50.000000,
+	{"Kcommaaccent", "Oacute"}:        -50.000000,
+	{"Kcommaaccent", "Ocircumflex"}:   -50.000000,
+	{"Kcommaaccent", "Odieresis"}:     -50.000000,
+	{"Kcommaaccent", "Ograve"}:        -50.000000,
+	{"Kcommaaccent", "Ohungarumlaut"}: -50.000000,
+	{"Kcommaaccent", "Omacron"}:       -50.000000,
+	{"Kcommaaccent", "Oslash"}:        -50.000000,
+	{"Kcommaaccent", "Otilde"}:        -50.000000,
+	{"Kcommaaccent", "e"}:             -35.000000,
+	{"Kcommaaccent", "eacute"}:        -35.000000,
+	{"Kcommaaccent", "ecaron"}:        -35.000000,
+	{"Kcommaaccent", "ecircumflex"}:   -35.000000,
+	{"Kcommaaccent", "edieresis"}:     -35.000000,
+	{"Kcommaaccent", "edotaccent"}:    -35.000000,
+	{"Kcommaaccent", "egrave"}:        -35.000000,
+	{"Kcommaaccent", "emacron"}:       -35.000000,
+	{"Kcommaaccent", "eogonek"}:       -35.000000,
+	{"Kcommaaccent", "o"}:             -40.000000,
+	{"Kcommaaccent", "oacute"}:        -40.000000,
+	{"Kcommaaccent", "ocircumflex"}:   -40.000000,
+	{"Kcommaaccent", "odieresis"}:     -40.000000,
+	{"Kcommaaccent", "ograve"}:        -40.000000,
+	{"Kcommaaccent", "ohungarumlaut"}: -40.000000,
+	{"Kcommaaccent", "omacron"}:       -40.000000,
+	{"Kcommaaccent", "oslash"}:        -40.000000,
+	{"Kcommaaccent", "otilde"}:        -40.000000,
+	{"Kcommaaccent", "u"}:             -40.000000,
+	{"Kcommaaccent", "uacute"}:        -40.000000,
+	{"Kcommaaccent", "ucircumflex"}:   -40.000000,
+	{"Kcommaaccent", "udieresis"}:     -40.000000,
+	{"Kcommaaccent", "ugrave"}:        -40.000000,
+	{"Kcommaaccent", "uhungarumlaut"}: -40.000000,
+	{"Kcommaaccent", "umacron"}:       -40.000000,
+	{"Kcommaaccent", "uogonek"}:       -40.000000,
+	{"Kcommaaccent", "uring"}:         -40.000000,
+	{"Kcommaaccent", "y"}:             -40.000000,
+	{"Kcommaaccent", "yacute"}:        -40.000000,
+	{"Kcommaaccent", "ydieresis"}:     -40.000000,
+	{"L", "T"}:                        -20.000000,
+	{"L", "Tcaron"}:                   -20.000000,
+	{"L", "Tcommaaccent"}:             -20.000000,
+	{"L", "V"}:                        -55.000000,
+	{"L", "W"}:                        -55.000000,
+	{"L", "Y"}:                        -20.000000,
+	{"L", "Yacute"}:                   -20.000000,
+	{"L", "Ydieresis"}:                -20.000000,
+	{"L", "quoteright"}:               -37.000000,
+	{"L", "y"}:                        -30.000000,
+	{"L", "yacute"}:                   -30.000000,
+	{"L", "ydieresis"}:                -30.000000,
+	{"Lacute", "T"}:                   -20.000000,
+	{"Lacute", "Tcaron"}:              -20.000000,
+	{"Lacute", "Tcommaaccent"}:        -20.000000,
+	{"Lacute", "V"}:                   -55.000000,
+	{"Lacute", "W"}:                   -55.000000,
+	{"Lacute", "Y"}:                   -20.000000,
+	{"Lacute", "Yacute"}:              -20.000000,
+	{"Lacute", "Ydieresis"}:           -20.000000,
+	{"Lacute", "quoteright"}:          -37.000000,
+	{"Lacute", "y"}:                   -30.000000,
+	{"Lacute", "yacute"}:              -30.000000,
+	{"Lacute", "ydieresis"}:           -30.000000,
+	{"Lcommaaccent", "T"}:             -20.000000,
+	{"Lcommaaccent", "Tcaron"}:        -20.000000,
+	{"Lcommaaccent", "Tcommaaccent"}:  -20.000000,
+	{"Lcommaaccent", "V"}:             -55.000000,
+	{"Lcommaaccent", "W"}:             -55.000000,
+	{"Lcommaaccent", "Y"}:             -20.000000,
+	{"Lcommaaccent", "Yacute"}:        -20.000000,
+	{"Lcommaaccent", "Ydieresis"}:     -20.000000,
+	{"Lcommaaccent", "quoteright"}:    -37.000000,
+	{"Lcommaaccent", "y"}:             -30.000000,
+	{"Lcommaaccent", "yacute"}:        -30.000000,
+	{"Lcommaaccent", "ydieresis"}:     -30.000000,
+	{"Lslash", "T"}:                   -20.000000,
+	{"Lslash", "Tcaron"}:              -20.000000,
+	{"Lslash", "Tcommaaccent"}:        -20.000000,
+	{"Lslash", "V"}:                   -55.000000,
+	{"Lslash", "W"}:                   -55.000000,
+	{"Lslash", "Y"}:                   -20.000000,
+	{"Lslash", "Yacute"}:              -20.000000,
+	{"Lslash", "Ydieresis"}:           -20.000000,
+	{"Lslash", "quoteright"}:          -37.000000,
+	{"Lslash", "y"}:                   -30.000000,
+	{"Lslash", "yacute"}:              -30.000000,
+	{"Lslash", "ydieresis"}:           -30.000000,
+	{"N", "A"}:                        -27.000000,
+	{"N", "Aacute"}:                   -27.000000,
+	{"N", "Abreve"}:                   -27.000000,
+	{"N", "Acircumflex"}:              -27.000000,
+	{"N", "Adieresis"}:                -27.000000,
+	{"N", "Agrave"}:                   -27.000000,
+	{"N", "Amacron"}:                  -27.000000,
+	{"N", "Aogonek"}:                  -27.000000,
+	{"N", "Aring"}:                    -27.000000,
+	{"N", "Atilde"}:                   -27.000000,
+	{"Nacute", "A"}:                   -27.000000,
+	{"Nacute", "Aacute"}:              -27.000000,
+	{"Nacute", "Abreve"}:              -27.000000,
+	{"Nacute", "Acircumflex"}:         -27.000000,
+	{"Nacute", "Adieresis"}:           -27.000000,
+	{"Nacute", "Agrave"}:              -27.000000,
+	{"Nacute", "Amacron"}:             -27.000000,
+	{"Nacute", "Aogonek"}:             -27.000000,
+	{"Nacute", "Aring"}:               -27.000000,
+	{"Nacute", "Atilde"}:              -27.000000,
+	{"Ncaron", "A"}:                   -27.000000,
+	{"Ncaron", "Aacute"}:              -27.000000,
+	{"Ncaron", "Abreve"}:              -27.000000,
+	{"Ncaron", "Acircumflex"}:         -27.000000,
+	{"Ncaron", "Adieresis"}:           -27.000000,
+	{"Ncaron", "Agrave"}:              -27.000000,
+	{"Ncaron", "Amacron"}:             -27.000000,
+	{"Ncaron", "Aogonek"}:             -27.000000,
+	{"Ncaron", "Aring"}:               -27.000000,
+	{"Ncaron", "Atilde"}:              -27.000000,
+	{"Ncommaaccent", "A"}:             -27.000000,
+	{"Ncommaaccent", "Aacute"}:        -27.000000,
+	{"Ncommaaccent", "Abreve"}:        -27.000000,
+	{"Ncommaaccent", "Acircumflex"}:   -27.000000,
+	{"Ncommaaccent", "Adieresis"}:     -27.000000,
+	{"Ncommaaccent", "Agrave"}:        -27.000000,
+	{"Ncommaaccent", "Amacron"}:       -27.000000,
+	{"Ncommaaccent", "Aogonek"}:       -27.000000,
+	{"Ncommaaccent", "Aring"}:         -27.000000,
+	{"Ncommaaccent", "Atilde"}:        -27.000000,
+	{"Ntilde", "A"}:                   -27.000000,
+	{"Ntilde", "Aacute"}:              -27.000000,
+	{"Ntilde", "Abreve"}:              -27.000000,
+	{"Ntilde", "Acircumflex"}:         -27.000000,
+	{"Ntilde", "Adieresis"}:           -27.000000,
+	{"Ntilde", "Agrave"}:              -27.000000,
+	{"Ntilde", "Amacron"}:             -27.000000,
+	{"Ntilde", "Aogonek"}:             -27.000000,
+	{"Ntilde", "Aring"}:               -27.000000,
+	{"Ntilde", "Atilde"}:              -27.000000,
+	{"O", "A"}:                        -55.000000,
+	{"O", "Aacute"}:                   -55.000000,
+	{"O", "Abreve"}:                   -55.000000,
+	{"O", "Acircumflex"}:              -55.000000,
+	{"O", "Adieresis"}:                -55.000000,
+	{"O", "Agrave"}:                   -55.000000,
+	{"O", "Amacron"}:                  -55.000000,
+	{"O", "Aogonek"}:                  -55.000000,
+	{"O", "Aring"}:                    -55.000000,
+	{"O", "Atilde"}:                   -55.000000,
+	{"O", "T"}:                        -40.000000,
+	{"O", "Tcaron"}:                   -40.000000,
+	{"O", "Tcommaaccent"}:             -40.000000,
+	{"O", "V"}:                        -50.000000,
+	{"O", "W"}:                        -50.000000,
+	{"O", "X"}:                        -40.000000,
+	{"O", "Y"}:                        -50.000000,
+	{"O", "Yacute"}:                   -50.000000,
+	{"O", "Ydieresis"}:                -50.000000,
+	{"Oacute", "A"}:                   -55.000000,
+	{"Oacute", "Aacute"}:              -55.000000,
+	{"Oacute", "Abreve"}:              -55.000000,
+	{"Oacute", "Acircumflex"}:         -55.000000,
+	{"Oacute", "Adieresis"}:           -55.000000,
+	{"Oacute", "Agrave"}:              -55.000000,
+	{"Oacute", "Amacron"}:             -55.000000,
+	{"Oacute", "Aogonek"}:             -55.000000,
+	{"Oacute", "Aring"}:               -55.000000,
+	{"Oacute", "Atilde"}:              -55.000000,
+	{"Oacute", "T"}:                   -40.000000,
+	{"Oacute", "Tcaron"}:              -40.000000,
+	{"Oacute", "Tcommaaccent"}:        -40.000000,
+	{"Oacute", "V"}:                   -50.000000,
+	{"Oacute", "W"}:                   -50.000000,
+	{"Oacute", "X"}:                   -40.000000,
+	{"Oacute", "Y"}:                   -50.000000,
+	{"Oacute", "Yacute"}:              -50.000000,
+	{"Oacute", "Ydieresis"}:           -50.000000,
+	{"Ocircumflex", "A"}:              -55.000000,
+	{"Ocircumflex", "Aacute"}:         -55.000000,
+	{"Ocircumflex", "Abreve"}:         -55.000000,
+	{"Ocircumflex", "Acircumflex"}:    -55.000000,
+	{"Ocircumflex", "Adieresis"}:      -55.000000,
+	{"Ocircumflex", "Agrave"}:         -55.000000,
+	{"Ocircumflex", "Amacron"}:        -55.000000,
+	{"Ocircumflex", "Aogonek"}:        -55.000000,
+	{"Ocircumflex", "Aring"}:          -55.000000,
+	{"Ocircumflex", "Atilde"}:         -55.000000,
+	{"Ocircumflex", "T"}:              -40.000000,
+	{"Ocircumflex", "Tcaron"}:         -40.000000,
+	{"Ocircumflex", "Tcommaaccent"}:   -40.000000,
+	{"Ocircumflex", "V"}:              -50.000000,
+	{"Ocircumflex", "W"}:              -50.000000,
+	{"Ocircumflex", "X"}:              -40.000000,
+	{"Ocircumflex", "Y"}:              -50.000000,
+	{"Ocircumflex", "Yacute"}:         -50.000000,
+	{"Ocircumflex", "Ydieresis"}:      -50.000000,
+	{"Odieresis", "A"}:                -55.000000,
+	{"Odieresis", "Aacute"}:           -55.000000,
+	{"Odieresis", "Abreve"}:           -55.000000,
+	{"Odieresis", "Acircumflex"}:      -55.000000,
+	{"Odieresis", "Adieresis"}:        -55.000000,
+	{"Odieresis", "Agrave"}:           -55.000000,
+	{"Odieresis", "Amacron"}:          -55.000000,
+	{"Odieresis", "Aogonek"}:          -55.000000,
+	{"Odieresis", "Aring"}:            -55.000000,
+	{"Odieresis", "Atilde"}:           -55.000000,
+	{"Odieresis", "T"}:                -40.000000,
+	{"Odieresis", "Tcaron"}:           -40.000000,
+	{"Odieresis", "Tcommaaccent"}:     -40.000000,
+	{"Odieresis", "V"}:                -50.000000,
+	{"Odieresis", "W"}:                -50.000000,
+	{"Odieresis", "X"}:                -40.000000,
+	{"Odieresis", "Y"}:                -50.000000,
+	{"Odieresis", "Yacute"}:           -50.000000,
+	{"Odieresis", "Ydieresis"}:        -50.000000,
+	{"Ograve", "A"}:                   -55.000000,
+	{"Ograve", "Aacute"}:              -55.000000,
+	{"Ograve", "Abreve"}:              -55.000000,
+	{"Ograve", "Acircumflex"}:         -55.000000,
+	{"Ograve", "Adieresis"}:           -55.000000,
+	{"Ograve", "Agrave"}:              -55.000000,
+	{"Ograve", "Amacron"}:             -55.000000,
+	{"Ograve", "Aogonek"}:             -55.000000,
+	{"Ograve", "Aring"}:               -55.000000,
+	{"Ograve", "Atilde"}:              -55.000000,
+	{"Ograve", "T"}:                   -40.000000,
+	{"Ograve", "Tcaron"}:              -40.000000,
+	{"Ograve", "Tcommaaccent"}:        -40.000000,
+	{"Ograve", "V"}:                   -50.000000,
+	{"Ograve", "W"}:                   -50.000000,
+	{"Ograve", "X"}:                   -40.000000,
+	{"Ograve", "Y"}:                   -50.000000,
+	{"Ograve", "Yacute"}:              -50.000000,
+	{"Ograve", "Ydieresis"}:           -50.000000,
+	{"Ohungarumlaut", "A"}:            -55.000000,
+	{"Ohungarumlaut", "Aacute"}:       -55.000000,
+	{"Ohungarumlaut", "Abreve"}:       -55.000000,
+	{"Ohungarumlaut", "Acircumflex"}:  -55.000000,
+	{"Ohungarumlaut", "Adieresis"}:    -55.000000,
+	{"Ohungarumlaut", "Agrave"}:       -55.000000,
+	{"Ohungarumlaut", "Amacron"}:      -55.000000,
+	{"Ohungarumlaut", "Aogonek"}:      -55.000000,
+	{"Ohungarumlaut", "Aring"}:        -55.000000,
+	{"Ohungarumlaut", "Atilde"}:       -55.000000,
+	{"Ohungarumlaut", "T"}:            -40.000000,
+	{"Ohungarumlaut", "Tcaron"}:       -40.000000,
+	{"Ohungarumlaut", "Tcommaaccent"}: -40.000000,
+	{"Ohungarumlaut", "V"}:            -50.000000,
+	{"Ohungarumlaut", "W"}:            -50.000000,
+	{"Ohungarumlaut", "X"}:            -40.000000,
+	{"Ohungarumlaut", "Y"}:            -50.000000,
+	{"Ohungarumlaut", "Yacute"}:       -50.000000,
+	{"Ohungarumlaut", "Ydieresis"}:    -50.000000,
+	{"Omacron", "A"}:                  -55.000000,
+	{"Omacron", "Aacute"}:             -55.000000,
+	{"Omacron", "Abreve"}:             -55.000000,
+	{"Omacron", "Acircumflex"}:        -55.000000,
+	{"Omacron", "Adieresis"}:          -55.000000,
+	{"Omacron", "Agrave"}:             -55.000000,
+	{"Omacron", "Amacron"}:            -55.000000,
+	{"Omacron", "Aogonek"}:            -55.000000,
+	{"Omacron", "Aring"}:              -55.000000,
+	{"Omacron", "Atilde"}:             -55.000000,
+	{"Omacron", "T"}:                  -40.000000,
+	{"Omacron", "Tcaron"}:             -40.000000,
+	{"Omacron", "Tcommaaccent"}:       -40.000000,
+	{"Omacron", "V"}:                  -50.000000,
+	{"Omacron", "W"}:                  -50.000000,
+	{"Omacron", "X"}:                  -40.000000,
+	{"Omacron", "Y"}:                  -50.000000,
+	{"Omacron", "Yacute"}:             -50.000000,
+	{"Omacron", "Ydieresis"}:          -50.000000,
+	{"Oslash", "A"}:                   -55.000000,
+	{"Oslash", "Aacute"}:              -55.000000,
+	{"Oslash", "Abreve"}:              -55.000000,
+	{"Oslash", "Acircumflex"}:         -55.000000,
+	{"Oslash", "Adieresis"}:           -55.000000,
+	{"Oslash", "Agrave"}:              -55.000000,
+	{"Oslash", "Amacron"}:             -55.000000,
+	{"Oslash", "Aogonek"}:             -55.000000,
+	{"Oslash", "Aring"}:               -55.000000,
+	{"Oslash", "Atilde"}:              -55.000000,
+	{"Oslash", "T"}:                   -40.000000,
+	{"Oslash", "Tcaron"}:              -40.000000,
+	{"Oslash", "Tcommaaccent"}:        -40.000000,
+	{"Oslash", "V"}:                   -50.000000,
+	{"Oslash", "W"}:                   -50.000000,
+	{"Oslash", "X"}:                   -40.000000,
+	{"Oslash", "Y"}:                   -50.000000,
+	{"Oslash", "Yacute"}:              -50.000000,
+	{"Oslash", "Ydieresis"}:           -50.000000,
+	{"Otilde", "A"}:                   -55.000000,
+	{"Otilde", "Aacute"}:              -55.000000,
+	{"Otilde", "Abreve"}:              -55.000000,
+	{"Otilde", "Acircumflex"}:         -55.000000,
+	{"Otilde", "Adieresis"}:           -55.000000,
+	{"Otilde", "Agrave"}:              -55.000000,
+	{"Otilde", "Amacron"}:             -55.000000,
+	{"Otilde", "Aogonek"}:             -55.000000,
+	{"Otilde", "Aring"}:               -55.000000,
+	{"Otilde", "Atilde"}:              -55.000000,
+	{"Otilde", "T"}:                   -40.000000,
+	{"Otilde", "Tcaron"}:              -40.000000,
+	{"Otilde", "Tcommaaccent"}:        -40.000000,
+	{"Otilde", "V"}:                   -50.000000,
+	{"Otilde", "W"}:                   -50.000000,
+	{"Otilde", "X"}:                   -40.000000,
+	{"Otilde", "Y"}:                   -50.000000,
+	{"Otilde", "Yacute"}:              -50.000000,
+	{"Otilde", "Ydieresis"}:           -50.000000,
+	{"P", "A"}:                        -90.000000,
+	{"P", "Aacute"}:                   -90.000000,
+	{"P", "Abreve"}:                   -90.000000,
+	{"P", "Acircumflex"}:              -90.000000,
+	{"P", "Adieresis"}:                -90.000000,
+	{"P", "Agrave"}:                   -90.000000,
+	{"P", "Amacron"}:                  -90.000000,
+	{"P", "Aogonek"}:                  -90.000000,
+	{"P", "Aring"}:                    -90.000000,
+	{"P", "Atilde"}:                   -90.000000,
+	{"P", "a"}:                        -80.000000,
+	{"P", "aacute"}:                   -80.000000,
+	{"P", "abreve"}:                   -80.000000,
+	{"P", "acircumflex"}:              -80.000000,
+	{"P", "adieresis"}:                -80.000000,
+	{"P", "agrave"}:                   -80.000000,
+	{"P", "amacron"}:                  -80.000000,
+	{"P", "aogonek"}:                  -80.000000,
+	{"P", "aring"}:                    -80.000000,
+	{"P", "atilde"}:                   -80.000000,
+	{"P", "comma"}:                    -135.000000,
+	{"P", "e"}:                        -80.000000,
+	{"P", "eacute"}:                   -80.000000,
+	{"P", "ecaron"}:                   -80.000000,
+	{"P", "ecircumflex"}:              -80.000000,
+	{"P", "edieresis"}:                -80.000000,
+	{"P", "edotaccent"}:               -80.000000,
+	{"P", "egrave"}:                   -80.000000,
+	{"P", "emacron"}:                  -80.000000,
+	{"P", "eogonek"}:                  -80.000000,
+	{"P", "o"}:                        -80.000000,
+	{"P", "oacute"}:                   -80.000000,
+	{"P", "ocircumflex"}:              -80.000000,
+	{"P", "odieresis"}:                -80.000000,
+	{"P", "ograve"}:                   -80.000000,
+	{"P", "ohungarumlaut"}:            -80.000000,
+	{"P", "omacron"}:                  -80.000000,
+	{"P", "oslash"}:                   -80.000000,
+	{"P", "otilde"}:                   -80.000000,
+	{"P", "period"}:                   -135.000000,
+	{"Q", "U"}:                        -10.000000,
+	{"Q", "Uacute"}:                   -10.000000,
+	{"Q", "Ucircumflex"}:              -10.000000,
+	{"Q", "Udieresis"}:                -10.000000,
+	{"Q", "Ugrave"}:                   -10.000000,
+	{"Q", "Uhungarumlaut"}:            -10.000000,
+	{"Q", "Umacron"}:                  -10.000000,
+	{"Q", "Uogonek"}:                  -10.000000,
+	{"Q", "Uring"}:                    -10.000000,
+	{"R", "O"}:                        -40.000000,
+	{"R", "Oacute"}:                   -40.000000,
+	{"R", "Ocircumflex"}:              -40.000000,
+	{"R", "Odieresis"}:                -40.000000,
+	{"R", "Ograve"}:                   -40.000000,
+	{"R", "Ohungarumlaut"}:            -40.000000,
+	{"R", "Omacron"}:                  -40.000000,
+	{"R", "Oslash"}:                   -40.000000,
+	{"R", "Otilde"}:                   -40.000000,
+	{"R", "U"}:                        -40.000000,
+	{"R", "Uacute"}:                   -40.000000,
+	{"R", "Ucircumflex"}:              -40.000000,
+	{"R", "Udieresis"}:                -40.000000,
+	{"R", "Ugrave"}:                   -40.000000,
+	{"R", "Uhungarumlaut"}:            -40.000000,
+	{"R", "Umacron"}:                  -40.000000,
+	{"R", "Uogonek"}:                  -40.000000,
+	{"R", "Uring"}:                    -40.000000,
+	{"R", "V"}:                        -18.000000,
+	{"R", "W"}:                        -18.000000,
+	{"R", "Y"}:                        -18.000000,
+	{"R", "Yacute"}:                   -18.000000,
+	{"R", "Ydieresis"}:                -18.000000,
+	{"Racute", "O"}:                   -40.000000,
+	{"Racute", "Oacute"}:              -40.000000,
+	{"Racute", "Ocircumflex"}:         -40.000000,
+	{"Racute", "Odieresis"}:           -40.000000,
+	{"Racute", "Ograve"}:              -40.000000,
+	{"Racute", "Ohungarumlaut"}:       -40.000000,
+	{"Racute", "Omacron"}:             -40.000000,
+	{"Racute", "Oslash"}:              -40.000000,
+	{"Racute", "Otilde"}:              -40.000000,
+	{"Racute", "U"}:                   -40.000000,
+	{"Racute", "Uacute"}:              -40.000000,
+	{"Racute", "Ucircumflex"}:         -40.000000,
+	{"Racute", "Udieresis"}:           -40.000000,
+	{"Racute", "Ugrave"}:              -40.000000,
+	{"Racute", "Uhungarumlaut"}:       -40.000000,
+	{"Racute", "Umacron"}:             -40.000000,
+	{"Racute", "Uogonek"}:             -40.000000,
+	{"Racute", "Uring"}:               -40.000000,
+	{"Racute", "V"}:                   -18.000000,
+	{"Racute", "W"}:                   -18.000000,
+	{"Racute", "Y"}:                   -18.000000,
+	{"Racute", "Yacute"}:              -18.000000,
+	{"Racute", "Ydieresis"}:           -18.000000,
+	{"Rcaron", "O"}:                   -40.000000,
+	{"Rcaron", "Oacute"}:              -40.000000,
+	{"Rcaron", "Ocircumflex"}:         -40.000000,
+	{"Rcaron", "Odieresis"}:           -40.000000,
+	{"Rcaron", "Ograve"}:              -40.000000,
+	{"Rcaron", "Ohungarumlaut"}:       -40.000000,
+	{"Rcaron", "Omacron"}:             -40.000000,
+	{"Rcaron", "Oslash"}:              -40.000000,
+	{"Rcaron", "Otilde"}:              -40.000000,
+	{"Rcaron", "U"}:                   -40.000000,
+	{"Rcaron", "Uacute"}:              -40.000000,
+	{"Rcaron", "Ucircumflex"}:         -40.000000,
+	{"Rcaron", "Udieresis"}:           -40.000000,
+	{"Rcaron", "Ugrave"}:              -40.000000,
+	{"Rcaron", "Uhungarumlaut"}:       -40.000000,
+	{"Rcaron", "Umacron"}:             -40.000000,
+	{"Rcaron", "Uogonek"}:             -40.000000,
+	{"Rcaron", "Uring"}:               -40.000000,
+	{"Rcaron", "V"}:                   -18.000000,
+	{"Rcaron", "W"}:                   -18.000000,
+	{"Rcaron", "Y"}:                   -18.000000,
+	{"Rcaron", "Yacute"}:              -18.000000,
+	{"Rcaron", "Ydieresis"}:           -18.000000,
+	{"Rcommaaccent", "O"}:             -40.000000,
+	{"Rcommaaccent", "Oacute"}:        -40.000000,
+	{"Rcommaaccent", "Ocircumflex"}:   -40.000000,
+	{"Rcommaaccent", "Odieresis"}:     -40.000000,
+	{"Rcommaaccent", "Ograve"}:        -40.000000,
+	{"Rcommaaccent", "Ohungarumlaut"}: -40.000000,
+	{"Rcommaaccent", "Omacron"}:       -40.000000,
+	{"Rcommaaccent", "Oslash"}:        -40.000000,
+	{"Rcommaaccent", "Otilde"}:        -40.000000,
+	{"Rcommaaccent", "U"}:             -40.000000,
+	{"Rcommaaccent", "Uacute"}:        -40.000000,
+	{"Rcommaaccent", "Ucircumflex"}:   -40.000000,
+	{"Rcommaaccent", "Udieresis"}:     -40.000000,
+	{"Rcommaaccent", "Ugrave"}:        -40.000000,
+	{"Rcommaaccent", "Uhungarumlaut"}: -40.000000,
+	{"Rcommaaccent", "Umacron"}:       -40.000000,
+	{"Rcommaaccent", "Uogonek"}:       -40.000000,
+	{"Rcommaaccent", "Uring"}:         -40.000000,
+	{"Rcommaaccent", "V"}:             -18.000000,
+	{"Rcommaaccent", "W"}:             -18.000000,
+	{"Rcommaaccent", "Y"}:             -18.000000,
+	{"Rcommaaccent", "Yacute"}:        -18.000000,
+	{"Rcommaaccent", "Ydieresis"}:     -18.000000,
+	{"T", "A"}:                        -50.000000,
+	{"T", "Aacute"}:                   -50.000000,
+	{"T", "Abreve"}:                   -50.000000,
+	{"T", "Acircumflex"}:              -50.000000,
+	{"T", "Adieresis"}:                -50.000000,
+	{"T", "Agrave"}:                   -50.000000,
+	{"T", "Amacron"}:                  -50.000000,
+	{"T", "Aogonek"}:                  -50.000000,
+	{"T", "Aring"}:                    -50.000000,
+	{"T", "Atilde"}:                   -50.000000,
+	{"T", "O"}:                        -18.000000,
+	{"T", "Oacute"}:                   -18.000000,
+	{"T", "Ocircumflex"}:              -18.000000,
+	{"T", "Odieresis"}:                -18.000000,
+	{"T", "Ograve"}:                   -18.000000,
+	{"T", "Ohungarumlaut"}:            -18.000000,
+	{"T", "Omacron"}:                  -18.000000,
+	{"T", "Oslash"}:                   -18.000000,
+	{"T", "Otilde"}:                   -18.000000,
+	{"T", "a"}:                        -92.000000,
+	{"T", "aacute"}:                   -92.000000,
+	{"T", "abreve"}:                   -92.000000,
+	{"T", "acircumflex"}:              -92.000000,
+	{"T", "adieresis"}:                -92.000000,
+	{"T", "agrave"}:                   -92.000000,
+	{"T", "amacron"}:                  -92.000000,
+	{"T", "aogonek"}:                  -92.000000,
+	{"T", "aring"}:                    -92.000000,
+	{"T", "atilde"}:                   -92.000000,
+	{"T", "colon"}:                    -55.000000,
+	{"T", "comma"}:                    -74.000000,
+	{"T", "e"}:                        -92.000000,
+	{"T", "eacute"}:                   -92.000000,
+	{"T", "ecaron"}:                   -92.000000,
+	{"T", "ecircumflex"}:              -52.000000,
+	{"T", "edieresis"}:                -52.000000,
+	{"T", "edotaccent"}:               -92.000000,
+	{"T", "egrave"}:                   -52.000000,
+	{"T", "emacron"}:                  -52.000000,
+	{"T", "eogonek"}:                  -92.000000,
+	{"T", "hyphen"}:                   -74.000000,
+	{"T", "i"}:                        -55.000000,
+	{"T", "iacute"}:                   -55.000000,
+	{"T", "iogonek"}:                  -55.000000,
+	{"T", "o"}:                        -92.000000,
+	{"T", "oacute"}:                   -92.000000,
+	{"T", "ocircumflex"}:              -92.000000,
+	{"T", "odieresis"}:                -92.000000,
+	{"T", "ograve"}:                   -92.000000,
+	{"T", "ohungarumlaut"}:            -92.000000,
+	{"T", "omacron"}:                  -92.000000,
+	{"T", "oslash"}:                   -92.000000,
+	{"T", "otilde"}:                   -92.000000,
+	{"T", "period"}:                   -74.000000,
+	{"T", "r"}:                        -55.000000,
+	{"T", "racute"}:                   -55.000000,
+	{"T", "rcaron"}:                   -55.000000,
+	{"T", "rcommaaccent"}:             -55.000000,
+	{"T", "semicolon"}:                -65.000000,
+	{"T", "u"}:                        -55.000000,
+	{"T", "uacute"}:                   -55.000000,
+	{"T", "ucircumflex"}:              -55.000000,
+	{"T", "udieresis"}:                -55.000000,
+	{"T", "ugrave"}:                   -55.000000,
+	{"T", "uhungarumlaut"}:            -55.000000,
+	{"T", "umacron"}:                  -55.000000,
+	{"T", "uogonek"}:                  -55.000000,
+	{"T", "uring"}:                    -55.000000,
+	{"T", "w"}:                        -74.000000,
+	{"T", "y"}:                        -74.000000,
+	{"T", "yacute"}:                   -74.000000,
+	{"T", "ydieresis"}:                -34.000000,
+	{"Tcaron", "A"}:                   -50.000000,
+	{"Tcaron", "Aacute"}:              -50.000000,
+	{"Tcaron", "Abreve"}:              -50.000000,
+	{"Tcaron", "Acircumflex"}:         -50.000000,
+	{"Tcaron", "Adieresis"}:           -50.000000,
+	{"Tcaron", "Agrave"}:              -50.000000,
+	{"Tcaron", "Amacron"}:             -50.000000,
+	{"Tcaron", "Aogonek"}:             -50.000000,
+	{"Tcaron", "Aring"}:               -50.000000,
+	{"Tcaron", "Atilde"}:              -50.000000,
+	{"Tcaron", "O"}:                   -18.000000,
+	{"Tcaron", "Oacute"}:              -18.000000,
+	{"Tcaron", "Ocircumflex"}:         -18.000000,
+	{"Tcaron", "Odieresis"}:           -18.000000,
+	{"Tcaron", "Ograve"}:              -18.000000,
+	{"Tcaron", "Ohungarumlaut"}:       -18.000000,
+	{"Tcaron", "Omacron"}:             -18.000000,
+	{"Tcaron", "Oslash"}:              -18.000000,
+	{"Tcaron", "Otilde"}:              -18.000000,
+	{"Tcaron", "a"}:                   -92.000000,
+	{"Tcaron", "aacute"}:              -92.000000,
+	{"Tcaron", "abreve"}:              -92.000000,
+	{"Tcaron", "acircumflex"}:         -92.000000,
+	{"Tcaron", "adieresis"}:           -92.000000,
+	{"Tcaron", "agrave"}:              -92.000000,
+	{"Tcaron", "amacron"}:             -92.000000,
+	{"Tcaron", "aogonek"}:             -92.000000,
+	{"Tcaron", "aring"}:               -92.000000,
+	{"Tcaron", "atilde"}:              -92.000000,
+	{"Tcaron", "colon"}:               -55.000000,
+	{"Tcaron", "comma"}:               -74.000000,
+	{"Tcaron", "e"}:                   -92.000000,
+	{"Tcaron", "eacute"}:              -92.000000,
+	{"Tcaron", "ecaron"}:              -92.000000,
+	{"Tcaron", "ecircumflex"}:         -52.000000,
+	{"Tcaron", "edieresis"}:           -52.000000,
+	{"Tcaron", "edotaccent"}:          -92.000000,
+	{"Tcaron", "egrave"}:              -52.000000,
+	{"Tcaron", "emacron"}:             -52.000000,
+	{"Tcaron", "eogonek"}:             -92.000000,
+	{"Tcaron", "hyphen"}:              -74.000000,
+	{"Tcaron", "i"}:                   -55.000000,
+	{"Tcaron", "iacute"}:              -55.000000,
+	{"Tcaron", "iogonek"}:             -55.000000,
+	{"Tcaron", "o"}:                   -92.000000,
+	{"Tcaron", "oacute"}:              -92.000000,
+	{"Tcaron", "ocircumflex"}:         -92.000000,
+	{"Tcaron", "odieresis"}:           -92.000000,
+	{"Tcaron", "ograve"}:              -92.000000,
+	{"Tcaron", "ohungarumlaut"}:       -92.000000,
+	{"Tcaron", "omacron"}:             -92.000000,
+	{"Tcaron", "oslash"}:              -92.000000,
+	{"Tcaron", "otilde"}:              -92.000000,
+	{"Tcaron", "period"}:              -74.000000,
+	{"Tcaron", "r"}:                   -55.000000,
+	{"Tcaron", "racute"}:              -55.000000,
+	{"Tcaron", "rcaron"}:              -55.000000,
+	{"Tcaron", "rcommaaccent"}:        -55.000000,
+	{"Tcaron", "semicolon"}:           -65.000000,
+	{"Tcaron", "u"}:                   -55.000000,
+	{"Tcaron", "uacute"}:              -55.000000,
+	{"Tcaron", "ucircumflex"}:         -55.000000,
+	{"Tcaron", "udieresis"}:           -55.000000,
+	{"Tcaron", "ugrave"}:              -55.000000,
+	{"Tcaron", "uhungarumlaut"}:       -55.000000,
+	{"Tcaron", "umacron"}:             -55.000000,
+	{"Tcaron", "uogonek"}:             -55.000000,
+	{"Tcaron", "uring"}:               -55.000000,
+	{"Tcaron", "w"}:                   -74.000000,
+	{"Tcaron", "y"}:                   -74.000000,
+	{"Tcaron", "yacute"}:              -74.000000,
+	{"Tcaron", "ydieresis"}:           -34.000000,
+	{"Tcommaaccent", "A"}:             -50.000000,
+	{"Tcommaaccent", "Aacute"}:        -50.000000,
+	{"Tcommaaccent", "Abreve"}:        -50.000000,
+	{"Tcommaaccent", "Acircumflex"}:   -50.000000,
+	{"Tcommaaccent", "Adieresis"}:     -50.000000,
+	{"Tcommaaccent", "Agrave"}:        -50.000000,
+	{"Tcommaaccent", "Amacron"}:       -50.000000,
+	{"Tcommaaccent", "Aogonek"}:       -50.000000,
+	{"Tcommaaccent", "Aring"}:         -50.000000,
+	{"Tcommaaccent", "Atilde"}:        -50.000000,
+	{"Tcommaaccent", "O"}:             -18.000000,
+	{"Tcommaaccent", "Oacute"}:        -18.000000,
+	{"Tcommaaccent", "Ocircumflex"}:   -18.000000,
+	{"Tcommaaccent", "Odieresis"}:     -18.000000,
+	{"Tcommaaccent", "Ograve"}:        -18.000000,
+	{"Tcommaaccent", "Ohungarumlaut"}: -18.000000,
+	{"Tcommaaccent", "Omacron"}:       -18.000000,
+	{"Tcommaaccent", "Oslash"}:        -18.000000,
+	{"Tcommaaccent", "Otilde"}:        -18.000000,
+	{"Tcommaaccent", "a"}:             -92.000000,
+	{"Tcommaaccent", "aacute"}:        -92.000000,
+	{"Tcommaaccent", "abreve"}:        -92.000000,
+	{"Tcommaaccent", "acircumflex"}:   -92.000000,
+	{"Tcommaaccent", "adieresis"}:     -92.000000,
+	{"Tcommaaccent", "agrave"}:        -92.000000,
+	{"Tcommaaccent", "amacron"}:       -92.000000,
+	{"Tcommaaccent", "aogonek"}:       -92.000000,
+	{"Tcommaaccent", "aring"}:         -92.000000,
+	{"Tcommaaccent", "atilde"}:        -92.000000,
+	{"Tcommaaccent", "colon"}:         -55.000000,
+	{"Tcommaaccent", "comma"}:         -74.000000,
+	{"Tcommaaccent", "e"}:             -92.000000,
+	{"Tcommaaccent", "eacute"}:        -92.000000,
+	{"Tcommaaccent", "ecaron"}:        -92.000000,
+	{"Tcommaaccent", "ecircumflex"}:   -52.000000,
+	{"Tcommaaccent", "edieresis"}:     -52.000000,
+	{"Tcommaaccent", "edotaccent"}:    -92.000000,
+	{"Tcommaaccent", "egrave"}:        -52.000000,
+	{"Tcommaaccent", "emacron"}:       -52.000000,
+	{"Tcommaaccent", "eogonek"}:       -92.000000,
+	{"Tcommaaccent", "hyphen"}:        -74.000000,
+	{"Tcommaaccent", "i"}:             -55.000000,
+	{"Tcommaaccent", "iacute"}:        -55.000000,
+	{"Tcommaaccent", "iogonek"}:       -55.000000,
+	{"Tcommaaccent", "o"}:             -92.000000,
+	{"Tcommaaccent", "oacute"}:        -92.000000,
+	{"Tcommaaccent", "ocircumflex"}:   -92.000000,
+	{"Tcommaaccent", "odieresis"}:     -92.000000,
+	{"Tcommaaccent", "ograve"}:        -92.000000,
+	{"Tcommaaccent", "ohungarumlaut"}: -92.000000,
+	{"Tcommaaccent", "omacron"}:       -92.000000,
+	{"Tcommaaccent", "oslash"}:        -92.000000,
+	{"Tcommaaccent", "otilde"}:        -92.000000,
+	{"Tcommaaccent", "period"}:        -74.000000,
+	{"Tcommaaccent", "r"}:             -55.000000,
+	{"Tcommaaccent", "racute"}:        -55.000000,
+	{"Tcommaaccent", "rcaron"}:        -55.000000,
+	{"Tcommaaccent", "rcommaaccent"}:  -55.000000,
+	{"Tcommaaccent", "semicolon"}:     -65.000000,
+	{"Tcommaaccent", "u"}:             -55.000000,
+	{"Tcommaaccent", "uacute"}:        -55.000000,
+	{"Tcommaaccent", "ucircumflex"}:   -55.000000,
+	{"Tcommaaccent", "udieresis"}:     -55.000000,
+	{"Tcommaaccent", "ugrave"}:        -55.000000,
+	{"Tcommaaccent", "uhungarumlaut"}: -55.000000,
+	{"Tcommaaccent", "umacron"}:       -55.000000,
+	{"Tcommaaccent", "uogonek"}:       -55.000000,
+	{"Tcommaaccent", "uring"}:         -55.000000,
+	{"Tcommaaccent", "w"}:             -74.000000,
+	{"Tcommaaccent", "y"}:             -74.000000,
+	{"Tcommaaccent", "yacute"}:        -74.000000,
+	{"Tcommaaccent", "ydieresis"}:     -34.000000,
+	{"U", "A"}:                        -40.000000,
+	{"U", "Aacute"}:                   -40.000000,
+	{"U", "Abreve"}:                   -40.000000,
+	{"U", "Acircumflex"}:              -40.000000,
+	{"U", "Adieresis"}:                -40.000000,
+	{"U", "Agrave"}:                   -40.000000,
+	{"U", "Amacron"}:                  -40.000000,
+	{"U", "Aogonek"}:                  -40.000000,
+	{"U", "Aring"}:                    -40.000000,
+	{"U", "Atilde"}:                   -40.000000,
+	{"U", "comma"}:                    -25.000000,
+	{"U", "period"}:                   -25.000000,
+	{"Uacute", "A"}:                   -40.000000,
+	{"Uacute", "Aacute"}:              -40.000000,
+	{"Uacute", "Abreve"}:              -40.000000,
+	{"Uacute", "Acircumflex"}:         -40.000000,
+	{"Uacute", "Adieresis"}:           -40.000000,
+	{"Uacute", "Agrave"}:              -40.000000,
+	{"Uacute", "Amacron"}:             -40.000000,
+	{"Uacute", "Aogonek"}:             -40.000000,
+	{"Uacute", "Aring"}:               -40.000000,
+	{"Uacute", "Atilde"}:              -40.000000,
+	{"Uacute", "comma"}:               -25.000000,
+	{"Uacute", "period"}:              -25.000000,
+	{"Ucircumflex", "A"}:              -40.000000,
+	{"Ucircumflex", "Aacute"}:         -40.000000,
+	{"Ucircumflex", "Abreve"}:         -40.000000,
+	{"Ucircumflex", "Acircumflex"}:    -40.000000,
+	{"Ucircumflex", "Adieresis"}:      -40.000000,
+	{"Ucircumflex", "Agrave"}:         -40.000000,
+	{"Ucircumflex", "Amacron"}:        -40.000000,
+	{"Ucircumflex", "Aogonek"}:        -40.000000,
+	{"Ucircumflex", "Aring"}:          -40.000000,
+	{"Ucircumflex", "Atilde"}:         -40.000000,
+	{"Ucircumflex", "comma"}:          -25.000000,
+	{"Ucircumflex", "period"}:         -25.000000,
+	{"Udieresis", "A"}:                -40.000000,
+	{"Udieresis", "Aacute"}:           -40.000000,
+	{"Udieresis", "Abreve"}:           -40.000000,
+	{"Udieresis", "Acircumflex"}:      -40.000000,
+	{"Udieresis", "Adieresis"}:        -40.000000,
+	{"Udieresis", "Agrave"}:           -40.000000,
+	{"Udieresis", "Amacron"}:          -40.000000,
+	{"Udieresis", "Aogonek"}:          -40.000000,
+	{"Udieresis", "Aring"}:            -40.000000,
+	{"Udieresis", "Atilde"}:           -40.000000,
+	{"Udieresis", "comma"}:            -25.000000,
+	{"Udieresis", "period"}:           -25.000000,
+	{"Ugrave", "A"}:                   -40.000000,
+	{"Ugrave", "Aacute"}:              -40.000000,
+	{"Ugrave", "Abreve"}:              -40.000000,
+	{"Ugrave", "Acircumflex"}:         -40.000000,
+	{"Ugrave", "Adieresis"}:           -40.000000,
+	{"Ugrave", "Agrave"}:              -40.000000,
+	{"Ugrave", "Amacron"}:             -40.000000,
+	{"Ugrave", "Aogonek"}:             -40.000000,
+	{"Ugrave", "Aring"}:               -40.000000,
+	{"Ugrave", "Atilde"}:              -40.000000,
+	{"Ugrave", "comma"}:               -25.000000,
+	{"Ugrave", "period"}:              -25.000000,
+	{"Uhungarumlaut", "A"}:            -40.000000,
+	{"Uhungarumlaut", "Aacute"}:       -40.000000,
+	{"Uhungarumlaut", "Abreve"}:       -40.000000,
+	{"Uhungarumlaut", "Acircumflex"}:  -40.000000,
+	{"Uhungarumlaut", "Adieresis"}:    -40.000000,
+	{"Uhungarumlaut", "Agrave"}:       -40.000000,
+	{"Uhungarumlaut", "Amacron"}:      -40.000000,
+	{"Uhungarumlaut", "Aogonek"}:      -40.000000,
+	{"Uhungarumlaut", "Aring"}:        -40.000000,
+	{"Uhungarumlaut", "Atilde"}:       -40.000000,
+	{"Uhungarumlaut", "comma"}:        -25.000000,
+	{"Uhungarumlaut", "period"}:       -25.000000,
+	{"Umacron", "A"}:                  -40.000000,
+	{"Umacron", "Aacute"}:             -40.000000,
+	{"Umacron", "Abreve"}:             -40.000000,
+	{"Umacron", "Acircumflex"}:        -40.000000,
+	{"Umacron", "Adieresis"}:          -40.000000,
+	{"Umacron", "Agrave"}:             -40.000000,
+	{"Umacron", "Amacron"}:            -40.000000,
+	{"Umacron", "Aogonek"}:            -40.000000,
+	{"Umacron", "Aring"}:              -40.000000,
+	{"Umacron", "Atilde"}:             -40.000000,
+	{"Umacron", "comma"}:              -25.000000,
+	{"Umacron", "period"}:             -25.000000,
+	{"Uogonek", "A"}:                  -40.000000,
+	{"Uogonek", "Aacute"}:             -40.000000,
+	{"Uogonek", "Abreve"}:             -40.000000,
+	{"Uogonek", "Acircumflex"}:        -40.000000,
+	{"Uogonek", "Adieresis"}:          -40.000000,
+	{"Uogonek", "Agrave"}:             -40.000000,
+	{"Uogonek", "Amacron"}:            -40.000000,
+	{"Uogonek", "Aogonek"}:            -40.000000,
+	{"Uogonek", "Aring"}:              -40.000000,
+	{"Uogonek", "Atilde"}:             -40.000000,
+	{"Uogonek", "comma"}:              -25.000000,
+	{"Uogonek", "period"}:             -25.000000,
+	{"Uring", "A"}:                    -40.000000,
+	{"Uring", "Aacute"}:               -40.000000,
+	{"Uring", "Abreve"}:               -40.000000,
+	{"Uring", "Acircumflex"}:          -40.000000,
+	{"Uring", "Adieresis"}:            -40.000000,
+	{"Uring", "Agrave"}:               -40.000000,
+	{"Uring", "Amacron"}:              -40.000000,
+	{"Uring", "Aogonek"}:              -40.000000,
+	{"Uring", "Aring"}:                -40.000000,
+	{"Uring", "Atilde"}:               -40.000000,
+	{"Uring", "comma"}:                -25.000000,
+	{"Uring", "period"}:               -25.000000,
+	{"V", "A"}:                        -60.000000,
+	{"V", "Aacute"}:                   -60.000000,
+	{"V", "Abreve"}:                   -60.000000,
+	{"V", "Acircumflex"}:              -60.000000,
+	{"V", "Adieresis"}:                -60.000000,
+	{"V", "Agrave"}:                   -60.000000,
+	{"V", "Amacron"}:                  -60.000000,
+	{"V", "Aogonek"}:                  -60.000000,
+	{"V", "Aring"}:                    -60.000000,
+	{"V", "Atilde"}:                   -60.000000,
+	{"V", "O"}:                        -30.000000,
+	{"V", "Oacute"}:                   -30.000000,
+	{"V", "Ocircumflex"}:              -30.000000,
+	{"V", "Odieresis"}:                -30.000000,
+	{"V", "Ograve"}:                   -30.000000,
+	{"V", "Ohungarumlaut"}:            -30.000000,
+	{"V", "Omacron"}:                  -30.000000,
+	{"V", "Oslash"}:                   -30.000000,
+	{"V", "Otilde"}:                   -30.000000,
+	{"V", "a"}:                        -111.000000,
+	{"V", "aacute"}:                   -111.000000,
+	{"V", "abreve"}:                   -111.000000,
+	{"V", "acircumflex"}:              -111.000000,
+	{"V", "adieresis"}:                -111.000000,
+	{"V", "agrave"}:                   -111.000000,
+	{"V", "amacron"}:                  -111.000000,
+	{"V", "aogonek"}:                  -111.000000,
+	{"V", "aring"}:                    -111.000000,
+	{"V", "atilde"}:                   -111.000000,
+	{"V", "colon"}:                    -65.000000,
+	{"V", "comma"}:                    -129.000000,
+	{"V", "e"}:                        -111.000000,
+	{"V", "eacute"}:                   -111.000000,
+	{"V", "ecaron"}:                   -111.000000,
+	{"V", "ecircumflex"}:              -111.000000,
+	{"V", "edieresis"}:                -71.000000,
+	{"V", "edotaccent"}:               -111.000000,
+	{"V", "egrave"}:                   -71.000000,
+	{"V", "emacron"}:                  -71.000000,
+	{"V", "eogonek"}:                  -111.000000,
+	{"V", "hyphen"}:                   -55.000000,
+	{"V", "i"}:                        -74.000000,
+	{"V", "iacute"}:                   -74.000000,
+	{"V", "icircumflex"}:              -34.000000,
+	{"V", "idieresis"}:                -34.000000,
+	{"V", "igrave"}:                   -34.000000,
+	{"V", "imacron"}:                  -34.000000,
+	{"V", "iogonek"}:                  -74.000000,
+	{"V", "o"}:                        -111.000000,
+	{"V", "oacute"}:                   -111.000000,
+	{"V", "ocircumflex"}:              -111.000000,
+	{"V", "odieresis"}:                -111.000000,
+	{"V", "ograve"}:                   -111.000000,
+	{"V", "ohungarumlaut"}:            -111.000000,
+	{"V", "omacron"}:                  -111.000000,
+	{"V", "oslash"}:                   -111.000000,
+	{"V", "otilde"}:                   -111.000000,
+	{"V", "period"}:                   -129.000000,
+	{"V", "semicolon"}:                -74.000000,
+	{"V", "u"}:                        -74.000000,
+	{"V", "uacute"}:                   -74.000000,
+	{"V", "ucircumflex"}:              -74.000000,
+	{"V", "udieresis"}:                -74.000000,
+	{"V", "ugrave"}:                   -74.000000,
+	{"V", "uhungarumlaut"}:            -74.000000,
+	{"V", "umacron"}:                  -74.000000,
+	{"V", "uogonek"}:                  -74.000000,
+	{"V", "uring"}:                    -74.000000,
+	{"W", "A"}:                        -60.000000,
+	{"W", "Aacute"}:                   -60.000000,
+	{"W", "Abreve"}:                   -60.000000,
+	{"W", "Acircumflex"}:              -60.000000,
+	{"W", "Adieresis"}:                -60.000000,
+	{"W", "Agrave"}:                   -60.000000,
+	{"W", "Amacron"}:                  -60.000000,
+	{"W", "Aogonek"}:                  -60.000000,
+	{"W", "Aring"}:                    -60.000000,
+	{"W", "Atilde"}:                   -60.000000,
+	{"W", "O"}:                        -25.000000,
+	{"W", "Oacute"}:                   -25.000000,
+	{"W", "Ocircumflex"}:              -25.000000,
+	{"W", "Odieresis"}:                -25.000000,
+	{"W", "Ograve"}:                   -25.000000,
+	{"W", "Ohungarumlaut"}:            -25.000000,
+	{"W", "Omacron"}:                  -25.000000,
+	{"W", "Oslash"}:                   -25.000000,
+	{"W", "Otilde"}:                   -25.000000,
+	{"W", "a"}:                        -92.000000,
+	{"W", "aacute"}:                   -92.000000,
+	{"W", "abreve"}:                   -92.000000,
+	{"W", "acircumflex"}:              -92.000000,
+	{"W", "adieresis"}:                -92.000000,
+	{"W", "agrave"}:                   -92.000000,
+	{"W", "amacron"}:                  -92.000000,
+	{"W", "aogonek"}:                  -92.000000,
+	{"W", "aring"}:                    -92.000000,
+	{"W", "atilde"}:                   -92.000000,
+	{"W", "colon"}:                    -65.000000,
+	{"W", "comma"}:                    -92.000000,
+	{"W", "e"}:                        -92.000000,
+	{"W", "eacute"}:                   -92.000000,
+	{"W", "ecaron"}:                   -92.000000,
+	{"W", "ecircumflex"}:              -92.000000,
+	{"W", "edieresis"}:                -52.000000,
+	{"W", "edotaccent"}:               -92.000000,
+	{"W", "egrave"}:                   -52.000000,
+	{"W", "emacron"}:                  -52.000000,
+	{"W", "eogonek"}:                  -92.000000,
+	{"W", "hyphen"}:                   -37.000000,
+	{"W", "i"}:                        -55.000000,
+	{"W", "iacute"}:                   -55.000000,
+	{"W", "iogonek"}:                  -55.000000,
+	{"W", "o"}:                        -92.000000,
+	{"W", "oacute"}:                   -92.000000,
+	{"W", "ocircumflex"}:              -92.000000,
+	{"W", "odieresis"}:                -92.000000,
+	{"W", "ograve"}:                   -92.000000,
+	{"W", "ohungarumlaut"}:            -92.000000,
+	{"W", "omacron"}:                  -92.000000,
+	{"W", "oslash"}:                   -92.000000,
+	{"W", "otilde"}:                   -92.000000,
+	{"W", "period"}:                   -92.000000,
+	{"W", "semicolon"}:                -65.000000,
+	{"W", "u"}:                        -55.000000,
+	{"W", "uacute"}:                   -55.000000,
+	{"W", "ucircumflex"}:              -55.000000,
+	{"W", "udieresis"}:                -55.000000,
+	{"W", "ugrave"}:                   -55.000000,
+	{"W", "uhungarumlaut"}:            -55.000000,
+	{"W", "umacron"}:                  -55.000000,
+	{"W", "uogonek"}:                  -55.000000,
+	{"W", "uring"}:                    -55.000000,
+	{"W", "y"}:                        -70.000000,
+	{"W", "yacute"}:                   -70.000000,
+	{"W", "ydieresis"}:                -70.000000,
+	{"Y", "A"}:                        -50.000000,
+	{"Y", "Aacute"}:                   -50.000000,
+	{"Y", "Abreve"}:                   -50.000000,
+	{"Y", "Acircumflex"}:              -50.000000,
+	{"Y", "Adieresis"}:                -50.000000,
+	{"Y", "Agrave"}:                   -50.000000,
+	{"Y", "Amacron"}:                  -50.000000,
+	{"Y", "Aogonek"}:                  -50.000000,
+	{"Y", "Aring"}:                    -50.000000,
+	{"Y", "Atilde"}:                   -50.000000,
+	{"Y", "O"}:                        -15.000000,
+	{"Y", "Oacute"}:                   -15.000000,
+	{"Y", "Ocircumflex"}:              -15.000000,
+	{"Y", "Odieresis"}:                -15.000000,
+	{"Y", "Ograve"}:                   -15.000000,
+	{"Y", "Ohungarumlaut"}:            -15.000000,
+	{"Y", "Omacron"}:                  -15.000000,
+	{"Y", "Oslash"}:                   -15.000000,
+	{"Y", "Otilde"}:                   -15.000000,
+	{"Y", "a"}:                        -92.000000,
+	{"Y", "aacute"}:                   -92.000000,
+	{"Y", "abreve"}:                   -92.000000,
+	{"Y", "acircumflex"}:              -92.000000,
+	{"Y", "adieresis"}:                -92.000000,
+	{"Y", "agrave"}:                   -92.000000,
+	{"Y", "amacron"}:                  -92.000000,
+	{"Y", "aogonek"}:                  -92.000000,
+	{"Y", "aring"}:                    -92.000000,
+	{"Y", "atilde"}:                   -92.000000,
+	{"Y", "colon"}:                    -65.000000,
+	{"Y", "comma"}:                    -92.000000,
+	{"Y", "e"}:                        -92.000000,
+	{"Y", "eacute"}:                   -92.000000,
+	{"Y", "ecaron"}:                   -92.000000,
+	{"Y", "ecircumflex"}:              -92.000000,
+	{"Y", "edieresis"}:                -52.000000,
+	{"Y", "edotaccent"}:               -92.000000,
+	{"Y", "egrave"}:                   -52.000000,
+	{"Y", "emacron"}:                  -52.000000,
+	{"Y", "eogonek"}:                  -92.000000,
+	{"Y", "hyphen"}:                   -74.000000,
+	{"Y", "i"}:                        -74.000000,
+	{"Y", "iacute"}:                   -74.000000,
+	{"Y", "icircumflex"}:              -34.000000,
+	{"Y", "idieresis"}:                -34.000000,
+	{"Y", "igrave"}:                   -34.000000,
+	{"Y", "imacron"}:                  -34.000000,
+	{"Y", "iogonek"}:                  -74.000000,
+	{"Y", "o"}:                        -92.000000,
+	{"Y", "oacute"}:                   -92.000000,
+	{"Y", "ocircumflex"}:              -92.000000,
+	{"Y", "odieresis"}:                -92.000000,
+	{"Y", "ograve"}:                   -92.000000,
+	{"Y", "ohungarumlaut"}:            -92.000000,
+	{"Y", "omacron"}:                  -92.000000,
+	{"Y", "oslash"}:                   -92.000000,
+	{"Y", "otilde"}:                   -92.000000,
+	{"Y", "period"}:                   -92.000000,
+	{"Y", "semicolon"}:                -65.000000,
+	{"Y", "u"}:                        -92.000000,
+	{"Y", "uacute"}:                   -92.000000,
+	{"Y", "ucircumflex"}:              -92.000000,
+	{"Y", "udieresis"}:                -92.000000,
+	{"Y", "ugrave"}:                   -92.000000,
+	{"Y", "uhungarumlaut"}:            -92.000000,
+	{"Y", "umacron"}:                  -92.000000,
+	{"Y", "uogonek"}:                  -92.000000,
+	{"Y", "uring"}:                    -92.000000,
+	{"Yacute", "A"}:                   -50.000000,
+	{"Yacute", "Aacute"}:              -50.000000,
+	{"Yacute", "Abreve"}:              -50.000000,
+	{"Yacute", "Acircumflex"}:         -50.000000,
+	{"Yacute", "Adieresis"}:           -50.000000,
+	{"Yacute", "Agrave"}:              -50.000000,
+	{"Yacute", "Amacron"}:             -50.000000,
+	{"Yacute", "Aogonek"}:             -50.000000,
+	{"Yacute", "Aring"}:               -50.000000,
+	{"Yacute", "Atilde"}:              -50.000000,
+	{"Yacute", "O"}:                   -15.000000,
+	{"Yacute", "Oacute"}:              -15.000000,
+	{"Yacute", "Ocircumflex"}:         -15.000000,
+	{"Yacute", "Odieresis"}:           -15.000000,
+	{"Yacute", "Ograve"}:              -15.000000,
+	{"Yacute", "Ohungarumlaut"}:       -15.000000,
+	{"Yacute", "Omacron"}:             -15.000000,
+	{"Yacute", "Oslash"}:              -15.000000,
+	{"Yacute", "Otilde"}:              -15.000000,
+	{"Yacute", "a"}:                   -92.000000,
+	{"Yacute", "aacute"}:              -92.000000,
+	{"Yacute", "abreve"}:              -92.000000,
+	{"Yacute", "acircumflex"}:         -92.000000,
+	{"Yacute", "adieresis"}:           -92.000000,
+	{"Yacute", "agrave"}:              -92.000000,
+	{"Yacute", "amacron"}:             -92.000000,
+	{"Yacute", "aogonek"}:             -92.000000,
+	{"Yacute", "aring"}:               -92.000000,
+	{"Yacute", "atilde"}:              -92.000000,
+	{"Yacute", "colon"}:               -65.000000,
+	{"Yacute", "comma"}:               -92.000000,
+	{"Yacute", "e"}:                   -92.000000,
+	{"Yacute", "eacute"}:              -92.000000,
+	{"Yacute", "ecaron"}:              -92.000000,
+	{"Yacute", "ecircumflex"}:         -92.000000,
+	{"Yacute", "edieresis"}:           -52.000000,
+	{"Yacute", "edotaccent"}:          -92.000000,
+	{"Yacute", "egrave"}:              -52.000000,
+	{"Yacute", "emacron"}:             -52.000000,
+	{"Yacute", "eogonek"}:             -92.000000,
+	{"Yacute", "hyphen"}:              -74.000000,
+	{"Yacute", "i"}:                   -74.000000,
+	{"Yacute", "iacute"}:              -74.000000,
+	{"Yacute", "icircumflex"}:         -34.000000,
+	{"Yacute", "idieresis"}:           -34.000000,
+	{"Yacute", "igrave"}:              -34.000000,
+	{"Yacute", "imacron"}:             -34.000000,
+	{"Yacute", "iogonek"}:             -74.000000,
+	{"Yacute", "o"}:                   -92.000000,
+	{"Yacute", "oacute"}:              -92.000000,
+	{"Yacute", "ocircumflex"}:         -92.000000,
+	{"Yacute", "odieresis"}:           -92.000000,
+	{"Yacute", "ograve"}:              -92.000000,
+	{"Yacute", "ohungarumlaut"}:       -92.000000,
+	{"Yacute", "omacron"}:             -92.000000,
+	{"Yacute", "oslash"}:              -92.000000,
+	{"Yacute", "otilde"}:              -92.000000,
+	{"Yacute", "period"}:              -92.000000,
+	{"Yacute", "semicolon"}:           -65.000000,
+	{"Yacute", "u"}:                   -92.000000,
+	{"Yacute", "uacute"}:              -92.000000,
+	{"Yacute", "ucircumflex"}:         -92.000000,
+	{"Yacute", "udieresis"}:           -92.000000,
+	{"Yacute", "ugrave"}:              -92.000000,
+	{"Yacute", "uhungarumlaut"}:       -92.000000,
+	{"Yacute", "umacron"}:             -92.000000,
+	{"Yacute", "uogonek"}:             -92.000000,
+	{"Yacute", "uring"}:               -92.000000,
+	{"Ydieresis", "A"}:                -50.000000,
+	{"Ydieresis", "Aacute"}:           -50.000000,
+	{"Ydieresis", "Abreve"}:           -50.000000,
+	{"Ydieresis", "Acircumflex"}:      -50.000000,
+	{"Ydieresis", "Adieresis"}:        -50.000000,
+	{"Ydieresis", "Agrave"}:           -50.000000,
+	{"Ydieresis", "Amacron"}:          -50.000000,
+	{"Ydieresis", "Aogonek"}:          -50.000000,
+	{"Ydieresis", "Aring"}:            -50.000000,
+	{"Ydieresis", "Atilde"}:           -50.000000,
+	{"Ydieresis", "O"}:                -15.000000,
+	{"Ydieresis", "Oacute"}:           -15.000000,
+	{"Ydieresis", "Ocircumflex"}:      -15.000000,
+	{"Ydieresis", "Odieresis"}:        -15.000000,
+	{"Ydieresis", "Ograve"}:           -15.000000,
+	{"Ydieresis", "Ohungarumlaut"}:    -15.000000,
+	{"Ydieresis", "Omacron"}:          -15.000000,
+	{"Ydieresis", "Oslash"}:           -15.000000,
+	{"Ydieresis", "Otilde"}:           -15.000000,
+	{"Ydieresis", "a"}:                -92.000000,
+	{"Ydieresis", "aacute"}:           -92.000000,
+	{"Ydieresis", "abreve"}:           -92.000000,
+	{"Ydieresis", "acircumflex"}:      -92.000000,
+	{"Ydieresis", "adieresis"}:        -92.000000,
+	{"Ydieresis", "agrave"}:           -92.000000,
+	{"Ydieresis", "amacron"}:          -92.000000,
+	{"Ydieresis", "aogonek"}:          -92.000000,
+	{"Ydieresis", "aring"}:            -92.000000,
+	{"Ydieresis", "atilde"}:           -92.000000,
+	{"Ydieresis", "colon"}:            -65.000000,
+	{"Ydieresis", "comma"}:            -92.000000,
+	{"Ydieresis", "e"}:                -92.000000,
+	{"Ydieresis", "eacute"}:           -92.000000,
+	{"Ydieresis", "ecaron"}:           -92.000000,
+	{"Ydieresis", "ecircumflex"}:      -92.000000,
+	{"Ydieresis", "edieresis"}:        -52.000000,
+	{"Ydieresis", "edotaccent"}:       -92.000000,
+	{"Ydieresis", "egrave"}:           -52.000000,
+	{"Ydieresis", "emacron"}:          -52.000000,
+	{"Ydieresis", "eogonek"}:          -92.000000,
+	{"Ydieresis", "hyphen"}:           -74.000000,
+	{"Ydieresis", "i"}:                -74.000000,
+	{"Ydieresis", "iacute"}:           -74.000000,
+	{"Ydieresis", "icircumflex"}:      -34.000000,
+	{"Ydieresis", "idieresis"}:        -34.000000,
+	{"Ydieresis", "igrave"}:           -34.000000,
+	{"Ydieresis", "imacron"}:          -34.000000,
+	{"Ydieresis", "iogonek"}:          -74.000000,
+	{"Ydieresis", "o"}:                -92.000000,
+	{"Ydieresis", "oacute"}:           -92.000000,
+	{"Ydieresis", "ocircumflex"}:      -92.000000,
+	{"Ydieresis", "odieresis"}:        -92.000000,
+	{"Ydieresis", "ograve"}:           -92.000000,
+	{"Ydieresis", "ohungarumlaut"}:    -92.000000,
+	{"Ydieresis", "omacron"}:          -92.000000,
+	{"Ydieresis", "oslash"}:           -92.000000,
+	{"Ydieresis", "otilde"}:           -92.000000,
+	{"Ydieresis", "period"}:           -92.000000,
+	{"Ydieresis", "semicolon"}:        -65.000000,
+	{"Ydieresis", "u"}:                -92.000000,
+	{"Ydieresis", "uacute"}:           -92.000000,
+	{"Ydieresis", "ucircumflex"}:      -92.000000,
+	{"Ydieresis", "udieresis"}:        -92.000000,
+	{"Ydieresis", "ugrave"}:           -92.000000,
+	{"Ydieresis", "uhungarumlaut"}:    -92.000000,
+	{"Ydieresis", "umacron"}:          -92.000000,
+	{"Ydieresis", "uogonek"}:          -92.000000,
+	{"Ydieresis", "uring"}:            -92.000000,
+	{"a", "g"}:                        -10.000000,
+	{"a", "gbreve"}:                   -10.000000,
+	{"a", "gcommaaccent"}:             -10.000000,
+	{"aacute", "g"}:                   -10.000000,
+	{"aacute", "gbreve"}:              -10.000000,
+	{"aacute", "gcommaaccent"}:        -10.000000,
+	{"abreve", "g"}:                   -10.000000,
+	{"abreve", "gbreve"}:              -10.000000,
+	{"abreve", "gcommaaccent"}:        -10.000000,
+	{"acircumflex", "g"}:              -10.000000,
+	{"acircumflex", "gbreve"}:         -10.000000,
+	{"acircumflex", "gcommaaccent"}:   -10.000000,
+	{"adieresis", "g"}:                -10.000000,
+	{"adieresis", "gbreve"}:           -10.000000,
+	{"adieresis", "gcommaaccent"}:     -10.000000,
+	{"agrave", "g"}:                   -10.000000,
+	{"agrave", "gbreve"}:              -10.000000,
+	{"agrave", "gcommaaccent"}:        -10.000000,
+	{"amacron", "g"}:                  -10.000000,
+	{"amacron", "gbreve"}:             -10.000000,
+	{"amacron", "gcommaaccent"}:       -10.000000,
+	{"aogonek", "g"}:                  -10.000000,
+	{"aogonek", "gbreve"}:             -10.000000,
+	{"aogonek", "gcommaaccent"}:       -10.000000,
+	{"aring", "g"}:                    -10.000000,
+	{"aring", "gbreve"}:               -10.000000,
+	{"aring", "gcommaaccent"}:         -10.000000,
+	{"atilde", "g"}:                   -10.000000,
+	{"atilde", "gbreve"}:              -10.000000,
+	{"atilde", "gcommaaccent"}:        -10.000000,
+	{"b", "period"}:                   -40.000000,
+	{"b", "u"}:                        -20.000000,
+	{"b", "uacute"}:                   -20.000000,
+	{"b", "ucircumflex"}:              -20.000000,
+	{"b", "udieresis"}:                -20.000000,
+	{"b", "ugrave"}:                   -20.000000,
+	{"b", "uhungarumlaut"}:            -20.000000,
+	{"b", "umacron"}:                  -20.000000,
+	{"b", "uogonek"}:                  -20.000000,
+	{"b", "uring"}:                    -20.000000,
+	{"c", "h"}:                        -15.000000,
+	{"c", "k"}:                        -20.000000,
+	{"c", "kcommaaccent"}:             -20.000000,
+	{"cacute", "h"}:                   -15.000000,
+	{"cacute", "k"}:                   -20.000000,
+	{"cacute", "kcommaaccent"}:        -20.000000,
+	{"ccaron", "h"}:                   -15.000000,
+	{"ccaron", "k"}:                   -20.000000,
+	{"ccaron", "kcommaaccent"}:        -20.000000,
+	{"ccedilla", "h"}:                 -15.000000,
+	{"ccedilla", "k"}:                 -20.000000,
+	{"ccedilla", "kcommaaccent"}:      -20.000000,
+	{"comma", "quotedblright"}:        -140.000000,
+	{"comma", "quoteright"}:           -140.000000,
+	{"e", "comma"}:                    -10.000000,
+	{"e", "g"}:                        -40.000000,
+	{"e", "gbreve"}:                   -40.000000,
+	{"e", "gcommaaccent"}:             -40.000000,
+	{"e", "period"}:                   -15.000000,
+	{"e", "v"}:                        -15.000000,
+	{"e", "w"}:                        -15.000000,
+	{"e", "x"}:                        -20.000000,
+	{"e", "y"}:                        -30.000000,
+	{"e", "yacute"}:                   -30.000000,
+	{"e", "ydieresis"}:                -30.000000,
+	{"eacute", "comma"}:               -10.000000,
+	{"eacute", "g"}:                   -40.000000,
+	{"eacute", "gbreve"}:              -40.000000,
+	{"eacute", "gcommaaccent"}:        -40.000000,
+	{"eacute", "period"}:              -15.000000,
+	{"eacute", "v"}:                   -15.000000,
+	{"eacute", "w"}:                   -15.000000,
+	{"eacute", "x"}:                   -20.000000,
+	{"eacute", "y"}:                   -30.000000,
+	{"eacute", "yacute"}:              -30.000000,
+	{"eacute", "ydieresis"}:           -30.000000,
+	{"ecaron", "comma"}:               -10.000000,
+	{"ecaron", "g"}:                   -40.000000,
+	{"ecaron", "gbreve"}:              -40.000000,
+	{"ecaron", "gcommaaccent"}:        -40.000000,
+	{"ecaron", "period"}:              -15.000000,
+	{"ecaron", "v"}:                   -15.000000,
+	{"ecaron", "w"}:                   -15.000000,
+	{"ecaron", "x"}:                   -20.000000,
+	{"ecaron", "y"}:                   -30.000000,
+	{"ecaron", "yacute"}:              -30.000000,
+	{"ecaron", "ydieresis"}:           -30.000000,
+	{"ecircumflex", "comma"}:          -10.000000,
+	{"ecircumflex", "g"}:              -40.000000,
+	{"ecircumflex", "gbreve"}:         -40.000000,
+	{"ecircumflex", "gcommaaccent"}:   -40.000000,
+	{"ecircumflex", "period"}:         -15.000000,
+	{"ecircumflex", "v"}:              -15.000000,
+	{"ecircumflex", "w"}:              -15.000000,
+	{"ecircumflex", "x"}:              -20.000000,
+	{"ecircumflex", "y"}:              -30.000000,
+	{"ecircumflex", "yacute"}:         -30.000000,
+	{"ecircumflex", "ydieresis"}:      -30.000000,
+	{"edieresis", "comma"}:            -10.000000,
+	{"edieresis", "g"}:                -40.000000,
+	{"edieresis", "gbreve"}:           -40.000000,
+	{"edieresis", "gcommaaccent"}:     -40.000000,
+	{"edieresis", "period"}:           -15.000000,
+	{"edieresis", "v"}:                -15.000000,
+	{"edieresis", "w"}:                -15.000000,
+	{"edieresis", "x"}:                -20.000000,
+	{"edieresis", "y"}:                -30.000000,
+	{"edieresis", "yacute"}:           -30.000000,
+	{"edieresis", "ydieresis"}:        -30.000000,
+	{"edotaccent", "comma"}:           -10.000000,
+	{"edotaccent", "g"}:               -40.000000,
+	{"edotaccent", "gbreve"}:          -40.000000,
+	{"edotaccent", "gcommaaccent"}:    -40.000000,
+	{"edotaccent", "period"}:          -15.000000,
+	{"edotaccent", "v"}:               -15.000000,
+	{"edotaccent", "w"}:               -15.000000,
+	{"edotaccent", "x"}:               -20.000000,
+	{"edotaccent", "y"}:               -30.000000,
+	{"edotaccent", "yacute"}:          -30.000000,
+	{"edotaccent", "ydieresis"}:       -30.000000,
+	{"egrave", "comma"}:               -10.000000,
+	{"egrave", "g"}:                   -40.000000,
+	{"egrave", "gbreve"}:              -40.000000,
+	{"egrave", "gcommaaccent"}:        -40.000000,
+	{"egrave", "period"}:              -15.000000,
+	{"egrave", "v"}:                   -15.000000,
+	{"egrave", "w"}:                   -15.000000,
+	{"egrave", "x"}:                   -20.000000,
+	{"egrave", "y"}:                   -30.000000,
+	{"egrave", "yacute"}:              -30.000000,
+	{"egrave", "ydieresis"}:           -30.000000,
+	{"emacron", "comma"}:              -10.000000,
+	{"emacron", "g"}:                  -40.000000,
+	{"emacron", "gbreve"}:             -40.000000,
+	{"emacron", "gcommaaccent"}:       -40.000000,
+	{"emacron", "period"}:             -15.000000,
+	{"emacron", "v"}:                  -15.000000,
+	{"emacron", "w"}:                  -15.000000,
+	{"emacron", "x"}:                  -20.000000,
+	{"emacron", "y"}:                  -30.000000,
+	{"emacron", "yacute"}:             -30.000000,
+	{"emacron", "ydieresis"}:          -30.000000,
+	{"eogonek", "comma"}:              -10.000000,
+	{"eogonek", "g"}:                  -40.000000,
+	{"eogonek", "gbreve"}:             -40.000000,
+	{"eogonek", "gcommaaccent"}:       -40.000000,
+	{"eogonek", "period"}:             -15.000000,
+	{"eogonek", "v"}:                  -15.000000,
+	{"eogonek", "w"}:                  -15.000000,
+	{"eogonek", "x"}:                  -20.000000,
+	{"eogonek", "y"}:                  -30.000000,
+	{"eogonek", "yacute"}:             -30.000000,
+	{"eogonek", "ydieresis"}:          -30.000000,
+	{"f", "comma"}:                    -10.000000,
+	{"f", "dotlessi"}:                 -60.000000,
+	{"f", "f"}:                        -18.000000,
+	{"f", "i"}:                        -20.000000,
+	{"f", "iogonek"}:                  -20.000000,
+	{"f", "period"}:                   -15.000000,
+	{"f", "quoteright"}:               92.000000,
+	{"g", "comma"}:                    -10.000000,
+	{"g", "e"}:                        -10.000000,
+	{"g", "eacute"}:                   -10.000000,
+	{"g", "ecaron"}:                   -10.000000,
+	{"g", "ecircumflex"}:              -10.000000,
+	{"g", "edieresis"}:                -10.000000,
+	{"g", "edotaccent"}:               -10.000000,
+	{"g", "egrave"}:                   -10.000000,
+	{"g", "emacron"}:                  -10.000000,
+	{"g", "eogonek"}:                  -10.000000,
+	{"g", "g"}:                        -10.000000,
+	{"g", "gbreve"}:                   -10.000000,
+	{"g", "gcommaaccent"}:             -10.000000,
+	{"g", "period"}:                   -15.000000,
+	{"gbreve", "comma"}:               -10.000000,
+	{"gbreve", "e"}:                   -10.000000,
+	{"gbreve", "eacute"}:              -10.000000,
+	{"gbreve", "ecaron"}:              -10.000000,
+	{"gbreve", "ecircumflex"}:         -10.000000,
+	{"gbreve", "edieresis"}:           -10.000000,
+	{"gbreve", "edotaccent"}:          -10.000000,
+	{"gbreve", "egrave"}:              -10.000000,
+	{"gbreve", "emacron"}:             -10.000000,
+	{"gbreve", "eogonek"}:             -10.000000,
+	{"gbreve", "g"}:                   -10.000000,
+	{"gbreve", "gbreve"}:              -10.000000,
+	{"gbreve", "gcommaaccent"}:        -10.000000,
+	{"gbreve", "period"}:              -15.000000,
+	{"gcommaaccent", "comma"}:         -10.000000,
+	{"gcommaaccent", "e"}:             -10.000000,
+	{"gcommaaccent", "eacute"}:        -10.000000,
+	{"gcommaaccent", "ecaron"}:        -10.000000,
+	{"gcommaaccent", "ecircumflex"}:   -10.000000,
+	{"gcommaaccent", "edieresis"}:     -10.000000,
+	{"gcommaaccent", "edotaccent"}:    -10.000000,
+	{"gcommaaccent", "egrave"}:        -10.000000,
+	{"gcommaaccent", "emacron"}:       -10.000000,
+	{"gcommaaccent", "eogonek"}:       -10.000000,
+	{"gcommaaccent", "g"}:             -10.000000,
+	{"gcommaaccent", "gbreve"}:        -10.000000,
+	{"gcommaaccent", "gcommaaccent"}:  -10.000000,
+	{"gcommaaccent", "period"}:        -15.000000,
+	{"k", "e"}:                        -10.000000,
+	{"k", "eacute"}:                   -10.000000,
+	{"k", "ecaron"}:                   -10.000000,
+	{"k", "ecircumflex"}:              -10.000000,
+	{"k", "edieresis"}:                -10.000000,
+	{"k", "edotaccent"}:               -10.000000,
+	{"k", "egrave"}:                   -10.000000,
+	{"k", "emacron"}:                  -10.000000,
+	{"k", "eogonek"}:                  -10.000000,
+	{"k", "o"}:                        -10.000000,
+	{"k", "oacute"}:                   -10.000000,
+	{"k", "ocircumflex"}:              -10.000000,
+	{"k", "odieresis"}:                -10.000000,
+	{"k", "ograve"}:                   -10.000000,
+	{"k", "ohungarumlaut"}:            -10.000000,
+	{"k", "omacron"}:                  -10.000000,
+	{"k", "oslash"}:                   -10.000000,
+	{"k", "otilde"}:                   -10.000000,
+	{"k", "y"}:                        -10.000000,
+	{"k", "yacute"}:                   -10.000000,
+	{"k", "ydieresis"}:                -10.000000,
+	{"kcommaaccent", "e"}:             -10.000000,
+	{"kcommaaccent", "eacute"}:        -10.000000,
+	{"kcommaaccent", "ecaron"}:        -10.000000,
+	{"kcommaaccent", "ecircumflex"}:   -10.000000,
+	{"kcommaaccent", "edieresis"}:     -10.000000,
+	{"kcommaaccent", "edotaccent"}:    -10.000000,
+	{"kcommaaccent", "egrave"}:        -10.000000,
+	{"kcommaaccent", "emacron"}:       -10.000000,
+	{"kcommaaccent", "eogonek"}:       -10.000000,
+	{"kcommaaccent", "o"}:             -10.000000,
+	{"kcommaaccent", "oacute"}:        -10.000000,
+	{"kcommaaccent", "ocircumflex"}:   -10.000000,
+	{"kcommaaccent", "odieresis"}:     -10.000000,
+	{"kcommaaccent", "ograve"}:        -10.000000,
+	{"kcommaaccent", "ohungarumlaut"}: -10.000000,
+	{"kcommaaccent", "omacron"}:       -10.000000,
+	{"kcommaaccent", "oslash"}:        -10.000000,
+	{"kcommaaccent", "otilde"}:        -10.000000,
+	{"kcommaaccent", "y"}:             -10.000000,
+	{"kcommaaccent", "yacute"}:        -10.000000,
+	{"kcommaaccent", "ydieresis"}:     -10.000000,
+	{"n", "v"}:                        -40.000000,
+	{"nacute", "v"}:                   -40.000000,
+	{"ncaron", "v"}:                   -40.000000,
+	{"ncommaaccent", "v"}:             -40.000000,
+	{"ntilde", "v"}:                   -40.000000,
+	{"o", "g"}:                        -10.000000,
+	{"o", "gbreve"}:                   -10.000000,
+	{"o", "gcommaaccent"}:             -10.000000,
+	{"o", "v"}:                        -10.000000,
+	{"oacute", "g"}:                   -10.000000,
+	{"oacute", "gbreve"}:              -10.000000,
+	{"oacute", "gcommaaccent"}:        -10.000000,
+	{"oacute", "v"}:                   -10.000000,
+	{"ocircumflex", "g"}:              -10.000000,
+	{"ocircumflex", "gbreve"}:         -10.000000,
+	{"ocircumflex", "gcommaaccent"}:   -10.000000,
+	{"ocircumflex", "v"}:              -10.000000,
+	{"odieresis", "g"}:                -10.000000,
+	{"odieresis", "gbreve"}:           -10.000000,
+	{"odieresis", "gcommaaccent"}:     -10.000000,
+	{"odieresis", "v"}:                -10.000000,
+	{"ograve", "g"}:                   -10.000000,
+	{"ograve", "gbreve"}:              -10.000000,
+	{"ograve", "gcommaaccent"}:        -10.000000,
+	{"ograve", "v"}:                   -10.000000,
+	{"ohungarumlaut", "g"}:            -10.000000,
+	{"ohungarumlaut", "gbreve"}:       -10.000000,
+	{"ohungarumlaut", "gcommaaccent"}: -10.000000,
+	{"ohungarumlaut", "v"}:            -10.000000,
+	{"omacron", "g"}:                  -10.000000,
+	{"omacron", "gbreve"}:             -10.000000,
+	{"omacron", "gcommaaccent"}:       -10.000000,
+	{"omacron", "v"}:                  -10.000000,
+	{"oslash", "g"}:                   -10.000000,
+	{"oslash", "gbreve"}:              -10.000000,
+	{"oslash", "gcommaaccent"}:        -10.000000,
+	{"oslash", "v"}:                   -10.000000,
+	{"otilde", "g"}:                   -10.000000,
+	{"otilde", "gbreve"}:              -10.000000,
+	{"otilde", "gcommaaccent"}:        -10.000000,
+	{"otilde", "v"}:                   -10.000000,
+	{"period", "quotedblright"}:       -140.000000,
+	{"period", "quoteright"}:          -140.000000,
+	{"quoteleft", "quoteleft"}:        -111.000000,
+	{"quoteright", "d"}:               -25.000000,
+	{"quoteright", "dcroat"}:          -25.000000,
+	{"quoteright", "quoteright"}:      -111.000000,
+	{"quoteright", "r"}:               -25.000000,
+	{"quoteright", "racute"}:          -25.000000,
+	{"quoteright", "rcaron"}:          -25.000000,
+	{"quoteright", "rcommaaccent"}:    -25.000000,
+	{"quoteright", "s"}:               -40.000000,
+	{"quoteright", "sacute"}:          -40.000000,
+	{"quoteright", "scaron"}:          -40.000000,
+	{"quoteright", "scedilla"}:        -40.000000,
+	{"quoteright", "scommaaccent"}:    -40.000000,
+	{"quoteright", "space"}:           -111.000000,
+	{"quoteright", "t"}:               -30.000000,
+	{"quoteright", "tcommaaccent"}:    -30.000000,
+	{"quoteright", "v"}:               -10.000000,
+	{"r", "a"}:                        -15.000000,
+	{"r", "aacute"}:                   -15.000000,
+	{"r", "abreve"}:                   -15.000000,
+	{"r", "acircumflex"}:              -15.000000,
+	{"r", "adieresis"}:                -15.000000,
+	{"r", "agrave"}:                   -15.000000,
+	{"r", "amacron"}:                  -15.000000,
+	{"r", "aogonek"}:                  -15.000000,
+	{"r", "aring"}:                    -15.000000,
+	{"r", "atilde"}:                   -15.000000,
+	{"r", "c"}:                        -37.000000,
+	{"r", "cacute"}:                   -37.000000,
+	{"r", "ccaron"}:                   -37.000000,
+	{"r", "ccedilla"}:                 -37.000000,
+	{"r", "comma"}:                    -111.000000,
+	{"r", "d"}:                        -37.000000,
+	{"r", "dcroat"}:                   -37.000000,
+	{"r", "e"}:                        -37.000000,
+	{"r", "eacute"}:                   -37.000000,
+	{"r", "ecaron"}:                   -37.000000,
+	{"r", "ecircumflex"}:              -37.000000,
+	{"r", "edieresis"}:                -37.000000,
+	{"r", "edotaccent"}:               -37.000000,
+	{"r", "egrave"}:                   -37.000000,
+	{"r", "emacron"}:                  -37.000000,
+	{"r", "eogonek"}:                  -37.000000,
+	{"r", "g"}:                        -37.000000,
+	{"r", "gbreve"}:                   -37.000000,
+	{"r", "gcommaaccent"}:             -37.000000,
+	{"r", "hyphen"}:                   -20.000000,
+	{"r", "o"}:                        -45.000000,
+	{"r", "oacute"}:                   -45.000000,
+	{"r", "ocircumflex"}:              -45.000000,
+	{"r", "odieresis"}:                -45.000000,
+	{"r", "ograve"}:                   -45.000000,
+	{"r", "ohungarumlaut"}:            -45.000000,
+	{"r", "omacron"}:                  -45.000000,
+	{"r", "oslash"}:                   -45.000000,
+	{"r", "otilde"}:                   -45.000000,
+	{"r", "period"}:                   -111.000000,
+	{"r", "q"}:                        -37.000000,
+	{"r", "s"}:                        -10.000000,
+	{"r", "sacute"}:                   -10.000000,
+	{"r", "scaron"}:                   -10.000000,
+	{"r", "scedilla"}:                 -10.000000,
+	{"r", "scommaaccent"}:             -10.000000,
+	{"racute", "a"}:                   -15.000000,
+	{"racute", "aacute"}:              -15.000000,
+	{"racute", "abreve"}:              -15.000000,
+	{"racute", "acircumflex"}:         -15.000000,
+	{"racute", "adieresis"}:           -15.000000,
+	{"racute", "agrave"}:              -15.000000,
+	{"racute", "amacron"}:             -15.000000,
+	{"racute", "aogonek"}:             -15.000000,
+	{"racute", "aring"}:               -15.000000,
+	{"racute", "atilde"}:              -15.000000,
+	{"racute", "c"}:                   -37.000000,
+	{"racute", "cacute"}:              -37.000000,
+	{"racute", "ccaron"}:              -37.000000,
+	{"racute", "ccedilla"}:            -37.000000,
+	{"racute", "comma"}:               -111.000000,
+	{"racute", "d"}:                   -37.000000,
+	{"racute", "dcroat"}:              -37.000000,
+	{"racute", "e"}:                   -37.000000,
+	{"racute", "eacute"}:              -37.000000,
+	{"racute", "ecaron"}:              -37.000000,
+	{"racute", "ecircumflex"}:         -37.000000,
+	{"racute", "edieresis"}:           -37.000000,
+	{"racute", "edotaccent"}:          -37.000000,
+	{"racute", "egrave"}:              -37.000000,
+	{"racute", "emacron"}:             -37.000000,
+	{"racute", "eogonek"}:             -37.000000,
+	{"racute", "g"}:                   -37.000000,
+	{"racute", "gbreve"}:              -37.000000,
+	{"racute", "gcommaaccent"}:        -37.000000,
+	{"racute", "hyphen"}:              -20.000000,
+	{"racute", "o"}:                   -45.000000,
+	{"racute", "oacute"}:              -45.000000,
+	{"racute", "ocircumflex"}:         -45.000000,
+	{"racute", "odieresis"}:           -45.000000,
+	{"racute", "ograve"}:              -45.000000,
+	{"racute", "ohungarumlaut"}:       -45.000000,
+	{"racute", "omacron"}:             -45.000000,
+	{"racute", "oslash"}:              -45.000000,
+	{"racute", "otilde"}:              -45.000000,
+	{"racute", "period"}:              -111.000000,
+	{"racute", "q"}:                   -37.000000,
+	{"racute", "s"}:                   -10.000000,
+	{"racute", "sacute"}:              -10.000000,
+	{"racute", "scaron"}:              -10.000000,
+	{"racute", "scedilla"}:            -10.000000,
+	{"racute", "scommaaccent"}:        -10.000000,
+	{"rcaron", "a"}:                   -15.000000,
+	{"rcaron", "aacute"}:              -15.000000,
+	{"rcaron", "abreve"}:              -15.000000,
+	{"rcaron", "acircumflex"}:         -15.000000,
+	{"rcaron", "adieresis"}:           -15.000000,
+	{"rcaron", "agrave"}:              -15.000000,
+	{"rcaron", "amacron"}:             -15.000000,
+	{"rcaron", "aogonek"}:             -15.000000,
+	{"rcaron", "aring"}:               -15.000000,
+	{"rcaron", "atilde"}:              -15.000000,
+	{"rcaron", "c"}:                   -37.000000,
+	{"rcaron", "cacute"}:              -37.000000,
+	{"rcaron", "ccaron"}:              -37.000000,
+	{"rcaron", "ccedilla"}:            -37.000000,
+	{"rcaron", "comma"}:               -111.000000,
+	{"rcaron", "d"}:                   -37.000000,
+	{"rcaron", "dcroat"}:              -37.000000,
+	{"rcaron", "e"}:                   -37.000000,
+	{"rcaron", "eacute"}:              -37.000000,
+	{"rcaron", "ecaron"}:              -37.000000,
+	{"rcaron", "ecircumflex"}:         -37.000000,
+	{"rcaron", "edieresis"}:           -37.000000,
+	{"rcaron", "edotaccent"}:          -37.000000,
+	{"rcaron", "egrave"}:              -37.000000,
+	{"rcaron", "emacron"}:             -37.000000,
+	{"rcaron", "eogonek"}:             -37.000000,
+	{"rcaron", "g"}:                   -37.000000,
+	{"rcaron", "gbreve"}:              -37.000000,
+	{"rcaron", "gcommaaccent"}:        -37.000000,
+	{"rcaron", "hyphen"}:              -20.000000,
+	{"rcaron", "o"}:                   -45.000000,
+	{"rcaron", "oacute"}:              -45.000000,
+	{"rcaron", "ocircumflex"}:         -45.000000,
+	{"rcaron", "odieresis"}:           -45.000000,
+	{"rcaron", "ograve"}:              -45.000000,
+	{"rcaron", "ohungarumlaut"}:       -45.000000,
+	{"rcaron", "omacron"}:             -45.000000,
+	{"rcaron", "oslash"}:              -45.000000,
+	{"rcaron", "otilde"}:              -45.000000,
+	{"rcaron", "period"}:              -111.000000,
+	{"rcaron", "q"}:                   -37.000000,
+	{"rcaron", "s"}:                   -10.000000,
+	{"rcaron", "sacute"}:              -10.000000,
+	{"rcaron", "scaron"}:              -10.000000,
+	{"rcaron", "scedilla"}:            -10.000000,
+	{"rcaron", "scommaaccent"}:        -10.000000,
+	{"rcommaaccent", "a"}:             -15.000000,
+	{"rcommaaccent", "aacute"}:        -15.000000,
+	{"rcommaaccent", "abreve"}:        -15.000000,
+	{"rcommaaccent", "acircumflex"}:   -15.000000,
+	{"rcommaaccent", "adieresis"}:     -15.000000,
+	{"rcommaaccent", "agrave"}:        -15.000000,
+	{"rcommaaccent", "amacron"}:       -15.000000,
+	{"rcommaaccent", "aogonek"}:       -15.000000,
+	{"rcommaaccent", "aring"}:         -15.000000,
+	{"rcommaaccent", "atilde"}:        -15.000000,
+	{"rcommaaccent", "c"}:             -37.000000,
+	{"rcommaaccent", "cacute"}:        -37.000000,
+	{"rcommaaccent", "ccaron"}:        -37.000000,
+	{"rcommaaccent", "ccedilla"}:      -37.000000,
+	{"rcommaaccent", "comma"}:         -111.000000,
+	{"rcommaaccent", "d"}:             -37.000000,
+	{"rcommaaccent", "dcroat"}:        -37.000000,
+	{"rcommaaccent", "e"}:             -37.000000,
+	{"rcommaaccent", "eacute"}:        -37.000000,
+	{"rcommaaccent", "ecaron"}:        -37.000000,
+	{"rcommaaccent", "ecircumflex"}:   -37.000000,
+	{"rcommaaccent", "edieresis"}:     -37.000000,
+	{"rcommaaccent", "edotaccent"}:    -37.000000,
+	{"rcommaaccent", "egrave"}:        -37.000000,
+	{"rcommaaccent", "emacron"}:       -37.000000,
+	{"rcommaaccent", "eogonek"}:       -37.000000,
+	{"rcommaaccent", "g"}:             -37.000000,
+	{"rcommaaccent", "gbreve"}:        -37.000000,
+	{"rcommaaccent", "gcommaaccent"}:  -37.000000,
+	{"rcommaaccent", "hyphen"}:        -20.000000,
+	{"rcommaaccent", "o"}:             -45.000000,
+	{"rcommaaccent", "oacute"}:        -45.000000,
+	{"rcommaaccent", "ocircumflex"}:   -45.000000,
+	{"rcommaaccent", "odieresis"}:     -45.000000,
+	{"rcommaaccent", "ograve"}:        -45.000000,
+	{"rcommaaccent", "ohungarumlaut"}: -45.000000,
+	{"rcommaaccent", "omacron"}:       -45.000000,
+	{"rcommaaccent", "oslash"}:        -45.000000,
+	{"rcommaaccent", "otilde"}:        -45.000000,
+	{"rcommaaccent", "period"}:        -111.000000,
+	{"rcommaaccent", "q"}:             -37.000000,
+	{"rcommaaccent", "s"}:             -10.000000,
+	{"rcommaaccent", "sacute"}:        -10.000000,
+	{"rcommaaccent", "scaron"}:        -10.000000,
+	{"rcommaaccent", "scedilla"}:      -10.000000,
+	{"rcommaaccent", "scommaaccent"}:  -10.000000,
+	{"space", "A"}:                    -18.000000,
+	{"space", "Aacute"}:               -18.000000,
+	{"space", "Abreve"}:               -18.000000,
+	{"space", "Acircumflex"}:          -18.000000,
+	{"space", "Adieresis"}:            -18.000000,
+	{"space", "Agrave"}:               -18.000000,
+	{"space", "Amacron"}:              -18.000000,
+	{"space", "Aogonek"}:              -18.000000,
+	{"space", "Aring"}:                -18.000000,
+	{"space", "Atilde"}:               -18.000000,
+	{"space", "T"}:                    -18.000000,
+	{"space", "Tcaron"}:               -18.000000,
+	{"space", "Tcommaaccent"}:         -18.000000,
+	{"space", "V"}:                    -35.000000,
+	{"space", "W"}:                    -40.000000,
+	{"space", "Y"}:                    -75.000000,
+	{"space", "Yacute"}:               -75.000000,
+	{"space", "Ydieresis"}:            -75.000000,
+	{"v", "comma"}:                    -74.000000,
+	{"v", "period"}:                   -74.000000,
+	{"w", "comma"}:                    -74.000000,
+	{"w", "period"}:                   -74.000000,
+	{"y", "comma"}:                    -55.000000,
+	{"y", "period"}:                   -55.000000,
+	{"yacute", "comma"}:               -55.000000,
+	{"yacute", "period"}:              -55.000000,
+	{"ydieresis", "comma"}:            -55.000000,
+	{"ydieresis", "period"}:           -55.000000,
+}
@@ -0,0 +1,439 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// TrueTypeFont represents the glyphs of a parsed TrueType font program, as found in FontFile2 streams:
+// the glyph outlines (glyf and loca tables), advance widths (hmtx) and character to glyph index maps
+// (cmap). Font subsets embedded in PDF files often omit the tables that are not needed to draw the
+// glyphs, so only head and the glyph tables are required.
+type TrueTypeFont struct {
+	unitsPerEm int
+	loca       []int // Glyph index to offset in glyf. numGlyphs+1 entries.
+	glyf       []byte
+	advances   []uint16 // Advance widths of the first numberOfHMetrics glyphs.
+	cmap       []byte
+	// cmapOffsets maps (platform ID, encoding ID) to the offsets of the cmap subtables.
+	cmapOffsets map[[2]int]int
+}
+
+// maxCompositeDepth limits the nesting of composite glyphs.
+const maxCompositeDepth = 8
+
+// ParseTrueType parses the TrueType font program `data`.
+func ParseTrueType(data []byte) (*TrueTypeFont, error) {
+	head, err := GetSfntTable(data, "head")
+	if err != nil {
+		return nil, err
+	}
+	if len(head) < 54 {
+		return nil, errors.New("head table too short")
+	}
+	font := &TrueTypeFont{unitsPerEm: int(binary.BigEndian.Uint16(head[18:]))}
+	if font.unitsPerEm == 0 {
+		font.unitsPerEm = 1000
+	}
+	longOffsets := binary.BigEndian.Uint16(head[50:]) != 0
+
+	loca, err := GetSfntTable(data, "loca")
+	if err != nil {
+		return nil, err
+	}
+	font.glyf, err = GetSfntTable(data, "glyf")
+	if err != nil {
+		return nil, err
+	}
+	if longOffsets {
+		for i := 0; i+4 <= len(loca); i += 4 {
+			font.loca = append(font.loca, int(binary.BigEndian.Uint32(loca[i:])))
+		}
+	} else {
+		for i := 0; i+2 <= len(loca); i += 2 {
+			font.loca = append(font.loca, 2*int(binary.BigEndian.Uint16(loca[i:])))
+		}
+	}
+	if maxp, err := GetSfntTable(data, "maxp"); err == nil && len(maxp) >= 6 {
+		if n := int(binary.BigEndian.Uint16(maxp[4:])); n+1 < len(font.loca) {
+			font.loca = font.loca[:n+1]
+		}
+	}
+
+	hhea, err1 := GetSfntTable(data, "hhea")
+	hmtx, err2 := GetSfntTable(data, "hmtx")
+	if err1 == nil && err2 == nil && len(hhea) >= 36 {
+		n := int(binary.BigEndian.Uint16(hhea[34:]))
+		for i := 0; i < n && 4*i+2 <= len(hmtx); i++ {
+			font.advances = append(font.advances, binary.BigEndian.Uint16(hmtx[4*i:]))
+		}
+	}
+
+	font.cmapOffsets = map[[2]int]int{}
+	if cmap, err := GetSfntTable(data, "cmap"); err == nil && len(cmap) >= 4 {
+		font.cmap = cmap
+		numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+		for i := 0; i < numTables && 4+8*i+8 <= len(cmap); i++ {
+			record := cmap[4+8*i:]
+			key := [2]int{int(binary.BigEndian.Uint16(record)), int(binary.BigEndian.Uint16(record[2:]))}
+			offset := int(binary.BigEndian.Uint32(record[4:]))
+			if _, has := font.cmapOffsets[key]; !has && offset+2 <= len(cmap) {
+				font.cmapOffsets[key] = offset
+			}
+		}
+	}
+	return font, nil
+}
+
+// UnitsPerEm returns the number of glyph space units per em. The font matrix of TrueType fonts is
+// [1/unitsPerEm 0 0 1/unitsPerEm 0 0].
+func (font *TrueTypeFont) UnitsPerEm() int {
+	return font.unitsPerEm
+}
+
+// NumGlyphs returns the number of glyphs in the font.
+func (font *TrueTypeFont) NumGlyphs() int {
+	if len(font.loca) == 0 {
+		return 0
+	}
+	return len(font.loca) - 1
+}
+
+// HasCmap returns true if the font has a cmap subtable for platform `platformID` and encoding
+// `encodingID`, e.g. (3, 1) for Windows Unicode BMP or (1, 0) for Macintosh Roman.
+func (font *TrueTypeFont) HasCmap(platformID, encodingID int) bool {
+	_, has := font.cmapOffsets[[2]int{platformID, encodingID}]
+	return has
+}
+
+// CmapLookup returns the glyph index of character code `code` in the cmap subtable for platform
+// `platformID` and encoding `encodingID`. Subtable formats 0, 4, 6 and 12 are supported.
+// The bool return flag is false if there is no such subtable or the code is not mapped.
+func (font *TrueTypeFont) CmapLookup(platformID, encodingID int, code uint32) (int, bool) {
+	offset, has := font.cmapOffsets[[2]int{platformID, encodingID}]
+	if !has {
+		return 0, false
+	}
+	t := font.cmap[offset:]
+	u16 := func(i int) uint32 {
+		if i < 0 || i+2 > len(t) {
+			return 0
+		}
+		return uint32(binary.BigEndian.Uint16(t[i:]))
+	}
+	u32 := func(i int) uint32 {
+		if i < 0 || i+4 > len(t) {
+			return 0
+		}
+		return binary.BigEndian.Uint32(t[i:])
+	}
+
+	var gid uint32
+	switch u16(0) {
+	case 0:
+		if code < 256 && 6+int(code) < len(t) {
+			gid = uint32(t[6+code])
+		}
+	case 4:
+		segCount := int(u16(6) / 2)
+		for i := 0; i < segCount; i++ {
+			end := u16(14 + 2*i)
+			if code > end {
+				continue
+			}
+			start := u16(16 + 2*segCount + 2*i)
+			if code < start {
+				break
+			}
+			delta := u16(16 + 4*segCount + 2*i)
+			rangeOffsetPos := 16 + 6*segCount + 2*i
+			rangeOffset := u16(rangeOffsetPos)
+			if rangeOffset == 0 {
+				gid = (code + delta) & 0xffff
+				break
+			}
+			gid = u16(rangeOffsetPos + int(rangeOffset) + 2*int(code-start))
+			if gid != 0 {
+				gid = (gid + delta) & 0xffff
+			}
+			break
+		}
+	case 6:
+		first, count := u16(6), u16(8)
+		if code >= first && code < first+count {
+			gid = u16(10 + 2*int(code-first))
+		}
+	case 12:
+		numGroups := int(u32(12))
+		for i := 0; i < numGroups && 16+12*i+12 <= len(t); i++ {
+			start, end := u32(16+12*i), u32(20+12*i)
+			if code >= start && code <= end {
+				gid = u32(24+12*i) + code - start
+				break
+			}
+		}
+	default:
+		return 0, false
+	}
+	if gid == 0 || int(gid) >= font.NumGlyphs() {
+		return 0, false
+	}
+	return int(gid), true
+}
+
+// GIDAdvance returns the advance width of the glyph with index `gid` in glyph space.
+// The bool return flag is false if the font has no horizontal metrics.
+func (font *TrueTypeFont) GIDAdvance(gid int) (float64, bool) {
+	if len(font.advances) == 0 || gid < 0 || gid >= font.NumGlyphs() {
+		return 0, false
+	}
+	if gid >= len(font.advances) {
+		// The last advance applies to the remaining glyphs.
+		gid = len(font.advances) - 1
+	}
+	return float64(font.advances[gid]), true
+}
+
+// GIDOutline returns the outline of the glyph with index `gid` in glyph space.
+func (font *TrueTypeFont) GIDOutline(gid int) (*GlyphOutline, error) {
+	outline := &GlyphOutline{}
+	if err := font.appendOutline(outline, gid, [6]float64{1, 0, 0, 1, 0, 0}, 0); err != nil {
+		return nil, err
+	}
+	return outline, nil
+}
+
+// appendOutline appends the outline of glyph `gid`, transformed by `m` [a b c d e f] (x' = a*x + c*y
+// + e, y' = b*x + d*y + f), to `outline`. `depth` is the nesting level of composite glyphs.
+func (font *TrueTypeFont) appendOutline(outline *GlyphOutline, gid int, m [6]float64, depth int) error {
+	if depth > maxCompositeDepth {
+		return errors.New("composite glyphs nested too deeply")
+	}
+	if gid < 0 || gid >= font.NumGlyphs() {
+		return fmt.Errorf("glyph index %d out of range", gid)
+	}
+	start, end := font.loca[gid], font.loca[gid+1]
+	if start == end {
+		// Glyphs without outlines, e.g. space.
+		return nil
+	}
+	if start > end || end > len(font.glyf) || end-start < 10 {
+		return fmt.Errorf("glyph %d: invalid glyf offsets", gid)
+	}
+	data := font.glyf[start:end]
+	numContours := int(int16(binary.BigEndian.Uint16(data)))
+	if numContours >= 0 {
+		return appendSimpleGlyph(outline, data, numContours, m)
+	}
+
+	// Composite glyph: a list of transformed components.
+	const (
+		argsAreWords   = 0x0001
+		argsAreXY      = 0x0002
+		haveScale      = 0x0008
+		moreComponents = 0x0020
+		haveXYScale    = 0x0040
+		haveTwoByTwo   = 0x0080
+	)
+	pos := 10
+	for {
+		if pos+4 > len(data) {
+			return fmt.Errorf("glyph %d: truncated composite glyph", gid)
+		}
+		flags := binary.BigEndian.Uint16(data[pos:])
+		component := int(binary.BigEndian.Uint16(data[pos+2:]))
+		pos += 4
+
+		var dx, dy float64
+		if flags&argsAreWords != 0 {
+			if pos+4 > len(data) {
+				return fmt.Errorf("glyph %d: truncated composite glyph", gid)
+			}
+			dx = float64(int16(binary.BigEndian.Uint16(data[pos:])))
+			dy = float64(int16(binary.BigEndian.Uint16(data[pos+2:])))
+			pos += 4
+		} else {
+			if pos+2 > len(data) {
+				return fmt.Errorf("glyph %d: truncated composite glyph", gid)
+			}
+			dx, dy = float64(int8(data[pos])), float64(int8(data[pos+1]))
+			pos += 2
+		}
+		if flags&argsAreXY == 0 {
+			// The arguments are points to be matched. Not supported, the component is not offset.
+			dx, dy = 0, 0
+		}
+
+		f2dot14 := func(i int) float64 {
+			return float64(int16(binary.BigEndian.Uint16(data[pos+2*i:]))) / 16384
+		}
+		a, b, c, d := 1.0, 0.0, 0.0, 1.0
+		switch {
+		case flags&haveScale != 0 && pos+2 <= len(data):
+			a = f2dot14(0)
+			d = a
+			pos += 2
+		case flags&haveXYScale != 0 && pos+4 <= len(data):
+			a, d = f2dot14(0), f2dot14(1)
+			pos += 4
+		case flags&haveTwoByTwo != 0 && pos+8 <= len(data):
+			a, b, c, d = f2dot14(0), f2dot14(1), f2dot14(2), f2dot14(3)
+			pos += 8
+		}
+
+		// The component transform is applied before `m`.
+		cm := [6]float64{
+			a*m[0] + b*m[2], a*m[1] + b*m[3],
+			c*m[0] + d*m[2], c*m[1] + d*m[3],
+			dx*m[0] + dy*m[2] + m[4], dx*m[1] + dy*m[3] + m[5],
+		}
+		if err := font.appendOutline(outline, component, cm, depth+1); err != nil {
+			return err
+		}
+		if flags&moreComponents == 0 {
+			return nil
+		}
+	}
+}
+
+// appendSimpleGlyph appends the contours of simple glyph `data` with `numContours` contours,
+// transformed by `m`, to `outline`.
+func appendSimpleGlyph(outline *GlyphOutline, data []byte, numContours int, m [6]float64) error {
+	const (
+		onCurve      = 0x01
+		xShort       = 0x02
+		yShort       = 0x04
+		repeat       = 0x08
+		xSameOrPlus  = 0x10
+		ySameOrPlus  = 0x20
+		errTruncated = "truncated simple glyph"
+	)
+	pos := 10
+	if pos+2*numContours+2 > len(data) {
+		return errors.New(errTruncated)
+	}
+	endPts := make([]int, numContours)
+	for i := range endPts {
+		endPts[i] = int(binary.BigEndian.Uint16(data[pos+2*i:]))
+	}
+	pos += 2 * numContours
+	if numContours == 0 {
+		return nil
+	}
+	numPoints := endPts[numContours-1] + 1
+	pos += 2 + int(binary.BigEndian.Uint16(data[pos:]))
+
+	flags := make([]byte, 0, numPoints)
+	for len(flags) < numPoints {
+		if pos >= len(data) {
+			return errors.New(errTruncated)
+		}
+		f := data[pos]
+		pos++
+		flags = append(flags, f)
+		if f&repeat != 0 {
+			if pos >= len(data) {
+				return errors.New(errTruncated)
+			}
+			for n := int(data[pos]); n > 0 && len(flags) < numPoints; n-- {
+				flags = append(flags, f)
+			}
+			pos++
+		}
+	}
+
+	readCoords := func(short, sameOrPlus byte) ([]float64, error) {
+		coords := make([]float64, numPoints)
+		v := 0
+		for i, f := range flags {
+			switch {
+			case f&short != 0:
+				if pos >= len(data) {
+					return nil, errors.New(errTruncated)
+				}
+				delta := int(data[pos])
+				pos++
+				if f&sameOrPlus == 0 {
+					delta = -delta
+				}
+				v += delta
+			case f&sameOrPlus == 0:
+				if pos+2 > len(data) {
+					return nil, errors.New(errTruncated)
+				}
+				v += int(int16(binary.BigEndian.Uint16(data[pos:])))
+				pos += 2
+			}
+			coords[i] = float64(v)
+		}
+		return coords, nil
+	}
+	xs, err := readCoords(xShort, xSameOrPlus)
+	if err != nil {
+		return err
+	}
+	ys, err := readCoords(yShort, ySameOrPlus)
+	if err != nil {
+		return err
+	}
+
+	type point struct {
+		x, y float64
+		on   bool
+	}
+	transform := func(x, y float64) (float64, float64) {
+		return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+	}
+	first := 0
+	for _, last := range endPts {
+		if last < first || last >= numPoints {
+			return errors.New("invalid contour end point")
+		}
+		var pts []point
+		for i := first; i <= last; i++ {
+			x, y := transform(xs[i], ys[i])
+			pts = append(pts, point{x, y, flags[i]&onCurve != 0})
+		}
+		first = last + 1
+
+		// Start on a point that is on the curve: the first, the last or the midpoint between them.
+		var start point
+		switch {
+		case pts[0].on:
+			start, pts = pts[0], pts[1:]
+		case pts[len(pts)-1].on:
+			start, pts = pts[len(pts)-1], pts[:len(pts)-1]
+		default:
+			start = point{(pts[0].x + pts[len(pts)-1].x) / 2, (pts[0].y + pts[len(pts)-1].y) / 2, true}
+		}
+		outline.MoveTo(start.x, start.y)
+		// Consecutive off curve points have an implied on curve point midway between them.
+		var ctrl *point
+		for i := range pts {
+			p := pts[i]
+			switch {
+			case p.on && ctrl == nil:
+				outline.LineTo(p.x, p.y)
+			case p.on:
+				outline.QuadTo(ctrl.x, ctrl.y, p.x, p.y)
+				ctrl = nil
+			case ctrl != nil:
+				outline.QuadTo(ctrl.x, ctrl.y, (ctrl.x+p.x)/2, (ctrl.y+p.y)/2)
+				ctrl = &pts[i]
+			default:
+				ctrl = &pts[i]
+			}
+		}
+		if ctrl != nil {
+			outline.QuadTo(ctrl.x, ctrl.y, start.x, start.y)
+		}
+		outline.Close()
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestTrueTypeOutlines(t *testing.T) {
+	font, err := ParseTrueType(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Error parsing font: %v", err)
+	}
+	if font.UnitsPerEm() != 2048 {
+		t.Errorf("UnitsPerEm %d != 2048", font.UnitsPerEm())
+	}
+
+	gidA, ok := font.CmapLookup(3, 1, 'A')
+	if !ok {
+		t.Fatalf("No glyph for A")
+	}
+	if _, ok := font.CmapLookup(3, 1, 0xe000); ok {
+		t.Errorf("Glyph for unmapped code")
+	}
+	if adv, ok := font.GIDAdvance(gidA); !ok || adv <= 0 {
+		t.Errorf("Invalid advance %v %v", adv, ok)
+	}
+	outlineA, err := font.GIDOutline(gidA)
+	if err != nil {
+		t.Fatalf("Error loading outline: %v", err)
+	}
+	llx, lly, urx, ury := outlineA.Bounds()
+	if llx < 0 || lly != 0 || urx > 1400 || ury < 1300 || ury > 1600 {
+		t.Errorf("Invalid bounds of A: %v %v %v %v", llx, lly, urx, ury)
+	}
+
+	// Aring is a composite of A and a ring above it.
+	gidAring, ok := font.CmapLookup(3, 1, 'Å')
+	if !ok {
+		t.Fatalf("No glyph for Aring")
+	}
+	outlineAring, err := font.GIDOutline(gidAring)
+	if err != nil {
+		t.Fatalf("Error loading outline: %v", err)
+	}
+	_, lly2, _, ury2 := outlineAring.Bounds()
+	if lly2 != lly || ury2 <= ury {
+		t.Errorf("Invalid bounds of Aring: %v %v", lly2, ury2)
+	}
+
+	space, _ := font.CmapLookup(3, 1, ' ')
+	if outline, err := font.GIDOutline(space); err != nil || len(outline.Segments) != 0 {
+		t.Errorf("Space has outline %v %v", outline, err)
+	}
+}
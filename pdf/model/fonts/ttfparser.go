@@ -23,8 +23,10 @@ package fonts
 // Port to Go: Kurt Jung, 2013-07-15
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
@@ -46,11 +48,14 @@ type TtfType struct {
 	CapHeight              int16
 	Widths                 []uint16
 	Chars                  map[uint16]uint16
+
+	// HasCFF is true for OpenType fonts with CFF outlines (sfnt version OTTO) rather than glyf outlines.
+	HasCFF bool
 }
 
 type ttfParser struct {
 	rec              TtfType
-	f                *os.File
+	f                io.ReadSeeker
 	tables           map[string]uint32
 	numberOfHMetrics uint16
 	numGlyphs        uint16
@@ -58,20 +63,30 @@ type ttfParser struct {
 
 // TtfParse extracts various metrics from a TrueType font file.
 func TtfParse(fileStr string) (TtfRec TtfType, err error) {
-	var t ttfParser
-	t.f, err = os.Open(fileStr)
+	f, err := os.Open(fileStr)
 	if err != nil {
 		return
 	}
+	defer f.Close()
+	return ttfParse(f)
+}
+
+// TtfParseBytes extracts various metrics from TrueType font data `data`. WOFF and WOFF2 fonts must
+// first be converted to sfnt form with ReadSfnt.
+func TtfParseBytes(data []byte) (TtfType, error) {
+	return ttfParse(bytes.NewReader(data))
+}
+
+func ttfParse(f io.ReadSeeker) (TtfRec TtfType, err error) {
+	var t ttfParser
+	t.f = f
 	version, err := t.ReadStr(4)
 	if err != nil {
 		return
 	}
 	if version == "OTTO" {
-		err = fmt.Errorf("fonts based on PostScript outlines are not supported")
-		return
-	}
-	if version != "\x00\x01\x00\x00" {
+		t.rec.HasCFF = true
+	} else if version != "\x00\x01\x00\x00" {
 		err = fmt.Errorf("unrecognized file format")
 		return
 	}
@@ -93,7 +108,6 @@ func TtfParse(fileStr string) (TtfRec TtfType, err error) {
 	if err != nil {
 		return
 	}
-	t.f.Close()
 	TtfRec = t.rec
 	return
 }
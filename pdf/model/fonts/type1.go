@@ -0,0 +1,820 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/model/textencoding"
+)
+
+// Type1Font represents a parsed Type 1 font program, as found in .pfb/.pfa files and the FontFile
+// streams of PDF font descriptors (Adobe Type 1 Font Format).
+// Implements the FontProgram interface.
+type Type1Font struct {
+	fontName    string
+	fontMatrix  [6]float64
+	fontBBox    [4]float64
+	italicAngle float64
+	fixedPitch  bool
+	encoding    map[byte]string
+
+	lenIV       int
+	subrs       [][]byte
+	charstrings map[string][]byte
+
+	// The clear text and the binary (eexec encrypted) portions of the font program, as needed for
+	// embedding in a FontFile stream.
+	cleartext []byte
+	encrypted []byte
+
+	// Cached glyph metrics.
+	advances map[string]float64
+}
+
+// Type 1 encryption keys.
+const (
+	eexecKey      = 55665
+	charstringKey = 4330
+)
+
+var (
+	reT1FontName    = regexp.MustCompile(`/FontName\s*/([^\s/\[\]{}()<>]+)`)
+	reT1FontMatrix  = regexp.MustCompile(`/FontMatrix\s*[\[{]([^\]}]*)[\]}]`)
+	reT1FontBBox    = regexp.MustCompile(`/FontBBox\s*[\[{]([^\]}]*)[\]}]`)
+	reT1ItalicAngle = regexp.MustCompile(`/ItalicAngle\s+([-+]?[\d.]+)`)
+	reT1FixedPitch  = regexp.MustCompile(`/isFixedPitch\s+(true|false)`)
+	reT1Encoding    = regexp.MustCompile(`/Encoding\s+(StandardEncoding|\d+\s+array)`)
+	reT1EncodingDup = regexp.MustCompile(`dup\s+(\d+)\s*/([^\s/\[\]{}()<>]+)\s+put`)
+	reT1LenIV       = regexp.MustCompile(`/lenIV\s+(\d+)`)
+)
+
+// LoadType1FontFile loads and parses the Type 1 font in the .pfb or .pfa file `filePath`.
+func LoadType1FontFile(filePath string) (*Type1Font, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseType1Font(data)
+}
+
+// ParseType1Font parses the Type 1 font program `data`, which can be in PFB (segmented binary), PFA
+// (hex encrypted portion) or PDF FontFile (clear text followed by binary encrypted portion) format.
+func ParseType1Font(data []byte) (*Type1Font, error) {
+	cleartext, encrypted, err := splitType1Segments(data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseType1FontSegments(cleartext, encrypted)
+}
+
+// ParseType1FontSegments parses the Type 1 font program with clear text portion `cleartext` and
+// binary encrypted portion `encrypted`. This is used for FontFile streams where the lengths of the
+// portions are given by Length1 and Length2.
+func ParseType1FontSegments(cleartext, encrypted []byte) (*Type1Font, error) {
+	font := &Type1Font{
+		cleartext:   cleartext,
+		encrypted:   encrypted,
+		fontMatrix:  [6]float64{0.001, 0, 0, 0.001, 0, 0},
+		lenIV:       4,
+		charstrings: map[string][]byte{},
+		advances:    map[string]float64{},
+	}
+
+	if err := font.parseCleartext(cleartext); err != nil {
+		return nil, err
+	}
+
+	private := type1Decrypt(encrypted, eexecKey, 4)
+	if err := font.parsePrivate(private); err != nil {
+		return nil, err
+	}
+
+	common.Log.Trace("Type1 font %s: %d glyphs %d subrs", font.fontName, len(font.charstrings), len(font.subrs))
+	return font, nil
+}
+
+// splitType1Segments returns the clear text and binary encrypted portions of Type 1 font program `data`.
+func splitType1Segments(data []byte) ([]byte, []byte, error) {
+	if len(data) > 6 && data[0] == 0x80 {
+		return splitPFBSegments(data)
+	}
+
+	idx := bytes.Index(data, []byte("eexec"))
+	if idx < 0 {
+		return nil, nil, errors.New("Type1 font: eexec not found")
+	}
+	// The clear text portion includes the whitespace following eexec.
+	end := idx + 5
+	for end < len(data) && (data[end] == '\r' || data[end] == '\n' || data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	cleartext := data[:end]
+	rest := data[end:]
+	if len(rest) < 4 {
+		return nil, nil, errors.New("Type1 font: encrypted portion too short")
+	}
+
+	// The encrypted portion is binary if any of the first 4 bytes is not a hex digit.
+	if !isHexDigit(rest[0]) || !isHexDigit(rest[1]) || !isHexDigit(rest[2]) || !isHexDigit(rest[3]) {
+		return cleartext, rest, nil
+	}
+
+	var digits []byte
+	for _, b := range rest {
+		if isHexDigit(b) {
+			digits = append(digits, b)
+		} else if !isType1Space(b) {
+			break
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = digits[:len(digits)-1]
+	}
+	encrypted := make([]byte, len(digits)/2)
+	if _, err := hex.Decode(encrypted, digits); err != nil {
+		return nil, nil, err
+	}
+	return cleartext, encrypted, nil
+}
+
+// splitPFBSegments returns the clear text and binary portions of PFB file data `data`. Each segment
+// starts with 0x80, a type byte (1: ASCII, 2: binary, 3: EOF) and a little endian 32 bit length.
+func splitPFBSegments(data []byte) ([]byte, []byte, error) {
+	var cleartext, encrypted []byte
+	for len(data) >= 2 {
+		if data[0] != 0x80 {
+			return nil, nil, errors.New("PFB: invalid segment marker")
+		}
+		segType := data[1]
+		if segType == 3 {
+			break
+		}
+		if len(data) < 6 {
+			return nil, nil, errors.New("PFB: truncated segment header")
+		}
+		n := int(binary.LittleEndian.Uint32(data[2:6]))
+		if n < 0 || 6+n > len(data) {
+			return nil, nil, errors.New("PFB: segment length out of range")
+		}
+		segment := data[6 : 6+n]
+		data = data[6+n:]
+
+		switch segType {
+		case 1:
+			if encrypted == nil {
+				cleartext = append(cleartext, segment...)
+			}
+			// ASCII segments after the binary part are the trailer (zeros and cleartomark).
+		case 2:
+			encrypted = append(encrypted, segment...)
+		default:
+			return nil, nil, fmt.Errorf("PFB: invalid segment type %d", segType)
+		}
+	}
+	if cleartext == nil || encrypted == nil {
+		return nil, nil, errors.New("PFB: missing segments")
+	}
+	return cleartext, encrypted, nil
+}
+
+// type1Decrypt decrypts `data` with key `r` and drops the first `skip` bytes (Type 1 spec 7.1).
+func type1Decrypt(data []byte, r uint16, skip int) []byte {
+	const c1, c2 = 52845, 22719
+	out := make([]byte, len(data))
+	for i, c := range data {
+		out[i] = c ^ byte(r>>8)
+		r = (uint16(c)+r)*c1 + c2
+	}
+	if skip > len(out) {
+		return nil
+	}
+	return out[skip:]
+}
+
+// parseCleartext reads the font dictionary entries in the clear text portion of the font program.
+func (font *Type1Font) parseCleartext(cleartext []byte) error {
+	text := string(cleartext)
+
+	m := reT1FontName.FindStringSubmatch(text)
+	if m == nil {
+		return errors.New("Type1 font: FontName missing")
+	}
+	font.fontName = m[1]
+
+	if m := reT1FontMatrix.FindStringSubmatch(text); m != nil {
+		vals, err := parseType1Numbers(m[1])
+		if err == nil && len(vals) == 6 {
+			copy(font.fontMatrix[:], vals)
+		}
+	}
+	if m := reT1FontBBox.FindStringSubmatch(text); m != nil {
+		vals, err := parseType1Numbers(m[1])
+		if err == nil && len(vals) == 4 {
+			copy(font.fontBBox[:], vals)
+		}
+	}
+	if m := reT1ItalicAngle.FindStringSubmatch(text); m != nil {
+		font.italicAngle, _ = strconv.ParseFloat(m[1], 64)
+	}
+	if m := reT1FixedPitch.FindStringSubmatch(text); m != nil {
+		font.fixedPitch = m[1] == "true"
+	}
+
+	font.encoding = map[byte]string{}
+	m = reT1Encoding.FindStringSubmatch(text)
+	if m == nil {
+		common.Log.Debug("Type1 font %s: no Encoding. Assuming StandardEncoding", font.fontName)
+	}
+	if m == nil || m[1] == "StandardEncoding" {
+		encoder := textencoding.NewStandardTextEncoder()
+		for code := 0; code <= 0xff; code++ {
+			if glyph, ok := encoder.CharcodeToGlyph(byte(code)); ok {
+				font.encoding[byte(code)] = glyph
+			}
+		}
+		return nil
+	}
+	for _, dup := range reT1EncodingDup.FindAllStringSubmatch(text, -1) {
+		code, err := strconv.Atoi(dup[1])
+		if err != nil || code > 0xff {
+			continue
+		}
+		if dup[2] != ".notdef" {
+			font.encoding[byte(code)] = dup[2]
+		}
+	}
+	return nil
+}
+
+// parseType1Numbers parses the whitespace separated numbers in `s`.
+func parseType1Numbers(s string) ([]float64, error) {
+	vals := []float64{}
+	for _, field := range strings.Fields(s) {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+// parsePrivate reads the Subrs and CharStrings from the decrypted private portion of the font program.
+func (font *Type1Font) parsePrivate(private []byte) error {
+	if m := reT1LenIV.FindSubmatch(private); m != nil {
+		font.lenIV, _ = strconv.Atoi(string(m[1]))
+	}
+
+	if idx := bytes.Index(private, []byte("/Subrs")); idx >= 0 {
+		s := &type1Scanner{data: private, pos: idx + len("/Subrs")}
+		if err := font.parseSubrs(s); err != nil {
+			return err
+		}
+	}
+
+	idx := bytes.Index(private, []byte("/CharStrings"))
+	if idx < 0 {
+		return errors.New("Type1 font: CharStrings missing")
+	}
+	s := &type1Scanner{data: private, pos: idx + len("/CharStrings")}
+	return font.parseCharStrings(s)
+}
+
+// parseSubrs parses entries of the form `dup index nbytes RD <binary> NP`.
+func (font *Type1Font) parseSubrs(s *type1Scanner) error {
+	count, err := strconv.Atoi(s.token())
+	if err != nil {
+		return errors.New("Type1 font: invalid Subrs count")
+	}
+	// Each entry takes more than a byte, so larger counts are invalid.
+	if count < 0 || count > len(s.data)-s.pos {
+		return errors.New("Type1 font: invalid Subrs count")
+	}
+	font.subrs = make([][]byte, count)
+
+	for read := 0; read < count; {
+		tok := s.token()
+		if tok == "" {
+			return errors.New("Type1 font: unexpected end of Subrs")
+		}
+		if tok != "dup" {
+			continue
+		}
+		index, err := strconv.Atoi(s.token())
+		if err != nil || index < 0 || index >= count {
+			return errors.New("Type1 font: invalid Subrs index")
+		}
+		data, err := s.binary()
+		if err != nil {
+			return err
+		}
+		font.subrs[index] = type1Decrypt(data, charstringKey, font.lenIV)
+		read++
+	}
+	return nil
+}
+
+// parseCharStrings parses entries of the form `/glyphname nbytes RD <binary> ND`.
+func (font *Type1Font) parseCharStrings(s *type1Scanner) error {
+	count, err := strconv.Atoi(s.token())
+	if err != nil {
+		return errors.New("Type1 font: invalid CharStrings count")
+	}
+
+	for len(font.charstrings) < count {
+		tok := s.token()
+		if tok == "" || tok == "end" {
+			break
+		}
+		if !strings.HasPrefix(tok, "/") || len(tok) == 1 {
+			continue
+		}
+		data, err := s.binary()
+		if err != nil {
+			return err
+		}
+		font.charstrings[tok[1:]] = type1Decrypt(data, charstringKey, font.lenIV)
+	}
+	if len(font.charstrings) == 0 {
+		return errors.New("Type1 font: no CharStrings")
+	}
+	return nil
+}
+
+// type1Scanner is a minimal PostScript tokenizer for the private portion of Type 1 fonts.
+type type1Scanner struct {
+	data []byte
+	pos  int
+}
+
+// token returns the next whitespace delimited token, or "" at the end of the data.
+func (s *type1Scanner) token() string {
+	for s.pos < len(s.data) && isType1Space(s.data[s.pos]) {
+		s.pos++
+	}
+	start := s.pos
+	for s.pos < len(s.data) && !isType1Space(s.data[s.pos]) {
+		s.pos++
+		if s.pos < len(s.data) && s.data[s.pos] == '/' {
+			break
+		}
+	}
+	return string(s.data[start:s.pos])
+}
+
+// binary reads a binary string of the form `nbytes RD <binary>`, where RD is the name of the
+// procedure that reads the string (typically RD or -|) and is followed by a single space.
+func (s *type1Scanner) binary() ([]byte, error) {
+	n, err := strconv.Atoi(s.token())
+	if err != nil || n < 0 {
+		return nil, errors.New("Type1 font: invalid binary string length")
+	}
+	s.token() // RD
+	s.pos++
+	if s.pos+n > len(s.data) {
+		return nil, errors.New("Type1 font: binary string out of range")
+	}
+	data := s.data[s.pos : s.pos+n]
+	s.pos += n
+	return data, nil
+}
+
+func isType1Space(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n' || b == '\f' || b == 0
+}
+
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+// Name returns the PostScript name of the font.
+func (font *Type1Font) Name() string {
+	return font.fontName
+}
+
+// FontMatrix returns the matrix that maps glyph space to text space.
+func (font *Type1Font) FontMatrix() [6]float64 {
+	return font.fontMatrix
+}
+
+// FontBBox returns the font bounding box [llx lly urx ury] in glyph space.
+func (font *Type1Font) FontBBox() [4]float64 {
+	return font.fontBBox
+}
+
+// ItalicAngle returns the italic angle of the font in degrees.
+func (font *Type1Font) ItalicAngle() float64 {
+	return font.italicAngle
+}
+
+// IsFixedPitch returns true if all glyphs of the font have the same width.
+func (font *Type1Font) IsFixedPitch() bool {
+	return font.fixedPitch
+}
+
+// BuiltinEncoding returns the font's built-in character code to glyph name map.
+func (font *Type1Font) BuiltinEncoding() map[byte]string {
+	return font.encoding
+}
+
+// GlyphNames returns the sorted names of the glyphs in the font.
+func (font *Type1Font) GlyphNames() []string {
+	names := make([]string, 0, len(font.charstrings))
+	for name := range font.charstrings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GlyphAdvance returns the advance width of glyph `glyph` in glyph space.
+// The bool return flag is true if there was a match, and false otherwise.
+func (font *Type1Font) GlyphAdvance(glyph string) (float64, bool) {
+	if w, ok := font.advances[glyph]; ok {
+		return w, true
+	}
+	if _, ok := font.charstrings[glyph]; !ok {
+		return 0, false
+	}
+	if _, err := font.GlyphOutline(glyph); err != nil {
+		common.Log.Debug("Type1 font %s: glyph %s: %v", font.fontName, glyph, err)
+		return 0, false
+	}
+	w, ok := font.advances[glyph]
+	return w, ok
+}
+
+// GlyphOutline returns the outline of glyph `glyph` in glyph space.
+func (font *Type1Font) GlyphOutline(glyph string) (*GlyphOutline, error) {
+	charstring, ok := font.charstrings[glyph]
+	if !ok {
+		return nil, fmt.Errorf("glyph %s not found", glyph)
+	}
+	ctx := newType1CharstringContext(font)
+	if err := ctx.run(charstring, 0); err != nil {
+		return nil, err
+	}
+	font.advances[glyph] = ctx.wx
+	return ctx.outline, nil
+}
+
+// GetFontFileSegments returns the clear text and binary encrypted portions of the font program.
+// These are the contents of a PDF FontFile stream with Length1 and Length2 set to their lengths.
+func (font *Type1Font) GetFontFileSegments() ([]byte, []byte) {
+	return font.cleartext, font.encrypted
+}
+
+// type1CharstringContext holds the state of the Type 1 charstring interpreter.
+type type1CharstringContext struct {
+	font    *Type1Font
+	outline *GlyphOutline
+
+	stack   []float64
+	psStack []float64
+
+	x, y     float64 // Current point.
+	sbx, sby float64 // Left side bearing point.
+	wx       float64 // Advance width.
+
+	open     bool // A subpath is open.
+	flexing  bool
+	flexPts  []OutlinePoint
+	finished bool
+}
+
+func newType1CharstringContext(font *Type1Font) *type1CharstringContext {
+	return &type1CharstringContext{font: font, outline: &GlyphOutline{}}
+}
+
+// Type 1 charstring commands (Type 1 spec 6.4). Escaped commands are 32 + the second byte.
+const (
+	t1Hstem           = 1
+	t1Vstem           = 3
+	t1Vmoveto         = 4
+	t1Rlineto         = 5
+	t1Hlineto         = 6
+	t1Vlineto         = 7
+	t1Rrcurveto       = 8
+	t1Closepath       = 9
+	t1Callsubr        = 10
+	t1Return          = 11
+	t1Escape          = 12
+	t1Hsbw            = 13
+	t1Endchar         = 14
+	t1Rmoveto         = 21
+	t1Hmoveto         = 22
+	t1Vhcurveto       = 30
+	t1Hvcurveto       = 31
+	t1Dotsection      = 32 + 0
+	t1Vstem3          = 32 + 1
+	t1Hstem3          = 32 + 2
+	t1Seac            = 32 + 6
+	t1Sbw             = 32 + 7
+	t1Div             = 32 + 12
+	t1Callothersubr   = 32 + 16
+	t1Pop             = 32 + 17
+	t1Setcurrentpoint = 32 + 33
+)
+
+// maxSubrDepth limits the nesting of subroutine calls in charstrings.
+const maxSubrDepth = 10
+
+// run interprets charstring `data` at subroutine nesting level `depth`.
+func (ctx *type1CharstringContext) run(data []byte, depth int) error {
+	if depth > maxSubrDepth {
+		return errors.New("charstring subroutine nesting too deep")
+	}
+
+	for i := 0; i < len(data) && !ctx.finished; {
+		v := int(data[i])
+		i++
+
+		switch {
+		case v >= 32 && v <= 246:
+			ctx.stack = append(ctx.stack, float64(v-139))
+			continue
+		case v >= 247 && v <= 250:
+			if i >= len(data) {
+				return errors.New("charstring: truncated number")
+			}
+			ctx.stack = append(ctx.stack, float64((v-247)*256+int(data[i])+108))
+			i++
+			continue
+		case v >= 251 && v <= 254:
+			if i >= len(data) {
+				return errors.New("charstring: truncated number")
+			}
+			ctx.stack = append(ctx.stack, float64(-(v-251)*256-int(data[i])-108))
+			i++
+			continue
+		case v == 255:
+			if i+4 > len(data) {
+				return errors.New("charstring: truncated number")
+			}
+			ctx.stack = append(ctx.stack, float64(int32(binary.BigEndian.Uint32(data[i:i+4]))))
+			i += 4
+			continue
+		}
+
+		cmd := v
+		if v == t1Escape {
+			if i >= len(data) {
+				return errors.New("charstring: truncated escape")
+			}
+			cmd = 32 + int(data[i])
+			i++
+		}
+
+		if cmd == t1Return {
+			return nil
+		}
+		if err := ctx.command(cmd, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// command executes charstring command `cmd` with the operands on the stack.
+func (ctx *type1CharstringContext) command(cmd int, depth int) error {
+	s := ctx.stack
+	need := func(n int) error {
+		if len(s) < n {
+			return fmt.Errorf("charstring: command %d needs %d operands, got %d", cmd, n, len(s))
+		}
+		return nil
+	}
+
+	clear := true
+	switch cmd {
+	case t1Hsbw:
+		if err := need(2); err != nil {
+			return err
+		}
+		ctx.sbx, ctx.wx = s[0], s[1]
+		ctx.x, ctx.y = ctx.sbx, 0
+	case t1Sbw:
+		if err := need(4); err != nil {
+			return err
+		}
+		ctx.sbx, ctx.sby, ctx.wx = s[0], s[1], s[2]
+		ctx.x, ctx.y = ctx.sbx, ctx.sby
+	case t1Rmoveto:
+		if err := need(2); err != nil {
+			return err
+		}
+		ctx.moveTo(s[0], s[1])
+	case t1Hmoveto:
+		if err := need(1); err != nil {
+			return err
+		}
+		ctx.moveTo(s[0], 0)
+	case t1Vmoveto:
+		if err := need(1); err != nil {
+			return err
+		}
+		ctx.moveTo(0, s[0])
+	case t1Rlineto:
+		if err := need(2); err != nil {
+			return err
+		}
+		ctx.lineTo(s[0], s[1])
+	case t1Hlineto:
+		if err := need(1); err != nil {
+			return err
+		}
+		ctx.lineTo(s[0], 0)
+	case t1Vlineto:
+		if err := need(1); err != nil {
+			return err
+		}
+		ctx.lineTo(0, s[0])
+	case t1Rrcurveto:
+		if err := need(6); err != nil {
+			return err
+		}
+		ctx.curveTo(s[0], s[1], s[2], s[3], s[4], s[5])
+	case t1Vhcurveto:
+		if err := need(4); err != nil {
+			return err
+		}
+		ctx.curveTo(0, s[0], s[1], s[2], s[3], 0)
+	case t1Hvcurveto:
+		if err := need(4); err != nil {
+			return err
+		}
+		ctx.curveTo(s[0], 0, s[1], s[2], 0, s[3])
+	case t1Closepath:
+		if ctx.open {
+			ctx.outline.Close()
+			ctx.open = false
+		}
+	case t1Endchar:
+		if ctx.open {
+			ctx.outline.Close()
+			ctx.open = false
+		}
+		ctx.finished = true
+	case t1Callsubr:
+		if err := need(1); err != nil {
+			return err
+		}
+		n := int(s[len(s)-1])
+		ctx.stack = s[:len(s)-1]
+		if n < 0 || n >= len(ctx.font.subrs) {
+			return fmt.Errorf("charstring: invalid subr %d", n)
+		}
+		return ctx.run(ctx.font.subrs[n], depth+1)
+	case t1Callothersubr:
+		if err := need(2); err != nil {
+			return err
+		}
+		othersubr := int(s[len(s)-1])
+		n := int(s[len(s)-2])
+		if n < 0 || len(s) < n+2 {
+			return errors.New("charstring: invalid callothersubr")
+		}
+		args := s[len(s)-2-n : len(s)-2]
+		ctx.stack = s[:len(s)-2-n]
+		ctx.callOtherSubr(othersubr, args)
+		clear = false
+	case t1Pop:
+		if len(ctx.psStack) > 0 {
+			v := ctx.psStack[len(ctx.psStack)-1]
+			ctx.psStack = ctx.psStack[:len(ctx.psStack)-1]
+			ctx.stack = append(ctx.stack, v)
+		}
+		clear = false
+	case t1Div:
+		if err := need(2); err != nil {
+			return err
+		}
+		a, b := s[len(s)-2], s[len(s)-1]
+		ctx.stack = s[:len(s)-2]
+		if b != 0 {
+			ctx.stack = append(ctx.stack, a/b)
+		} else {
+			ctx.stack = append(ctx.stack, 0)
+		}
+		clear = false
+	case t1Setcurrentpoint:
+		if err := need(2); err != nil {
+			return err
+		}
+		ctx.x, ctx.y = s[0], s[1]
+	case t1Seac:
+		if err := need(5); err != nil {
+			return err
+		}
+		return ctx.seac(s[0], s[1], s[2], int(s[3]), int(s[4]), depth)
+	case t1Hstem, t1Vstem, t1Dotsection, t1Vstem3, t1Hstem3:
+		// Hints are not needed for outlines.
+	default:
+		common.Log.Debug("Type1 charstring: unsupported command %d", cmd)
+	}
+	if clear {
+		ctx.stack = ctx.stack[:0]
+	}
+	return nil
+}
+
+// callOtherSubr emulates the standard OtherSubrs: 0-2 implement flex and 3 hint replacement.
+// The results are left on the PostScript stack for the pop command.
+func (ctx *type1CharstringContext) callOtherSubr(othersubr int, args []float64) {
+	switch othersubr {
+	case 1:
+		ctx.flexing = true
+		ctx.flexPts = ctx.flexPts[:0]
+		return
+	case 2:
+		return
+	case 0:
+		ctx.flexing = false
+		if len(ctx.flexPts) >= 7 {
+			p := ctx.flexPts
+			ctx.outline.CubeTo(p[1].X, p[1].Y, p[2].X, p[2].Y, p[3].X, p[3].Y)
+			ctx.outline.CubeTo(p[4].X, p[4].Y, p[5].X, p[5].Y, p[6].X, p[6].Y)
+			ctx.x, ctx.y = p[6].X, p[6].Y
+		}
+		ctx.psStack = append(ctx.psStack, ctx.y, ctx.x)
+		return
+	}
+	// Hint replacement (3) and unknown othersubrs: return the arguments so that pop gets them in order.
+	for i := len(args) - 1; i >= 0; i-- {
+		ctx.psStack = append(ctx.psStack, args[i])
+	}
+}
+
+// seac draws an accented character composed of base glyph `bchar` and accent `achar`, which are
+// character codes in StandardEncoding. The accent is offset by (adx - asb, ady).
+func (ctx *type1CharstringContext) seac(asb, adx, ady float64, bchar, achar int, depth int) error {
+	encoder := textencoding.NewStandardTextEncoder()
+	baseName, ok1 := encoder.CharcodeToGlyph(byte(bchar))
+	accentName, ok2 := encoder.CharcodeToGlyph(byte(achar))
+	if !ok1 || !ok2 {
+		return errors.New("charstring: invalid seac character codes")
+	}
+	base, ok1 := ctx.font.charstrings[baseName]
+	accent, ok2 := ctx.font.charstrings[accentName]
+	if !ok1 || !ok2 {
+		return fmt.Errorf("charstring: seac glyphs %s %s not in font", baseName, accentName)
+	}
+
+	wx := ctx.wx
+	baseCtx := newType1CharstringContext(ctx.font)
+	if err := baseCtx.run(base, depth+1); err != nil {
+		return err
+	}
+	accentCtx := newType1CharstringContext(ctx.font)
+	if err := accentCtx.run(accent, depth+1); err != nil {
+		return err
+	}
+	accentCtx.outline.Translate(adx-asb, ady)
+
+	ctx.outline.Append(baseCtx.outline)
+	ctx.outline.Append(accentCtx.outline)
+	ctx.wx = wx
+	ctx.finished = true
+	return nil
+}
+
+func (ctx *type1CharstringContext) moveTo(dx, dy float64) {
+	ctx.x += dx
+	ctx.y += dy
+	if ctx.flexing {
+		ctx.flexPts = append(ctx.flexPts, OutlinePoint{ctx.x, ctx.y})
+		return
+	}
+	if ctx.open {
+		ctx.outline.Close()
+	}
+	ctx.outline.MoveTo(ctx.x, ctx.y)
+	ctx.open = true
+}
+
+func (ctx *type1CharstringContext) lineTo(dx, dy float64) {
+	ctx.x += dx
+	ctx.y += dy
+	ctx.outline.LineTo(ctx.x, ctx.y)
+}
+
+func (ctx *type1CharstringContext) curveTo(dx1, dy1, dx2, dy2, dx3, dy3 float64) {
+	x1, y1 := ctx.x+dx1, ctx.y+dy1
+	x2, y2 := x1+dx2, y1+dy2
+	ctx.x, ctx.y = x2+dx3, y2+dy3
+	ctx.outline.CubeTo(x1, y1, x2, y2, ctx.x, ctx.y)
+}
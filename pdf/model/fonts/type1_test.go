@@ -0,0 +1,180 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+// type1Encrypt is the inverse of type1Decrypt. `data` is prefixed with `skip` zero bytes.
+func type1Encrypt(data []byte, r uint16, skip int) []byte {
+	const c1, c2 = 52845, 22719
+	plain := append(make([]byte, skip), data...)
+	out := make([]byte, len(plain))
+	for i, p := range plain {
+		c := p ^ byte(r>>8)
+		r = (uint16(c)+r)*c1 + c2
+		out[i] = c
+	}
+	return out
+}
+
+// makeTestType1Font returns the clear text and encrypted portions of a Type 1 font with glyphs
+// A (a 400x700 box drawn with a subroutine) and Aring (a seac composite of A and A).
+func makeTestType1Font() ([]byte, []byte) {
+	cleartext := []byte(`%!PS-AdobeFont-1.0: TestFont 001
+/FontName /TestFont def
+/FontMatrix [0.001 0 0 0.001 0 0] readonly def
+/FontBBox {0 0 500 700} readonly def
+/ItalicAngle -12 def
+/Encoding 256 array
+0 1 255 {1 index exch /.notdef put} for
+dup 65 /A put
+dup 197 /Aring put
+readonly def
+currentfile eexec
+`)
+
+	subr := []byte{139, 249, 80, 5, 11}                                                // 0 700 rlineto return
+	glyphA := []byte{189, 248, 236, 13, 139, 139, 21, 248, 36, 139, 5, 139, 10, 9, 14} // 50 600 hsbw ... endchar
+	glyphAring := []byte{189, 248, 236, 13, 189, 239, 139, 204, 204, 12, 6}            // 50 600 hsbw 50 100 0 65 65 seac
+	charstrings := map[string][]byte{"A": glyphA, "Aring": glyphAring, ".notdef": {139, 248, 236, 13, 14}}
+
+	var private bytes.Buffer
+	private.WriteString("dup /Private 8 dict dup begin\n/RD{string currentfile exch readstring pop}executeonly def\n")
+	private.WriteString("/lenIV 4 def\n/Subrs 1 array\n")
+	enc := type1Encrypt(subr, charstringKey, 4)
+	fmt.Fprintf(&private, "dup 0 %d RD ", len(enc))
+	private.Write(enc)
+	private.WriteString(" NP\nND\n2 index /CharStrings 3 dict dup begin\n")
+	for _, name := range []string{".notdef", "A", "Aring"} {
+		enc := type1Encrypt(charstrings[name], charstringKey, 4)
+		fmt.Fprintf(&private, "/%s %d -| ", name, len(enc))
+		private.Write(enc)
+		private.WriteString(" |-\n")
+	}
+	private.WriteString("end\nend\nmark currentfile closefile\n")
+
+	return cleartext, type1Encrypt(private.Bytes(), eexecKey, 4)
+}
+
+// pfbSegment returns a PFB segment of type `segType` containing `data`.
+func pfbSegment(segType byte, data []byte) []byte {
+	header := []byte{0x80, segType, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(header[2:], uint32(len(data)))
+	return append(header, data...)
+}
+
+func checkTestType1Font(t *testing.T, font *Type1Font) {
+	if font.Name() != "TestFont" {
+		t.Errorf("Name %q != TestFont", font.Name())
+	}
+	if font.ItalicAngle() != -12 {
+		t.Errorf("ItalicAngle %v != -12", font.ItalicAngle())
+	}
+	if bbox := font.FontBBox(); bbox != [4]float64{0, 0, 500, 700} {
+		t.Errorf("FontBBox %v", bbox)
+	}
+	encoding := font.BuiltinEncoding()
+	if len(encoding) != 2 || encoding[65] != "A" || encoding[197] != "Aring" {
+		t.Errorf("Incorrect encoding %v", encoding)
+	}
+	if names := font.GlyphNames(); len(names) != 3 {
+		t.Errorf("Incorrect glyph names %v", names)
+	}
+
+	if w, ok := font.GlyphAdvance("A"); !ok || w != 600 {
+		t.Errorf("Advance of A %v %t != 600", w, ok)
+	}
+	outline, err := font.GlyphOutline("A")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	expected := []OutlineSegment{
+		{Op: OutlineMoveTo, Points: [3]OutlinePoint{{50, 0}}},
+		{Op: OutlineLineTo, Points: [3]OutlinePoint{{450, 0}}},
+		{Op: OutlineLineTo, Points: [3]OutlinePoint{{450, 700}}},
+		{Op: OutlineClose},
+	}
+	if len(outline.Segments) != len(expected) {
+		t.Fatalf("Outline of A %v", outline.Segments)
+	}
+	for i, seg := range expected {
+		if outline.Segments[i] != seg {
+			t.Errorf("Segment %d: %v != %v", i, outline.Segments[i], seg)
+		}
+	}
+
+	// The accent of the seac glyph is offset by adx - asb = 50.
+	outline, err = font.GlyphOutline("Aring")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(outline.Segments) != 8 || outline.Segments[4].Points[0] != (OutlinePoint{100, 0}) {
+		t.Errorf("Outline of Aring %v", outline.Segments)
+	}
+	if w, ok := font.GlyphAdvance("Aring"); !ok || w != 600 {
+		t.Errorf("Advance of Aring %v %t != 600", w, ok)
+	}
+}
+
+// Type 1 font in PDF FontFile format: clear text followed by binary encrypted portion.
+func TestType1FontFile(t *testing.T) {
+	cleartext, encrypted := makeTestType1Font()
+	font, err := ParseType1Font(append(append([]byte{}, cleartext...), encrypted...))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	checkTestType1Font(t, font)
+
+	clear, binary := font.GetFontFileSegments()
+	if !bytes.Equal(clear, cleartext) || !bytes.Equal(binary, encrypted) {
+		t.Errorf("Incorrect font file segments")
+	}
+}
+
+// Type 1 font in PFB and PFA formats.
+func TestType1FontPFBAndPFA(t *testing.T) {
+	cleartext, encrypted := makeTestType1Font()
+
+	var pfb []byte
+	pfb = append(pfb, pfbSegment(1, cleartext)...)
+	pfb = append(pfb, pfbSegment(2, encrypted)...)
+	pfb = append(pfb, pfbSegment(1, []byte("0000000000\ncleartomark\n"))...)
+	pfb = append(pfb, 0x80, 3)
+	font, err := ParseType1Font(pfb)
+	if err != nil {
+		t.Fatalf("PFB error: %v", err)
+	}
+	checkTestType1Font(t, font)
+
+	pfa := append([]byte{}, cleartext...)
+	h := hex.EncodeToString(encrypted)
+	for len(h) > 64 {
+		pfa = append(pfa, h[:64]+"\n"...)
+		h = h[64:]
+	}
+	pfa = append(pfa, h+"\n0000000000\ncleartomark\n"...)
+	font, err = ParseType1Font(pfa)
+	if err != nil {
+		t.Fatalf("PFA error: %v", err)
+	}
+	checkTestType1Font(t, font)
+}
+
+// Subrs counts that are negative or larger than the data can hold are invalid.
+func TestType1InvalidSubrs(t *testing.T) {
+	for _, data := range []string{" -1 array\n", " 1000000000 array\ndup 0 1 RD x NP\n"} {
+		font := &Type1Font{}
+		if err := font.parseSubrs(&type1Scanner{data: []byte(data)}); err == nil {
+			t.Errorf("No error for Subrs %q", data)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+// ReadSfnt reads a TrueType (.ttf), OpenType (.otf), WOFF (.woff) or WOFF2 (.woff2) font from `r` and
+// returns it in sfnt form, i.e. as a TrueType or OpenType font file. TrueType and OpenType fonts are
+// returned as read.
+func ReadSfnt(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, errors.New("font data too short")
+	}
+	switch string(data[:4]) {
+	case "wOFF":
+		return DecodeWOFF(data)
+	case "wOF2":
+		return DecodeWOFF2(data)
+	case "OTTO", "\x00\x01\x00\x00", "true":
+		return data, nil
+	}
+	return nil, errors.New("unrecognized font format")
+}
+
+// maxSfntSize is the largest font size accepted by DecodeWOFF and DecodeWOFF2.
+const maxSfntSize = 1 << 26
+
+// maxZlibRatio bounds the zlib compression ratio (the deflate limit is about 1032:1).
+const maxZlibRatio = 1032
+
+// DecodeWOFF converts the WOFF 1.0 font `data` to sfnt form.
+func DecodeWOFF(data []byte) ([]byte, error) {
+	if len(data) < 44 || string(data[:4]) != "wOFF" {
+		return nil, errors.New("woff: invalid header")
+	}
+	flavor := binary.BigEndian.Uint32(data[4:])
+	numTables := int(binary.BigEndian.Uint16(data[12:]))
+	if 44+20*numTables > len(data) {
+		return nil, errors.New("woff: table directory out of range")
+	}
+	// The remaining declared font size bounds the decompressed table lengths.
+	remaining := int64(binary.BigEndian.Uint32(data[16:]))
+	if remaining > maxSfntSize {
+		return nil, errors.New("woff: font too large")
+	}
+
+	tables := make([]sfntTable, 0, numTables)
+	for i := 0; i < numTables; i++ {
+		entry := data[44+20*i:]
+		tag := string(entry[:4])
+		offset := int(binary.BigEndian.Uint32(entry[4:]))
+		compLength := int(binary.BigEndian.Uint32(entry[8:]))
+		origLength := int(binary.BigEndian.Uint32(entry[12:]))
+		if offset < 0 || compLength < 0 || offset+compLength > len(data) || compLength > origLength {
+			return nil, fmt.Errorf("woff: table %q out of range", tag)
+		}
+		if int64(origLength) > remaining || origLength > maxZlibRatio*compLength {
+			return nil, fmt.Errorf("woff: table %q too large", tag)
+		}
+		remaining -= int64(origLength)
+		table := data[offset : offset+compLength]
+		if compLength < origLength {
+			zr, err := zlib.NewReader(bytes.NewReader(table))
+			if err != nil {
+				return nil, fmt.Errorf("woff: table %q: %v", tag, err)
+			}
+			table = make([]byte, origLength)
+			_, err = io.ReadFull(zr, table)
+			zr.Close()
+			if err != nil {
+				return nil, fmt.Errorf("woff: table %q: %v", tag, err)
+			}
+		}
+		tables = append(tables, sfntTable{tag: tag, data: table})
+	}
+
+	return buildSfnt(flavor, tables), nil
+}
+
+// sfntTable is a table of an sfnt font.
+type sfntTable struct {
+	tag  string
+	data []byte
+}
+
+// sfntChecksum returns the checksum of table `data`.
+func sfntChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// buildSfnt returns an sfnt font file with version `flavor` and tables `tables`. The tables are sorted
+// by tag and the head checkSumAdjustment is recomputed.
+func buildSfnt(flavor uint32, tables []sfntTable) []byte {
+	sort.Slice(tables, func(i, j int) bool { return tables[i].tag < tables[j].tag })
+
+	numTables := len(tables)
+	entrySelector := 0
+	for 1<<uint(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := 16 << uint(entrySelector)
+
+	header := make([]byte, 12+16*numTables)
+	binary.BigEndian.PutUint32(header[0:], flavor)
+	binary.BigEndian.PutUint16(header[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(16*numTables-searchRange))
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	headOffset := -1
+	for i, table := range tables {
+		if table.tag == "head" && len(table.data) >= 12 {
+			// checkSumAdjustment is set to 0 when computing the checksums.
+			table.data = append([]byte{}, table.data...)
+			binary.BigEndian.PutUint32(table.data[8:], 0)
+			tables[i].data = table.data
+			headOffset = buf.Len()
+		}
+		record := buf.Bytes()[12+16*i:]
+		copy(record, table.tag)
+		binary.BigEndian.PutUint32(record[4:], sfntChecksum(table.data))
+		binary.BigEndian.PutUint32(record[8:], uint32(buf.Len()))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(table.data)))
+		buf.Write(table.data)
+		for buf.Len()%4 != 0 {
+			buf.WriteByte(0)
+		}
+	}
+
+	font := buf.Bytes()
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(font[headOffset+8:], 0xB1B0AFBA-sfntChecksum(font))
+	}
+	return font
+}
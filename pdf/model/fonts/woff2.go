@@ -0,0 +1,650 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// woff2KnownTags are the table tags of the WOFF2 table directory flags (WOFF2 5.1).
+var woff2KnownTags = [63]string{
+	"cmap", "head", "hhea", "hmtx", "maxp", "name", "OS/2", "post", "cvt ", "fpgm", "glyf", "loca",
+	"prep", "CFF ", "VORG", "EBDT", "EBLC", "gasp", "hdmx", "kern", "LTSH", "PCLT", "VDMX", "vhea",
+	"vmtx", "BASE", "GDEF", "GPOS", "GSUB", "EBSC", "JSTF", "MATH", "CBDT", "CBLC", "COLR", "CPAL",
+	"SVG ", "sbix", "acnt", "avar", "bdat", "bloc", "bsln", "cvar", "fdsc", "feat", "fmtx", "fvar",
+	"gvar", "hsty", "just", "lcar", "mort", "morx", "opbd", "prop", "trak", "Zapf", "Silf", "Glat",
+	"Gloc", "Feat", "Sill",
+}
+
+var errWOFF2Range = errors.New("woff2: data out of range")
+
+// woff2Reader is a bounds checked big endian reader of WOFF2 data.
+type woff2Reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *woff2Reader) u8() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errWOFF2Range
+	}
+	r.pos++
+	return r.data[r.pos-1], nil
+}
+
+func (r *woff2Reader) u16() (uint16, error) {
+	if r.pos+2 > len(r.data) {
+		return 0, errWOFF2Range
+	}
+	r.pos += 2
+	return binary.BigEndian.Uint16(r.data[r.pos-2:]), nil
+}
+
+func (r *woff2Reader) u32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errWOFF2Range
+	}
+	r.pos += 4
+	return binary.BigEndian.Uint32(r.data[r.pos-4:]), nil
+}
+
+// bytes returns the next `n` bytes.
+func (r *woff2Reader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, errWOFF2Range
+	}
+	r.pos += n
+	return r.data[r.pos-n : r.pos], nil
+}
+
+// base128 reads a UIntBase128 value.
+func (r *woff2Reader) base128() (uint32, error) {
+	var val uint32
+	for i := 0; i < 5; i++ {
+		b, err := r.u8()
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 && b == 0x80 {
+			return 0, errors.New("woff2: invalid UIntBase128 leading zero")
+		}
+		if val&0xFE000000 != 0 {
+			return 0, errors.New("woff2: UIntBase128 overflow")
+		}
+		val = val<<7 | uint32(b&0x7F)
+		if b&0x80 == 0 {
+			return val, nil
+		}
+	}
+	return 0, errors.New("woff2: UIntBase128 too long")
+}
+
+// u255 reads a 255UInt16 value.
+func (r *woff2Reader) u255() (uint16, error) {
+	code, err := r.u8()
+	if err != nil {
+		return 0, err
+	}
+	switch code {
+	case 253:
+		return r.u16()
+	case 254:
+		b, err := r.u8()
+		return 253*2 + uint16(b), err
+	case 255:
+		b, err := r.u8()
+		return 253 + uint16(b), err
+	}
+	return uint16(code), nil
+}
+
+// woff2Table is an entry of the WOFF2 table directory.
+type woff2Table struct {
+	tag         string
+	transformed bool
+	origLength  uint32
+	length      uint32 // Length in the decompressed data.
+}
+
+// DecodeWOFF2 converts the WOFF2 font `data` to sfnt form. The transformed glyf, loca and hmtx tables
+// are reconstructed. Font collections are not supported.
+func DecodeWOFF2(data []byte) ([]byte, error) {
+	r := &woff2Reader{data: data}
+	header, err := r.bytes(48)
+	if err != nil || string(header[:4]) != "wOF2" {
+		return nil, errors.New("woff2: invalid header")
+	}
+	flavor := binary.BigEndian.Uint32(header[4:])
+	if flavor == 0x74746366 { // ttcf
+		return nil, errors.New("woff2: font collections not supported")
+	}
+	numTables := int(binary.BigEndian.Uint16(header[12:]))
+	totalSfntSize := int64(binary.BigEndian.Uint32(header[16:]))
+	totalCompressedSize := int(binary.BigEndian.Uint32(header[20:]))
+	if totalSfntSize > maxSfntSize {
+		return nil, errors.New("woff2: font too large")
+	}
+
+	directory := make([]woff2Table, numTables)
+	for i := range directory {
+		flags, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		t := &directory[i]
+		if flags&0x3F == 0x3F {
+			tag, err := r.bytes(4)
+			if err != nil {
+				return nil, err
+			}
+			t.tag = string(tag)
+		} else {
+			t.tag = woff2KnownTags[flags&0x3F]
+		}
+		version := flags >> 6
+		if t.origLength, err = r.base128(); err != nil {
+			return nil, err
+		}
+		t.length = t.origLength
+		// Transformation version 0 is the glyf/loca transform and the null transform of the other
+		// tables. Version 3 is the null transform of glyf/loca.
+		if t.tag == "glyf" || t.tag == "loca" {
+			t.transformed = version == 0
+		} else {
+			t.transformed = version != 0
+		}
+		if t.transformed {
+			if t.length, err = r.base128(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	compressed, err := r.bytes(totalCompressedSize)
+	if err != nil {
+		return nil, err
+	}
+	// The table lengths are untrusted, so their sum is checked against the declared font size before
+	// decompressing. The stream is read without preallocating it.
+	var total int64
+	for _, t := range directory {
+		total += int64(t.length)
+	}
+	if total > totalSfntSize {
+		return nil, errors.New("woff2: table data exceeds font size")
+	}
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, io.LimitReader(brotli.NewReader(bytes.NewReader(compressed)), total))
+	if err != nil {
+		return nil, fmt.Errorf("woff2: %v", err)
+	}
+	stream := buf.Bytes()
+	if int64(len(stream)) != total {
+		return nil, fmt.Errorf("woff2: %v", io.ErrUnexpectedEOF)
+	}
+
+	tableData := map[string][]byte{}
+	offset := 0
+	for _, t := range directory {
+		end := offset + int(t.length)
+		if end < offset || end > len(stream) {
+			return nil, fmt.Errorf("woff2: table %q out of range", t.tag)
+		}
+		tableData[t.tag] = stream[offset:end]
+		offset = end
+	}
+
+	tables := make([]sfntTable, 0, numTables)
+	var xMins []int16
+	for _, t := range directory {
+		switch {
+		case !t.transformed:
+			tables = append(tables, sfntTable{tag: t.tag, data: tableData[t.tag]})
+		case t.tag == "glyf":
+			glyf, loca, mins, err := reconstructGlyf(tableData["glyf"])
+			if err != nil {
+				return nil, err
+			}
+			xMins = mins
+			tables = append(tables, sfntTable{tag: "glyf", data: glyf}, sfntTable{tag: "loca", data: loca})
+		case t.tag == "loca":
+			// Reconstructed with glyf.
+		case t.tag == "hmtx":
+			// Reconstructed below, after glyf.
+		default:
+			return nil, fmt.Errorf("woff2: unsupported transform of table %q", t.tag)
+		}
+	}
+	for _, t := range directory {
+		if t.tag == "hmtx" && t.transformed {
+			hmtx, err := reconstructHmtx(tableData["hmtx"], tableData["hhea"], xMins)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, sfntTable{tag: "hmtx", data: hmtx})
+		}
+	}
+
+	return buildSfnt(flavor, tables), nil
+}
+
+// woff2Point is a glyph outline point.
+type woff2Point struct {
+	x, y    int
+	onCurve bool
+}
+
+// woff2WithSign returns `val` if bit 0 of `flag` is set and -`val` otherwise.
+func woff2WithSign(flag byte, val int) int {
+	if flag&1 != 0 {
+		return val
+	}
+	return -val
+}
+
+// decodeTriplets decodes the coordinates of `flags` points from the glyph stream `r`.
+func decodeTriplets(flags []byte, r *woff2Reader) ([]woff2Point, error) {
+	points := make([]woff2Point, len(flags))
+	x, y := 0, 0
+	for i, f := range flags {
+		flag := f & 0x7F
+		n := 4
+		switch {
+		case flag < 84:
+			n = 1
+		case flag < 120:
+			n = 2
+		case flag < 124:
+			n = 3
+		}
+		b, err := r.bytes(n)
+		if err != nil {
+			return nil, err
+		}
+
+		var dx, dy int
+		switch {
+		case flag < 10:
+			dy = woff2WithSign(flag, int(flag&14)<<7+int(b[0]))
+		case flag < 20:
+			dx = woff2WithSign(flag, int((flag-10)&14)<<7+int(b[0]))
+		case flag < 84:
+			b0 := int(flag - 20)
+			dx = woff2WithSign(flag, 1+(b0&0x30)+int(b[0]>>4))
+			dy = woff2WithSign(flag>>1, 1+(b0&0x0C)<<2+int(b[0]&0x0F))
+		case flag < 120:
+			b0 := int(flag - 84)
+			dx = woff2WithSign(flag, 1+(b0/12)<<8+int(b[0]))
+			dy = woff2WithSign(flag>>1, 1+((b0%12)>>2)<<8+int(b[1]))
+		case flag < 124:
+			dx = woff2WithSign(flag, int(b[0])<<4+int(b[1]>>4))
+			dy = woff2WithSign(flag>>1, int(b[1]&0x0F)<<8+int(b[2]))
+		default:
+			dx = woff2WithSign(flag, int(b[0])<<8+int(b[1]))
+			dy = woff2WithSign(flag>>1, int(b[2])<<8+int(b[3]))
+		}
+		x += dx
+		y += dy
+		points[i] = woff2Point{x: x, y: y, onCurve: f>>7 == 0}
+	}
+	return points, nil
+}
+
+// reconstructGlyf reconstructs the glyf and loca tables from the transformed glyf table `data`
+// (WOFF2 5.1). The xMin of each glyph is returned for the reconstruction of hmtx.
+func reconstructGlyf(data []byte) ([]byte, []byte, []int16, error) {
+	r := &woff2Reader{data: data}
+	header, err := r.bytes(36)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	optionFlags := binary.BigEndian.Uint16(header[2:])
+	numGlyphs := int(binary.BigEndian.Uint16(header[4:]))
+	indexFormat := binary.BigEndian.Uint16(header[6:])
+
+	// The nContour, nPoints, flag, glyph, composite, bbox and instruction streams.
+	var streams [7]*woff2Reader
+	for i := range streams {
+		size := int(binary.BigEndian.Uint32(header[8+4*i:]))
+		b, err := r.bytes(size)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		streams[i] = &woff2Reader{data: b}
+	}
+	nContourStream, nPointsStream, flagStream := streams[0], streams[1], streams[2]
+	glyphStream, compositeStream, bboxStream, instructionStream := streams[3], streams[4], streams[5], streams[6]
+
+	bboxBitmap, err := bboxStream.bytes(4 * ((numGlyphs + 31) / 32))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var overlapBitmap []byte
+	if optionFlags&1 != 0 {
+		if overlapBitmap, err = r.bytes((numGlyphs + 7) / 8); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var glyf bytes.Buffer
+	offsets := make([]int, numGlyphs+1)
+	xMins := make([]int16, numGlyphs)
+	for gid := 0; gid < numGlyphs; gid++ {
+		offsets[gid] = glyf.Len()
+		v, err := nContourStream.u16()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		nContours := int16(v)
+		hasBBox := bboxBitmap[gid>>3]&(0x80>>uint(gid&7)) != 0
+
+		var bbox [4]int16
+		if hasBBox {
+			for i := range bbox {
+				v, err := bboxStream.u16()
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				bbox[i] = int16(v)
+			}
+		}
+
+		switch {
+		case nContours == 0:
+			// Empty glyph.
+			if hasBBox {
+				return nil, nil, nil, errors.New("woff2: empty glyph with bounding box")
+			}
+			continue
+		case nContours < 0:
+			// Composite glyph.
+			if !hasBBox {
+				return nil, nil, nil, errors.New("woff2: composite glyph without bounding box")
+			}
+			components, haveInstructions, err := readCompositeGlyph(compositeStream)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			writeGlyphHeader(&glyf, -1, bbox)
+			glyf.Write(components)
+			if haveInstructions {
+				if err := copyInstructions(&glyf, glyphStream, instructionStream); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+		default:
+			// Simple glyph.
+			endPts := make([]uint16, nContours)
+			nPoints := 0
+			for i := range endPts {
+				n, err := nPointsStream.u255()
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				nPoints += int(n)
+				endPts[i] = uint16(nPoints - 1)
+			}
+			flags, err := flagStream.bytes(nPoints)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			points, err := decodeTriplets(flags, glyphStream)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if !hasBBox && len(points) > 0 {
+				bbox = [4]int16{int16(points[0].x), int16(points[0].y), int16(points[0].x), int16(points[0].y)}
+				for _, p := range points[1:] {
+					bbox[0] = minInt16(bbox[0], int16(p.x))
+					bbox[1] = minInt16(bbox[1], int16(p.y))
+					bbox[2] = maxInt16(bbox[2], int16(p.x))
+					bbox[3] = maxInt16(bbox[3], int16(p.y))
+				}
+			}
+			overlap := overlapBitmap != nil && overlapBitmap[gid>>3]&(0x80>>uint(gid&7)) != 0
+
+			writeGlyphHeader(&glyf, nContours, bbox)
+			for _, endPt := range endPts {
+				binary.Write(&glyf, binary.BigEndian, endPt)
+			}
+			if err := copyInstructions(&glyf, glyphStream, instructionStream); err != nil {
+				return nil, nil, nil, err
+			}
+			writeSimpleGlyphPoints(&glyf, points, overlap)
+		}
+		xMins[gid] = bbox[0]
+		for glyf.Len()%4 != 0 {
+			glyf.WriteByte(0)
+		}
+	}
+	offsets[numGlyphs] = glyf.Len()
+
+	var loca bytes.Buffer
+	for _, offset := range offsets {
+		if indexFormat == 0 {
+			binary.Write(&loca, binary.BigEndian, uint16(offset/2))
+		} else {
+			binary.Write(&loca, binary.BigEndian, uint32(offset))
+		}
+	}
+	return glyf.Bytes(), loca.Bytes(), xMins, nil
+}
+
+func minInt16(a, b int16) int16 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt16(a, b int16) int16 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// writeGlyphHeader writes a glyf table glyph header.
+func writeGlyphHeader(w *bytes.Buffer, nContours int16, bbox [4]int16) {
+	binary.Write(w, binary.BigEndian, nContours)
+	binary.Write(w, binary.BigEndian, bbox)
+}
+
+// copyInstructions reads the instruction length from the glyph stream and writes it followed by the
+// instructions from the instruction stream.
+func copyInstructions(w *bytes.Buffer, glyphStream, instructionStream *woff2Reader) error {
+	n, err := glyphStream.u255()
+	if err != nil {
+		return err
+	}
+	instructions, err := instructionStream.bytes(int(n))
+	if err != nil {
+		return err
+	}
+	binary.Write(w, binary.BigEndian, n)
+	w.Write(instructions)
+	return nil
+}
+
+// readCompositeGlyph returns the component records of the next composite glyph in the composite
+// stream `r` and whether the glyph has instructions.
+func readCompositeGlyph(r *woff2Reader) ([]byte, bool, error) {
+	const (
+		arg1And2AreWords   = 0x0001
+		weHaveAScale       = 0x0008
+		moreComponents     = 0x0020
+		weHaveAnXAndYScale = 0x0040
+		weHaveATwoByTwo    = 0x0080
+		weHaveInstructions = 0x0100
+	)
+	start := r.pos
+	haveInstructions := false
+	for {
+		flags, err := r.u16()
+		if err != nil {
+			return nil, false, err
+		}
+		size := 2 // glyphIndex
+		if flags&arg1And2AreWords != 0 {
+			size += 4
+		} else {
+			size += 2
+		}
+		switch {
+		case flags&weHaveAScale != 0:
+			size += 2
+		case flags&weHaveAnXAndYScale != 0:
+			size += 4
+		case flags&weHaveATwoByTwo != 0:
+			size += 8
+		}
+		if _, err := r.bytes(size); err != nil {
+			return nil, false, err
+		}
+		if flags&weHaveInstructions != 0 {
+			haveInstructions = true
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+	return r.data[start:r.pos], haveInstructions, nil
+}
+
+// writeSimpleGlyphPoints writes the flags and coordinates of the points of a simple glyph.
+func writeSimpleGlyphPoints(w *bytes.Buffer, points []woff2Point, overlap bool) {
+	const (
+		onCurvePoint      = 0x01
+		xShortVector      = 0x02
+		yShortVector      = 0x04
+		repeatFlag        = 0x08
+		xIsSameOrPositive = 0x10
+		yIsSameOrPositive = 0x20
+		overlapSimple     = 0x40
+	)
+
+	flags := make([]byte, len(points))
+	var xs, ys bytes.Buffer
+	lastX, lastY := 0, 0
+	for i, p := range points {
+		var flag byte
+		if p.onCurve {
+			flag |= onCurvePoint
+		}
+		if i == 0 && overlap {
+			flag |= overlapSimple
+		}
+
+		dx := p.x - lastX
+		switch {
+		case dx == 0:
+			flag |= xIsSameOrPositive
+		case dx > -256 && dx < 256:
+			flag |= xShortVector
+			if dx > 0 {
+				flag |= xIsSameOrPositive
+				xs.WriteByte(byte(dx))
+			} else {
+				xs.WriteByte(byte(-dx))
+			}
+		default:
+			binary.Write(&xs, binary.BigEndian, int16(dx))
+		}
+
+		dy := p.y - lastY
+		switch {
+		case dy == 0:
+			flag |= yIsSameOrPositive
+		case dy > -256 && dy < 256:
+			flag |= yShortVector
+			if dy > 0 {
+				flag |= yIsSameOrPositive
+				ys.WriteByte(byte(dy))
+			} else {
+				ys.WriteByte(byte(-dy))
+			}
+		default:
+			binary.Write(&ys, binary.BigEndian, int16(dy))
+		}
+
+		flags[i] = flag
+		lastX, lastY = p.x, p.y
+	}
+
+	// Runs of identical flags are written with the repeat flag.
+	for i := 0; i < len(flags); {
+		n := 1
+		for i+n < len(flags) && flags[i+n] == flags[i] && n < 256 {
+			n++
+		}
+		if n > 2 {
+			w.WriteByte(flags[i] | repeatFlag)
+			w.WriteByte(byte(n - 1))
+		} else {
+			n = 1
+			w.WriteByte(flags[i])
+		}
+		i += n
+	}
+	w.Write(xs.Bytes())
+	w.Write(ys.Bytes())
+}
+
+// reconstructHmtx reconstructs the hmtx table from the transformed hmtx table `data` (WOFF2 5.4)
+// using the numberOfHMetrics of `hhea` and the glyph xMins `xMins` from the glyf table.
+func reconstructHmtx(data, hhea []byte, xMins []int16) ([]byte, error) {
+	if len(hhea) < 36 {
+		return nil, errors.New("woff2: hhea table missing")
+	}
+	if xMins == nil {
+		return nil, errors.New("woff2: hmtx transform without glyf transform")
+	}
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	numGlyphs := len(xMins)
+	if numHMetrics < 1 || numHMetrics > numGlyphs {
+		return nil, errors.New("woff2: invalid numberOfHMetrics")
+	}
+
+	r := &woff2Reader{data: data}
+	flags, err := r.u8()
+	if err != nil {
+		return nil, err
+	}
+	advances := make([]uint16, numHMetrics)
+	for i := range advances {
+		if advances[i], err = r.u16(); err != nil {
+			return nil, err
+		}
+	}
+	lsbs := make([]int16, numGlyphs)
+	for i := range lsbs {
+		// Bit 0: no lsb for proportional glyphs. Bit 1: no leftSideBearing for monospaced glyphs.
+		if (i < numHMetrics && flags&1 != 0) || (i >= numHMetrics && flags&2 != 0) {
+			lsbs[i] = xMins[i]
+			continue
+		}
+		v, err := r.u16()
+		if err != nil {
+			return nil, err
+		}
+		lsbs[i] = int16(v)
+	}
+
+	var hmtx bytes.Buffer
+	for i, lsb := range lsbs {
+		if i < numHMetrics {
+			binary.Write(&hmtx, binary.BigEndian, advances[i])
+		}
+		binary.Write(&hmtx, binary.BigEndian, lsb)
+	}
+	return hmtx.Bytes(), nil
+}
@@ -0,0 +1,170 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fonts
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// makeTestWOFF packs the sfnt font `data` as WOFF, compressing the tables that shrink.
+func makeTestWOFF(t *testing.T, data []byte) []byte {
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	header := make([]byte, 44+20*numTables)
+	copy(header, "wOFF")
+	copy(header[4:], data[:4])
+	binary.BigEndian.PutUint16(header[12:], uint16(numTables))
+	binary.BigEndian.PutUint32(header[16:], uint32(len(data)))
+
+	var body bytes.Buffer
+	for i := 0; i < numTables; i++ {
+		record := data[12+16*i:]
+		table, err := GetSfntTable(data, string(record[:4]))
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		var zbuf bytes.Buffer
+		zw := zlib.NewWriter(&zbuf)
+		zw.Write(table)
+		zw.Close()
+		packed := table
+		if zbuf.Len() < len(table) {
+			packed = zbuf.Bytes()
+		}
+
+		entry := header[44+20*i:]
+		copy(entry, record[:4])
+		binary.BigEndian.PutUint32(entry[4:], uint32(len(header)+body.Len()))
+		binary.BigEndian.PutUint32(entry[8:], uint32(len(packed)))
+		binary.BigEndian.PutUint32(entry[12:], uint32(len(table)))
+		body.Write(packed)
+		for body.Len()%4 != 0 {
+			body.WriteByte(0)
+		}
+	}
+	return append(header, body.Bytes()...)
+}
+
+func TestWOFF(t *testing.T) {
+	data, err := ioutil.ReadFile("../../../testfiles/roboto/Roboto-Regular.ttf")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	sfnt, err := ReadSfnt(bytes.NewReader(makeTestWOFF(t, data)))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	for _, tag := range []string{"cmap", "glyf", "GPOS", "hmtx", "name"} {
+		orig, _ := GetSfntTable(data, tag)
+		table, err := GetSfntTable(sfnt, tag)
+		if err != nil || !bytes.Equal(orig, table) {
+			t.Errorf("Table %q differs: %v", tag, err)
+		}
+	}
+	ttf, err := TtfParseBytes(sfnt)
+	if err != nil || ttf.PostScriptName != "Roboto-Regular" {
+		t.Errorf("Incorrect font %q: %v", ttf.PostScriptName, err)
+	}
+}
+
+// Table lengths beyond the declared font size are rejected without allocating them.
+func TestWOFFTooLarge(t *testing.T) {
+	data := make([]byte, 64)
+	copy(data, "wOFF")
+	binary.BigEndian.PutUint16(data[12:], 1)
+	binary.BigEndian.PutUint32(data[16:], 1024)
+	entry := data[44:]
+	copy(entry, "glyf")
+	binary.BigEndian.PutUint32(entry[4:], 60)
+	binary.BigEndian.PutUint32(entry[8:], 4)
+	binary.BigEndian.PutUint32(entry[12:], 0xFFFFFFF)
+	if _, err := DecodeWOFF(data); err == nil {
+		t.Errorf("Expected error for oversized table")
+	}
+
+	// 8 tables of 0xFFFFFFF bytes each, as UIntBase128.
+	woff2 := make([]byte, 48)
+	copy(woff2, "wOF2")
+	binary.BigEndian.PutUint16(woff2[12:], 8)
+	binary.BigEndian.PutUint32(woff2[16:], 1024)
+	for i := 0; i < 8; i++ {
+		woff2 = append(woff2, 0x3F, 'a', 'b', 'c', byte('0'+i), 0xFF, 0xFF, 0xFF, 0x7F)
+	}
+	if _, err := DecodeWOFF2(woff2); err == nil {
+		t.Errorf("Expected error for oversized WOFF2 tables")
+	}
+	binary.BigEndian.PutUint32(woff2[16:], 0xFFFFFFFF)
+	if _, err := DecodeWOFF2(woff2); err == nil {
+		t.Errorf("Expected error for oversized WOFF2 font")
+	}
+}
+
+func TestWOFF2(t *testing.T) {
+	f, err := os.Open("../../../testfiles/woff2/SourceCodePro-Regular.ttf.woff2")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	defer f.Close()
+	sfnt, err := ReadSfnt(f)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if sfntChecksum(sfnt) != 0xB1B0AFBA {
+		t.Errorf("Incorrect font checksum")
+	}
+
+	ttf, err := TtfParseBytes(sfnt)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if ttf.PostScriptName != "SourceCodePro-Regular" || len(ttf.Widths) != 1568 {
+		t.Errorf("Incorrect font %q with %d glyphs", ttf.PostScriptName, len(ttf.Widths))
+	}
+	if w := ttf.Widths[ttf.Chars['A']]; w != 600 {
+		t.Errorf("Width of A %d != 600", w)
+	}
+
+	// The reconstructed loca table has an offset for each glyph, within the glyf table.
+	head, _ := GetSfntTable(sfnt, "head")
+	loca, _ := GetSfntTable(sfnt, "loca")
+	glyf, _ := GetSfntTable(sfnt, "glyf")
+	var last int
+	if binary.BigEndian.Uint16(head[50:]) == 0 {
+		if len(loca) != 2*1569 {
+			t.Fatalf("Incorrect short loca length %d", len(loca))
+		}
+		last = 2 * int(binary.BigEndian.Uint16(loca[2*1568:]))
+	} else {
+		if len(loca) != 4*1569 {
+			t.Fatalf("Incorrect long loca length %d", len(loca))
+		}
+		last = int(binary.BigEndian.Uint32(loca[4*1568:]))
+	}
+	if last != len(glyf) {
+		t.Errorf("Last loca offset %d != glyf length %d", last, len(glyf))
+	}
+}
+
+// Transformed hmtx with the left side bearings taken from the glyph xMins.
+func TestWOFF2Hmtx(t *testing.T) {
+	hhea := make([]byte, 36)
+	binary.BigEndian.PutUint16(hhea[34:], 2)
+	transformed := []byte{0x01, 0x01, 0xF4, 0x02, 0x58, 0xFF, 0xF6}
+	hmtx, err := reconstructHmtx(transformed, hhea, []int16{10, 20, 30})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// advance 500 lsb 10, advance 600 lsb 20, lsb -10.
+	expected := []byte{0x01, 0xF4, 0x00, 0x0A, 0x02, 0x58, 0x00, 0x14, 0xFF, 0xF6}
+	if !bytes.Equal(hmtx, expected) {
+		t.Errorf("Incorrect hmtx % X", hmtx)
+	}
+}
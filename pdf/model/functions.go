@@ -229,10 +229,54 @@ func newPdfFunctionType0FromStream(stream *PdfObjectStream) (*PdfFunctionType0,
 	return fun, nil
 }
 
+// NewPdfFunctionType0 returns a sampled function with len(`size`) inputs in `domain` and outputs in
+// `rang`, with size[i] samples along input i spanning its domain. `samples` holds the outputs at the
+// sample points, with the first input varying fastest. They are stored with 16 bits per sample.
+func NewPdfFunctionType0(domain, rang []float64, size []int, samples []float64) (*PdfFunctionType0, error) {
+	if len(domain) != 2*len(size) || len(rang) == 0 || len(rang)%2 != 0 {
+		return nil, errors.New("Range check")
+	}
+	numOutputs := len(rang) / 2
+	count := numOutputs
+	for _, n := range size {
+		if n < 2 {
+			return nil, errors.New("Range check")
+		}
+		count *= n
+	}
+	if len(samples) != count {
+		return nil, errors.New("Range check")
+	}
+
+	fun := &PdfFunctionType0{
+		Domain:        domain,
+		Range:         rang,
+		NumInputs:     len(size),
+		NumOutputs:    numOutputs,
+		Size:          size,
+		BitsPerSample: 16,
+		Order:         1,
+	}
+	fun.rawData = make([]byte, 2*count)
+	for i, val := range samples {
+		j := i % numOutputs
+		rmin, rmax := rang[2*j], rang[2*j+1]
+		t := 0.0
+		if rmax > rmin {
+			t = math.Min(math.Max((val-rmin)/(rmax-rmin), 0), 1)
+		}
+		v := uint16(t*65535 + 0.5)
+		fun.rawData[2*i] = byte(v >> 8)
+		fun.rawData[2*i+1] = byte(v)
+	}
+	return fun, nil
+}
+
 func (this *PdfFunctionType0) ToPdfObject() PdfObject {
 	container := this.container
-	if container != nil {
-		this.container = &PdfObjectStream{}
+	if container == nil {
+		container = &PdfObjectStream{}
+		this.container = container
 	}
 
 	dict := MakeDict()
@@ -310,7 +354,7 @@ func (this *PdfFunctionType0) Evaluate(x []float64) ([]float64, error) {
 		xip := math.Min(math.Max(xi, this.Domain[2*i]), this.Domain[2*i+1])
 
 		ei := interpolate(xip, this.Domain[2*i], this.Domain[2*i+1], encode[2*i], encode[2*i+1])
-		eip := math.Min(math.Max(ei, 0), float64(this.Size[i]))
+		eip := math.Min(math.Max(ei, 0), float64(this.Size[i]-1))
 		// eip represents coordinate into the data table.
 		// At this point it is real values.
 
@@ -322,7 +366,7 @@ func (this *PdfFunctionType0) Evaluate(x []float64) ([]float64, error) {
 		index := int(math.Floor(eip + 0.5))
 		if index < 0 {
 			index = 0
-		} else if index > this.Size[i] {
+		} else if index >= this.Size[i] {
 			index = this.Size[i] - 1
 		}
 		indices = append(indices, index)
@@ -344,7 +388,7 @@ func (this *PdfFunctionType0) Evaluate(x []float64) ([]float64, error) {
 	outputs := []float64{}
 	for j := 0; j < this.NumOutputs; j++ {
 		rj := this.data[m+j]
-		rjp := interpolate(float64(rj), 0, math.Pow(2, float64(this.BitsPerSample)), decode[2*j], decode[2*j+1])
+		rjp := interpolate(float64(rj), 0, math.Pow(2, float64(this.BitsPerSample))-1, decode[2*j], decode[2*j+1])
 		yj := math.Min(math.Max(rjp, this.Range[2*j]), this.Range[2*j+1])
 		outputs = append(outputs, yj)
 	}
@@ -566,11 +610,41 @@ func (this *PdfFunctionType3) Evaluate(x []float64) ([]float64, error) {
 		return nil, errors.New("Range check")
 	}
 
-	// Determine which function to use
+	if len(this.Domain) != 2 || len(this.Functions) == 0 || len(this.Encode) != 2*len(this.Functions) ||
+		len(this.Bounds) != len(this.Functions)-1 {
+		common.Log.Debug("Invalid stitching function")
+		return nil, errors.New("Range check")
+	}
+	xi := math.Min(math.Max(x[0], this.Domain[0]), this.Domain[1])
 
-	// Encode
+	// Determine which function to use: the subdomains are [Domain0 Bounds0), [Bounds0 Bounds1), ...
+	// [Bounds(k-2) Domain1].
+	k := 0
+	for k < len(this.Bounds) && xi >= this.Bounds[k] {
+		k++
+	}
+	low, high := this.Domain[0], this.Domain[1]
+	if k > 0 {
+		low = this.Bounds[k-1]
+	}
+	if k < len(this.Bounds) {
+		high = this.Bounds[k]
+	}
 
-	return nil, errors.New("Not implemented yet")
+	// Encode the input to the domain of the subfunction.
+	e := interpolate(xi, low, high, this.Encode[2*k], this.Encode[2*k+1])
+	outputs, err := this.Functions[k].Evaluate([]float64{e})
+	if err != nil {
+		return nil, err
+	}
+	if this.Range != nil {
+		for j := range outputs {
+			if 2*j+1 < len(this.Range) {
+				outputs[j] = math.Min(math.Max(outputs[j], this.Range[2*j]), this.Range[2*j+1])
+			}
+		}
+	}
+	return outputs, nil
 }
 
 func newPdfFunctionType3FromPdfObject(obj PdfObject) (*PdfFunctionType3, error) {
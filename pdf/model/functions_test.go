@@ -101,3 +101,103 @@ endobj
 
 	fmt.Printf("%s", stream.Stream)
 }
+
+func TestType3Function(t *testing.T) {
+	rawText := `
+10 0 obj
+<<
+	/FunctionType 3
+	/Domain [ 0 2 ]
+	/Functions [
+		<< /FunctionType 2 /Domain [ 0 1 ] /C0 [ 0 ] /C1 [ 1 ] /N 1 >>
+		<< /FunctionType 2 /Domain [ 0 1 ] /C0 [ 1 ] /C1 [ 0 ] /N 1 >>
+	]
+	/Bounds [ 0.5 ]
+	/Encode [ 0 1 1 0 ]
+>>
+endobj
+`
+	/*
+	 * The first function rises from 0 to 1 on [0 0.5]. The second function falls from 1 to 0 on
+	 * [0.5 2], with the encoding reversing its input.
+	 */
+
+	parser := NewParserFromString(rawText)
+
+	obj, err := parser.ParseIndirectObject()
+	if err != nil {
+		t.Fatalf("Failed to parse indirect obj (%s)", err)
+	}
+
+	fun, err := newPdfFunctionFromPdfObject(obj)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+
+	testcases := []Type4TestCase{
+		{[]float64{-1}, []float64{0}},
+		{[]float64{0.25}, []float64{0.5}},
+		{[]float64{0.5}, []float64{0}},
+		{[]float64{1.25}, []float64{0.5}},
+		{[]float64{2}, []float64{1}},
+		{[]float64{3}, []float64{1}},
+	}
+
+	for _, testcase := range testcases {
+		outputs, err := fun.Evaluate(testcase.Inputs)
+		if err != nil {
+			t.Fatalf("Failed: %v", err)
+		}
+		if len(outputs) != len(testcase.Expected) {
+			t.Fatalf("Failed, output length mismatch")
+		}
+		for i := 0; i < len(outputs); i++ {
+			if math.Abs(outputs[i]-testcase.Expected[i]) > 0.000001 {
+				t.Errorf("%v: %v != %v", testcase.Inputs, outputs, testcase.Expected)
+			}
+		}
+	}
+}
+
+func TestType0FunctionNew(t *testing.T) {
+	// Two inputs on a 2x3 grid and two outputs: (x, y/2) at the grid points.
+	samples := []float64{0, 0, 1, 0, 0, 0.5, 1, 0.5, 0, 1, 1, 1}
+	if _, err := NewPdfFunctionType0([]float64{0, 1, 0, 2}, []float64{0, 1, 0, 2}, []int{2, 2}, samples); err == nil {
+		t.Errorf("Expected error for wrong number of samples")
+	}
+	fun, err := NewPdfFunctionType0([]float64{0, 1, 0, 2}, []float64{0, 1, 0, 2}, []int{2, 3}, samples)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+
+	// Evaluate after a round trip through the stream.
+	stream, ok := fun.ToPdfObject().(*PdfObjectStream)
+	if !ok {
+		t.Fatalf("Not a stream")
+	}
+	loaded, err := newPdfFunctionFromPdfObject(stream)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+
+	testcases := []Type4TestCase{
+		{[]float64{0, 0}, []float64{0, 0}},
+		{[]float64{1, 0}, []float64{1, 0}},
+		{[]float64{0, 1}, []float64{0, 0.5}},
+		{[]float64{1, 2}, []float64{1, 1}},
+	}
+	for _, testcase := range testcases {
+		outputs, err := loaded.Evaluate(testcase.Inputs)
+		if err != nil {
+			t.Fatalf("Failed: %v", err)
+		}
+		if len(outputs) != len(testcase.Expected) {
+			t.Fatalf("Failed, output length mismatch")
+		}
+		for i := 0; i < len(outputs); i++ {
+			if math.Abs(outputs[i]-testcase.Expected[i]) > 0.0001 {
+				t.Errorf("%v: %v != %v", testcase.Inputs, outputs, testcase.Expected)
+			}
+		}
+	}
+}
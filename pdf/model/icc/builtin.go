@@ -0,0 +1,119 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package icc
+
+import (
+	"errors"
+)
+
+// srgb is the sRGB profile.
+var srgb = newSRGBProfile()
+
+// SRGB returns the sRGB profile, with the D50 adapted primaries of version 4 profiles.
+func SRGB() *Profile {
+	return srgb
+}
+
+func newSRGBProfile() *Profile {
+	trc := paraCurve{typ: 3, g: 2.4, a: 1 / 1.055, b: 0.055 / 1.055, c: 1 / 12.92, d: 0.04045}
+	m := [9]float64{
+		0.4360747, 0.3850649, 0.1430804,
+		0.2225045, 0.7168786, 0.0606169,
+		0.0139322, 0.0971045, 0.7141733,
+	}
+	p, _ := newRGBProfile(m, [3]curve{trc, trc, trc}, d50)
+	p.description = "sRGB IEC61966-2.1"
+	return p
+}
+
+// NewCalRGBProfile returns the profile of the colors of a PDF CalRGB color space with white point
+// `white`, gamma values `gamma` and matrix `matrix` [XA YA ZA XB YB ZB XC YC ZC].
+func NewCalRGBProfile(white, gamma [3]float64, matrix [9]float64) (*Profile, error) {
+	if err := checkWhitePoint(white); err != nil {
+		return nil, err
+	}
+	var m [9]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[3*i+j] = matrix[3*j+i]
+		}
+	}
+	return newRGBProfile(multiply(adaptation(white, d50), m),
+		[3]curve{gammaCurve(gamma[0]), gammaCurve(gamma[1]), gammaCurve(gamma[2])}, white)
+}
+
+// NewCalGrayProfile returns the profile of the colors of a PDF CalGray color space with white point
+// `white` and gamma value `gamma`.
+func NewCalGrayProfile(white [3]float64, gamma float64) (*Profile, error) {
+	if err := checkWhitePoint(white); err != nil {
+		return nil, err
+	}
+	p := newProfile(ColorSpaceGray, white)
+	to, from := newGrayTRC(gammaCurve(gamma), ColorSpaceXYZ)
+	p.setPipelines(to, from)
+	return p, nil
+}
+
+// NewLabProfile returns the profile of the colors of a PDF Lab color space with white point `white`.
+// The components of the colors are encoded as L*/100, (a*+128)/255 and (b*+128)/255.
+func NewLabProfile(white [3]float64) (*Profile, error) {
+	if err := checkWhitePoint(white); err != nil {
+		return nil, err
+	}
+	p := newProfile(ColorSpaceLab, white)
+	m := adaptation(white, d50)
+	inv, _ := invert(m)
+	to := &pipeline{enc: pcsXYZ, stages: []stage{func(x []float64) []float64 {
+		xyz := transform(m, labToXYZ(100*x[0], 255*x[1]-128, 255*x[2]-128, white))
+		return xyz[:]
+	}}}
+	from := &pipeline{enc: pcsXYZ, stages: []stage{func(x []float64) []float64 {
+		l, a, b := xyzToLab(transform(inv, [3]float64{x[0], x[1], x[2]}), white)
+		return []float64{l / 100, (a + 128) / 255, (b + 128) / 255}
+	}}}
+	p.setPipelines(to, from)
+	return p, nil
+}
+
+// newRGBProfile returns the matrix/TRC profile of RGB colors with tone reproduction curves `trc`,
+// matrix `m` from linear RGB to D50 XYZ and media white point `white`.
+func newRGBProfile(m [9]float64, trc [3]curve, white [3]float64) (*Profile, error) {
+	to, from, err := newMatrixTRC(m, trc)
+	if err != nil {
+		return nil, err
+	}
+	p := newProfile(ColorSpaceRGB, white)
+	p.setPipelines(to, from)
+	return p, nil
+}
+
+// newProfile returns a version 4 color space profile of color space `cs` with an XYZ PCS and media
+// white point `white`, without conversions.
+func newProfile(cs string, white [3]float64) *Profile {
+	return &Profile{
+		Major:      4,
+		Class:      ClassColorSpace,
+		ColorSpace: cs,
+		PCS:        ColorSpaceXYZ,
+		Intent:     RelativeColorimetric,
+		WhitePoint: white,
+	}
+}
+
+// setPipelines sets `to` and `from` as the conversions of the profile for all intents.
+func (p *Profile) setPipelines(to, from *pipeline) {
+	for i := range p.toPCS {
+		p.toPCS[i], p.fromPCS[i] = to, from
+	}
+}
+
+// checkWhitePoint returns an error if `white` is not a valid white point.
+func checkWhitePoint(white [3]float64) error {
+	if white[0] <= 0 || white[1] <= 0 || white[2] <= 0 {
+		return errors.New("icc: invalid white point")
+	}
+	return nil
+}
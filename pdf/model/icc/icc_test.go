@@ -0,0 +1,370 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package icc
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// makeProfile returns the data of a display profile of color space `cs` and connection space `pcs`
+// with tags `tags`, in the order of `sigs`.
+func makeProfile(cs, pcs string, sigs []string, tags map[string][]byte) []byte {
+	header := make([]byte, 128)
+	header[8] = 4
+	copy(header[12:], ClassDisplay)
+	copy(header[16:], cs)
+	copy(header[20:], pcs)
+	copy(header[36:], "acsp")
+	binary.BigEndian.PutUint32(header[64:], uint32(RelativeColorimetric))
+
+	table := u32(uint32(len(sigs)))
+	var data []byte
+	offset := 128 + 4 + 12*len(sigs)
+	for _, sig := range sigs {
+		tag := tags[sig]
+		table = append(table, sig...)
+		table = append(table, u32(uint32(offset+len(data)))...)
+		table = append(table, u32(uint32(len(tag)))...)
+		data = append(data, tag...)
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+	}
+	profile := append(append(header, table...), data...)
+	binary.BigEndian.PutUint32(profile, uint32(len(profile)))
+	return profile
+}
+
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+func u16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return b
+}
+
+func fixed(v float64) []byte {
+	return u32(uint32(int32(math.Round(v * 65536))))
+}
+
+func xyzTag(x, y, z float64) []byte {
+	tag := append([]byte("XYZ \x00\x00\x00\x00"), fixed(x)...)
+	return append(append(tag, fixed(y)...), fixed(z)...)
+}
+
+func paraTag(typ int, params ...float64) []byte {
+	tag := append([]byte("para\x00\x00\x00\x00"), u16(uint16(typ))...)
+	tag = append(tag, 0, 0)
+	for _, p := range params {
+		tag = append(tag, fixed(p)...)
+	}
+	return tag
+}
+
+func gammaTag(gamma float64) []byte {
+	return append(append([]byte("curv\x00\x00\x00\x00"), u32(1)...), u16(uint16(gamma*256))...)
+}
+
+func identityTag() []byte {
+	return append([]byte("curv\x00\x00\x00\x00"), u32(0)...)
+}
+
+func descTag(s string) []byte {
+	tag := append([]byte("desc\x00\x00\x00\x00"), u32(uint32(len(s)+1))...)
+	return append(append(tag, s...), 0)
+}
+
+// clutData returns the 16-bit samples of a color lookup table with `in` inputs and `grid` points per
+// input of function `f`.
+func clutData(in, grid int, f func(x []float64) []float64) []byte {
+	count := 1
+	for i := 0; i < in; i++ {
+		count *= grid
+	}
+	var data []byte
+	x := make([]float64, in)
+	for k := 0; k < count; k++ {
+		// The last input varies fastest.
+		rem := k
+		for i := in - 1; i >= 0; i-- {
+			x[i] = float64(rem%grid) / float64(grid-1)
+			rem /= grid
+		}
+		for _, v := range f(x) {
+			data = append(data, u16(uint16(math.Round(clip(v)*65535)))...)
+		}
+	}
+	return data
+}
+
+// mft2Tag returns a lut16Type tag with identity tables and a color lookup table of `f`.
+func mft2Tag(in, out, grid int, f func(x []float64) []float64) []byte {
+	tag := []byte("mft2\x00\x00\x00\x00")
+	tag = append(tag, byte(in), byte(out), byte(grid), 0)
+	for i := 0; i < 9; i++ {
+		if i%4 == 0 {
+			tag = append(tag, fixed(1)...)
+		} else {
+			tag = append(tag, fixed(0)...)
+		}
+	}
+	tag = append(append(tag, u16(2)...), u16(2)...)
+	for i := 0; i < in; i++ {
+		tag = append(append(tag, u16(0)...), u16(65535)...)
+	}
+	tag = append(tag, clutData(in, grid, f)...)
+	for i := 0; i < out; i++ {
+		tag = append(append(tag, u16(0)...), u16(65535)...)
+	}
+	return tag
+}
+
+// lutTag returns a lutAToBType (`typ` "mAB ") or lutBToAType (`typ` "mBA ") tag with identity curves
+// and a color lookup table of `f`.
+func lutTag(typ string, in, out, grid int, f func(x []float64) []float64) []byte {
+	tag := []byte(typ + "\x00\x00\x00\x00")
+	tag = append(tag, byte(in), byte(out), 0, 0)
+	curves := func(n int) []byte {
+		var b []byte
+		for i := 0; i < n; i++ {
+			b = append(b, identityTag()...)
+		}
+		return b
+	}
+	deviceN := in
+	if typ == "mBA " {
+		deviceN = out
+	}
+	bCurves, aCurves := curves(3), curves(deviceN)
+	clutOffset := 32 + len(bCurves)
+	table := make([]byte, 20)
+	for i := 0; i < in; i++ {
+		table[i] = byte(grid)
+	}
+	table[16] = 2
+	table = append(table, clutData(in, grid, f)...)
+	aOffset := clutOffset + len(table)
+	for len(table)%4 != 0 {
+		table = append(table, 0)
+		aOffset++
+	}
+
+	tag = append(tag, u32(32)...)                 // B curves
+	tag = append(tag, u32(0)...)                  // Matrix
+	tag = append(tag, u32(0)...)                  // M curves
+	tag = append(tag, u32(uint32(clutOffset))...) // CLUT
+	tag = append(tag, u32(uint32(aOffset))...)    // A curves
+	tag = append(tag, bCurves...)
+	tag = append(tag, table...)
+	return append(tag, aCurves...)
+}
+
+// srgbTags are the matrix/TRC tags of an sRGB profile.
+func srgbTags(white [3]float64) ([]string, map[string][]byte) {
+	trc := paraTag(3, 2.4, 1/1.055, 0.055/1.055, 1/12.92, 0.04045)
+	return []string{"desc", "wtpt", "rXYZ", "gXYZ", "bXYZ", "rTRC", "gTRC", "bTRC"}, map[string][]byte{
+		"desc": descTag("Test sRGB"),
+		"wtpt": xyzTag(white[0], white[1], white[2]),
+		"rXYZ": xyzTag(0.4360747, 0.2225045, 0.0139322),
+		"gXYZ": xyzTag(0.3850649, 0.7168786, 0.0971045),
+		"bXYZ": xyzTag(0.1430804, 0.0606169, 0.7141733),
+		"rTRC": trc,
+		"gTRC": trc,
+		"bTRC": trc,
+	}
+}
+
+// srgbLab returns the L*a*b* values of sRGB color `rgb`, encoded as L*/100, (a*+128)/255 and
+// (b*+128)/255.
+func srgbLab(rgb []float64) []float64 {
+	pl := SRGB().toPCS[RelativeColorimetric]
+	return pcsLab8.fromXYZ(pl.enc.toXYZ(pl.apply(rgb)))
+}
+
+// checkTransform checks that the transform from `src` to `dst` with intent `intent` converts `in`
+// to `expected`, within `tolerance`.
+func checkTransform(t *testing.T, src, dst *Profile, intent RenderingIntent, in, expected []float64,
+	tolerance float64) {
+	tr, err := NewTransform(src, dst, intent)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	out, err := tr.Apply(in)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(out) != len(expected) {
+		t.Fatalf("%v -> %v, expected %v", in, out, expected)
+	}
+	for i := range out {
+		if math.Abs(out[i]-expected[i]) > tolerance {
+			t.Errorf("%v -> %v, expected %v", in, out, expected)
+			return
+		}
+	}
+}
+
+func TestParseMatrixTRC(t *testing.T) {
+	sigs, tags := srgbTags(d50)
+	p, err := Parse(makeProfile(ColorSpaceRGB, ColorSpaceXYZ, sigs, tags))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if p.Major != 4 || p.Class != ClassDisplay || p.ColorSpace != ColorSpaceRGB || p.PCS != ColorSpaceXYZ ||
+		p.Intent != RelativeColorimetric || p.NumComponents() != 3 || p.Description() != "Test sRGB" {
+		t.Errorf("Wrong header: %+v", p)
+	}
+
+	for _, rgb := range [][]float64{{0, 0, 0}, {1, 1, 1}, {1, 0, 0}, {0.2, 0.5, 0.8}, {0.9, 0.1, 0.4}} {
+		checkTransform(t, p, SRGB(), Perceptual, rgb, rgb, 0.002)
+		checkTransform(t, SRGB(), p, AbsoluteColorimetric, rgb, rgb, 0.002)
+	}
+}
+
+func TestParseGrayTRC(t *testing.T) {
+	p, err := Parse(makeProfile(ColorSpaceGray, ColorSpaceXYZ, []string{"kTRC"},
+		map[string][]byte{"kTRC": gammaTag(1)}))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// Linear gray 0.5 is sRGB 0.7354.
+	checkTransform(t, p, SRGB(), RelativeColorimetric, []float64{0.5}, []float64{0.7354, 0.7354, 0.7354}, 0.002)
+	checkTransform(t, SRGB(), p, RelativeColorimetric, []float64{0.7354, 0.7354, 0.7354}, []float64{0.5}, 0.002)
+}
+
+func TestAbsoluteColorimetric(t *testing.T) {
+	sigs, tags := srgbTags([3]float64{0.9505, 1, 1.089})
+	p, err := Parse(makeProfile(ColorSpaceRGB, ColorSpaceXYZ, sigs, tags))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	white := []float64{1, 1, 1}
+	checkTransform(t, p, SRGB(), RelativeColorimetric, white, white, 0.002)
+
+	// The white of the D65 media is bluish on D50 media.
+	tr, err := NewTransform(p, SRGB(), AbsoluteColorimetric)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	out, err := tr.Apply(white)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !(out[2] > out[0]+0.02) {
+		t.Errorf("Absolute white %v not bluish", out)
+	}
+}
+
+func TestParseLut16(t *testing.T) {
+	// The corners of the lookup table are exact sRGB colors in the legacy 16-bit Lab encoding.
+	a2b := mft2Tag(3, 3, 2, func(x []float64) []float64 {
+		lab := srgbLab(x)
+		return []float64{lab[0] / lab16Scale, lab[1] / lab16Scale, lab[2] / lab16Scale}
+	})
+	p, err := Parse(makeProfile(ColorSpaceRGB, ColorSpaceLab, []string{"A2B0"}, map[string][]byte{"A2B0": a2b}))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	for _, rgb := range [][]float64{{0, 0, 0}, {1, 1, 1}, {1, 0, 0}, {0, 1, 1}, {1, 0, 1}} {
+		checkTransform(t, p, SRGB(), Saturation, rgb, rgb, 0.002)
+	}
+
+	// There is no conversion to the colors of the profile.
+	if _, err := NewTransform(SRGB(), p, Perceptual); err == nil {
+		t.Errorf("Transform to profile without B2A tags")
+	}
+}
+
+func TestParseLutAToB(t *testing.T) {
+	// CMYK colors are converted as DeviceCMYK colors taken as sRGB colors.
+	a2b := lutTag("mAB ", 4, 3, 2, func(x []float64) []float64 {
+		return srgbLab([]float64{(1 - x[0]) * (1 - x[3]), (1 - x[1]) * (1 - x[3]), (1 - x[2]) * (1 - x[3])})
+	})
+	b2a := lutTag("mBA ", 3, 4, 17, func(x []float64) []float64 {
+		pl := SRGB().fromPCS[RelativeColorimetric]
+		rgb := pl.apply(pl.enc.fromXYZ(pcsLab8.toXYZ(x)))
+		return []float64{1 - rgb[0], 1 - rgb[1], 1 - rgb[2], 0}
+	})
+	p, err := Parse(makeProfile(ColorSpaceCMYK, ColorSpaceLab, []string{"A2B0", "B2A0"},
+		map[string][]byte{"A2B0": a2b, "B2A0": b2a}))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if p.NumComponents() != 4 {
+		t.Errorf("Wrong number of components %d", p.NumComponents())
+	}
+	checkTransform(t, p, SRGB(), RelativeColorimetric, []float64{1, 0, 0, 0}, []float64{0, 1, 1}, 0.002)
+	checkTransform(t, p, SRGB(), RelativeColorimetric, []float64{0, 0, 0, 1}, []float64{0, 0, 0}, 0.002)
+	checkTransform(t, SRGB(), p, RelativeColorimetric, []float64{1, 1, 1}, []float64{0, 0, 0, 0}, 0.02)
+	checkTransform(t, p, p, Perceptual, []float64{0, 0, 0, 0}, []float64{0, 0, 0, 0}, 0.02)
+}
+
+func TestCIEProfiles(t *testing.T) {
+	d65 := [3]float64{0.9505, 1, 1.089}
+	// The sRGB primaries under D65 with gamma 2.2.
+	calRGB, err := NewCalRGBProfile(d65, [3]float64{2.2, 2.2, 2.2}, [9]float64{
+		0.4124, 0.2126, 0.0193, 0.3576, 0.7152, 0.1192, 0.1805, 0.0722, 0.9505})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	checkTransform(t, calRGB, SRGB(), RelativeColorimetric, []float64{1, 1, 1}, []float64{1, 1, 1}, 0.005)
+	checkTransform(t, calRGB, SRGB(), RelativeColorimetric, []float64{1, 0, 0}, []float64{1, 0, 0}, 0.005)
+	checkTransform(t, calRGB, SRGB(), RelativeColorimetric, []float64{0.5, 0.5, 0.5}, []float64{0.504, 0.504, 0.504}, 0.005)
+
+	calGray, err := NewCalGrayProfile(d65, 1)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	checkTransform(t, calGray, SRGB(), RelativeColorimetric, []float64{0.5}, []float64{0.7354, 0.7354, 0.7354}, 0.002)
+
+	lab, err := NewLabProfile(d50)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// L* 50 is the luminance 0.1842, sRGB 0.4663.
+	checkTransform(t, lab, SRGB(), RelativeColorimetric, []float64{0.5, 128.0 / 255, 128.0 / 255},
+		[]float64{0.4663, 0.4663, 0.4663}, 0.002)
+	checkTransform(t, SRGB(), lab, RelativeColorimetric, []float64{1, 1, 1}, []float64{1, 128.0 / 255, 128.0 / 255}, 0.002)
+
+	if _, err := NewLabProfile([3]float64{0, 1, 1}); err == nil {
+		t.Errorf("Invalid white point accepted")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	sigs, tags := srgbTags(d50)
+	valid := makeProfile(ColorSpaceRGB, ColorSpaceXYZ, sigs, tags)
+
+	badSignature := append([]byte{}, valid...)
+	copy(badSignature[36:], "xxxx")
+	badPCS := append([]byte{}, valid...)
+	copy(badPCS[20:], "RGB ")
+	missingTag := makeProfile(ColorSpaceRGB, ColorSpaceXYZ, sigs[:len(sigs)-1], tags)
+
+	for name, data := range map[string][]byte{
+		"short":         valid[:100],
+		"signature":     badSignature,
+		"pcs":           badPCS,
+		"truncated":     valid[:len(valid)-8],
+		"missing tag":   missingTag,
+		"lut too short": makeProfile(ColorSpaceRGB, ColorSpaceLab, []string{"A2B0"}, map[string][]byte{"A2B0": []byte("mft2\x00\x00\x00\x00\x03\x03\x02\x00")}),
+	} {
+		if _, err := Parse(data); err == nil {
+			t.Errorf("%s: invalid profile accepted", name)
+		}
+	}
+
+	if IntentFromName("Perceptual") != Perceptual || IntentFromName("Unknown") != RelativeColorimetric ||
+		AbsoluteColorimetric.String() != "AbsoluteColorimetric" {
+		t.Errorf("Wrong intent names")
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package icc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Signatures of the color spaces of profiles: the data color spaces of devices and the profile
+// connection spaces (PCS).
+const (
+	ColorSpaceXYZ  = "XYZ "
+	ColorSpaceLab  = "Lab "
+	ColorSpaceGray = "GRAY"
+	ColorSpaceRGB  = "RGB "
+	ColorSpaceCMY  = "CMY "
+	ColorSpaceCMYK = "CMYK"
+)
+
+// Signatures of the profile classes.
+const (
+	ClassInput      = "scnr"
+	ClassDisplay    = "mntr"
+	ClassOutput     = "prtr"
+	ClassLink       = "link"
+	ClassColorSpace = "spac"
+	ClassAbstract   = "abst"
+	ClassNamedColor = "nmcl"
+)
+
+// d50 is the illuminant of the profile connection space.
+var d50 = [3]float64{0.9642, 1.0, 0.8249}
+
+// Profile is an ICC color profile, which defines the conversions between the colors of a device
+// space and the profile connection space (PCS), the CIE XYZ or L*a*b* colors under the D50
+// illuminant. Version 2 and 4 profiles with matrix/TRC or LUT based (lut8, lut16, lutAToB and
+// lutBToA) conversions are supported.
+type Profile struct {
+	Major, Minor int    // Version of the profile format.
+	Class        string // Profile class signature, such as "mntr".
+	ColorSpace   string // Data color space signature, such as "RGB ".
+	PCS          string // Profile connection space signature: "XYZ " or "Lab ".
+	// Intent is the rendering intent of the profile, which applies to profiles embedded in images.
+	Intent RenderingIntent
+	// WhitePoint is the XYZ media white point, the D50 illuminant by default.
+	WhitePoint [3]float64
+
+	description string
+	// Conversions from device colors to the PCS and back for the perceptual, relative colorimetric
+	// and saturation intents. Nil for unsupported intents.
+	toPCS   [3]*pipeline
+	fromPCS [3]*pipeline
+}
+
+// Parse parses the ICC profile `data`.
+func Parse(data []byte) (*Profile, error) {
+	if len(data) < 132 {
+		return nil, errors.New("icc: profile too short")
+	}
+	if string(data[36:40]) != "acsp" {
+		return nil, errors.New("icc: invalid profile signature")
+	}
+	p := &Profile{
+		Major:      int(data[8]),
+		Minor:      int(data[9] >> 4),
+		Class:      string(data[12:16]),
+		ColorSpace: string(data[16:20]),
+		PCS:        string(data[20:24]),
+		Intent:     RenderingIntent(binary.BigEndian.Uint32(data[64:]) & 3),
+		WhitePoint: d50,
+	}
+	if p.PCS != ColorSpaceXYZ && p.PCS != ColorSpaceLab {
+		return nil, fmt.Errorf("icc: unsupported profile connection space %q", p.PCS)
+	}
+	if numComponents(p.ColorSpace) == 0 {
+		return nil, fmt.Errorf("icc: unsupported color space %q", p.ColorSpace)
+	}
+
+	tags, err := readTagTable(data)
+	if err != nil {
+		return nil, err
+	}
+	if wtpt, ok := tags["wtpt"]; ok {
+		if p.WhitePoint, err = parseXYZ(wtpt); err != nil {
+			return nil, err
+		}
+	}
+	if desc, ok := tags["desc"]; ok {
+		p.description = parseText(desc)
+	}
+
+	for i, sig := range []string{"A2B0", "A2B1", "A2B2"} {
+		if tag, ok := tags[sig]; ok {
+			if p.toPCS[i], err = parseLut(tag, numComponents(p.ColorSpace), 3, p.PCS, false); err != nil {
+				return nil, fmt.Errorf("icc: tag %s: %v", sig, err)
+			}
+		}
+	}
+	for i, sig := range []string{"B2A0", "B2A1", "B2A2"} {
+		if tag, ok := tags[sig]; ok {
+			if p.fromPCS[i], err = parseLut(tag, 3, numComponents(p.ColorSpace), p.PCS, true); err != nil {
+				return nil, fmt.Errorf("icc: tag %s: %v", sig, err)
+			}
+		}
+	}
+
+	// Matrix/TRC conversions are used for intents without LUTs.
+	var to, from *pipeline
+	switch p.ColorSpace {
+	case ColorSpaceRGB:
+		if _, ok := tags["rXYZ"]; ok && p.PCS == ColorSpaceXYZ {
+			to, from, err = parseMatrixTRC(tags)
+		}
+	case ColorSpaceGray:
+		if _, ok := tags["kTRC"]; ok {
+			to, from, err = parseGrayTRC(tags, p.PCS)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range p.toPCS {
+		if p.toPCS[i] == nil {
+			p.toPCS[i] = to
+		}
+		if p.fromPCS[i] == nil {
+			p.fromPCS[i] = from
+		}
+	}
+	return p, nil
+}
+
+// NumComponents returns the number of components of the colors of the data color space of the profile.
+func (p *Profile) NumComponents() int {
+	return numComponents(p.ColorSpace)
+}
+
+// Description returns the description of the profile, which is empty if not given.
+func (p *Profile) Description() string {
+	return p.description
+}
+
+// String returns a short description of the profile for debugging.
+func (p *Profile) String() string {
+	return fmt.Sprintf("ICC v%d.%d %q %s->%s", p.Major, p.Minor, p.description,
+		strings.TrimSpace(p.ColorSpace), strings.TrimSpace(p.PCS))
+}
+
+// numComponents returns the number of components of the colors of color space signature `sig`, or
+// 0 if unknown.
+func numComponents(sig string) int {
+	switch sig {
+	case ColorSpaceGray:
+		return 1
+	case ColorSpaceXYZ, ColorSpaceLab, ColorSpaceRGB, ColorSpaceCMY, "Luv ", "YCbr", "Yxy ", "HSV ", "HLS ":
+		return 3
+	case ColorSpaceCMYK:
+		return 4
+	}
+	// Generic n color spaces, 2CLR to FCLR.
+	if len(sig) == 4 && sig[1:] == "CLR" {
+		if n := strings.IndexByte("23456789ABCDEF", sig[0]); n >= 0 {
+			return n + 2
+		}
+	}
+	return 0
+}
+
+// readTagTable returns the data of the tags of profile `data` by signature.
+func readTagTable(data []byte) (map[string][]byte, error) {
+	count := int(binary.BigEndian.Uint32(data[128:]))
+	if count < 0 || 132+12*count > len(data) {
+		return nil, errors.New("icc: tag table out of range")
+	}
+	tags := map[string][]byte{}
+	for i := 0; i < count; i++ {
+		entry := data[132+12*i:]
+		sig := string(entry[:4])
+		offset := int(binary.BigEndian.Uint32(entry[4:]))
+		size := int(binary.BigEndian.Uint32(entry[8:]))
+		if offset < 0 || size < 8 || offset+size > len(data) || offset+size < offset {
+			return nil, fmt.Errorf("icc: tag %q out of range", sig)
+		}
+		tags[sig] = data[offset : offset+size]
+	}
+	return tags, nil
+}
+
+// parseXYZ returns the first XYZ value of XYZType tag `tag`.
+func parseXYZ(tag []byte) ([3]float64, error) {
+	var xyz [3]float64
+	if len(tag) < 20 || string(tag[:4]) != "XYZ " {
+		return xyz, errors.New("icc: invalid XYZ tag")
+	}
+	for i := range xyz {
+		xyz[i] = s15Fixed16(tag[8+4*i:])
+	}
+	return xyz, nil
+}
+
+// parseText returns the text of textDescriptionType (version 2), multiLocalizedUnicodeType
+// (version 4) or textType tag `tag`. The first record of multi-localized tags is returned.
+func parseText(tag []byte) string {
+	switch string(tag[:4]) {
+	case "desc":
+		if len(tag) < 12 {
+			return ""
+		}
+		n := int(binary.BigEndian.Uint32(tag[8:]))
+		if n < 0 || 12+n > len(tag) {
+			return ""
+		}
+		return strings.TrimRight(string(tag[12:12+n]), "\x00")
+	case "mluc":
+		if len(tag) < 28 || binary.BigEndian.Uint32(tag[8:]) == 0 {
+			return ""
+		}
+		n := int(binary.BigEndian.Uint32(tag[20:]))
+		offset := int(binary.BigEndian.Uint32(tag[24:]))
+		if n < 0 || offset < 0 || offset+n > len(tag) || offset+n < offset {
+			return ""
+		}
+		units := make([]uint16, n/2)
+		for i := range units {
+			units[i] = binary.BigEndian.Uint16(tag[offset+2*i:])
+		}
+		return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+	case "text":
+		return strings.TrimRight(string(tag[8:]), "\x00")
+	}
+	return ""
+}
+
+// s15Fixed16 returns the s15Fixed16Number at the start of `b`.
+func s15Fixed16(b []byte) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b))) / 65536
+}
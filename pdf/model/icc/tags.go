@@ -0,0 +1,584 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package icc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// stage is a step of a conversion between device colors and the PCS.
+type stage func(x []float64) []float64
+
+// pipeline is a conversion between device colors and the PCS, with PCS values in encoding `enc`.
+type pipeline struct {
+	stages []stage
+	enc    pcsEncoding
+}
+
+// apply returns the result of the stages of the pipeline applied to `x`.
+func (pl *pipeline) apply(x []float64) []float64 {
+	for _, s := range pl.stages {
+		x = s(x)
+	}
+	return x
+}
+
+// curve is a one-dimensional function of the range 0 to 1, such as a tone reproduction curve (TRC).
+type curve interface {
+	eval(x float64) float64
+}
+
+// gammaCurve is the curve x^gamma.
+type gammaCurve float64
+
+func (c gammaCurve) eval(x float64) float64 {
+	return math.Pow(clip(x), float64(c))
+}
+
+// tableCurve is a curve linearly interpolated between samples at equal intervals.
+type tableCurve []float64
+
+func (c tableCurve) eval(x float64) float64 {
+	pos := clip(x) * float64(len(c)-1)
+	i := int(pos)
+	if i >= len(c)-1 {
+		return c[len(c)-1]
+	}
+	return c[i] + (pos-float64(i))*(c[i+1]-c[i])
+}
+
+// paraCurve is a parametric curve of one of the five function types of parametricCurveType tags.
+type paraCurve struct {
+	typ                 int
+	g, a, b, c, d, e, f float64
+}
+
+func (c paraCurve) eval(x float64) float64 {
+	x = clip(x)
+	var y float64
+	switch c.typ {
+	case 0:
+		y = math.Pow(x, c.g)
+	case 1:
+		if c.a != 0 && x >= -c.b/c.a {
+			y = math.Pow(c.a*x+c.b, c.g)
+		}
+	case 2:
+		y = c.c
+		if c.a != 0 && x >= -c.b/c.a {
+			y += math.Pow(c.a*x+c.b, c.g)
+		}
+	case 3:
+		if x >= c.d {
+			y = math.Pow(c.a*x+c.b, c.g)
+		} else {
+			y = c.c * x
+		}
+	case 4:
+		if x >= c.d {
+			y = math.Pow(c.a*x+c.b, c.g) + c.e
+		} else {
+			y = c.c*x + c.f
+		}
+	}
+	return clip(y)
+}
+
+// inverseCurveSamples is the number of samples of inverted curves.
+const inverseCurveSamples = 4096
+
+// inverseCurve is the inverse of a monotonic curve, interpolated between samples of the curve.
+type inverseCurve []float64
+
+// newInverseCurve returns the inverse of monotonic curve `c`.
+func newInverseCurve(c curve) inverseCurve {
+	ys := make(inverseCurve, inverseCurveSamples)
+	for i := range ys {
+		ys[i] = c.eval(float64(i) / float64(len(ys)-1))
+	}
+	return ys
+}
+
+func (ys inverseCurve) eval(y float64) float64 {
+	n := len(ys)
+	increasing := ys[n-1] >= ys[0]
+	// First sample at or beyond `y`.
+	i := sort.Search(n, func(i int) bool {
+		if increasing {
+			return ys[i] >= y
+		}
+		return ys[i] <= y
+	})
+	if i == 0 {
+		return 0
+	}
+	if i == n {
+		return 1
+	}
+	x := float64(i - 1)
+	if ys[i] != ys[i-1] {
+		x += (y - ys[i-1]) / (ys[i] - ys[i-1])
+	}
+	return x / float64(n-1)
+}
+
+// curveStage returns the stage that applies `curves` to the corresponding components.
+func curveStage(curves []curve) stage {
+	return func(x []float64) []float64 {
+		y := make([]float64, len(curves))
+		for i, c := range curves {
+			y[i] = c.eval(x[i])
+		}
+		return y
+	}
+}
+
+// matrixStage returns the stage that multiplies 3 components by 3x3 matrix `m`, in row order, and
+// adds `offset`.
+func matrixStage(m [9]float64, offset [3]float64) stage {
+	return func(x []float64) []float64 {
+		y := make([]float64, 3)
+		for i := range y {
+			y[i] = m[3*i]*x[0] + m[3*i+1]*x[1] + m[3*i+2]*x[2] + offset[i]
+		}
+		return y
+	}
+}
+
+// clut is a color lookup table: a grid of output values over the inputs, in which the last input
+// varies fastest.
+type clut struct {
+	grid   []int
+	out    int
+	values []float64
+}
+
+// eval returns the outputs of the table at inputs `x`, multilinearly interpolated.
+func (t *clut) eval(x []float64) []float64 {
+	n := len(t.grid)
+	base := make([]int, n)
+	frac := make([]float64, n)
+	stride := make([]int, n)
+	s := t.out
+	for i := n - 1; i >= 0; i-- {
+		stride[i] = s
+		s *= t.grid[i]
+		pos := clip(x[i]) * float64(t.grid[i]-1)
+		base[i] = int(pos)
+		if base[i] >= t.grid[i]-1 {
+			base[i] = t.grid[i] - 1
+		}
+		frac[i] = pos - float64(base[i])
+	}
+
+	y := make([]float64, t.out)
+	for corner := 0; corner < 1<<uint(n); corner++ {
+		weight := 1.0
+		offset := 0
+		for i := 0; i < n && weight != 0; i++ {
+			if corner>>uint(n-1-i)&1 != 0 {
+				weight *= frac[i]
+				offset += (base[i] + 1) * stride[i]
+			} else {
+				weight *= 1 - frac[i]
+				offset += base[i] * stride[i]
+			}
+		}
+		if weight == 0 {
+			continue
+		}
+		for j := range y {
+			y[j] += weight * t.values[offset+j]
+		}
+	}
+	return y
+}
+
+// readCLUT returns the color lookup table with `grid` points per input and `out` outputs at the
+// start of `data`, with samples of `size` bytes, and the rest of the data.
+func readCLUT(data []byte, grid []int, out, size int) (*clut, []byte, error) {
+	count := out
+	for _, g := range grid {
+		if g < 1 || count > len(data) {
+			return nil, nil, errors.New("invalid color lookup table")
+		}
+		count *= g
+	}
+	if count*size > len(data) {
+		return nil, nil, errors.New("color lookup table out of range")
+	}
+	values := readNormalized(data, count, size)
+	return &clut{grid: grid, out: out, values: values}, data[count*size:], nil
+}
+
+// readNormalized returns `count` unsigned values of `size` bytes of `data`, normalized to the range
+// 0 to 1. The data must be long enough.
+func readNormalized(data []byte, count, size int) []float64 {
+	values := make([]float64, count)
+	for i := range values {
+		if size == 1 {
+			values[i] = float64(data[i]) / 255
+		} else {
+			values[i] = float64(binary.BigEndian.Uint16(data[2*i:])) / 65535
+		}
+	}
+	return values
+}
+
+// readTables returns `n` curves of `entries` samples of `size` bytes at the start of `data`, and
+// the rest of the data.
+func readTables(data []byte, n, entries, size int) ([]curve, []byte, error) {
+	if entries < 2 || n*entries*size > len(data) {
+		return nil, nil, errors.New("lookup tables out of range")
+	}
+	curves := make([]curve, n)
+	for i := range curves {
+		curves[i] = tableCurve(readNormalized(data[i*entries*size:], entries, size))
+	}
+	return curves, data[n*entries*size:], nil
+}
+
+// parseCurve returns the curve of curveType or parametricCurveType data `b`, and its length in bytes.
+func parseCurve(b []byte) (curve, int, error) {
+	if len(b) < 12 {
+		return nil, 0, errors.New("curve too short")
+	}
+	switch string(b[:4]) {
+	case "curv":
+		n := int(binary.BigEndian.Uint32(b[8:]))
+		if n < 0 || 12+2*n > len(b) {
+			return nil, 0, errors.New("curve out of range")
+		}
+		switch n {
+		case 0:
+			return gammaCurve(1), 12, nil
+		case 1:
+			return gammaCurve(float64(binary.BigEndian.Uint16(b[12:])) / 256), 14, nil
+		}
+		return tableCurve(readNormalized(b[12:], n, 2)), 12 + 2*n, nil
+	case "para":
+		typ := int(binary.BigEndian.Uint16(b[8:]))
+		if typ > 4 {
+			return nil, 0, fmt.Errorf("unsupported parametric curve type %d", typ)
+		}
+		numParams := []int{1, 3, 4, 5, 7}[typ]
+		if 12+4*numParams > len(b) {
+			return nil, 0, errors.New("parametric curve out of range")
+		}
+		var params [7]float64
+		for i := 0; i < numParams; i++ {
+			params[i] = s15Fixed16(b[12+4*i:])
+		}
+		c := paraCurve{typ: typ, g: params[0], a: params[1], b: params[2], c: params[3], d: params[4],
+			e: params[5], f: params[6]}
+		return c, 12 + 4*numParams, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported curve type %q", b[:4])
+}
+
+// parseCurves returns the `n` curves at `offset` of tag `tag`, each starting at a 4-byte boundary.
+func parseCurves(tag []byte, offset, n int) ([]curve, error) {
+	curves := make([]curve, n)
+	for i := range curves {
+		if offset < 0 || offset >= len(tag) {
+			return nil, errors.New("curves out of range")
+		}
+		c, size, err := parseCurve(tag[offset:])
+		if err != nil {
+			return nil, err
+		}
+		curves[i] = c
+		offset += (size + 3) / 4 * 4
+	}
+	return curves, nil
+}
+
+// parseMatrix returns the 3x3 matrix, and the offsets if `withOffset`, of s15Fixed16 numbers at the
+// start of `b`.
+func parseMatrix(b []byte, withOffset bool) ([9]float64, [3]float64, error) {
+	var m [9]float64
+	var offset [3]float64
+	size := 36
+	if withOffset {
+		size = 48
+	}
+	if len(b) < size {
+		return m, offset, errors.New("matrix out of range")
+	}
+	for i := range m {
+		m[i] = s15Fixed16(b[4*i:])
+	}
+	if withOffset {
+		for i := range offset {
+			offset[i] = s15Fixed16(b[36+4*i:])
+		}
+	}
+	return m, offset, nil
+}
+
+// parseLut returns the pipeline of LUT tag `tag`, with `in` inputs and `out` outputs, of a profile
+// with connection space `pcs`. `fromPCS` is true for conversions from the PCS.
+func parseLut(tag []byte, in, out int, pcs string, fromPCS bool) (*pipeline, error) {
+	if len(tag) < 32 {
+		return nil, errors.New("lut too short")
+	}
+	typ := string(tag[:4])
+	if int(tag[8]) != in || int(tag[9]) != out {
+		return nil, fmt.Errorf("lut has %d inputs and %d outputs, expecting %d and %d", tag[8], tag[9], in, out)
+	}
+
+	pl := &pipeline{enc: pcsXYZ16}
+	if pcs == ColorSpaceLab {
+		pl.enc = pcsLab8
+		if typ == "mft2" {
+			pl.enc = pcsLab16
+		}
+	}
+
+	switch typ {
+	case "mft1", "mft2":
+		if len(tag) < 52 {
+			return nil, errors.New("lut too short")
+		}
+		// The matrix only applies to XYZ inputs.
+		if fromPCS && pcs == ColorSpaceXYZ {
+			m, _, err := parseMatrix(tag[12:], false)
+			if err != nil {
+				return nil, err
+			}
+			pl.stages = append(pl.stages, matrixStage(m, [3]float64{}))
+		}
+		size, inEntries, outEntries, data := 1, 256, 256, tag[48:]
+		if typ == "mft2" {
+			size = 2
+			inEntries = int(binary.BigEndian.Uint16(tag[48:]))
+			outEntries = int(binary.BigEndian.Uint16(tag[50:]))
+			data = tag[52:]
+		}
+		inCurves, data, err := readTables(data, in, inEntries, size)
+		if err != nil {
+			return nil, err
+		}
+		grid := make([]int, in)
+		for i := range grid {
+			grid[i] = int(tag[10])
+		}
+		table, data, err := readCLUT(data, grid, out, size)
+		if err != nil {
+			return nil, err
+		}
+		outCurves, _, err := readTables(data, out, outEntries, size)
+		if err != nil {
+			return nil, err
+		}
+		pl.stages = append(pl.stages, curveStage(inCurves), table.eval, curveStage(outCurves))
+		return pl, nil
+
+	case "mAB ", "mBA ":
+		offsets := make([]int, 5)
+		for i := range offsets {
+			offsets[i] = int(binary.BigEndian.Uint32(tag[12+4*i:]))
+		}
+		bOffset, matrixOffset, mOffset, clutOffset, aOffset := offsets[0], offsets[1], offsets[2], offsets[3],
+			offsets[4]
+		if bOffset == 0 {
+			return nil, errors.New("lut missing B curves")
+		}
+
+		// The CLUT is between the A curves on the device side and the M curves on the PCS side.
+		deviceN := in
+		if typ == "mBA " {
+			deviceN = out
+		}
+		var aCurves, mCurves []curve
+		var table *clut
+		bCurves, err := parseCurves(tag, bOffset, 3)
+		if err != nil {
+			return nil, err
+		}
+		if aOffset != 0 {
+			if aCurves, err = parseCurves(tag, aOffset, deviceN); err != nil {
+				return nil, err
+			}
+		}
+		if mOffset != 0 {
+			if mCurves, err = parseCurves(tag, mOffset, 3); err != nil {
+				return nil, err
+			}
+		}
+		if clutOffset != 0 {
+			if clutOffset < 0 || clutOffset+20 > len(tag) {
+				return nil, errors.New("color lookup table out of range")
+			}
+			var grid []int
+			for i := 0; i < in; i++ {
+				grid = append(grid, int(tag[clutOffset+i]))
+			}
+			size := int(tag[clutOffset+16])
+			if size != 1 && size != 2 {
+				return nil, fmt.Errorf("invalid color lookup table precision %d", size)
+			}
+			if table, _, err = readCLUT(tag[clutOffset+20:], grid, out, size); err != nil {
+				return nil, err
+			}
+		} else if in != out {
+			return nil, errors.New("lut missing color lookup table")
+		}
+		var matrix stage
+		if matrixOffset != 0 {
+			if matrixOffset < 0 || matrixOffset > len(tag) {
+				return nil, errors.New("matrix out of range")
+			}
+			m, offset, err := parseMatrix(tag[matrixOffset:], true)
+			if err != nil {
+				return nil, err
+			}
+			matrix = matrixStage(m, offset)
+		}
+
+		var stages []stage
+		add := func(s stage) {
+			if s != nil {
+				stages = append(stages, s)
+			}
+		}
+		addCurves := func(curves []curve) {
+			if curves != nil {
+				stages = append(stages, curveStage(curves))
+			}
+		}
+		if typ == "mAB " {
+			addCurves(aCurves)
+			if table != nil {
+				add(table.eval)
+			}
+			addCurves(mCurves)
+			add(matrix)
+			addCurves(bCurves)
+		} else {
+			addCurves(bCurves)
+			add(matrix)
+			addCurves(mCurves)
+			if table != nil {
+				add(table.eval)
+			}
+			addCurves(aCurves)
+		}
+		pl.stages = stages
+		return pl, nil
+	}
+	return nil, fmt.Errorf("unsupported lut type %q", typ)
+}
+
+// parseMatrixTRC returns the conversions to and from the XYZ PCS of the matrix/TRC tags of an RGB
+// profile, of which `tags` are the tags.
+func parseMatrixTRC(tags map[string][]byte) (*pipeline, *pipeline, error) {
+	var columns [3][3]float64
+	var trc [3]curve
+	for i, c := range []string{"r", "g", "b"} {
+		xyz, ok := tags[c+"XYZ"]
+		if !ok {
+			return nil, nil, fmt.Errorf("icc: missing %sXYZ tag", c)
+		}
+		var err error
+		if columns[i], err = parseXYZ(xyz); err != nil {
+			return nil, nil, err
+		}
+		t, ok := tags[c+"TRC"]
+		if !ok {
+			return nil, nil, fmt.Errorf("icc: missing %sTRC tag", c)
+		}
+		if trc[i], _, err = parseCurve(t); err != nil {
+			return nil, nil, fmt.Errorf("icc: tag %sTRC: %v", c, err)
+		}
+	}
+	var m [9]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[3*i+j] = columns[j][i]
+		}
+	}
+	return newMatrixTRC(m, trc)
+}
+
+// newMatrixTRC returns the conversions to and from the XYZ PCS of RGB colors with tone reproduction
+// curves `trc` and matrix `m` from linear RGB to XYZ.
+func newMatrixTRC(m [9]float64, trc [3]curve) (*pipeline, *pipeline, error) {
+	inv, ok := invert(m)
+	if !ok {
+		return nil, nil, errors.New("icc: singular colorant matrix")
+	}
+	inverseTRC := make([]curve, 3)
+	for i, c := range trc {
+		inverseTRC[i] = newInverseCurve(c)
+	}
+	to := &pipeline{stages: []stage{curveStage(trc[:]), matrixStage(m, [3]float64{})}, enc: pcsXYZ}
+	from := &pipeline{stages: []stage{matrixStage(inv, [3]float64{}), curveStage(inverseTRC)}, enc: pcsXYZ}
+	return to, from, nil
+}
+
+// parseGrayTRC returns the conversions to and from PCS `pcs` of the gray TRC tag of a gray profile,
+// of which `tags` are the tags.
+func parseGrayTRC(tags map[string][]byte, pcs string) (*pipeline, *pipeline, error) {
+	trc, _, err := parseCurve(tags["kTRC"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("icc: tag kTRC: %v", err)
+	}
+	to, from := newGrayTRC(trc, pcs)
+	return to, from, nil
+}
+
+// newGrayTRC returns the conversions to and from PCS `pcs` of gray levels with tone reproduction
+// curve `trc`, which gives the luminance Y for XYZ connection spaces or L*/100 for L*a*b*.
+func newGrayTRC(trc curve, pcs string) (*pipeline, *pipeline) {
+	inverse := newInverseCurve(trc)
+	if pcs == ColorSpaceLab {
+		to := &pipeline{enc: pcsLab, stages: []stage{func(x []float64) []float64 {
+			return []float64{100 * trc.eval(x[0]), 0, 0}
+		}}}
+		from := &pipeline{enc: pcsLab, stages: []stage{func(x []float64) []float64 {
+			return []float64{inverse.eval(x[0] / 100)}
+		}}}
+		return to, from
+	}
+	to := &pipeline{enc: pcsXYZ, stages: []stage{func(x []float64) []float64 {
+		y := trc.eval(x[0])
+		return []float64{y * d50[0], y, y * d50[2]}
+	}}}
+	from := &pipeline{enc: pcsXYZ, stages: []stage{func(x []float64) []float64 {
+		return []float64{inverse.eval(x[1])}
+	}}}
+	return to, from
+}
+
+// invert returns the inverse of 3x3 matrix `m` in row order. The bool return flag is false if `m`
+// is singular.
+func invert(m [9]float64) ([9]float64, bool) {
+	det := m[0]*(m[4]*m[8]-m[5]*m[7]) - m[1]*(m[3]*m[8]-m[5]*m[6]) + m[2]*(m[3]*m[7]-m[4]*m[6])
+	if math.Abs(det) < 1e-12 {
+		return [9]float64{}, false
+	}
+	return [9]float64{
+		(m[4]*m[8] - m[5]*m[7]) / det,
+		(m[2]*m[7] - m[1]*m[8]) / det,
+		(m[1]*m[5] - m[2]*m[4]) / det,
+		(m[5]*m[6] - m[3]*m[8]) / det,
+		(m[0]*m[8] - m[2]*m[6]) / det,
+		(m[2]*m[3] - m[0]*m[5]) / det,
+		(m[3]*m[7] - m[4]*m[6]) / det,
+		(m[1]*m[6] - m[0]*m[7]) / det,
+		(m[0]*m[4] - m[1]*m[3]) / det,
+	}, true
+}
+
+// clip clips `x` to the range 0 to 1.
+func clip(x float64) float64 {
+	return math.Min(math.Max(x, 0), 1)
+}
@@ -0,0 +1,241 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package icc
+
+import (
+	"fmt"
+	"math"
+)
+
+// RenderingIntent is the rendering intent of a color conversion, which determines how colors
+// outside the gamut of the destination device are handled.
+type RenderingIntent int
+
+// The rendering intents, numbered as in ICC profiles.
+const (
+	Perceptual RenderingIntent = iota
+	RelativeColorimetric
+	Saturation
+	AbsoluteColorimetric
+)
+
+// IntentFromName returns the rendering intent of PDF name `name`, such as the value of the /Intent
+// entry of images and ri operations. Unknown names give RelativeColorimetric, the default.
+func IntentFromName(name string) RenderingIntent {
+	switch name {
+	case "Perceptual":
+		return Perceptual
+	case "Saturation":
+		return Saturation
+	case "AbsoluteColorimetric":
+		return AbsoluteColorimetric
+	}
+	return RelativeColorimetric
+}
+
+// String returns the PDF name of the rendering intent.
+func (ri RenderingIntent) String() string {
+	switch ri {
+	case Perceptual:
+		return "Perceptual"
+	case Saturation:
+		return "Saturation"
+	case AbsoluteColorimetric:
+		return "AbsoluteColorimetric"
+	}
+	return "RelativeColorimetric"
+}
+
+// Transform converts colors from the device space of one profile to the device space of another.
+type Transform struct {
+	in    int
+	to    *pipeline
+	from  *pipeline
+	scale [3]float64 // Scaling of the PCS XYZ values between the media white points.
+}
+
+// NewTransform returns the transform from the colors of profile `src` to those of profile `dst`
+// with rendering intent `intent`. The conversions of the intent are used where the profiles have
+// them, otherwise the perceptual ones, or the matrix/TRC ones of matrix/TRC profiles. Absolute
+// colorimetric transforms use the relative colorimetric conversions and preserve the media white
+// points of the profiles.
+func NewTransform(src, dst *Profile, intent RenderingIntent) (*Transform, error) {
+	t := &Transform{
+		in:    src.NumComponents(),
+		to:    selectPipeline(src.toPCS, intent),
+		from:  selectPipeline(dst.fromPCS, intent),
+		scale: [3]float64{1, 1, 1},
+	}
+	if t.to == nil {
+		return nil, fmt.Errorf("icc: no conversion from %s colors", src)
+	}
+	if t.from == nil {
+		return nil, fmt.Errorf("icc: no conversion to %s colors", dst)
+	}
+	if intent == AbsoluteColorimetric {
+		for i := range t.scale {
+			if dst.WhitePoint[i] > 0 {
+				t.scale[i] = src.WhitePoint[i] / dst.WhitePoint[i]
+			}
+		}
+	}
+	return t, nil
+}
+
+// selectPipeline returns the conversion of `pipelines` for `intent`.
+func selectPipeline(pipelines [3]*pipeline, intent RenderingIntent) *pipeline {
+	i := int(intent)
+	if intent == AbsoluteColorimetric {
+		i = int(RelativeColorimetric)
+	}
+	if i >= 0 && i < len(pipelines) && pipelines[i] != nil {
+		return pipelines[i]
+	}
+	for _, pl := range pipelines {
+		if pl != nil {
+			return pl
+		}
+	}
+	return nil
+}
+
+// Apply returns the color with components `vals` in the source space converted to the destination
+// space. The components of the device spaces are in the range 0 to 1, as are those of L*a*b* spaces
+// encoded as L*/100, (a*+128)/255 and (b*+128)/255.
+func (t *Transform) Apply(vals []float64) ([]float64, error) {
+	if len(vals) != t.in {
+		return nil, fmt.Errorf("icc: got %d color components, expecting %d", len(vals), t.in)
+	}
+	x := make([]float64, len(vals))
+	for i, v := range vals {
+		x[i] = clip(v)
+	}
+	xyz := t.to.enc.toXYZ(t.to.apply(x))
+	for i := range xyz {
+		xyz[i] *= t.scale[i]
+	}
+	out := t.from.apply(t.from.enc.fromXYZ(xyz))
+	for i, v := range out {
+		out[i] = clip(v)
+	}
+	return out, nil
+}
+
+// pcsEncoding is the encoding of PCS values of a conversion.
+type pcsEncoding int
+
+const (
+	pcsXYZ   pcsEncoding = iota // XYZ values.
+	pcsLab                      // L*a*b* values.
+	pcsXYZ16                    // XYZ values divided by 65535/32768, as in LUTs.
+	pcsLab8                     // L*/100, (a*+128)/255 and (b*+128)/255, as in lut8 and version 4 LUTs.
+	pcsLab16                    // L*a*b* values in the legacy 16-bit encoding of lut16 LUTs.
+)
+
+// Scale factors of PCS encodings.
+const (
+	xyz16Scale = 65535.0 / 32768
+	lab16Scale = 65535.0 / 65280
+)
+
+// toXYZ returns the XYZ PCS value of PCS value `v` in encoding `enc`.
+func (enc pcsEncoding) toXYZ(v []float64) [3]float64 {
+	switch enc {
+	case pcsLab:
+		return labToXYZ(v[0], v[1], v[2], d50)
+	case pcsXYZ16:
+		return [3]float64{v[0] * xyz16Scale, v[1] * xyz16Scale, v[2] * xyz16Scale}
+	case pcsLab8:
+		return labToXYZ(100*v[0], 255*v[1]-128, 255*v[2]-128, d50)
+	case pcsLab16:
+		return labToXYZ(100*lab16Scale*v[0], 255*lab16Scale*v[1]-128, 255*lab16Scale*v[2]-128, d50)
+	}
+	return [3]float64{v[0], v[1], v[2]}
+}
+
+// fromXYZ returns XYZ PCS value `xyz` in encoding `enc`.
+func (enc pcsEncoding) fromXYZ(xyz [3]float64) []float64 {
+	switch enc {
+	case pcsLab:
+		l, a, b := xyzToLab(xyz, d50)
+		return []float64{l, a, b}
+	case pcsXYZ16:
+		return []float64{xyz[0] / xyz16Scale, xyz[1] / xyz16Scale, xyz[2] / xyz16Scale}
+	case pcsLab8:
+		l, a, b := xyzToLab(xyz, d50)
+		return []float64{l / 100, (a + 128) / 255, (b + 128) / 255}
+	case pcsLab16:
+		l, a, b := xyzToLab(xyz, d50)
+		return []float64{l / 100 / lab16Scale, (a + 128) / 255 / lab16Scale, (b + 128) / 255 / lab16Scale}
+	}
+	return []float64{xyz[0], xyz[1], xyz[2]}
+}
+
+// labToXYZ returns the XYZ value of L*a*b* value `l`, `a`, `b` relative to white point `white`.
+func labToXYZ(l, a, b float64, white [3]float64) [3]float64 {
+	finv := func(t float64) float64 {
+		if t > 6.0/29 {
+			return t * t * t
+		}
+		return 3 * (6.0 / 29) * (6.0 / 29) * (t - 4.0/29)
+	}
+	fy := (l + 16) / 116
+	return [3]float64{
+		white[0] * finv(fy+a/500),
+		white[1] * finv(fy),
+		white[2] * finv(fy-b/200),
+	}
+}
+
+// xyzToLab returns the L*a*b* value of XYZ value `xyz` relative to white point `white`.
+func xyzToLab(xyz, white [3]float64) (float64, float64, float64) {
+	f := func(t float64) float64 {
+		if t > (6.0/29)*(6.0/29)*(6.0/29) {
+			return math.Cbrt(t)
+		}
+		return t/(3*(6.0/29)*(6.0/29)) + 4.0/29
+	}
+	fx, fy, fz := f(xyz[0]/white[0]), f(xyz[1]/white[1]), f(xyz[2]/white[2])
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// bradford is the Bradford cone response matrix of chromatic adaptations.
+var bradford = [9]float64{
+	0.8951, 0.2664, -0.1614,
+	-0.7502, 1.7135, 0.0367,
+	0.0389, -0.0685, 1.0296,
+}
+
+// adaptation returns the Bradford chromatic adaptation matrix from white point `src` to white
+// point `dst`.
+func adaptation(src, dst [3]float64) [9]float64 {
+	s, d := transform(bradford, src), transform(bradford, dst)
+	scale := [9]float64{d[0] / s[0], 0, 0, 0, d[1] / s[1], 0, 0, 0, d[2] / s[2]}
+	inv, _ := invert(bradford)
+	return multiply(inv, multiply(scale, bradford))
+}
+
+// transform returns the product of 3x3 matrix `m` in row order and vector `v`.
+func transform(m [9]float64, v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0]*v[0] + m[1]*v[1] + m[2]*v[2],
+		m[3]*v[0] + m[4]*v[1] + m[5]*v[2],
+		m[6]*v[0] + m[7]*v[1] + m[8]*v[2],
+	}
+}
+
+// multiply returns the product of 3x3 matrices `a` and `b` in row order.
+func multiply(a, b [9]float64) [9]float64 {
+	var m [9]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				m[3*i+j] += a[3*i+k] * b[3*k+j]
+			}
+		}
+	}
+	return m
+}
@@ -29,7 +29,7 @@ type Image struct {
 	Data             []byte // Image data stored as bytes.
 
 	// Transparency data: alpha channel.
-	// Stored in same bits per component as original data with 1 color component.
+	// Stored with 8 bits per pixel, from 0 (transparent) to 255 (opaque).
 	alphaData []byte // Alpha channel data.
 	hasAlpha  bool   // Indicates whether the alpha channel data is available.
 
@@ -129,12 +129,21 @@ func (this *Image) Resample(targetBitsPerComponent int64) {
 }
 
 // Converts the unidoc Image to a golang Image structure.
+// Images with an alpha channel, such as those of image XObjects with soft masks or masks, are
+// converted to image.NRGBA or image.NRGBA64 images.
 func (this *Image) ToGoImage() (goimage.Image, error) {
 	common.Log.Trace("Converting to go image")
 	bounds := goimage.Rect(0, 0, int(this.Width), int(this.Height))
 	var img DrawableImage
 
-	if this.ColorComponents == 1 {
+	hasAlpha := this.hasAlpha && len(this.alphaData) >= int(this.Width)*int(this.Height)
+	if hasAlpha && (this.ColorComponents == 1 || this.ColorComponents == 3 || this.ColorComponents == 4) {
+		if this.BitsPerComponent == 16 {
+			img = goimage.NewNRGBA64(bounds)
+		} else {
+			img = goimage.NewNRGBA(bounds)
+		}
+	} else if this.ColorComponents == 1 {
 		if this.BitsPerComponent == 16 {
 			img = goimage.NewGray16(bounds)
 		} else {
@@ -157,7 +166,6 @@ func (this *Image) ToGoImage() (goimage.Image, error) {
 	// Draw the data on the image..
 	x := 0
 	y := 0
-	aidx := 0
 
 	samples := this.GetSamples()
 	//bytesPerColor := colorComponents * int(this.BitsPerComponent) / 8
@@ -177,22 +185,12 @@ func (this *Image) ToGoImage() (goimage.Image, error) {
 				r := uint16(samples[i])<<8 | uint16(samples[i+1])
 				g := uint16(samples[i+2])<<8 | uint16(samples[i+3])
 				b := uint16(samples[i+4])<<8 | uint16(samples[i+5])
-				a := uint16(0xffff) // Default: solid (0xffff) whereas transparent=0.
-				if this.alphaData != nil && len(this.alphaData) > aidx+1 {
-					a = (uint16(this.alphaData[aidx]) << 8) | uint16(this.alphaData[aidx+1])
-					aidx += 2
-				}
-				c = gocolor.RGBA64{R: r, G: g, B: b, A: a}
+				c = gocolor.RGBA64{R: r, G: g, B: b, A: 0xffff}
 			} else {
 				r := uint8(samples[i] & 0xff)
 				g := uint8(samples[i+1] & 0xff)
 				b := uint8(samples[i+2] & 0xff)
-				a := uint8(0xff) // Default: solid (0xff) whereas transparent=0.
-				if this.alphaData != nil && len(this.alphaData) > aidx {
-					a = uint8(this.alphaData[aidx])
-					aidx++
-				}
-				c = gocolor.RGBA{R: r, G: g, B: b, A: a}
+				c = gocolor.RGBA{R: r, G: g, B: b, A: 0xff}
 			}
 		} else if this.ColorComponents == 4 {
 			c1 := uint8(samples[i] & 0xff)
@@ -202,6 +200,17 @@ func (this *Image) ToGoImage() (goimage.Image, error) {
 			c = gocolor.CMYK{C: c1, M: m1, Y: y1, K: k1}
 		}
 
+		if hasAlpha {
+			// The colors are not premultiplied by the alpha.
+			r, g, b, _ := c.RGBA()
+			a := this.alphaData[y*int(this.Width)+x]
+			if this.BitsPerComponent == 16 {
+				c = gocolor.NRGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: uint16(a) * 0x101}
+			} else {
+				c = gocolor.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: a}
+			}
+		}
+
 		img.Set(x, y, c)
 		x++
 		if x == int(this.Width) {
@@ -232,12 +241,21 @@ type ImageHandler interface {
 type DefaultImageHandler struct{}
 
 // Create a unidoc Image from a golang Image.
+// The transparency of images with alpha, such as image.NRGBA and image.RGBA images, is kept as the
+// alpha channel, which is written as the soft mask of image XObjects.
 func (this DefaultImageHandler) NewImageFromGoImage(goimg goimage.Image) (*Image, error) {
-	// Speed up jpeg encoding by converting to RGBA first.
+	// Speed up jpeg encoding by converting to NRGBA first.
 	// Will not be required once the golang image/jpeg package is optimized.
+	// The colors are not premultiplied by alpha, so that transparent pixels keep their colors.
 	b := goimg.Bounds()
-	m := goimage.NewRGBA(goimage.Rect(0, 0, b.Dx(), b.Dy()))
-	draw.Draw(m, m.Bounds(), goimg, b.Min, draw.Src)
+	m := goimage.NewNRGBA(goimage.Rect(0, 0, b.Dx(), b.Dy()))
+	if src, ok := goimg.(*goimage.NRGBA); ok {
+		for y := 0; y < b.Dy(); y++ {
+			copy(m.Pix[y*m.Stride:y*m.Stride+4*b.Dx()], src.Pix[src.PixOffset(b.Min.X, b.Min.Y+y):])
+		}
+	} else {
+		draw.Draw(m, m.Bounds(), goimg, b.Min, draw.Src)
+	}
 
 	alphaData := []byte{}
 	hasAlpha := false
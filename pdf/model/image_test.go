@@ -6,7 +6,11 @@
 package model
 
 import (
+	"bytes"
+	goimage "image"
 	"testing"
+
+	. "github.com/unidoc/unidoc/pdf/core"
 )
 
 func TestImageResampling(t *testing.T) {
@@ -64,3 +68,118 @@ func TestImageResampling(t *testing.T) {
 		t.Errorf("Value != 64 (%d)", img.Data[1])
 	}
 }
+
+// makeImageStream returns the stream of an image XObject with image `img` in colorspace `cs` and
+// masks `smask` and `mask`.
+func makeImageStream(t *testing.T, img *Image, cs PdfColorspace, smask, mask PdfObject) *PdfObjectStream {
+	ximg, err := NewXObjectImageFromImage(img, cs, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	ximg.SMask, ximg.Mask = smask, mask
+	return ximg.ToPdfObject().(*PdfObjectStream)
+}
+
+// loadImage returns the Go image of the image XObject `stream`.
+func loadImage(t *testing.T, stream *PdfObjectStream) goimage.Image {
+	ximg, err := NewXObjectImageFromStream(stream)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	img, err := ximg.ToImage()
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	goimg, err := img.ToGoImage()
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	return goimg
+}
+
+func TestImageAlpha(t *testing.T) {
+	// An opaque red pixel, a half transparent green pixel and a transparent blue pixel.
+	src := goimage.NewNRGBA(goimage.Rect(0, 0, 3, 1))
+	copy(src.Pix, []byte{255, 0, 0, 255, 0, 255, 0, 128, 0, 0, 255, 0})
+	img, err := ImageHandling.NewImageFromGoImage(src)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// The colors are not premultiplied by alpha.
+	if !bytes.Equal(img.Data, []byte{255, 0, 0, 0, 255, 0, 0, 0, 255}) {
+		t.Errorf("Data % x", img.Data)
+	}
+	if !img.hasAlpha || !bytes.Equal(img.alphaData, []byte{255, 128, 0}) {
+		t.Errorf("Alpha % x", img.alphaData)
+	}
+
+	// The alpha is written as an 8 bit DeviceGray soft mask, and read back.
+	ximg, err := NewXObjectImageFromImage(img, nil, NewFlateEncoder())
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	stream := ximg.ToPdfObject().(*PdfObjectStream)
+	smask, ok := stream.Get("SMask").(*PdfObjectStream)
+	if !ok {
+		t.Fatalf("SMask %v", stream.Get("SMask"))
+	}
+	if bpc, _ := smask.Get("BitsPerComponent").(*PdfObjectInteger); bpc == nil || *bpc != 8 ||
+		smask.Get("ColorSpace").String() != "DeviceGray" {
+		t.Errorf("SMask dictionary %s", smask.PdfObjectDictionary)
+	}
+	nrgba, ok := loadImage(t, stream).(*goimage.NRGBA)
+	if !ok || !bytes.Equal(nrgba.Pix, src.Pix) {
+		t.Errorf("Image %v", nrgba)
+	}
+
+	// Images without transparency are opaque.
+	opaque := &Image{Width: 3, Height: 1, BitsPerComponent: 8, ColorComponents: 1, Data: []byte{0, 128, 255}}
+	if _, ok := loadImage(t, makeImageStream(t, opaque, nil, nil, nil)).(*goimage.Gray); !ok {
+		t.Errorf("Opaque image with alpha")
+	}
+
+	// Color key masking.
+	gray := loadImage(t, makeImageStream(t, opaque, nil, nil, MakeArray(MakeInteger(100), MakeInteger(200))))
+	if nrgba, ok := gray.(*goimage.NRGBA); !ok || !bytes.Equal(nrgba.Pix, []byte{0, 0, 0, 255, 128, 128,
+		128, 0, 255, 255, 255, 255}) {
+		t.Errorf("Color key masked image %v", gray)
+	}
+
+	// Stencil masking by a mask of twice the width of the image, where samples of 1 are masked out.
+	stencil := &Image{Width: 6, Height: 1, BitsPerComponent: 1, ColorComponents: 1, Data: []byte{0x30}}
+	mask := makeImageStream(t, stencil, nil, nil, nil)
+	isMask := PdfObjectBool(true)
+	mask.Set("ImageMask", &isMask)
+	gray = loadImage(t, makeImageStream(t, opaque, nil, nil, mask))
+	if nrgba, ok := gray.(*goimage.NRGBA); !ok || nrgba.Pix[3] != 255 || nrgba.Pix[7] != 0 || nrgba.Pix[11] != 255 {
+		t.Errorf("Stencil masked image %v", gray)
+	}
+
+	// Soft masks with Matte colors: the colors are premultiplied with white.
+	matted := &Image{Width: 2, Height: 1, BitsPerComponent: 8, ColorComponents: 1, Data: []byte{128, 128}}
+	alpha := &Image{Width: 2, Height: 1, BitsPerComponent: 8, ColorComponents: 1, Data: []byte{128, 255}}
+	smask = makeImageStream(t, alpha, NewPdfColorspaceDeviceGray(), nil, nil)
+	smask.Set("Matte", MakeArray(MakeFloat(1)))
+	gray = loadImage(t, makeImageStream(t, matted, nil, smask, nil))
+	if nrgba, ok := gray.(*goimage.NRGBA); !ok || nrgba.Pix[0] > 3 || nrgba.Pix[3] != 128 ||
+		nrgba.Pix[4] != 128 || nrgba.Pix[7] != 255 {
+		t.Errorf("Matted image %v", gray)
+	}
+
+	// Masks of images with invalid sizes, or sizes exceeding their data, are ignored.
+	one := &Image{Width: 1, Height: 1, BitsPerComponent: 8, ColorComponents: 1, Data: []byte{128}}
+	for _, size := range [][2]int64{{-2, 3}, {0, 1}, {100000, 100000}} {
+		stream := makeImageStream(t, opaque, nil, makeImageStream(t, one, NewPdfColorspaceDeviceGray(), nil, nil),
+			nil)
+		stream.Set("Width", MakeInteger(size[0]))
+		stream.Set("Height", MakeInteger(size[1]))
+		ximg, err := NewXObjectImageFromStream(stream)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		img, err := ximg.ToImage()
+		if err != nil || img.hasAlpha {
+			t.Errorf("Image of size %v: alpha %v, %v", size, img.hasAlpha, err)
+		}
+	}
+}
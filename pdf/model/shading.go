@@ -333,7 +333,7 @@ func newPdfShadingType1FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		return nil, ErrRequiredAttributeMissing
 	}
 	shading.Function = []PdfFunction{}
-	if array, is := obj.(*PdfObjectArray); is {
+	if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
 		for _, obj := range *array {
 			function, err := newPdfFunctionFromPdfObject(obj)
 			if err != nil {
@@ -364,7 +364,7 @@ func newPdfShadingType2FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing:  Coords")
 		return nil, ErrRequiredAttributeMissing
 	}
-	arr, ok := obj.(*PdfObjectArray)
+	arr, ok := TraceToDirectObject(obj).(*PdfObjectArray)
 	if !ok {
 		common.Log.Debug("Coords not an array (got %T)", obj)
 		return nil, errors.New("Type check error")
@@ -393,7 +393,7 @@ func newPdfShadingType2FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		return nil, ErrRequiredAttributeMissing
 	}
 	shading.Function = []PdfFunction{}
-	if array, is := obj.(*PdfObjectArray); is {
+	if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
 		for _, obj := range *array {
 			function, err := newPdfFunctionFromPdfObject(obj)
 			if err != nil {
@@ -439,7 +439,7 @@ func newPdfShadingType3FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: Coords")
 		return nil, ErrRequiredAttributeMissing
 	}
-	arr, ok := obj.(*PdfObjectArray)
+	arr, ok := TraceToDirectObject(obj).(*PdfObjectArray)
 	if !ok {
 		common.Log.Debug("Coords not an array (got %T)", obj)
 		return nil, ErrTypeError
@@ -468,7 +468,7 @@ func newPdfShadingType3FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		return nil, ErrRequiredAttributeMissing
 	}
 	shading.Function = []PdfFunction{}
-	if array, is := obj.(*PdfObjectArray); is {
+	if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
 		for _, obj := range *array {
 			function, err := newPdfFunctionFromPdfObject(obj)
 			if err != nil {
@@ -514,7 +514,7 @@ func newPdfShadingType4FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerCoordinate")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok := obj.(*PdfObjectInteger)
+	integer, ok := TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerCoordinate not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -527,7 +527,7 @@ func newPdfShadingType4FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerComponent")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerComponent not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -540,12 +540,12 @@ func newPdfShadingType4FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerFlag")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerFlag not an integer (got %T)", obj)
 		return nil, ErrTypeError
 	}
-	shading.BitsPerComponent = integer
+	shading.BitsPerFlag = integer
 
 	// Decode (required).
 	obj = dict.Get("Decode")
@@ -553,22 +553,26 @@ func newPdfShadingType4FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: Decode")
 		return nil, ErrRequiredAttributeMissing
 	}
-	arr, ok := obj.(*PdfObjectArray)
+	arr, ok := TraceToDirectObject(obj).(*PdfObjectArray)
 	if !ok {
 		common.Log.Debug("Decode not an array (got %T)", obj)
 		return nil, ErrTypeError
 	}
 	shading.Decode = arr
 
-	// Function (required).
-	obj = dict.Get("Function")
-	if obj == nil {
-		common.Log.Debug("Required attribute missing:  Function")
-		return nil, ErrRequiredAttributeMissing
-	}
-	shading.Function = []PdfFunction{}
-	if array, is := obj.(*PdfObjectArray); is {
-		for _, obj := range *array {
+	// Function (optional).
+	if obj := dict.Get("Function"); obj != nil {
+		shading.Function = []PdfFunction{}
+		if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
+			for _, obj := range *array {
+				function, err := newPdfFunctionFromPdfObject(obj)
+				if err != nil {
+					common.Log.Debug("Error parsing function: %v", err)
+					return nil, err
+				}
+				shading.Function = append(shading.Function, function)
+			}
+		} else {
 			function, err := newPdfFunctionFromPdfObject(obj)
 			if err != nil {
 				common.Log.Debug("Error parsing function: %v", err)
@@ -576,13 +580,6 @@ func newPdfShadingType4FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 			}
 			shading.Function = append(shading.Function, function)
 		}
-	} else {
-		function, err := newPdfFunctionFromPdfObject(obj)
-		if err != nil {
-			common.Log.Debug("Error parsing function: %v", err)
-			return nil, err
-		}
-		shading.Function = append(shading.Function, function)
 	}
 
 	return &shading, nil
@@ -598,7 +595,7 @@ func newPdfShadingType5FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerCoordinate")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok := obj.(*PdfObjectInteger)
+	integer, ok := TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerCoordinate not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -611,7 +608,7 @@ func newPdfShadingType5FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerComponent")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerComponent not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -624,7 +621,7 @@ func newPdfShadingType5FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: VerticesPerRow")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("VerticesPerRow not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -637,7 +634,7 @@ func newPdfShadingType5FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: Decode")
 		return nil, ErrRequiredAttributeMissing
 	}
-	arr, ok := obj.(*PdfObjectArray)
+	arr, ok := TraceToDirectObject(obj).(*PdfObjectArray)
 	if !ok {
 		common.Log.Debug("Decode not an array (got %T)", obj)
 		return nil, ErrTypeError
@@ -648,7 +645,7 @@ func newPdfShadingType5FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 	if obj := dict.Get("Function"); obj != nil {
 		// Function (required).
 		shading.Function = []PdfFunction{}
-		if array, is := obj.(*PdfObjectArray); is {
+		if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
 			for _, obj := range *array {
 				function, err := newPdfFunctionFromPdfObject(obj)
 				if err != nil {
@@ -680,7 +677,7 @@ func newPdfShadingType6FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerCoordinate")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok := obj.(*PdfObjectInteger)
+	integer, ok := TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerCoordinate not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -693,7 +690,7 @@ func newPdfShadingType6FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerComponent")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerComponent not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -706,12 +703,12 @@ func newPdfShadingType6FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerFlag")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerFlag not an integer (got %T)", obj)
 		return nil, ErrTypeError
 	}
-	shading.BitsPerComponent = integer
+	shading.BitsPerFlag = integer
 
 	// Decode (required).
 	obj = dict.Get("Decode")
@@ -719,7 +716,7 @@ func newPdfShadingType6FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: Decode")
 		return nil, ErrRequiredAttributeMissing
 	}
-	arr, ok := obj.(*PdfObjectArray)
+	arr, ok := TraceToDirectObject(obj).(*PdfObjectArray)
 	if !ok {
 		common.Log.Debug("Decode not an array (got %T)", obj)
 		return nil, ErrTypeError
@@ -729,7 +726,7 @@ func newPdfShadingType6FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 	// Function (optional).
 	if obj := dict.Get("Function"); obj != nil {
 		shading.Function = []PdfFunction{}
-		if array, is := obj.(*PdfObjectArray); is {
+		if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
 			for _, obj := range *array {
 				function, err := newPdfFunctionFromPdfObject(obj)
 				if err != nil {
@@ -761,7 +758,7 @@ func newPdfShadingType7FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerCoordinate")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok := obj.(*PdfObjectInteger)
+	integer, ok := TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerCoordinate not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -774,7 +771,7 @@ func newPdfShadingType7FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerComponent")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerComponent not an integer (got %T)", obj)
 		return nil, ErrTypeError
@@ -787,12 +784,12 @@ func newPdfShadingType7FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: BitsPerFlag")
 		return nil, ErrRequiredAttributeMissing
 	}
-	integer, ok = obj.(*PdfObjectInteger)
+	integer, ok = TraceToDirectObject(obj).(*PdfObjectInteger)
 	if !ok {
 		common.Log.Debug("BitsPerFlag not an integer (got %T)", obj)
 		return nil, ErrTypeError
 	}
-	shading.BitsPerComponent = integer
+	shading.BitsPerFlag = integer
 
 	// Decode (required).
 	obj = dict.Get("Decode")
@@ -800,7 +797,7 @@ func newPdfShadingType7FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 		common.Log.Debug("Required attribute missing: Decode")
 		return nil, ErrRequiredAttributeMissing
 	}
-	arr, ok := obj.(*PdfObjectArray)
+	arr, ok := TraceToDirectObject(obj).(*PdfObjectArray)
 	if !ok {
 		common.Log.Debug("Decode not an array (got %T)", obj)
 		return nil, ErrTypeError
@@ -810,7 +807,7 @@ func newPdfShadingType7FromDictionary(dict *PdfObjectDictionary) (*PdfShadingTyp
 	// Function (optional).
 	if obj := dict.Get("Function"); obj != nil {
 		shading.Function = []PdfFunction{}
-		if array, is := obj.(*PdfObjectArray); is {
+		if array, is := TraceToDirectObject(obj).(*PdfObjectArray); is {
 			for _, obj := range *array {
 				function, err := newPdfFunctionFromPdfObject(obj)
 				if err != nil {
@@ -0,0 +1,216 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+
+	"github.com/unidoc/unidoc/common"
+	. "github.com/unidoc/unidoc/pdf/core"
+)
+
+// PdfStructTreeRoot represents the structure tree root of a tagged PDF (14.7.2 PDF32000_2008), the
+// logical structure of the document.
+type PdfStructTreeRoot struct {
+	// K are the top level structure elements in logical order.
+	K []*PdfStructElement
+	// RoleMap maps the structure types used in the document to standard structure types.
+	RoleMap map[string]string
+}
+
+// PdfStructElement represents a structure element (Table 323 PDF32000_2008).
+type PdfStructElement struct {
+	// S is the structure type as used in the document. See PdfStructTreeRoot.StandardType.
+	S          string
+	T          string // Title.
+	Lang       string
+	Alt        string
+	ActualText string
+	// PageNum is the number (starting from 1) of the page that contains the element's marked content
+	// (Pg), or 0 if it is not given.
+	PageNum int
+	Parent  *PdfStructElement
+	// Kids are the children of the element in logical order.
+	Kids []*PdfStructKid
+}
+
+// PdfStructKid is a child of a structure element. It is either a structure element (Element), a
+// marked-content sequence (MCID >= 0) or another object, e.g. an annotation (Object).
+type PdfStructKid struct {
+	Element *PdfStructElement
+
+	// MCID is the identifier of a marked-content sequence, or -1 for other kids.
+	MCID int
+	// PageNum is the number of the page that contains the marked-content sequence.
+	PageNum int
+	// StmObjectNumber is the object number of the content stream that contains the marked-content
+	// sequence, e.g. a form XObject, or 0 for the page's content stream.
+	StmObjectNumber int64
+
+	// Object is the object referenced by an object reference (OBJR).
+	Object PdfObject
+}
+
+// maxRoleMapDepth is the maximum length of chains of role mappings that are followed.
+const maxRoleMapDepth = 16
+
+// StandardType returns the standard structure type of element `e`, e.g. P, H1 or TD, by following
+// the role map from its structure type.
+func (root *PdfStructTreeRoot) StandardType(e *PdfStructElement) string {
+	s := e.S
+	for i := 0; i < maxRoleMapDepth; i++ {
+		mapped, has := root.RoleMap[s]
+		if !has || mapped == s {
+			break
+		}
+		s = mapped
+	}
+	return s
+}
+
+// GetStructTreeRoot returns the structure tree of the document, or nil if the document is not tagged.
+func (this *PdfReader) GetStructTreeRoot() (*PdfStructTreeRoot, error) {
+	obj := this.catalog.Get("StructTreeRoot")
+	if obj == nil {
+		return nil, nil
+	}
+	obj, err := this.traceToObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	dict, ok := TraceToDirectObject(obj).(*PdfObjectDictionary)
+	if !ok {
+		common.Log.Debug("StructTreeRoot not a dictionary (%T)", obj)
+		return nil, errors.New("Type check error")
+	}
+
+	root := &PdfStructTreeRoot{RoleMap: map[string]string{}}
+	if roleMap, ok := this.traceToDict(dict.Get("RoleMap")); ok {
+		for _, key := range roleMap.Keys() {
+			if name, ok := TraceToDirectObject(roleMap.Get(key)).(*PdfObjectName); ok {
+				root.RoleMap[string(key)] = string(*name)
+			}
+		}
+	}
+
+	pageNums := map[int64]int{}
+	for i, page := range this.pageList {
+		pageNums[page.ObjectNumber] = i + 1
+	}
+	b := structTreeBuilder{reader: this, pageNums: pageNums, visited: map[PdfObject]bool{}}
+	for _, kid := range b.kids(dict.Get("K"), nil, 0) {
+		if kid.Element != nil {
+			root.K = append(root.K, kid.Element)
+		}
+	}
+	return root, nil
+}
+
+// traceToDict returns the dictionary of `obj`, resolving references.
+func (this *PdfReader) traceToDict(obj PdfObject) (*PdfObjectDictionary, bool) {
+	if obj == nil {
+		return nil, false
+	}
+	obj, err := this.traceToObject(obj)
+	if err != nil {
+		return nil, false
+	}
+	dict, ok := TraceToDirectObject(obj).(*PdfObjectDictionary)
+	return dict, ok
+}
+
+// structTreeBuilder loads the structure elements of a document.
+type structTreeBuilder struct {
+	reader *PdfReader
+	// pageNums maps the object numbers of the page objects to page numbers.
+	pageNums map[int64]int
+	// visited holds the element dictionaries and kid arrays that have been loaded, to break reference
+	// cycles.
+	visited map[PdfObject]bool
+}
+
+// kids returns the kids of `obj`, the K entry of element `parent` (nil for the root) whose marked
+// content is on page `pageNum`.
+func (b *structTreeBuilder) kids(obj PdfObject, parent *PdfStructElement, pageNum int) []*PdfStructKid {
+	if obj == nil {
+		return nil
+	}
+	resolved, err := b.reader.traceToObject(obj)
+	if err != nil {
+		common.Log.Debug("Invalid structure element kid: %v", err)
+		return nil
+	}
+	if arr, ok := TraceToDirectObject(resolved).(*PdfObjectArray); ok {
+		if b.visited[arr] {
+			common.Log.Debug("Structure tree cycle, skipping")
+			return nil
+		}
+		b.visited[arr] = true
+		var kids []*PdfStructKid
+		for _, item := range *arr {
+			kids = append(kids, b.kids(item, parent, pageNum)...)
+		}
+		return kids
+	}
+
+	switch v := TraceToDirectObject(resolved).(type) {
+	case *PdfObjectInteger:
+		return []*PdfStructKid{{MCID: int(*v), PageNum: pageNum}}
+	case *PdfObjectDictionary:
+		if b.visited[v] {
+			common.Log.Debug("Structure tree cycle, skipping")
+			return nil
+		}
+		b.visited[v] = true
+
+		if pg := v.Get("Pg"); pg != nil {
+			pageNum = b.pageNums[objectNumber(pg)]
+		}
+		typ, _ := TraceToDirectObject(v.Get("Type")).(*PdfObjectName)
+		switch {
+		case typ != nil && *typ == "MCR":
+			mcid, ok := TraceToDirectObject(v.Get("MCID")).(*PdfObjectInteger)
+			if !ok {
+				common.Log.Debug("Marked-content reference without MCID")
+				return nil
+			}
+			kid := &PdfStructKid{MCID: int(*mcid), PageNum: pageNum}
+			if stm := v.Get("Stm"); stm != nil {
+				kid.StmObjectNumber = objectNumber(stm)
+			}
+			return []*PdfStructKid{kid}
+		case typ != nil && *typ == "OBJR":
+			return []*PdfStructKid{{MCID: -1, PageNum: pageNum, Object: v.Get("Obj")}}
+		}
+
+		e := &PdfStructElement{PageNum: pageNum, Parent: parent}
+		if s, ok := TraceToDirectObject(v.Get("S")).(*PdfObjectName); ok {
+			e.S = string(*s)
+		}
+		e.T, _ = DecodeTextString(v.Get("T"))
+		e.Lang, _ = DecodeTextString(v.Get("Lang"))
+		e.Alt, _ = DecodeTextString(v.Get("Alt"))
+		e.ActualText, _ = DecodeTextString(v.Get("ActualText"))
+		e.Kids = b.kids(v.Get("K"), e, pageNum)
+		return []*PdfStructKid{{Element: e, MCID: -1, PageNum: pageNum}}
+	}
+	common.Log.Debug("Invalid structure element kid (%T)", resolved)
+	return nil
+}
+
+// objectNumber returns the object number of `obj`, a reference or an indirect object or stream, or 0
+// if it is a direct object.
+func objectNumber(obj PdfObject) int64 {
+	switch v := obj.(type) {
+	case *PdfObjectReference:
+		return v.ObjectNumber
+	case *PdfIndirectObject:
+		return v.ObjectNumber
+	case *PdfObjectStream:
+		return v.ObjectNumber
+	}
+	return 0
+}
@@ -0,0 +1,234 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package textencoding
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+)
+
+// SimpleEncoder represents the encoding of a simple font: a base encoding, either one of the
+// predefined encodings or the built-in encoding of a font program, modified by the glyph names of a
+// Differences array (9.6.6 PDF32000_2008).
+type SimpleEncoder struct {
+	baseName    string
+	differences map[byte]string
+	codeToGlyph map[byte]string
+	glyphToCode map[string]byte
+}
+
+// simpleEncodings maps the names of the predefined encodings to their code to glyph name maps.
+var simpleEncodings = map[string]map[byte]string{
+	"StandardEncoding":  standardEncodingCharcodeToGlyphMap,
+	"WinAnsiEncoding":   winansiEncodingCharcodeToGlyphMap,
+	"MacRomanEncoding":  macRomanEncodingCharcodeToGlyphMap,
+	"MacExpertEncoding": macExpertEncodingCharcodeToGlyphMap,
+}
+
+// NewSimpleTextEncoder returns an encoder for the predefined encoding `baseName` (StandardEncoding,
+// WinAnsiEncoding, MacRomanEncoding or MacExpertEncoding) modified by `differences`, which may be nil.
+func NewSimpleTextEncoder(baseName string, differences map[byte]string) (SimpleEncoder, error) {
+	base, ok := simpleEncodings[baseName]
+	if !ok {
+		common.Log.Debug("Unsupported base encoding: %s", baseName)
+		return SimpleEncoder{}, errors.New("Unsupported font encoding")
+	}
+	return newSimpleEncoder(baseName, base, differences), nil
+}
+
+// NewCustomSimpleTextEncoder returns an encoder for `encoding`, e.g. the built-in encoding of a font
+// program, modified by `differences`, which may be nil.
+func NewCustomSimpleTextEncoder(encoding, differences map[byte]string) SimpleEncoder {
+	return newSimpleEncoder("", encoding, differences)
+}
+
+// NewSymbolicTextEncoder returns an encoder for the built-in encoding of the standard 14 font
+// `basefont` (Symbol or ZapfDingbats) modified by `differences`, which may be nil.
+func NewSymbolicTextEncoder(basefont string, differences map[byte]string) (SimpleEncoder, error) {
+	switch basefont {
+	case "Symbol":
+		return NewCustomSimpleTextEncoder(symbolEncodingCharcodeToGlyphMap, differences), nil
+	case "ZapfDingbats":
+		return NewCustomSimpleTextEncoder(zapfDingbatsEncodingCharcodeToGlyphMap, differences), nil
+	}
+	common.Log.Debug("Not a symbolic standard 14 font: %s", basefont)
+	return SimpleEncoder{}, errors.New("Unsupported font encoding")
+}
+
+func newSimpleEncoder(baseName string, base, differences map[byte]string) SimpleEncoder {
+	enc := SimpleEncoder{
+		baseName:    baseName,
+		differences: differences,
+		codeToGlyph: map[byte]string{},
+		glyphToCode: map[string]byte{},
+	}
+	for code, glyph := range base {
+		enc.codeToGlyph[code] = glyph
+	}
+	for code, glyph := range differences {
+		enc.codeToGlyph[code] = glyph
+	}
+	for code, glyph := range enc.codeToGlyph {
+		// Use the lowest code for glyphs that are encoded more than once, e.g. space in
+		// WinAnsiEncoding.
+		if old, has := enc.glyphToCode[glyph]; !has || code < old {
+			enc.glyphToCode[glyph] = code
+		}
+	}
+	return enc
+}
+
+// Convert a raw utf8 string (series of runes) to an encoded string (series of character codes) to be used in PDF.
+func (enc SimpleEncoder) Encode(raw string) string {
+	encoded := []byte{}
+	for _, r := range raw {
+		code, has := enc.RuneToCharcode(r)
+		if has {
+			encoded = append(encoded, code)
+		}
+	}
+
+	return string(encoded)
+}
+
+// Conversion between character code and glyph name.
+// The bool return flag is true if there was a match, and false otherwise.
+func (enc SimpleEncoder) CharcodeToGlyph(code byte) (string, bool) {
+	glyph, has := enc.codeToGlyph[code]
+	if !has {
+		common.Log.Trace("Charcode -> Glyph error: charcode not found: %d", code)
+		return "", false
+	}
+	return glyph, true
+}
+
+// Conversion between glyph name and character code.
+// The bool return flag is true if there was a match, and false otherwise.
+func (enc SimpleEncoder) GlyphToCharcode(glyph string) (byte, bool) {
+	code, found := enc.glyphToCode[glyph]
+	if !found {
+		common.Log.Trace("Glyph -> Charcode error: glyph not found: %s", glyph)
+		return 0, false
+	}
+	return code, true
+}
+
+// Convert rune to character code.
+// The bool return flag is true if there was a match, and false otherwise.
+func (enc SimpleEncoder) RuneToCharcode(val rune) (byte, bool) {
+	glyph, found := enc.RuneToGlyph(val)
+	if !found {
+		return 0, false
+	}
+	return enc.GlyphToCharcode(glyph)
+}
+
+// Convert character code to rune.
+// The bool return flag is true if there was a match, and false otherwise.
+func (enc SimpleEncoder) CharcodeToRune(charcode byte) (rune, bool) {
+	glyph, found := enc.CharcodeToGlyph(charcode)
+	if !found {
+		return 0, false
+	}
+	return GuessGlyphRune(glyph)
+}
+
+// Convert rune to glyph name.
+// The bool return flag is true if there was a match, and false otherwise.
+func (enc SimpleEncoder) RuneToGlyph(val rune) (string, bool) {
+	if glyph, found := runeToGlyph(val, glyphlistRuneToGlyphMap); found {
+		if _, has := enc.glyphToCode[glyph]; has {
+			return glyph, true
+		}
+	}
+	// The glyph may be encoded under another name, e.g. a uniXXXX name in a Differences array. The
+	// name of the lowest code is used.
+	for code := 0; code <= 0xff; code++ {
+		glyph, has := enc.codeToGlyph[byte(code)]
+		if !has {
+			continue
+		}
+		if r, found := GlyphToRune(glyph); found && r == val {
+			return glyph, true
+		}
+	}
+	return runeToGlyph(val, glyphlistRuneToGlyphMap)
+}
+
+// Convert glyph to rune.
+// The bool return flag is true if there was a match, and false otherwise.
+func (enc SimpleEncoder) GlyphToRune(glyph string) (rune, bool) {
+	return GlyphToRune(glyph)
+}
+
+// ToPdfObject returns the encoding as a PDF name if it is a predefined encoding and as an encoding
+// dictionary with a Differences array otherwise.
+func (enc SimpleEncoder) ToPdfObject() core.PdfObject {
+	if enc.baseName != "" && len(enc.differences) == 0 {
+		return core.MakeName(enc.baseName)
+	}
+
+	dict := core.MakeDict()
+	dict.Set("Type", core.MakeName("Encoding"))
+	if enc.baseName != "" {
+		dict.Set("BaseEncoding", core.MakeName(enc.baseName))
+	}
+	if len(enc.differences) > 0 {
+		dict.Set("Differences", ToFontDifferences(enc.differences))
+	}
+	return core.MakeIndirectObject(dict)
+}
+
+// FromFontDifferences converts a Differences array of an encoding dictionary to a map of character
+// codes to glyph names. The array consists of character codes, each followed by the names of the
+// glyphs of that code and the codes following it.
+func FromFontDifferences(diffList *core.PdfObjectArray) (map[byte]string, error) {
+	differences := map[byte]string{}
+	code := 0
+	for _, obj := range *diffList {
+		switch v := core.TraceToDirectObject(obj).(type) {
+		case *core.PdfObjectInteger:
+			if *v < 0 || *v > 0xff {
+				common.Log.Debug("Differences code out of range: %d", *v)
+				return nil, errors.New("Range check error")
+			}
+			code = int(*v)
+		case *core.PdfObjectName:
+			if code > 0xff {
+				common.Log.Debug("Differences glyph %s past code 255, skipping", *v)
+				continue
+			}
+			differences[byte(code)] = string(*v)
+			code++
+		default:
+			common.Log.Debug("Invalid Differences entry (%T)", obj)
+			return nil, errors.New("Type check error")
+		}
+	}
+	return differences, nil
+}
+
+// ToFontDifferences converts `differences`, a map of character codes to glyph names, to a
+// Differences array.
+func ToFontDifferences(differences map[byte]string) *core.PdfObjectArray {
+	codes := make([]int, 0, len(differences))
+	for code := range differences {
+		codes = append(codes, int(code))
+	}
+	sort.Ints(codes)
+
+	diffList := core.PdfObjectArray{}
+	for i, code := range codes {
+		if i == 0 || code != codes[i-1]+1 {
+			diffList = append(diffList, core.MakeInteger(int64(code)))
+		}
+		diffList = append(diffList, core.MakeName(differences[byte(code)]))
+	}
+	return &diffList
+}
@@ -0,0 +1,112 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package textencoding
+
+import (
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/core"
+)
+
+func TestSimpleEncoder(t *testing.T) {
+	enc, err := NewSimpleTextEncoder("MacRomanEncoding", map[byte]string{0x80: "Euro", 0x81: "uni0394"})
+	if err != nil {
+		t.Fatalf("Error creating encoder: %v", err)
+	}
+
+	expected := map[byte]rune{'A': 'A', 0x27: '\'', 0x8e: 'é', 0xde: 'ﬁ', 0x80: '€', 0x81: 'Δ'}
+	for code, r := range expected {
+		if val, found := enc.CharcodeToRune(code); !found || val != r {
+			t.Errorf("Code 0x%02x: %q != %q", code, val, r)
+		}
+		if val, found := enc.RuneToCharcode(r); !found || val != code {
+			t.Errorf("Rune %q: 0x%02x != 0x%02x", r, val, code)
+		}
+	}
+
+	dict, ok := core.TraceToDirectObject(enc.ToPdfObject()).(*core.PdfObjectDictionary)
+	if !ok {
+		t.Fatalf("Encoding with differences not a dictionary")
+	}
+	if s := dict.Get("Differences").DefaultWriteString(); s != "[128 /Euro /uni0394]" {
+		t.Errorf("Incorrect Differences %s", s)
+	}
+
+	if _, err := NewSimpleTextEncoder("FooEncoding", nil); err == nil {
+		t.Errorf("Unknown encoding accepted")
+	}
+}
+
+// Glyphs named in Differences past code 255 are skipped.
+func TestFromFontDifferences(t *testing.T) {
+	arr := core.MakeArray(core.MakeInteger(254), core.MakeName("a"), core.MakeName("b"), core.MakeName("c"))
+	differences, err := FromFontDifferences(arr)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(differences) != 2 || differences[254] != "a" || differences[255] != "b" {
+		t.Errorf("Incorrect differences %v", differences)
+	}
+}
+
+// The glyph of the lowest code is used for runes encoded under several names.
+func TestRuneToGlyphLowestCode(t *testing.T) {
+	enc, err := NewSimpleTextEncoder("StandardEncoding", map[byte]string{0x91: "uni0394", 0x90: "u0394"})
+	if err != nil {
+		t.Fatalf("Error creating encoder: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if glyph, found := enc.RuneToGlyph('Δ'); !found || glyph != "u0394" {
+			t.Fatalf("Incorrect glyph %q", glyph)
+		}
+	}
+}
+
+func TestGlyphToRune(t *testing.T) {
+	expected := map[string]rune{
+		"A":           'A',
+		"a20":         '✔',
+		"uni00E9":     'é',
+		"uni00E90301": 'é',
+		"u1F600":      '😀',
+		"a.sc":        'a',
+	}
+	for glyph, r := range expected {
+		if val, found := GlyphToRune(glyph); !found || val != r {
+			t.Errorf("Glyph %s: %q != %q", glyph, val, r)
+		}
+	}
+
+	for _, glyph := range []string{"", "foo", "uniform", "uniXYZW", "g4", "uD800", "g41", "c67"} {
+		if val, found := GlyphToRune(glyph); found {
+			t.Errorf("Glyph %q: unexpected rune %q", glyph, val)
+		}
+	}
+}
+
+func TestGuessGlyphRune(t *testing.T) {
+	expected := map[string]rune{
+		"A":       'A',
+		"uni00E9": 'é',
+		"g41":     'A',
+		"G0042":   'B',
+		"c67":     'C',
+		"C067":    'C',
+		"g41.sc":  'A',
+	}
+	for glyph, r := range expected {
+		if val, found := GuessGlyphRune(glyph); !found || val != r {
+			t.Errorf("Glyph %s: %q != %q", glyph, val, r)
+		}
+	}
+
+	// Control characters and names of other lengths are not guessed.
+	for _, glyph := range []string{"", "g12", "c10", "G0009", "g4", "g041", "c0067", "gXY"} {
+		if val, found := GuessGlyphRune(glyph); found {
+			t.Errorf("Glyph %q: unexpected rune %q", glyph, val)
+		}
+	}
+}
@@ -5,7 +5,85 @@
 
 package textencoding
 
-import "github.com/unidoc/unidoc/common"
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unidoc/unidoc/common"
+)
+
+// GlyphToRune returns the rune that glyph name `glyph` maps to. The Adobe Glyph List and the
+// ZapfDingbats glyph list are used for standard glyph names. Other names are interpreted as
+//   - uniXXXX and uXXXX[XX]: the unicode code point in hexadecimal,
+//   - name.suffix: the glyph `name`, e.g. a.sc for a small capital a.
+//
+// The bool return flag is true if there was a match, and false otherwise.
+func GlyphToRune(glyph string) (rune, bool) {
+	if r, ok := glyphToRune(glyph, glyphlistGlyphToRuneMap); ok {
+		return r, true
+	}
+	if r, ok := glyphToRune(glyph, zapfdingbatsGlyphToRuneMap); ok {
+		return r, true
+	}
+	if i := strings.IndexByte(glyph, '.'); i > 0 {
+		return GlyphToRune(glyph[:i])
+	}
+	switch {
+	case strings.HasPrefix(glyph, "uni") && len(glyph) >= 7 && (len(glyph)-3)%4 == 0:
+		// uniXXXXYYYY... is a sequence of code points, the first is used.
+		return parseGlyphRune(glyph[3:7], 16)
+	case strings.HasPrefix(glyph, "u") && len(glyph) >= 5 && len(glyph) <= 7:
+		return parseGlyphRune(glyph[1:], 16)
+	}
+	return 0, false
+}
+
+// GuessGlyphRune returns the rune that glyph name `glyph` maps to as GlyphToRune does, and also
+// interprets the names that some PDF producers give to the character codes of their fonts:
+//   - gXX, GXX, gXXXX and GXXXX: the character code in hexadecimal,
+//   - Cdd, Cddd, cdd and cddd: the character code in decimal.
+//
+// As these are guesses, only graphic characters are returned. It is meant for extracting text, not
+// for encoding it.
+// The bool return flag is true if there was a match, and false otherwise.
+func GuessGlyphRune(glyph string) (rune, bool) {
+	if r, ok := GlyphToRune(glyph); ok {
+		return r, true
+	}
+	if i := strings.IndexByte(glyph, '.'); i > 0 {
+		return GuessGlyphRune(glyph[:i])
+	}
+
+	var r rune
+	var ok bool
+	switch {
+	case len(glyph) != 3 && len(glyph) != 4 && len(glyph) != 5:
+		return 0, false
+	case (glyph[0] == 'g' || glyph[0] == 'G') && len(glyph) != 4:
+		r, ok = parseGlyphRune(glyph[1:], 16)
+	case (glyph[0] == 'c' || glyph[0] == 'C') && len(glyph) != 5:
+		r, ok = parseGlyphRune(glyph[1:], 10)
+	}
+	if !ok || !unicode.IsGraphic(r) {
+		return 0, false
+	}
+	return r, true
+}
+
+// parseGlyphRune returns the rune with code point `digits` in base `base`.
+func parseGlyphRune(digits string, base int) (rune, bool) {
+	v, err := strconv.ParseUint(digits, base, 32)
+	if err != nil {
+		return 0, false
+	}
+	r := rune(v)
+	if !utf8.ValidRune(r) {
+		return 0, false
+	}
+	return r, true
+}
 
 func glyphToRune(glyph string, glyphToRuneMap map[string]rune) (rune, bool) {
 	ucode, found := glyphToRuneMap[glyph]
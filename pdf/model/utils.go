@@ -7,6 +7,7 @@ package model
 
 import (
 	"errors"
+	"unicode/utf16"
 
 	"github.com/unidoc/unidoc/common"
 	. "github.com/unidoc/unidoc/pdf/core"
@@ -104,3 +105,42 @@ func debugObject(obj PdfObject) {
 		common.Log.Debug("%s", indObj.PdfObject.String())
 	}
 }
+
+// pdfDocEncoding maps the codes of PDFDocEncoding that differ from ISO Latin-1 to unicode
+// (PDF 32000-1:2008 Annex D.2).
+var pdfDocEncoding = map[byte]rune{
+	0x18: '˘', 0x19: 'ˇ', 0x1a: 'ˆ', 0x1b: '˙', 0x1c: '˝', 0x1d: '˛',
+	0x1e: '˚', 0x1f: '˜', 0x80: '•', 0x81: '†', 0x82: '‡', 0x83: '…',
+	0x84: '—', 0x85: '–', 0x86: 'ƒ', 0x87: '⁄', 0x88: '‹', 0x89: '›',
+	0x8a: '−', 0x8b: '‰', 0x8c: '„', 0x8d: '“', 0x8e: '”', 0x8f: '‘',
+	0x90: '’', 0x91: '‚', 0x92: '™', 0x93: 'ﬁ', 0x94: 'ﬂ', 0x95: 'Ł',
+	0x96: 'Œ', 0x97: 'Š', 0x98: 'Ÿ', 0x99: 'Ž', 0x9a: 'ı', 0x9b: 'ł',
+	0x9c: 'œ', 0x9d: 'š', 0x9e: 'ž', 0xa0: '€',
+}
+
+// DecodeTextString returns the unicode text of `obj`, a text string (PDF 32000-1:2008 7.9.2.2)
+// encoded in UTF-16BE with a byte order mark or in PDFDocEncoding.
+// The bool return flag is false if `obj` is not a string.
+func DecodeTextString(obj PdfObject) (string, bool) {
+	str, ok := TraceToDirectObject(obj).(*PdfObjectString)
+	if !ok {
+		return "", false
+	}
+	data := []byte(*str)
+	if len(data) >= 2 && data[0] == 0xfe && data[1] == 0xff {
+		var units []uint16
+		for i := 2; i+1 < len(data); i += 2 {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		}
+		return string(utf16.Decode(units)), true
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		if r, has := pdfDocEncoding[b]; has {
+			runes[i] = r
+		} else {
+			runes[i] = rune(b)
+		}
+	}
+	return string(runes), true
+}
@@ -7,6 +7,7 @@ package model
 
 import (
 	"errors"
+	"math"
 
 	"github.com/unidoc/unidoc/common"
 	. "github.com/unidoc/unidoc/pdf/core"
@@ -278,18 +279,24 @@ func UpdateXObjectImageFromImage(xobjIn *XObjectImage, img *Image, cs PdfColorsp
 	}
 
 	if img.hasAlpha {
-		// Add the alpha channel information as a stencil mask (SMask).
-		// Has same width and height as original and stored in same
-		// bits per component (1 component, hence the DeviceGray channel).
+		// Add the alpha channel information as a soft mask (SMask).
+		// Has same width and height as original and 8 bits per component
+		// (1 component, hence the DeviceGray channel).
+		// The encoder of the image may be specific to its colors, e.g. DCT, so the alpha channel is
+		// Flate encoded unless the image is not compressed.
 		smask := NewXObjectImage()
 		smask.Filter = encoder
-		encoded, err := encoder.EncodeBytes(img.alphaData)
+		if _, isRaw := encoder.(*RawEncoder); !isRaw {
+			smask.Filter = NewFlateEncoder()
+		}
+		encoded, err := smask.Filter.EncodeBytes(img.alphaData)
 		if err != nil {
 			common.Log.Debug("Error with encoding: %v", err)
 			return nil, err
 		}
 		smask.Stream = encoded
-		smask.BitsPerComponent = &img.BitsPerComponent
+		bpc := int64(8)
+		smask.BitsPerComponent = &bpc
 		smask.Width = &img.Width
 		smask.Height = &img.Height
 		smask.ColorSpace = NewPdfColorspaceDeviceGray()
@@ -535,9 +542,218 @@ func (ximg *XObjectImage) ToImage() (*Image, error) {
 		image.decode = decode
 	}
 
+	if err := ximg.loadAlpha(image); err != nil {
+		// The image is returned without transparency if its masks are invalid.
+		common.Log.Debug("Unable to load image mask: %v", err)
+	}
+
 	return image, nil
 }
 
+// loadAlpha sets the alpha channel of `img`, the image of `ximg`, from the soft mask (SMask) of the
+// image or, if it has none, its mask (Mask): a stencil mask or a color key mask array. The colors of
+// images with soft masks with Matte colors are converted to colors that are not premultiplied.
+func (ximg *XObjectImage) loadAlpha(img *Image) error {
+	if ximg.SMask == nil && ximg.Mask == nil {
+		return nil
+	}
+	if err := checkImageSize(img); err != nil {
+		return err
+	}
+	width, height := int(img.Width), int(img.Height)
+	var alpha []byte
+	var err error
+	if stream, ok := TraceToDirectObject(ximg.SMask).(*PdfObjectStream); ok {
+		alpha, err = loadSoftMask(img, stream)
+	} else {
+		switch mask := TraceToDirectObject(ximg.Mask).(type) {
+		case *PdfObjectStream:
+			alpha, err = loadStencilMask(mask, width, height)
+		case *PdfObjectArray:
+			alpha, err = colorKeyAlpha(img, mask)
+		}
+	}
+	if err != nil || alpha == nil {
+		return err
+	}
+
+	for _, a := range alpha {
+		if a != 255 {
+			img.alphaData = alpha
+			img.hasAlpha = true
+			break
+		}
+	}
+	return nil
+}
+
+// loadSoftMask returns the alpha channel of `img` given by soft mask image `stream` (8.9.6.4
+// "Soft-Mask Images"), which is scaled to the size of `img`. The Matte color of the soft mask, if
+// any, is removed from the colors of `img`.
+func loadSoftMask(img *Image, stream *PdfObjectStream) ([]byte, error) {
+	smask, err := NewXObjectImageFromStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	// Soft masks have no masks of their own.
+	smask.SMask, smask.Mask = nil, nil
+	maskImg, err := smask.ToImage()
+	if err != nil {
+		return nil, err
+	}
+	if maskImg.ColorComponents != 1 {
+		return nil, errors.New("Soft mask not a DeviceGray image")
+	}
+	if err := checkImageSize(maskImg); err != nil {
+		return nil, err
+	}
+	decode := maskImg.decode
+	if len(decode) != 2 {
+		decode = []float64{0, 1}
+	}
+	samples := maskImg.GetSamples()
+	maxVal := math.Pow(2, float64(maskImg.BitsPerComponent)) - 1
+	alpha := scaleMask(int(maskImg.Width), int(maskImg.Height), int(img.Width), int(img.Height),
+		func(i int) byte {
+			if i >= len(samples) {
+				return 255
+			}
+			a := interpolate(float64(samples[i]), 0, maxVal, decode[0], decode[1])
+			return byte(math.Max(0, math.Min(1, a))*255 + 0.5)
+		})
+
+	if matte, ok := TraceToDirectObject(smask.Matte).(*PdfObjectArray); ok {
+		vals, err := matte.ToFloat64Array()
+		if err != nil || len(vals) != img.ColorComponents {
+			common.Log.Debug("Invalid Matte %s", matte)
+		} else {
+			removeMatte(img, alpha, vals)
+		}
+	}
+	return alpha, nil
+}
+
+// removeMatte converts the colors of `img`, which are premultiplied by alpha channel `alpha` with
+// matte color components `matte`, to colors that are not premultiplied: c = m + (c' - m) / alpha.
+func removeMatte(img *Image, alpha []byte, matte []float64) {
+	n := img.ColorComponents
+	samples := img.GetSamples()
+	maxVal := math.Pow(2, float64(img.BitsPerComponent)) - 1
+	decode := img.decode
+	if len(decode) != 2*n {
+		decode = nil
+		for i := 0; i < n; i++ {
+			decode = append(decode, 0, 1)
+		}
+	}
+	for i := 0; i < len(alpha) && (i+1)*n <= len(samples); i++ {
+		if alpha[i] == 0 || alpha[i] == 255 {
+			continue
+		}
+		a := float64(alpha[i]) / 255
+		for j := 0; j < n; j++ {
+			// The matte color is given in the colorspace of the image, and mapped to its samples.
+			m := interpolate(matte[j], decode[2*j], decode[2*j+1], 0, maxVal)
+			v := m + (float64(samples[i*n+j])-m)/a
+			samples[i*n+j] = uint32(math.Max(0, math.Min(maxVal, v)) + 0.5)
+		}
+	}
+	img.SetSamples(samples)
+}
+
+// loadStencilMask returns the alpha channel of size `width` x `height` given by stencil mask image
+// `stream` (8.9.6.3 "Explicit Masking"), which is scaled to that size. Mask samples of 1 are
+// transparent, or samples of 0 if the Decode array of the mask is [1 0].
+func loadStencilMask(stream *PdfObjectStream, width, height int) ([]byte, error) {
+	mask, err := NewXObjectImageFromStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	data, err := DecodeStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	transparentBit := byte(1)
+	if darr, ok := TraceToDirectObject(mask.Decode).(*PdfObjectArray); ok {
+		if decode, err := darr.ToFloat64Array(); err == nil && len(decode) == 2 && decode[0] > decode[1] {
+			transparentBit = 0
+		}
+	}
+	mw, mh := int(*mask.Width), int(*mask.Height)
+	if mw <= 0 || mh <= 0 || mw > 8*len(data)/mh {
+		return nil, errors.New("Invalid mask size")
+	}
+	// Rows of 1 bit samples are padded to whole bytes.
+	stride := (mw + 7) / 8
+	return scaleMask(mw, mh, width, height, func(i int) byte {
+		x, y := i%mw, i/mw
+		j := y*stride + x/8
+		if j < len(data) && (data[j]>>uint(7-x%8))&1 == transparentBit {
+			return 0
+		}
+		return 255
+	}), nil
+}
+
+// colorKeyAlpha returns the alpha channel of `img` given by color key mask `mask` (8.9.6.4 "Colour
+// Key Masking"): pixels with all samples in the ranges [min1 max1 ... minN maxN] are transparent.
+func colorKeyAlpha(img *Image, mask *PdfObjectArray) ([]byte, error) {
+	n := img.ColorComponents
+	ranges, err := mask.ToIntegerArray()
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) != 2*n {
+		return nil, errors.New("Invalid color key mask")
+	}
+	samples := img.GetSamples()
+	alpha := make([]byte, int(img.Width)*int(img.Height))
+	for i := range alpha {
+		alpha[i] = 255
+		if (i+1)*n > len(samples) {
+			continue
+		}
+		masked := true
+		for j := 0; j < n; j++ {
+			s := int(samples[i*n+j])
+			if s < ranges[2*j] || s > ranges[2*j+1] {
+				masked = false
+				break
+			}
+		}
+		if masked {
+			alpha[i] = 0
+		}
+	}
+	return alpha, nil
+}
+
+// checkImageSize returns an error if the size of `img` is not positive or if it has more pixels than
+// its decoded data holds samples for.
+func checkImageSize(img *Image) error {
+	bits := int(img.BitsPerComponent) * img.ColorComponents
+	if img.Width <= 0 || img.Height <= 0 || bits <= 0 {
+		return errors.New("Invalid image size")
+	}
+	if img.Width > int64(8*len(img.Data)/bits)/img.Height {
+		return errors.New("Image size exceeds image data")
+	}
+	return nil
+}
+
+// scaleMask returns an alpha channel of size `width` x `height` given by a mask of size `mw` x `mh`,
+// with alpha values `at` of the pixels of the mask, in row order, which is scaled to that size.
+func scaleMask(mw, mh, width, height int, at func(i int) byte) []byte {
+	alpha := make([]byte, width*height)
+	for y := 0; y < height; y++ {
+		my := y * mh / height
+		for x := 0; x < width; x++ {
+			alpha[y*width+x] = at(my*mw + x*mw/width)
+		}
+	}
+	return alpha
+}
+
 func (ximg *XObjectImage) GetContainingPdfObject() PdfObject {
 	return ximg.primitive
 }
@@ -0,0 +1,21 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+// Package render draws PDF pages as images, e.g. for thumbnails and visual comparisons.
+//
+// Pages are rendered with anti-aliasing by a pure Go rasterizer that paints the page's content
+// stream: filled and stroked paths, clipping paths, images with their masks, shadings and text drawn
+// with the glyph outlines of embedded TrueType, CFF and Type 1 font programs. Text in fonts without
+// embedded programs, such as the standard 14 fonts, is drawn with the Go fonts as substitutes. Colors
+// are converted to RGB by the model colorspaces. Paths are painted with shading patterns, but not
+// with tiling patterns. Annotations are not drawn.
+//
+// RenderShading renders shadings of all seven types on their own, e.g. to replace smooth shadings
+// with images.
+//
+// AnalyzeInks reports the process and spot colorants of pages for print preflight, with the area
+// coverage of their plates, which are rendered with the overprint settings of the content, and
+// flags pages that exceed a total ink limit.
+package render
@@ -0,0 +1,305 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// drawImage paints `img` in the unit square of user space, mapped to device space by `m`, with clip
+// `clip` and constant alpha `alpha`. The first row of the image is at the top of the square.
+func (r *renderer) drawImage(img *image.NRGBA, m contentstream.Matrix, clip *mask, alpha float64) {
+	inv, ok := invertMatrix(m)
+	if !ok {
+		return
+	}
+	r.raster.reset()
+	r.raster.addPolygon(transformPoints([]point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, m))
+	coverage := r.raster.rasterize(false)
+
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	paint(r.dst, coverage, clip, func(x, y int) rgba {
+		u, v := inv.Transform(float64(x)+0.5, float64(y)+0.5)
+		col := clampInt(int(math.Floor(u*float64(width))), 0, width-1)
+		row := clampInt(int(math.Floor((1-v)*float64(height))), 0, height-1)
+		c := img.NRGBAAt(col, row)
+		return rgba{float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255, float64(c.A) / 255}
+	}, alpha)
+}
+
+// imageFunc returns the colors of image `img` with samples in colorspace `cs` and Decode array
+// `decode`, nil for the default decoding.
+type imageFunc func(img *model.Image, cs model.PdfColorspace, decode core.PdfObject) (*image.NRGBA, error)
+
+// loadImageXObject returns the colors and alpha of image XObject `stream`, with colors given by
+// `colors`. Stencil masks are painted with color `fill`.
+func loadImageXObject(stream *core.PdfObjectStream, fill rgba, colors imageFunc) (*image.NRGBA, error) {
+	ximg, err := model.NewXObjectImageFromStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	if isTrue(ximg.ImageMask) {
+		return loadStencil(ximg, stream, fill)
+	}
+
+	img, err := ximg.ToImage()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImageSize(img); err != nil {
+		return nil, err
+	}
+	width, height := int(img.Width), int(img.Height)
+	out, err := colors(img, ximg.ColorSpace, ximg.Decode)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mask := core.TraceToDirectObject(ximg.Mask).(type) {
+	case *core.PdfObjectArray:
+		// Color key masking: pixels with all components in the ranges are not painted.
+		ranges, err := mask.ToIntegerArray()
+		if err == nil && len(ranges) == 2*img.ColorComponents {
+			samples := img.GetSamples()
+			for i := 0; i < width*height && (i+1)*img.ColorComponents <= len(samples); i++ {
+				masked := true
+				for j := 0; j < img.ColorComponents; j++ {
+					s := int(samples[i*img.ColorComponents+j])
+					if s < ranges[2*j] || s > ranges[2*j+1] {
+						masked = false
+						break
+					}
+				}
+				if masked {
+					out.Pix[4*i+3] = 0
+				}
+			}
+		}
+	case *core.PdfObjectStream:
+		// Stencil masking by an image mask, which may differ in size from the image. The mask's own
+		// masks are not applied.
+		maskImg, err := loadStencilMask(mask)
+		if err != nil {
+			common.Log.Debug("Unable to load image mask: %v", err)
+			break
+		}
+		applyAlpha(out, maskImg)
+	}
+
+	if smask, ok := core.TraceToDirectObject(ximg.SMask).(*core.PdfObjectStream); ok {
+		alpha, err := loadSoftMask(smask)
+		if err != nil {
+			common.Log.Debug("Unable to load soft mask: %v", err)
+		} else {
+			applyAlpha(out, alpha)
+		}
+	}
+	return out, nil
+}
+
+// loadStencilMask returns the image mask `stream` of an image (8.9.6.3 "Explicit Masking"), opaque
+// where the image is painted.
+func loadStencilMask(stream *core.PdfObjectStream) (*image.NRGBA, error) {
+	ximg, err := model.NewXObjectImageFromStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	return loadStencil(ximg, stream, rgba{0, 0, 0, 1})
+}
+
+// loadStencil returns the image of stencil mask `ximg` with data `stream`, painted with color `fill`.
+func loadStencil(ximg *model.XObjectImage, stream *core.PdfObjectStream, fill rgba) (*image.NRGBA, error) {
+	if ximg.Width == nil || ximg.Height == nil {
+		return nil, errors.New("Image size missing")
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	img := &model.Image{Width: *ximg.Width, Height: *ximg.Height, BitsPerComponent: 1, ColorComponents: 1,
+		Data: data}
+	if err := checkImageSize(img); err != nil {
+		return nil, err
+	}
+	return stencilImage(data, int(img.Width), int(img.Height), ximg.Decode, fill), nil
+}
+
+// loadSoftMask returns the soft mask image `stream` (8.9.6.4 "Soft-Mask Images") with its gray
+// levels as alpha.
+func loadSoftMask(stream *core.PdfObjectStream) (*image.NRGBA, error) {
+	ximg, err := model.NewXObjectImageFromStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	img, err := ximg.ToImage()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImageSize(img); err != nil {
+		return nil, err
+	}
+	gray, err := colorImage(img, ximg.ColorSpace)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(gray.Pix); i += 4 {
+		gray.Pix[i+3] = gray.Pix[i]
+	}
+	return gray, nil
+}
+
+// loadInlineImage returns the colors and alpha of inline image `inline` of a content stream with
+// resources `resources`, with colors given by `colors`. Stencil masks are painted with color `fill`.
+func loadInlineImage(inline *contentstream.ContentStreamInlineImage, resources *model.PdfPageResources,
+	fill rgba, colors imageFunc) (*image.NRGBA, error) {
+	img, err := inline.ToImage(resources)
+	if err != nil {
+		return nil, err
+	}
+	isMask, err := inline.IsMask()
+	if err != nil {
+		return nil, err
+	}
+	if isMask {
+		img.BitsPerComponent, img.ColorComponents = 1, 1
+	}
+	if err := checkImageSize(img); err != nil {
+		return nil, err
+	}
+	if isMask {
+		return stencilImage(img.Data, int(img.Width), int(img.Height), inline.Decode, fill), nil
+	}
+	cs := model.PdfColorspace(model.NewPdfColorspaceDeviceGray())
+	if inline.ColorSpace != nil {
+		if cs, err = inline.GetColorSpace(resources); err != nil {
+			return nil, err
+		}
+	}
+	return colors(img, cs, inline.Decode)
+}
+
+// colorImage returns image `img` with samples in colorspace `cs` converted to RGB.
+func colorImage(img *model.Image, cs model.PdfColorspace) (*image.NRGBA, error) {
+	rgbImg, err := cs.ImageToRGB(*img)
+	if err != nil {
+		return nil, err
+	}
+	width, height := int(img.Width), int(img.Height)
+	samples := rgbImg.GetSamples()
+	maxVal := math.Pow(2, float64(rgbImg.BitsPerComponent)) - 1
+	if maxVal <= 0 {
+		return nil, errors.New("Invalid bits per component")
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, width, height))
+	n := rgbImg.ColorComponents
+	for i := 0; i < width*height; i++ {
+		pix := out.Pix[4*i : 4*i+4]
+		pix[3] = 255
+		if (i+1)*n > len(samples) {
+			// Missing samples are black.
+			continue
+		}
+		for j := 0; j < 3; j++ {
+			s := samples[i*n]
+			if n >= 3 {
+				s = samples[i*n+j]
+			}
+			pix[j] = uint8(math.Floor(float64(s)*255/maxVal + 0.5))
+		}
+	}
+	return out, nil
+}
+
+// stencilImage returns the image of stencil mask `data` of size `width` x `height` with 1 bit
+// samples: color `fill`, with its alpha, where it is painted and transparent elsewhere. Samples of 0 are painted, or
+// samples of 1 if the Decode array `decode` is [1 0].
+func stencilImage(data []byte, width, height int, decode core.PdfObject, fill rgba) *image.NRGBA {
+	paintBit := byte(0)
+	if arr, ok := core.TraceToDirectObject(decode).(*core.PdfObjectArray); ok {
+		if d, err := arr.ToFloat64Array(); err == nil && len(d) == 2 && d[0] > d[1] {
+			paintBit = 1
+		}
+	}
+	out := image.NewNRGBA(image.Rect(0, 0, width, height))
+	r, g, b := uint8(clamp01(fill.r)*255+0.5), uint8(clamp01(fill.g)*255+0.5), uint8(clamp01(fill.b)*255+0.5)
+	a := uint8(clamp01(fill.a)*255 + 0.5)
+	stride := (width + 7) / 8
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*stride + x/8
+			if i >= len(data) || (data[i]>>uint(7-x%8))&1 != paintBit {
+				continue
+			}
+			pix := out.Pix[out.PixOffset(x, y):]
+			pix[0], pix[1], pix[2], pix[3] = r, g, b, a
+		}
+	}
+	return out
+}
+
+// checkImageSize returns an error if the size of `img` is not positive or if it has more pixels than
+// its data holds.
+func checkImageSize(img *model.Image) error {
+	bits := int(img.BitsPerComponent) * img.ColorComponents
+	if img.Width <= 0 || img.Height <= 0 || bits <= 0 {
+		return errors.New("Invalid image size")
+	}
+	if img.Width > int64(8*len(img.Data)/bits)/img.Height {
+		return errors.New("Image size exceeds image data")
+	}
+	return nil
+}
+
+// applyAlpha multiplies the alpha of `img` by the alpha of `mask`, which is scaled to the size of
+// `img`.
+func applyAlpha(img, mask *image.NRGBA) {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	mw, mh := mask.Rect.Dx(), mask.Rect.Dy()
+	for y := 0; y < h; y++ {
+		my := y * mh / h
+		for x := 0; x < w; x++ {
+			a := mask.Pix[mask.PixOffset(x*mw/w, my)+3]
+			i := img.PixOffset(x, y) + 3
+			img.Pix[i] = uint8((int(img.Pix[i])*int(a) + 127) / 255)
+		}
+	}
+}
+
+// invertMatrix returns the inverse of affine transform `m`. The bool return flag is false if `m` is
+// not invertible.
+func invertMatrix(m contentstream.Matrix) (contentstream.Matrix, bool) {
+	det := m[0]*m[4] - m[1]*m[3]
+	if det == 0 || math.IsNaN(det) {
+		return m, false
+	}
+	a, b, c, d := m[4]/det, -m[1]/det, -m[3]/det, m[0]/det
+	return contentstream.NewMatrix(a, b, c, d, -(m[6]*a + m[7]*c), -(m[6]*b + m[7]*d)), true
+}
+
+// clampInt returns `v` clamped to the range `lo` to `hi`.
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// isTrue returns true if `obj` is the boolean true.
+func isTrue(obj core.PdfObject) bool {
+	b, ok := core.TraceToDirectObject(obj).(*core.PdfObjectBool)
+	return ok && bool(*b)
+}
@@ -0,0 +1,510 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// DefaultTotalInkLimit is the total ink limit of the ink analysis if none is set, in percent.
+const DefaultTotalInkLimit = 300
+
+// inkTolerance is the total ink, in percent, by which pixels may exceed the total ink limit before
+// they are flagged. It allows for the 8 bit precision of the rendered plates.
+const inkTolerance = 0.5
+
+// The names of the process colorants, which are the colorants of DeviceCMYK, in component order.
+var processColorants = []string{"Cyan", "Magenta", "Yellow", "Black"}
+
+// InkOptions are the options of the ink analysis of pages.
+type InkOptions struct {
+	// DPI is the resolution in pixels per inch of the rendering of the plates. 0 means 72 DPI.
+	DPI float64
+	// Box is the page boundary that is analyzed.
+	Box PageBox
+	// TotalInkLimit is the maximum total ink of the plates, in percent, e.g. 300 for three plates
+	// with full coverage. 0 means DefaultTotalInkLimit.
+	TotalInkLimit float64
+}
+
+// Separation is a colorant of a page: a process colorant or a spot colorant of Separation and
+// DeviceN colorspaces.
+type Separation struct {
+	// Name is the colorant name: Cyan, Magenta, Yellow or Black for process colorants.
+	Name string
+	// Process is true for process colorants.
+	Process bool
+	// AlternateSpace is the alternate colorspace of the first colorspace of a spot colorant, nil for
+	// process colorants.
+	AlternateSpace model.PdfColorspace
+	// Alternate is the color of the full tint of a spot colorant in AlternateSpace, nil if it cannot
+	// be computed.
+	Alternate model.PdfColor
+	// Coverage is the area coverage of the plate: the mean tint of its pixels, in percent.
+	Coverage float64
+	// Area is the percentage of the pixels of the plate with ink.
+	Area float64
+}
+
+// InkReport is the ink analysis of a page.
+type InkReport struct {
+	// Separations are the colorants of the colors that are painted: the process colorants in CMYK
+	// order, then the spot colorants in the order in which they are first painted.
+	Separations []Separation
+	// TotalCoverage is the sum of the area coverages of the plates, in percent.
+	TotalCoverage float64
+	// MaxTotalInk is the highest total ink of the plates in a pixel, in percent.
+	MaxTotalInk float64
+	// TotalInkLimit is the total ink limit of the analysis, in percent.
+	TotalInkLimit float64
+	// ExceedingArea is the percentage of the pixels with total ink above the limit.
+	ExceedingArea float64
+	// ExceedsLimit is true if the total ink of some pixels is above the limit.
+	ExceedsLimit bool
+}
+
+// AnalyzeInks returns the ink analysis of `page`: the process and spot colorants painted by its
+// content, including images and shadings, and the coverage of their plates. The plates are rendered
+// as RenderPage renders the page, at the resolution of `opts`, with the overprint parameters of
+// the graphics state: colorants that are not painted by colors painted with overprint are left
+// unchanged, and erased otherwise.
+//
+// Process colors are separated as DeviceCMYK colors: gray levels are black ink, and other colors
+// are converted from RGB with full black generation. The colorant All of Separation colorspaces
+// paints all plates and the colorant None paints none.
+func AnalyzeInks(page *model.PdfPage, opts InkOptions) (*InkReport, error) {
+	renderOpts := RenderOptions{DPI: opts.DPI, Box: opts.Box}
+	report := &InkReport{TotalInkLimit: opts.TotalInkLimit}
+	if report.TotalInkLimit <= 0 {
+		report.TotalInkLimit = DefaultTotalInkLimit
+	}
+
+	// The colorants are recorded by a rendering that paints nothing.
+	sep := newSeparator()
+	if _, err := renderPage(page, renderOpts, sep); err != nil {
+		return nil, err
+	}
+	sep.plates = sep.orderPlates()
+
+	var total []float64
+	for i := range sep.plates {
+		sep.plate = i
+		img, err := renderPage(page, renderOpts, sep)
+		if err != nil {
+			return nil, err
+		}
+		if total == nil {
+			total = make([]float64, len(img.Pix)/4)
+		}
+		var sum float64
+		inked := 0
+		for j := range total {
+			ink := 1 - float64(img.Pix[4*j])/255
+			if ink > 0 {
+				inked++
+			}
+			total[j] += ink
+			sum += ink
+		}
+		plate := &sep.plates[i]
+		plate.Coverage = 100 * sum / float64(len(total))
+		plate.Area = 100 * float64(inked) / float64(len(total))
+		report.TotalCoverage += plate.Coverage
+	}
+	report.Separations = sep.plates
+
+	exceeding := 0
+	for _, ink := range total {
+		report.MaxTotalInk = math.Max(report.MaxTotalInk, 100*ink)
+		if 100*ink > report.TotalInkLimit+inkTolerance {
+			exceeding++
+		}
+	}
+	if exceeding > 0 {
+		report.ExceedsLimit = true
+		report.ExceedingArea = 100 * float64(exceeding) / float64(len(total))
+	}
+	return report, nil
+}
+
+// overprint is the overprint control of a painting operation (8.6.7 "Overprint Control").
+type overprint struct {
+	on   bool // Colorants that are not painted are left unchanged instead of erased.
+	mode int  // With mode 1, zero DeviceCMYK components leave their colorants unchanged.
+}
+
+// newOverprint returns the overprint control of stroking, if `stroking` is true, or nonstroking
+// operations in graphics state `gs`.
+func newOverprint(gs contentstream.GraphicsState, stroking bool) overprint {
+	if stroking {
+		return overprint{gs.OverprintStroking, gs.OverprintMode}
+	}
+	return overprint{gs.OverprintNonStroking, gs.OverprintMode}
+}
+
+// separator renders the ink of the plate of a colorant, with gray levels of 1 minus the tint,
+// instead of the colors of a page. Before the plates are rendered, it records the colorants of the
+// colors that are painted.
+type separator struct {
+	plates  []Separation
+	process [4]bool // The process colorants that are painted.
+	spots   []Separation
+	// plate is the index of the rendered plate, -1 when the colorants are recorded.
+	plate    int
+	recorded map[model.PdfColorspace]bool
+	lookups  map[*model.PdfColorspaceSpecialIndexed][]byte
+}
+
+// newSeparator returns a separator that records the colorants of a page.
+func newSeparator() *separator {
+	return &separator{
+		plate:    -1,
+		recorded: map[model.PdfColorspace]bool{},
+		lookups:  map[*model.PdfColorspaceSpecialIndexed][]byte{},
+	}
+}
+
+// orderPlates returns the plates of the recorded colorants: the process colorants, then the spot
+// colorants.
+func (s *separator) orderPlates() []Separation {
+	var plates []Separation
+	for i, painted := range s.process {
+		if painted {
+			plates = append(plates, Separation{Name: processColorants[i], Process: true})
+		}
+	}
+	return append(plates, s.spots...)
+}
+
+// record records the colorants of the colors of `cs`.
+func (s *separator) record(cs model.PdfColorspace) {
+	if s.recorded[cs] {
+		return
+	}
+	s.recorded[cs] = true
+	switch t := cs.(type) {
+	case *model.PdfColorspaceSpecialSeparation:
+		if t.ColorantName != nil {
+			s.recordColorant(string(*t.ColorantName), t, []float64{1})
+		}
+	case *model.PdfColorspaceDeviceN:
+		names := colorantNames(t)
+		for i, name := range names {
+			tints := make([]float64, len(names))
+			tints[i] = 1
+			s.recordColorant(name, t, tints)
+		}
+	case *model.PdfColorspaceSpecialIndexed:
+		if t.Base != nil {
+			s.record(t.Base)
+		}
+	case *model.PdfColorspaceSpecialPattern:
+		// The colorspaces of shading patterns are recorded when they are painted.
+	case *model.PdfColorspaceDeviceGray, *model.PdfColorspaceCalGray:
+		s.process[3] = true
+	default:
+		if cs.GetNumComponents() == 1 {
+			s.process[3] = true
+		} else {
+			s.process = [4]bool{true, true, true, true}
+		}
+	}
+}
+
+// recordColorant records colorant `name` of Separation or DeviceN colorspace `cs`, whose full tint
+// is the color with components `tints`.
+func (s *separator) recordColorant(name string, cs model.PdfColorspace, tints []float64) {
+	if name == "All" || name == "None" {
+		return
+	}
+	if i := processIndex(name); i >= 0 {
+		s.process[i] = true
+		return
+	}
+	for _, spot := range s.spots {
+		if spot.Name == name {
+			return
+		}
+	}
+	spot := Separation{Name: name}
+	var tintTransform model.PdfFunction
+	switch t := cs.(type) {
+	case *model.PdfColorspaceSpecialSeparation:
+		spot.AlternateSpace, tintTransform = t.AlternateSpace, t.TintTransform
+	case *model.PdfColorspaceDeviceN:
+		spot.AlternateSpace, tintTransform = t.AlternateSpace, t.TintTransform
+	}
+	if spot.AlternateSpace != nil && tintTransform != nil {
+		if vals, err := tintTransform.Evaluate(tints); err == nil {
+			spot.Alternate, _ = spot.AlternateSpace.ColorFromFloats(vals)
+		}
+	}
+	s.spots = append(s.spots, spot)
+}
+
+// color returns the color of the ink of the rendered plate painted by color `c` with components
+// `comps` of colorspace `cs` with overprint control `op`. `c` may be nil if `comps` are given. The
+// color is transparent where the plate is left unchanged, and while the colorants are recorded. The
+// bool return flag is false if the color cannot be painted as a solid color, e.g. for patterns.
+func (s *separator) color(cs model.PdfColorspace, c model.PdfColor, comps []float64, op overprint) (rgba, bool) {
+	if cs == nil {
+		return rgba{}, false
+	}
+	if _, isPattern := cs.(*model.PdfColorspaceSpecialPattern); isPattern {
+		return rgba{}, false
+	}
+	if s.plate < 0 {
+		s.record(cs)
+		return rgba{}, true
+	}
+	if paintsNothing(cs) {
+		return rgba{}, true
+	}
+
+	tint, paints, err := s.tint(cs, c, comps, s.plates[s.plate].Name)
+	if err != nil {
+		common.Log.Debug("Unable to separate color %v of %s: %v", comps, cs, err)
+		return rgba{}, false
+	}
+	if _, isCMYK := cs.(*model.PdfColorspaceDeviceCMYK); isCMYK && op.on && op.mode == 1 && tint == 0 {
+		paints = false
+	}
+	if !paints {
+		if op.on {
+			return rgba{}, true
+		}
+		tint = 0
+	}
+	v := 1 - clamp01(tint)
+	return rgba{v, v, v, 1}, true
+}
+
+// tint returns the tint of colorant `name` in color `c` with components `comps` of colorspace `cs`.
+// The bool return flag is false if the color does not paint the colorant.
+func (s *separator) tint(cs model.PdfColorspace, c model.PdfColor, comps []float64, name string) (float64, bool, error) {
+	switch t := cs.(type) {
+	case *model.PdfColorspaceSpecialSeparation:
+		if t.ColorantName == nil || len(comps) != 1 {
+			return 0, false, errors.New("Invalid Separation color")
+		}
+		colorant := string(*t.ColorantName)
+		if colorant == "All" || colorant == name {
+			return comps[0], true, nil
+		}
+		return 0, false, nil
+	case *model.PdfColorspaceDeviceN:
+		names := colorantNames(t)
+		if len(comps) != len(names) {
+			return 0, false, errors.New("Invalid DeviceN color")
+		}
+		for i, colorant := range names {
+			if colorant == name {
+				return comps[i], true, nil
+			}
+		}
+		return 0, false, nil
+	case *model.PdfColorspaceSpecialIndexed:
+		baseComps, err := s.lookup(t, comps)
+		if err != nil {
+			return 0, false, err
+		}
+		return s.tint(t.Base, nil, baseComps, name)
+	}
+
+	i := processIndex(name)
+	if i < 0 {
+		return 0, false, nil
+	}
+	if c == nil {
+		var err error
+		if c, err = cs.ColorFromFloats(comps); err != nil {
+			return 0, false, err
+		}
+	}
+	switch t := c.(type) {
+	case *model.PdfColorDeviceCMYK:
+		return []float64{t.C(), t.M(), t.Y(), t.K()}[i], true, nil
+	case *model.PdfColorDeviceGray:
+		if i == 3 {
+			return 1 - t.Val(), true, nil
+		}
+		return 0, true, nil
+	}
+	rgbColor, err := cs.ColorToRGB(c)
+	if err != nil {
+		return 0, false, err
+	}
+	rgb, ok := rgbColor.(*model.PdfColorDeviceRGB)
+	if !ok {
+		return 0, false, errors.New("Type check error")
+	}
+	return rgbToCMYK(rgb.R(), rgb.G(), rgb.B())[i], true, nil
+}
+
+// lookup returns the components of the base colorspace of the color with components `comps` of
+// Indexed colorspace `cs`.
+func (s *separator) lookup(cs *model.PdfColorspaceSpecialIndexed, comps []float64) ([]float64, error) {
+	if cs.Base == nil || len(comps) != 1 {
+		return nil, errors.New("Invalid Indexed color")
+	}
+	data, has := s.lookups[cs]
+	if !has {
+		switch t := core.TraceToDirectObject(cs.Lookup).(type) {
+		case *core.PdfObjectString:
+			data = []byte(*t)
+		case *core.PdfObjectStream:
+			var err error
+			if data, err = core.DecodeStream(t); err != nil {
+				common.Log.Debug("Unable to decode Indexed lookup table: %v", err)
+			}
+		}
+		s.lookups[cs] = data
+	}
+	n := cs.Base.GetNumComponents()
+	index := int(comps[0])
+	if index < 0 || (index+1)*n > len(data) {
+		return nil, errors.New("Outside range")
+	}
+	baseComps := make([]float64, n)
+	for i := range baseComps {
+		baseComps[i] = float64(data[index*n+i]) / 255
+	}
+	return baseComps, nil
+}
+
+// image returns the ink of the rendered plate painted by image `img` with samples in colorspace
+// `cs` and Decode array `decode`, with overprint control `op`. While the colorants are recorded,
+// the image is transparent.
+func (s *separator) image(img *model.Image, cs model.PdfColorspace, decode core.PdfObject,
+	op overprint) (*image.NRGBA, error) {
+	if s.plate < 0 {
+		s.record(cs)
+		return image.NewNRGBA(image.Rect(0, 0, 1, 1)), nil
+	}
+	n := cs.GetNumComponents()
+	bpc := int(img.BitsPerComponent)
+	maxVal := math.Pow(2, float64(bpc)) - 1
+	if maxVal <= 0 || n <= 0 {
+		return nil, errors.New("Invalid image")
+	}
+	d := imageDecode(decode, cs, maxVal)
+
+	width, height := int(img.Width), int(img.Height)
+	out := image.NewNRGBA(image.Rect(0, 0, width, height))
+	samples := img.GetSamples()
+	// Images often have few distinct colors, which are separated once.
+	cache := map[uint64]rgba{}
+	cacheable := n*bpc <= 64
+	vals := make([]float64, n)
+	for i := 0; i < width*height && (i+1)*n <= len(samples); i++ {
+		var key uint64
+		for j := range vals {
+			key = key<<uint(bpc) | uint64(samples[i*n+j])
+			vals[j] = d[2*j] + float64(samples[i*n+j])*(d[2*j+1]-d[2*j])/maxVal
+		}
+		col, has := cache[key]
+		if !has || !cacheable {
+			col, _ = s.color(cs, nil, vals, op)
+			if cacheable {
+				cache[key] = col
+			}
+		}
+		pix := out.Pix[4*i : 4*i+4]
+		pix[0] = uint8(col.r*255 + 0.5)
+		pix[1], pix[2] = pix[0], pix[0]
+		pix[3] = uint8(col.a*255 + 0.5)
+	}
+	return out, nil
+}
+
+// imageDecode returns the Decode array `decode` of an image with samples in colorspace `cs` with
+// maximum value `maxVal`, or the default Decode array if it is not valid.
+func imageDecode(decode core.PdfObject, cs model.PdfColorspace, maxVal float64) []float64 {
+	n := cs.GetNumComponents()
+	if arr, ok := core.TraceToDirectObject(decode).(*core.PdfObjectArray); ok {
+		if d, err := arr.ToFloat64Array(); err == nil && len(d) == 2*n {
+			return d
+		}
+	}
+	if _, isIndexed := cs.(*model.PdfColorspaceSpecialIndexed); isIndexed {
+		return []float64{0, maxVal}
+	}
+	if d := cs.DecodeArray(); len(d) == 2*n {
+		return d
+	}
+	d := make([]float64, 0, 2*n)
+	for i := 0; i < n; i++ {
+		d = append(d, 0, 1)
+	}
+	return d
+}
+
+// colorantNames returns the names of the colorants of DeviceN colorspace `cs`.
+func colorantNames(cs *model.PdfColorspaceDeviceN) []string {
+	if cs.ColorantNames == nil {
+		return nil
+	}
+	var names []string
+	for _, obj := range *cs.ColorantNames {
+		name, _ := core.TraceToDirectObject(obj).(*core.PdfObjectName)
+		if name == nil {
+			names = append(names, "")
+			continue
+		}
+		names = append(names, string(*name))
+	}
+	return names
+}
+
+// paintsNothing returns true if the colors of `cs` do not paint any plate: those of the Separation
+// colorant None and of DeviceN colorspaces with only None colorants.
+func paintsNothing(cs model.PdfColorspace) bool {
+	switch t := cs.(type) {
+	case *model.PdfColorspaceSpecialSeparation:
+		return t.ColorantName != nil && *t.ColorantName == "None"
+	case *model.PdfColorspaceDeviceN:
+		for _, name := range colorantNames(t) {
+			if name != "None" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// processIndex returns the index of process colorant `name` in processColorants, or -1 if it is not
+// a process colorant.
+func processIndex(name string) int {
+	for i, process := range processColorants {
+		if name == process {
+			return i
+		}
+	}
+	return -1
+}
+
+// rgbToCMYK returns the DeviceCMYK components of RGB color `r`, `g`, `b` with full black generation
+// and undercolor removal.
+func rgbToCMYK(r, g, b float64) []float64 {
+	k := 1 - math.Max(r, math.Max(g, b))
+	if k >= 1 {
+		return []float64{0, 0, 0, 1}
+	}
+	return []float64{
+		clamp01((1 - r - k) / (1 - k)),
+		clamp01((1 - g - k) / (1 - k)),
+		clamp01((1 - b - k) / (1 - k)),
+		clamp01(k),
+	}
+}
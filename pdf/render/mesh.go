@@ -0,0 +1,451 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// meshVertex is a vertex of a shading mesh in device space with its color components, or the
+// parametric variable of the shading's function.
+type meshVertex struct {
+	p point
+	c []float64
+}
+
+// meshReader reads the vertices of the stream of a mesh shading (8.7.4.5.5 - 8.7.4.5.8).
+type meshReader struct {
+	data   []byte
+	pos    int // Position in bits.
+	bpc    int // Bits per coordinate.
+	bpcomp int // Bits per color component.
+	bpf    int // Bits per flag.
+	decode []float64
+	ncomp  int
+	sr     *shadingRenderer
+}
+
+// newMeshReader returns a reader of the vertices of mesh shading `shading`.
+func newMeshReader(sr *shadingRenderer, shading *model.PdfShading, funcs []model.PdfFunction,
+	bitsPerCoordinate, bitsPerComponent, bitsPerFlag *core.PdfObjectInteger,
+	decode *core.PdfObjectArray) (*meshReader, error) {
+	stream, ok := shading.GetContainingPdfObject().(*core.PdfObjectStream)
+	if !ok {
+		return nil, errors.New("Mesh shading not a stream")
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		return nil, err
+	}
+	mr := &meshReader{data: data, sr: sr, ncomp: shading.ColorSpace.GetNumComponents()}
+	if len(funcs) > 0 {
+		// A single parametric value replaces the color components.
+		mr.ncomp = 1
+	}
+	if bitsPerCoordinate == nil || bitsPerComponent == nil || decode == nil {
+		return nil, errors.New("Mesh shading parameters missing")
+	}
+	mr.bpc, mr.bpcomp = int(*bitsPerCoordinate), int(*bitsPerComponent)
+	if bitsPerFlag != nil {
+		mr.bpf = int(*bitsPerFlag)
+	}
+	if mr.bpc < 1 || mr.bpc > 32 || mr.bpcomp < 1 || mr.bpcomp > 16 || mr.bpf < 0 || mr.bpf > 8 {
+		return nil, errors.New("Invalid mesh shading bit sizes")
+	}
+	mr.decode, err = decode.ToFloat64Array()
+	if err != nil {
+		return nil, err
+	}
+	if len(mr.decode) < 4+2*mr.ncomp {
+		return nil, errors.New("Invalid mesh shading Decode")
+	}
+	return mr, nil
+}
+
+// read returns the next `n` bits of the data. The bool return flag is false at the end of the data.
+func (mr *meshReader) read(n int) (uint64, bool) {
+	if mr.pos+n > 8*len(mr.data) {
+		return 0, false
+	}
+	var v uint64
+	for i := 0; i < n; i++ {
+		bit := mr.data[mr.pos/8] >> uint(7-mr.pos%8) & 1
+		v = v<<1 | uint64(bit)
+		mr.pos++
+	}
+	return v, true
+}
+
+// align skips to the start of the next byte.
+func (mr *meshReader) align() {
+	mr.pos = (mr.pos + 7) / 8 * 8
+}
+
+// readFlag returns the next edge flag.
+func (mr *meshReader) readFlag() (int, bool) {
+	f, ok := mr.read(mr.bpf)
+	return int(f), ok
+}
+
+// readPoint returns the next point, in device space.
+func (mr *meshReader) readPoint() (point, bool) {
+	var xy [2]float64
+	for i := range xy {
+		v, ok := mr.read(mr.bpc)
+		if !ok {
+			return point{}, false
+		}
+		xy[i] = decodeSample(v, mr.bpc, mr.decode[2*i], mr.decode[2*i+1])
+	}
+	x, y := mr.sr.m.Transform(xy[0], xy[1])
+	return point{x, y}, true
+}
+
+// readColor returns the next color components or parametric value.
+func (mr *meshReader) readColor() ([]float64, bool) {
+	c := make([]float64, mr.ncomp)
+	for i := range c {
+		v, ok := mr.read(mr.bpcomp)
+		if !ok {
+			return nil, false
+		}
+		c[i] = decodeSample(v, mr.bpcomp, mr.decode[4+2*i], mr.decode[5+2*i])
+	}
+	return c, true
+}
+
+// readVertex returns the next vertex of a triangle mesh, which starts at a byte boundary.
+func (mr *meshReader) readVertex() (meshVertex, bool) {
+	p, ok := mr.readPoint()
+	if !ok {
+		return meshVertex{}, false
+	}
+	c, ok := mr.readColor()
+	if !ok {
+		return meshVertex{}, false
+	}
+	mr.align()
+	return meshVertex{p, c}, true
+}
+
+// decodeSample maps `bits` bit value `v` to the range `dmin` to `dmax`.
+func decodeSample(v uint64, bits int, dmin, dmax float64) float64 {
+	return dmin + float64(v)*(dmax-dmin)/float64(uint64(1)<<uint(bits)-1)
+}
+
+// meshShader returns the shader of the vertex colors of a mesh shading.
+func meshShader(shading *model.PdfShading, funcs []model.PdfFunction, mr *meshReader) *shader {
+	s := mr.sr.newShader(shading.ColorSpace, funcs)
+	if len(funcs) > 0 {
+		s.sampleRamp(mr.decode[4], mr.decode[5])
+	}
+	return s
+}
+
+// freeFormMesh paints free-form Gouraud-shaded triangle mesh `ctx` (8.7.4.5.5 "Type 4 Shadings").
+func (sr *shadingRenderer) freeFormMesh(shading *model.PdfShading, ctx *model.PdfShadingType4) error {
+	mr, err := newMeshReader(sr, shading, ctx.Function, ctx.BitsPerCoordinate, ctx.BitsPerComponent,
+		ctx.BitsPerFlag, ctx.Decode)
+	if err != nil {
+		return err
+	}
+	s := meshShader(shading, ctx.Function, mr)
+
+	// tri holds the vertices of the last triangle.
+	var tri []meshVertex
+	for {
+		flag, ok := mr.readFlag()
+		if !ok {
+			break
+		}
+		v, ok := mr.readVertex()
+		if !ok {
+			break
+		}
+		switch {
+		case flag == 0:
+			// A new triangle: the flags of the next two vertices are ignored.
+			tri = []meshVertex{v}
+			for len(tri) < 3 {
+				if _, ok = mr.readFlag(); !ok {
+					break
+				}
+				if v, ok = mr.readVertex(); !ok {
+					break
+				}
+				tri = append(tri, v)
+			}
+			if !ok {
+				break
+			}
+		case len(tri) != 3:
+			common.Log.Debug("Mesh edge flag %d without previous triangle", flag)
+			return nil
+		case flag == 1:
+			tri = []meshVertex{tri[1], tri[2], v}
+		default:
+			tri = []meshVertex{tri[0], tri[2], v}
+		}
+		if len(tri) == 3 {
+			sr.triangle(s, tri[0], tri[1], tri[2])
+		}
+	}
+	return nil
+}
+
+// latticeMesh paints lattice-form Gouraud-shaded triangle mesh `ctx` (8.7.4.5.6 "Type 5 Shadings").
+func (sr *shadingRenderer) latticeMesh(shading *model.PdfShading, ctx *model.PdfShadingType5) error {
+	mr, err := newMeshReader(sr, shading, ctx.Function, ctx.BitsPerCoordinate, ctx.BitsPerComponent,
+		nil, ctx.Decode)
+	if err != nil {
+		return err
+	}
+	if ctx.VerticesPerRow == nil || *ctx.VerticesPerRow < 2 {
+		return errors.New("Invalid VerticesPerRow")
+	}
+	perRow := int(*ctx.VerticesPerRow)
+	s := meshShader(shading, ctx.Function, mr)
+
+	var prev []meshVertex
+	for {
+		row := make([]meshVertex, 0, perRow)
+		for len(row) < perRow {
+			v, ok := mr.readVertex()
+			if !ok {
+				return nil
+			}
+			row = append(row, v)
+		}
+		if prev != nil {
+			for i := 0; i+1 < perRow; i++ {
+				sr.triangle(s, prev[i], prev[i+1], row[i])
+				sr.triangle(s, prev[i+1], row[i+1], row[i])
+			}
+		}
+		prev = row
+	}
+}
+
+// patchPoints are the indexes (u, v) of the 12 boundary control points of a patch, in the order
+// of the shading data, followed by the 4 internal control points of tensor-product patches.
+var patchPoints = [16][2]int{
+	{0, 0}, {0, 1}, {0, 2}, {0, 3}, {1, 3}, {2, 3}, {3, 3}, {3, 2}, {3, 1}, {3, 0}, {2, 0}, {1, 0},
+	{1, 1}, {1, 2}, {2, 2}, {2, 1},
+}
+
+// patchCorners are the indexes (u, v) of the colors of the corners of a patch, in the order of the
+// shading data.
+var patchCorners = [4][2]int{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+
+// patchShared are the indexes in the shading data order of the boundary points and corner colors
+// of the previous patch that start a patch with edge flag 1, 2 or 3.
+var patchShared = [4]struct {
+	points [4]int
+	colors [2]int
+}{
+	{},
+	{[4]int{3, 4, 5, 6}, [2]int{1, 2}},
+	{[4]int{6, 7, 8, 9}, [2]int{2, 3}},
+	{[4]int{9, 10, 11, 0}, [2]int{3, 0}},
+}
+
+// patch is a tensor-product patch: control points p[u][v] in device space and colors c[u][v] of the
+// corners.
+type patch struct {
+	p [4][4]point
+	c [2][2][]float64
+}
+
+// patchMesh paints Coons patch mesh (8.7.4.5.7 "Type 6 Shadings") or, if `tensor` is true,
+// tensor-product patch mesh (8.7.4.5.8 "Type 7 Shadings") `shading`.
+func (sr *shadingRenderer) patchMesh(shading *model.PdfShading, funcs []model.PdfFunction,
+	bitsPerCoordinate, bitsPerComponent, bitsPerFlag *core.PdfObjectInteger, decode *core.PdfObjectArray,
+	tensor bool) error {
+	mr, err := newMeshReader(sr, shading, funcs, bitsPerCoordinate, bitsPerComponent, bitsPerFlag, decode)
+	if err != nil {
+		return err
+	}
+	s := meshShader(shading, funcs, mr)
+
+	var prev *patch
+	for {
+		flag, ok := mr.readFlag()
+		if !ok || flag > 3 {
+			return nil
+		}
+		var pts [16]point
+		var colors [4][]float64
+		first, firstColor := 0, 0
+		if flag != 0 {
+			if prev == nil {
+				common.Log.Debug("Patch edge flag %d without previous patch", flag)
+				return nil
+			}
+			shared := patchShared[flag]
+			for i, j := range shared.points {
+				uv := patchPoints[j]
+				pts[i] = prev.p[uv[0]][uv[1]]
+			}
+			for i, j := range shared.colors {
+				uv := patchCorners[j]
+				colors[i] = prev.c[uv[0]][uv[1]]
+			}
+			first, firstColor = 4, 2
+		}
+		n := 12
+		if tensor {
+			n = 16
+		}
+		for i := first; i < n && ok; i++ {
+			pts[i], ok = mr.readPoint()
+		}
+		for i := firstColor; i < 4 && ok; i++ {
+			colors[i], ok = mr.readColor()
+		}
+		if !ok {
+			return nil
+		}
+		mr.align()
+
+		pt := &patch{}
+		for i := 0; i < n; i++ {
+			uv := patchPoints[i]
+			pt.p[uv[0]][uv[1]] = pts[i]
+		}
+		for i, uv := range patchCorners {
+			pt.c[uv[0]][uv[1]] = colors[i]
+		}
+		if !tensor {
+			pt.coonsInterior()
+		}
+		sr.patch(s, pt)
+		prev = pt
+	}
+}
+
+// coonsInterior sets the internal control points of Coons patch `pt` so that it is painted as the
+// equivalent tensor-product patch.
+func (pt *patch) coonsInterior() {
+	p := &pt.p
+	comb := func(w []float64, pts ...point) point {
+		var r point
+		for i, q := range pts {
+			r.x += w[i] * q.x / 9
+			r.y += w[i] * q.y / 9
+		}
+		return r
+	}
+	w := []float64{-4, 6, 6, -2, -2, 3, 3, -1}
+	p[1][1] = comb(w, p[0][0], p[0][1], p[1][0], p[0][3], p[3][0], p[3][1], p[1][3], p[3][3])
+	p[1][2] = comb(w, p[0][3], p[0][2], p[1][3], p[0][0], p[3][3], p[3][2], p[1][0], p[3][0])
+	p[2][1] = comb(w, p[3][0], p[3][1], p[2][0], p[3][3], p[0][0], p[0][1], p[2][3], p[0][3])
+	p[2][2] = comb(w, p[3][3], p[3][2], p[2][3], p[3][0], p[0][3], p[1][3], p[2][0], p[0][0])
+}
+
+// at returns the point of patch `pt` at parameters (`u`, `v`).
+func (pt *patch) at(u, v float64) point {
+	bu, bv := bernstein(u), bernstein(v)
+	var r point
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			w := bu[i] * bv[j]
+			r.x += w * pt.p[i][j].x
+			r.y += w * pt.p[i][j].y
+		}
+	}
+	return r
+}
+
+// color returns the color components of patch `pt` at parameters (`u`, `v`), interpolated from the
+// colors of its corners.
+func (pt *patch) color(u, v float64) []float64 {
+	c := make([]float64, len(pt.c[0][0]))
+	for k := range c {
+		c[k] = (1-u)*(1-v)*pt.c[0][0][k] + u*(1-v)*pt.c[1][0][k] + (1-u)*v*pt.c[0][1][k] + u*v*pt.c[1][1][k]
+	}
+	return c
+}
+
+// bernstein returns the cubic Bernstein polynomials at `t`.
+func bernstein(t float64) [4]float64 {
+	s := 1 - t
+	return [4]float64{s * s * s, 3 * t * s * s, 3 * t * t * s, t * t * t}
+}
+
+// patch paints patch `pt`, divided into a grid of Gouraud-shaded triangles small enough for the
+// curvature of its edges to be smooth.
+func (sr *shadingRenderer) patch(s *shader, pt *patch) {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, row := range pt.p {
+		for _, q := range row {
+			minX, maxX = math.Min(minX, q.x), math.Max(maxX, q.x)
+			minY, maxY = math.Min(minY, q.y), math.Max(maxY, q.y)
+		}
+	}
+	size := math.Max(maxX-minX, maxY-minY)
+	if math.IsNaN(size) || math.IsInf(size, 0) {
+		return
+	}
+	n := 2
+	if steps := size / 4; steps > float64(n) {
+		n = int(math.Min(steps, 64))
+	}
+
+	grid := make([][]meshVertex, n+1)
+	for i := range grid {
+		grid[i] = make([]meshVertex, n+1)
+		u := float64(i) / float64(n)
+		for j := range grid[i] {
+			v := float64(j) / float64(n)
+			grid[i][j] = meshVertex{pt.at(u, v), pt.color(u, v)}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			sr.triangle(s, grid[i][j], grid[i+1][j], grid[i][j+1])
+			sr.triangle(s, grid[i+1][j], grid[i+1][j+1], grid[i][j+1])
+		}
+	}
+}
+
+// triangle paints the triangle with vertices `a`, `b` and `c` with the colors of the vertices
+// interpolated over it.
+func (sr *shadingRenderer) triangle(s *shader, a, b, c meshVertex) {
+	area := (b.p.x-a.p.x)*(c.p.y-a.p.y) - (c.p.x-a.p.x)*(b.p.y-a.p.y)
+	if math.Abs(area) < 1e-9 || len(a.c) != len(b.c) || len(a.c) != len(c.c) {
+		return
+	}
+	bounds := sr.dst.Rect
+	x0 := clampInt(int(math.Floor(math.Min(a.p.x, math.Min(b.p.x, c.p.x)))), bounds.Min.X, bounds.Max.X)
+	x1 := clampInt(int(math.Ceil(math.Max(a.p.x, math.Max(b.p.x, c.p.x)))), bounds.Min.X, bounds.Max.X)
+	y0 := clampInt(int(math.Floor(math.Min(a.p.y, math.Min(b.p.y, c.p.y)))), bounds.Min.Y, bounds.Max.Y)
+	y1 := clampInt(int(math.Ceil(math.Max(a.p.y, math.Max(b.p.y, c.p.y)))), bounds.Min.Y, bounds.Max.Y)
+
+	// Pixel centers on the edges are painted, so that adjacent triangles leave no gaps.
+	const eps = -1e-9
+	comps := make([]float64, len(a.c))
+	for y := y0; y < y1; y++ {
+		py := float64(y) + 0.5
+		for x := x0; x < x1; x++ {
+			px := float64(x) + 0.5
+			wa := ((b.p.x-px)*(c.p.y-py) - (c.p.x-px)*(b.p.y-py)) / area
+			wb := ((c.p.x-px)*(a.p.y-py) - (a.p.x-px)*(c.p.y-py)) / area
+			wc := 1 - wa - wb
+			if wa < eps || wb < eps || wc < eps {
+				continue
+			}
+			for k := range comps {
+				comps[k] = wa*a.c[k] + wb*b.c[k] + wc*c.c[k]
+			}
+			sr.set(x, y, s.color(comps))
+		}
+	}
+}
@@ -0,0 +1,426 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"math"
+
+	"github.com/unidoc/unidoc/pdf/contentstream"
+)
+
+// flatness is the maximum distance in pixels between curves and the lines that approximate them.
+const flatness = 0.1
+
+// subpath is a sequence of connected lines.
+type subpath struct {
+	pts    []point
+	closed bool
+}
+
+// path is a path with its curves approximated by lines.
+type path struct {
+	subpaths []subpath
+	// tolerance is the maximum distance between curves and their lines in the space of the path.
+	tolerance float64
+}
+
+// newPath returns an empty path in a space that is mapped to device space by `m`.
+func newPath(m contentstream.Matrix) *path {
+	return &path{tolerance: flatness / matrixScale(m)}
+}
+
+// newUserPath returns path `p` of the content stream processor, in a space that is mapped to device
+// space by `m`.
+func newUserPath(p contentstream.Path, m contentstream.Matrix) *path {
+	out := newPath(m)
+	pt := func(seg contentstream.PathSegment, i int) point {
+		return point{seg.Points[i].X, seg.Points[i].Y}
+	}
+	for _, seg := range p.Segments {
+		switch seg.Type {
+		case contentstream.PathMoveTo:
+			out.moveTo(pt(seg, 0))
+		case contentstream.PathLineTo:
+			out.lineTo(pt(seg, 0))
+		case contentstream.PathCurveTo:
+			out.curveTo(pt(seg, 0), pt(seg, 1), pt(seg, 2))
+		case contentstream.PathClose:
+			out.closePath()
+		}
+	}
+	return out
+}
+
+// empty returns true if the path has no subpaths.
+func (p *path) empty() bool {
+	return len(p.subpaths) == 0
+}
+
+// current returns the current point. The bool return flag is false if there is none.
+func (p *path) current() (point, bool) {
+	if len(p.subpaths) == 0 {
+		return point{}, false
+	}
+	pts := p.subpaths[len(p.subpaths)-1].pts
+	return pts[len(pts)-1], true
+}
+
+// moveTo begins a new subpath at `pt`.
+func (p *path) moveTo(pt point) {
+	if n := len(p.subpaths); n > 0 && len(p.subpaths[n-1].pts) == 1 && !p.subpaths[n-1].closed {
+		// A subpath of a single point is only painted by strokes with round or square caps.
+		p.subpaths[n-1].pts[0] = pt
+		return
+	}
+	p.subpaths = append(p.subpaths, subpath{pts: []point{pt}})
+}
+
+// lineTo appends a line from the current point to `pt`.
+func (p *path) lineTo(pt point) {
+	if len(p.subpaths) == 0 || p.subpaths[len(p.subpaths)-1].closed {
+		start, ok := p.current()
+		if !ok {
+			start = pt
+		}
+		p.subpaths = append(p.subpaths, subpath{pts: []point{start}})
+	}
+	sp := &p.subpaths[len(p.subpaths)-1]
+	sp.pts = append(sp.pts, pt)
+}
+
+// curveTo appends a cubic Bézier curve from the current point with control points `c1` and `c2` to
+// `pt`.
+func (p *path) curveTo(c1, c2, pt point) {
+	p0, ok := p.current()
+	if !ok {
+		p0 = c1
+	}
+	// The number of lines is chosen so that the distance to the curve is within the tolerance.
+	dd := math.Max(math.Hypot(p0.x-2*c1.x+c2.x, p0.y-2*c1.y+c2.y),
+		math.Hypot(c1.x-2*c2.x+pt.x, c1.y-2*c2.y+pt.y))
+	n := int(math.Ceil(math.Sqrt(0.75 * dd / p.tolerance)))
+	if n < 1 {
+		n = 1
+	} else if n > 100 {
+		n = 100
+	}
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		p.lineTo(point{a*p0.x + b*c1.x + c*c2.x + d*pt.x, a*p0.y + b*c1.y + c*c2.y + d*pt.y})
+	}
+}
+
+// quadTo appends a quadratic Bézier curve from the current point with control point `c` to `pt`.
+func (p *path) quadTo(c, pt point) {
+	p0, ok := p.current()
+	if !ok {
+		p0 = c
+	}
+	p.curveTo(point{p0.x + 2*(c.x-p0.x)/3, p0.y + 2*(c.y-p0.y)/3},
+		point{pt.x + 2*(c.x-pt.x)/3, pt.y + 2*(c.y-pt.y)/3}, pt)
+}
+
+// closePath closes the current subpath. The next segment begins a new subpath at its start.
+func (p *path) closePath() {
+	if len(p.subpaths) == 0 {
+		return
+	}
+	sp := &p.subpaths[len(p.subpaths)-1]
+	if sp.closed {
+		return
+	}
+	sp.closed = true
+	// Following segments start at the start of the closed subpath.
+	p.subpaths = append(p.subpaths, subpath{pts: []point{sp.pts[0]}})
+}
+
+// fill adds the area of the path, mapped to device space by `m`, to `r`. Open subpaths are closed.
+func (p *path) fill(r *rasterizer, m contentstream.Matrix) {
+	for _, sp := range p.subpaths {
+		if len(sp.pts) < 2 {
+			continue
+		}
+		r.addPolygon(transformPoints(sp.pts, m))
+	}
+}
+
+// strokeStyle holds the parameters of the graphics state that determine the shape of strokes.
+type strokeStyle struct {
+	width      float64
+	cap        int // 0: butt, 1: round, 2: projecting square.
+	join       int // 0: miter, 1: round, 2: bevel.
+	miterLimit float64
+	dash       []float64
+	dashPhase  float64
+}
+
+// stroke adds the area of the stroke of the path with style `style`, mapped to device space by `m`,
+// to `r`. The stroke is at least one pixel wide.
+func (p *path) stroke(r *rasterizer, m contentstream.Matrix, style strokeStyle) {
+	scale := matrixScale(m)
+	s := stroker{style: style, tolerance: p.tolerance}
+	if s.style.width*scale < 1 {
+		s.style.width = 1 / scale
+	}
+	for _, sp := range p.subpaths {
+		pts := dedupPoints(sp.pts)
+		closed := sp.closed && len(pts) > 2
+		if len(pts) == 1 && len(sp.pts) == 1 && !sp.closed {
+			// Only zero length segments are stroked, not moveto operations.
+			continue
+		}
+		for _, dash := range s.dashes(pts, closed) {
+			s.polyline(dash, closed && len(s.style.dash) == 0)
+		}
+	}
+	for _, poly := range s.polygons {
+		r.addPolygon(transformPoints(poly, m))
+	}
+}
+
+// stroker builds the polygons that make up the stroke of a path. They have the same orientation so
+// that their union is filled by the nonzero winding number rule.
+type stroker struct {
+	style     strokeStyle
+	tolerance float64
+	polygons  [][]point
+}
+
+// dashes returns the dashes of the subpath with points `pts` for the dash pattern of the style, or
+// the subpath if it is not dashed. A closed subpath is returned with its first point appended.
+func (s *stroker) dashes(pts []point, closed bool) [][]point {
+	if closed {
+		pts = append(pts, pts[0])
+	}
+	total := 0.0
+	for _, d := range s.style.dash {
+		if d < 0 {
+			return [][]point{pts}
+		}
+		total += d
+	}
+	if len(s.style.dash) == 0 || total <= 0 {
+		return [][]point{pts}
+	}
+
+	pattern := s.style.dash
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64{}, pattern...), pattern...)
+	}
+	// Find the position in the pattern at the dash phase.
+	i := 0
+	left := pattern[0]
+	phase := math.Mod(s.style.dashPhase, 2*total)
+	if phase < 0 {
+		phase += 2 * total
+	}
+	for phase > 0 {
+		if phase < left {
+			left -= phase
+			break
+		}
+		phase -= left
+		i = (i + 1) % len(pattern)
+		left = pattern[i]
+	}
+
+	var dashes [][]point
+	var cur []point
+	if i%2 == 0 {
+		cur = []point{pts[0]}
+	}
+	for j := 1; j < len(pts); j++ {
+		a, b := pts[j-1], pts[j]
+		segLen := math.Hypot(b.x-a.x, b.y-a.y)
+		pos := 0.0
+		for segLen-pos > left {
+			pos += left
+			t := pos / segLen
+			pt := point{a.x + t*(b.x-a.x), a.y + t*(b.y-a.y)}
+			if i%2 == 0 {
+				dashes = append(dashes, append(cur, pt))
+				cur = nil
+			} else {
+				cur = []point{pt}
+			}
+			i = (i + 1) % len(pattern)
+			left = pattern[i]
+		}
+		left -= segLen - pos
+		if i%2 == 0 {
+			cur = append(cur, b)
+		}
+	}
+	if len(cur) > 1 {
+		dashes = append(dashes, cur)
+	}
+	return dashes
+}
+
+// polyline adds the stroke of the connected lines through `pts`. `closed` is true if the lines form a
+// closed subpath, where the last point is the first point, so that they are joined rather than
+// capped.
+func (s *stroker) polyline(pts []point, closed bool) {
+	hw := s.style.width / 2
+	pts = dedupPoints(pts)
+	if len(pts) == 1 {
+		// Zero length subpaths are painted by round and square caps.
+		switch s.style.cap {
+		case 1:
+			s.add(s.circle(pts[0], hw))
+		case 2:
+			p := pts[0]
+			s.add([]point{{p.x - hw, p.y - hw}, {p.x + hw, p.y - hw}, {p.x + hw, p.y + hw}, {p.x - hw, p.y + hw}})
+		}
+		return
+	}
+
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		n := normal(a, b, hw)
+		s.add([]point{{a.x + n.x, a.y + n.y}, {b.x + n.x, b.y + n.y}, {b.x - n.x, b.y - n.y}, {a.x - n.x, a.y - n.y}})
+		if i+1 < len(pts) {
+			s.join(a, b, pts[i+1])
+		}
+	}
+	if closed {
+		s.join(pts[len(pts)-2], pts[0], pts[1])
+		return
+	}
+	s.cap(pts[1], pts[0])
+	s.cap(pts[len(pts)-2], pts[len(pts)-1])
+}
+
+// join adds the join of the line from `a` to `b` and the line from `b` to `c`.
+func (s *stroker) join(a, b, c point) {
+	hw := s.style.width / 2
+	d1x, d1y := b.x-a.x, b.y-a.y
+	d2x, d2y := c.x-b.x, c.y-b.y
+	cross := d1x*d2y - d1y*d2x
+	dot := d1x*d2x + d1y*d2y
+	if math.Abs(cross) <= 1e-9*math.Hypot(d1x, d1y)*math.Hypot(d2x, d2y) && dot > 0 {
+		// Collinear lines.
+		return
+	}
+	if s.style.join == 1 {
+		s.add(s.circle(b, hw))
+		return
+	}
+
+	// The join is on the outer side of the turn.
+	n1, n2 := normal(a, b, hw), normal(b, c, hw)
+	if cross > 0 {
+		n1, n2 = point{-n1.x, -n1.y}, point{-n2.x, -n2.y}
+	}
+	o1, o2 := point{b.x + n1.x, b.y + n1.y}, point{b.x + n2.x, b.y + n2.y}
+	if s.style.join == 0 {
+		// The miter length ratio is 1/sin(φ/2) where φ is the angle between the lines.
+		cosPhi := -dot / (math.Hypot(d1x, d1y) * math.Hypot(d2x, d2y))
+		sinHalf := math.Sqrt(math.Max(0, (1-cosPhi)/2))
+		if sinHalf > 0 && 1/sinHalf <= s.style.miterLimit {
+			mx, my := n1.x+n2.x, n1.y+n2.y
+			l := math.Hypot(mx, my)
+			if l > 0 {
+				length := hw / sinHalf
+				tip := point{b.x + mx/l*length, b.y + my/l*length}
+				s.add([]point{b, o1, tip, o2})
+				return
+			}
+		}
+	}
+	s.add([]point{b, o1, o2})
+}
+
+// cap adds the line cap at end `b` of the line from `a` to `b`.
+func (s *stroker) cap(a, b point) {
+	hw := s.style.width / 2
+	switch s.style.cap {
+	case 1:
+		s.add(s.circle(b, hw))
+	case 2:
+		n := normal(a, b, hw)
+		d := point{-n.y, n.x}
+		if (b.x-a.x)*d.x+(b.y-a.y)*d.y < 0 {
+			d = point{-d.x, -d.y}
+		}
+		s.add([]point{{b.x + n.x, b.y + n.y}, {b.x + n.x + d.x, b.y + n.y + d.y},
+			{b.x - n.x + d.x, b.y - n.y + d.y}, {b.x - n.x, b.y - n.y}})
+	}
+}
+
+// circle returns a polygon that approximates the circle with center `c` and radius `r`.
+func (s *stroker) circle(c point, r float64) []point {
+	n := 128
+	if a := math.Acos(math.Max(0, 1-s.tolerance/r)); a > 0 {
+		n = int(math.Min(128, math.Max(8, math.Ceil(math.Pi/a))))
+	}
+	pts := make([]point, n)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		pts[i] = point{c.x + r*math.Cos(a), c.y + r*math.Sin(a)}
+	}
+	return pts
+}
+
+// add adds polygon `pts` to the stroke with the orientation of the other polygons.
+func (s *stroker) add(pts []point) {
+	area := 0.0
+	for i, p := range pts {
+		q := pts[(i+1)%len(pts)]
+		area += p.x*q.y - q.x*p.y
+	}
+	if area < 0 {
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+	s.polygons = append(s.polygons, pts)
+}
+
+// normal returns the vector of length `hw` perpendicular to the line from `a` to `b`, to its left.
+func normal(a, b point, hw float64) point {
+	dx, dy := b.x-a.x, b.y-a.y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return point{}
+	}
+	return point{-dy / l * hw, dx / l * hw}
+}
+
+// dedupPoints returns `pts` without consecutive duplicate points.
+func dedupPoints(pts []point) []point {
+	out := make([]point, 0, len(pts))
+	for i, p := range pts {
+		if i > 0 && p == out[len(out)-1] {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// transformPoints returns `pts` transformed by `m`.
+func transformPoints(pts []point, m contentstream.Matrix) []point {
+	out := make([]point, len(pts))
+	for i, p := range pts {
+		out[i].x, out[i].y = m.Transform(p.x, p.y)
+	}
+	return out
+}
+
+// matrixScale returns the factor by which `m` scales lengths on average.
+func matrixScale(m contentstream.Matrix) float64 {
+	scale := math.Sqrt(math.Abs(m[0]*m[4] - m[1]*m[3]))
+	if scale == 0 || math.IsNaN(scale) {
+		scale = math.Max(math.Hypot(m[0], m[1]), math.Hypot(m[3], m[4]))
+	}
+	if scale == 0 {
+		return 1
+	}
+	return scale
+}
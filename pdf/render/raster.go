@@ -0,0 +1,276 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// subsamples is the number of scanlines sampled per row of pixels. The horizontal coverage of the
+// pixels is computed exactly.
+const subsamples = 16
+
+// point is a point in user space, glyph space or device space.
+type point struct {
+	x, y float64
+}
+
+// mask is the coverage of the pixels of a region of the device by a shape, from 0 to 1. Pixels
+// outside the region are not covered.
+type mask struct {
+	rect image.Rectangle
+	a    []float32
+}
+
+// at returns the coverage of pixel (`x`, `y`).
+func (m *mask) at(x, y int) float32 {
+	if !image.Pt(x, y).In(m.rect) {
+		return 0
+	}
+	return m.a[(y-m.rect.Min.Y)*m.rect.Dx()+x-m.rect.Min.X]
+}
+
+// intersectMasks returns the coverage of the intersection of the shapes of `a` and `b`. A nil mask
+// covers the whole device.
+func intersectMasks(a, b *mask) *mask {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	m := &mask{rect: a.rect.Intersect(b.rect)}
+	m.a = make([]float32, m.rect.Dx()*m.rect.Dy())
+	i := 0
+	for y := m.rect.Min.Y; y < m.rect.Max.Y; y++ {
+		for x := m.rect.Min.X; x < m.rect.Max.X; x++ {
+			m.a[i] = a.at(x, y) * b.at(x, y)
+			i++
+		}
+	}
+	return m
+}
+
+// edge is a line of the outline of a shape in device space, with y0 < y1. dir is +1 for lines that
+// go down in the outline and -1 for lines that go up.
+type edge struct {
+	x0, y0, x1, y1 float64
+	dir            int
+}
+
+// rasterizer computes the anti-aliased coverage of shapes in device space.
+type rasterizer struct {
+	bounds     image.Rectangle
+	edges      []edge
+	minX, minY float64
+	maxX, maxY float64
+}
+
+// newRasterizer returns a rasterizer for a device with pixels `bounds`.
+func newRasterizer(bounds image.Rectangle) *rasterizer {
+	r := &rasterizer{bounds: bounds}
+	r.reset()
+	return r
+}
+
+// reset removes the shapes of the rasterizer.
+func (r *rasterizer) reset() {
+	r.edges = r.edges[:0]
+	r.minX, r.minY = math.Inf(1), math.Inf(1)
+	r.maxX, r.maxY = math.Inf(-1), math.Inf(-1)
+}
+
+// addPolygon adds the closed polygon with vertices `pts` to the shape.
+func (r *rasterizer) addPolygon(pts []point) {
+	for i, p := range pts {
+		r.addLine(p, pts[(i+1)%len(pts)])
+	}
+}
+
+// addLine adds the line from `a` to `b` to the outline of the shape.
+func (r *rasterizer) addLine(a, b point) {
+	if math.IsNaN(a.x+a.y+b.x+b.y) || math.IsInf(a.x+a.y+b.x+b.y, 0) {
+		return
+	}
+	r.minX, r.maxX = math.Min(r.minX, math.Min(a.x, b.x)), math.Max(r.maxX, math.Max(a.x, b.x))
+	r.minY, r.maxY = math.Min(r.minY, math.Min(a.y, b.y)), math.Max(r.maxY, math.Max(a.y, b.y))
+	switch {
+	case a.y < b.y:
+		r.edges = append(r.edges, edge{a.x, a.y, b.x, b.y, 1})
+	case a.y > b.y:
+		r.edges = append(r.edges, edge{b.x, b.y, a.x, a.y, -1})
+	}
+}
+
+// rasterize returns the coverage of the shape filled with the nonzero winding number rule, or the
+// even-odd rule if `evenOdd` is true.
+func (r *rasterizer) rasterize(evenOdd bool) *mask {
+	m := &mask{}
+	if len(r.edges) == 0 {
+		return m
+	}
+	m.rect = image.Rect(int(math.Floor(r.minX)), int(math.Floor(r.minY)),
+		int(math.Ceil(r.maxX))+1, int(math.Ceil(r.maxY))).Intersect(r.bounds)
+	if m.rect.Empty() {
+		return m
+	}
+	width := m.rect.Dx()
+	m.a = make([]float32, width*m.rect.Dy())
+
+	sort.Slice(r.edges, func(i, j int) bool { return r.edges[i].y0 < r.edges[j].y0 })
+	type crossing struct {
+		x   float64
+		dir int
+	}
+	var active []edge
+	var crossings []crossing
+	next := 0
+	// Coverage of partially covered pixels and differences of the coverage of fully covered runs.
+	partial := make([]float32, width+1)
+	runs := make([]float32, width+1)
+	const weight = 1.0 / subsamples
+	minX, maxX := float64(m.rect.Min.X), float64(m.rect.Max.X)
+
+	for y := m.rect.Min.Y; y < m.rect.Max.Y; y++ {
+		for i := range partial {
+			partial[i], runs[i] = 0, 0
+		}
+		for s := 0; s < subsamples; s++ {
+			sy := float64(y) + (float64(s)+0.5)/subsamples
+			for next < len(r.edges) && r.edges[next].y0 <= sy {
+				active = append(active, r.edges[next])
+				next++
+			}
+			crossings = crossings[:0]
+			n := 0
+			for _, e := range active {
+				if e.y1 <= sy {
+					continue
+				}
+				active[n] = e
+				n++
+				if e.y0 <= sy {
+					x := e.x0 + (sy-e.y0)*(e.x1-e.x0)/(e.y1-e.y0)
+					crossings = append(crossings, crossing{x, e.dir})
+				}
+			}
+			active = active[:n]
+			sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+
+			winding := 0
+			for i, c := range crossings {
+				winding += c.dir
+				inside := winding != 0
+				if evenOdd {
+					inside = winding%2 != 0
+				}
+				if !inside || i+1 == len(crossings) {
+					continue
+				}
+				// Add the span from this crossing to the next one.
+				xa := math.Max(c.x, minX) - minX
+				xb := math.Min(crossings[i+1].x, maxX) - minX
+				if xb <= xa {
+					continue
+				}
+				ia, ib := int(xa), int(xb)
+				if ia == ib {
+					partial[ia] += float32((xb - xa) * weight)
+					continue
+				}
+				partial[ia] += float32((float64(ia+1) - xa) * weight)
+				runs[ia+1] += weight
+				runs[ib] -= weight
+				partial[ib] += float32((xb - float64(ib)) * weight)
+			}
+		}
+
+		row := m.a[(y-m.rect.Min.Y)*width:]
+		var run float32
+		for x := 0; x < width; x++ {
+			run += runs[x]
+			if a := partial[x] + run; a > 1 {
+				row[x] = 1
+			} else if a > 0 {
+				row[x] = a
+			}
+		}
+	}
+	return m
+}
+
+// rgba is a color with non-premultiplied components from 0 to 1.
+type rgba struct {
+	r, g, b, a float64
+}
+
+// painter returns the color to paint at device pixel (`x`, `y`).
+type painter func(x, y int) rgba
+
+// imagePainter returns a painter of the pixels of `img`. Pixels outside `img` are transparent.
+func imagePainter(img *image.NRGBA) painter {
+	return func(x, y int) rgba {
+		c := img.NRGBAAt(x, y)
+		return rgba{float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255, float64(c.A) / 255}
+	}
+}
+
+// solid returns a painter of color `c`.
+func solid(c rgba) painter {
+	return func(x, y int) rgba { return c }
+}
+
+// paint composites the colors of `src` onto `dst` with the source-over blend mode, where they are
+// covered by the shape `m` (nil for the whole device) and the clipping path `clip` (nil if there is
+// none), with constant alpha `alpha`.
+func paint(dst *image.RGBA, m *mask, clip *mask, src painter, alpha float64) {
+	rect := dst.Rect
+	if m != nil {
+		rect = rect.Intersect(m.rect)
+	}
+	if clip != nil {
+		rect = rect.Intersect(clip.rect)
+	}
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			cov := 1.0
+			if m != nil {
+				cov = float64(m.at(x, y))
+			}
+			if clip != nil {
+				cov *= float64(clip.at(x, y))
+			}
+			if cov <= 0 {
+				continue
+			}
+			c := src(x, y)
+			a := cov * alpha * c.a
+			if a <= 0 {
+				continue
+			}
+			i := dst.PixOffset(x, y)
+			pix := dst.Pix[i : i+4 : i+4]
+			pix[0] = blend(pix[0], c.r, a)
+			pix[1] = blend(pix[1], c.g, a)
+			pix[2] = blend(pix[2], c.b, a)
+			pix[3] = blend(pix[3], 1, a)
+		}
+	}
+}
+
+// blend returns the 8 bit component `dst` with component `src` (0 to 1) painted over it with alpha
+// `a`.
+func blend(dst uint8, src, a float64) uint8 {
+	v := float64(dst)*(1-a) + clamp01(src)*255*a
+	return uint8(math.Floor(v + 0.5))
+}
+
+// clamp01 returns `v` clamped to the range 0 to 1.
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,464 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// PageBox selects the page boundary that is rendered.
+type PageBox int
+
+const (
+	// CropBox is the visible region of the page as displayed by viewers. It is the MediaBox if the
+	// page has no CropBox.
+	CropBox PageBox = iota
+	// MediaBox is the whole page.
+	MediaBox
+)
+
+// RenderOptions are the options of the rendering of pages.
+type RenderOptions struct {
+	// DPI is the resolution in pixels per inch. 0 means 72 DPI, i.e. 1 pixel per point.
+	DPI float64
+	// Box is the page boundary that is rendered.
+	Box PageBox
+}
+
+// maxNestingDepth is the maximum depth of nested Type 3 glyph procedures that are drawn. The depth of
+// nested form XObjects is limited by the content stream processor.
+const maxNestingDepth = 16
+
+// RenderPage renders `page` as an image with a white background, rotated by its Rotate entry as
+// displayed by viewers.
+func RenderPage(page *model.PdfPage, opts RenderOptions) (*image.RGBA, error) {
+	return renderPage(page, opts, nil)
+}
+
+// renderPage renders `page` as RenderPage does, or the ink of a plate of separator `sep` if it is
+// not nil.
+func renderPage(page *model.PdfPage, opts RenderOptions, sep *separator) (*image.RGBA, error) {
+	box, err := page.GetMediaBox()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Box == CropBox && page.CropBox != nil {
+		box = page.CropBox
+	}
+	scale := opts.DPI / 72
+	if scale <= 0 {
+		scale = 1
+	}
+	rotate := 0
+	if page.Rotate != nil {
+		rotate = (int(*page.Rotate)%360 + 360) % 360
+	}
+
+	llx, lly := math.Min(box.Llx, box.Urx), math.Min(box.Lly, box.Ury)
+	urx, ury := math.Max(box.Llx, box.Urx), math.Max(box.Lly, box.Ury)
+	width := int(math.Ceil((urx-llx)*scale - 0.001))
+	height := int(math.Ceil((ury-lly)*scale - 0.001))
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("Empty page box")
+	}
+
+	// The page matrix maps default user space to pixels from the top left corner of the displayed
+	// page.
+	var pageMatrix contentstream.Matrix
+	switch rotate {
+	case 90:
+		pageMatrix = contentstream.NewMatrix(0, scale, scale, 0, -lly*scale, -llx*scale)
+		width, height = height, width
+	case 180:
+		pageMatrix = contentstream.NewMatrix(-scale, 0, 0, scale, urx*scale, -lly*scale)
+	case 270:
+		pageMatrix = contentstream.NewMatrix(0, -scale, -scale, 0, ury*scale, urx*scale)
+		width, height = height, width
+	default:
+		pageMatrix = contentstream.NewMatrix(scale, 0, 0, -scale, -llx*scale, ury*scale)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Rect, image.NewUniform(color.White), image.ZP, draw.Src)
+
+	contents, err := page.GetAllContentStreams()
+	if err != nil {
+		return nil, err
+	}
+	r := newRenderer(dst)
+	r.sep = sep
+	gs := contentstream.NewGraphicsState()
+	gs.CTM = pageMatrix
+	// The page is clipped to the page box.
+	var clip contentstream.Path
+	clip.Rect(llx, lly, urx-llx, ury-lly)
+	gs.Clip = []contentstream.ClipPath{{Path: clip.Transform(pageMatrix)}}
+
+	if err := r.render(contents, page.Resources, gs, 0); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// maxClipMasks is the maximum number of clipping masks that are cached.
+const maxClipMasks = 64
+
+// renderer paints content streams on an image.
+type renderer struct {
+	dst    *image.RGBA
+	raster *rasterizer
+	// active holds the Type 3 glyph procedures being drawn, to detect glyphs that draw themselves.
+	active map[*core.PdfObjectStream]bool
+	// clipMasks caches the coverage of clipping paths by the last path of their clip list. The clip
+	// lists of the content stream processor are copied when a path is added, so the address of their
+	// last path identifies them.
+	clipMasks map[*contentstream.ClipPath]*mask
+	// sep renders the ink of a plate instead of the colors, if it is not nil.
+	sep *separator
+}
+
+// newRenderer returns a renderer that paints on `dst`.
+func newRenderer(dst *image.RGBA) *renderer {
+	return &renderer{
+		dst:       dst,
+		raster:    newRasterizer(dst.Rect),
+		active:    map[*core.PdfObjectStream]bool{},
+		clipMasks: map[*contentstream.ClipPath]*mask{},
+	}
+}
+
+// render paints content stream `contents` with resources `resources`, starting in graphics state
+// `gs`. `depth` is the nesting depth of Type 3 glyph procedures: 0 for the page's content stream.
+func (r *renderer) render(contents string, resources *model.PdfPageResources, gs contentstream.GraphicsState,
+	depth int) error {
+	cstreamParser := contentstream.NewContentStreamParser(contents)
+	operations, err := cstreamParser.Parse()
+	if err != nil {
+		return err
+	}
+
+	processor := contentstream.NewContentStreamProcessor(*operations)
+	processor.SetInitialGraphicsState(gs)
+	processor.EnableFormRecursion()
+	processor.SetGlyphOutlineFunc(glyphOutline)
+
+	processor.AddHandler(contentstream.HandlerConditionEnumAllOperands, "",
+		func(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState,
+			resources *model.PdfPageResources) error {
+			operand := op.Operand
+			switch operand {
+			// Path painting (Table 60 p. 135). Closing operators have closed the path.
+			case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*":
+				p := newUserPath(gs.Path, gs.CTM)
+				evenOdd := operand == "f*" || operand == "B*" || operand == "b*"
+				switch operand {
+				case "f", "F", "f*":
+					r.fillPath(p, gs, resources, evenOdd)
+				case "S", "s":
+					r.strokePath(p, gs, resources)
+				default:
+					r.fillPath(p, gs, resources, evenOdd)
+					r.strokePath(p, gs, resources)
+				}
+
+			// Text showing (Table 109). The text state is that of the start of the operation.
+			case "Tj", "'", "\"":
+				if len(op.Params) < 1 {
+					return nil
+				}
+				if s, ok := op.Params[len(op.Params)-1].(*core.PdfObjectString); ok {
+					r.showText(&gs.Text, gs, resources, []byte(*s), depth)
+				}
+			case "TJ":
+				if len(op.Params) < 1 {
+					return nil
+				}
+				arr, ok := op.Params[0].(*core.PdfObjectArray)
+				if !ok {
+					return nil
+				}
+				for _, obj := range *arr {
+					switch v := obj.(type) {
+					case *core.PdfObjectString:
+						r.showText(&gs.Text, gs, resources, []byte(*v), depth)
+					case *core.PdfObjectFloat, *core.PdfObjectInteger:
+						f, _ := model.GetNumbersAsFloat([]core.PdfObject{v})
+						gs.Text.Adjust(f[0])
+					}
+				}
+
+			// Shadings (8.7.4.2 "Shading Operator").
+			case "sh":
+				if len(op.Params) != 1 {
+					common.Log.Debug("sh invalid arguments")
+					return nil
+				}
+				name, ok := op.Params[0].(*core.PdfObjectName)
+				if !ok || resources == nil {
+					return nil
+				}
+				shading, found := resources.GetShadingByName(*name)
+				if !found {
+					common.Log.Debug("Shading %s not found", *name)
+					return nil
+				}
+				clip := r.clipMask(gs.Clip)
+				bounds := r.dst.Rect
+				if clip != nil {
+					bounds = bounds.Intersect(clip.rect)
+				}
+				img, err := renderShading(shading, gs.CTM, bounds, false, r.shadingColors(newOverprint(gs, false)))
+				if err != nil {
+					common.Log.Debug("Unable to render shading %s: %v", *name, err)
+					return nil
+				}
+				paint(r.dst, nil, clip, imagePainter(img), clamp01(gs.FillAlpha))
+
+			// Image XObjects and inline images. Forms are painted by the processor.
+			case "Do":
+				if len(op.Params) != 1 {
+					common.Log.Debug("Do invalid arguments")
+					return nil
+				}
+				if name, ok := op.Params[0].(*core.PdfObjectName); ok {
+					r.drawImageXObject(resources, *name, gs)
+				}
+			case "BI":
+				if len(op.Params) != 1 {
+					return nil
+				}
+				inline, ok := op.Params[0].(*contentstream.ContentStreamInlineImage)
+				if !ok {
+					return nil
+				}
+				fill := r.fillColor(gs)
+				img, err := loadInlineImage(inline, resources, fill, r.imageColors(newOverprint(gs, false)))
+				if err != nil {
+					common.Log.Debug("Unable to load inline image: %v", err)
+					return nil
+				}
+				r.drawImage(img, gs.CTM, r.clipMask(gs.Clip), clamp01(gs.FillAlpha))
+			}
+			return nil
+		})
+
+	return processor.Process(resources)
+}
+
+// clipMask returns the coverage of the intersection of clipping paths `clip`, nil if there are none.
+func (r *renderer) clipMask(clip []contentstream.ClipPath) *mask {
+	if len(clip) == 0 {
+		return nil
+	}
+	key := &clip[len(clip)-1]
+	if m, has := r.clipMasks[key]; has {
+		return m
+	}
+	parent := r.clipMask(clip[:len(clip)-1])
+	r.raster.reset()
+	newUserPath(key.Path, contentstream.IdentityMatrix()).fill(r.raster, contentstream.IdentityMatrix())
+	m := intersectMasks(parent, r.raster.rasterize(key.EvenOdd))
+	if len(r.clipMasks) >= maxClipMasks {
+		r.clipMasks = map[*contentstream.ClipPath]*mask{}
+	}
+	r.clipMasks[key] = m
+	return m
+}
+
+// fillPath fills `p` with the nonstroking color of `gs`, by the even-odd rule if `evenOdd` is true.
+func (r *renderer) fillPath(p *path, gs contentstream.GraphicsState, resources *model.PdfPageResources,
+	evenOdd bool) {
+	if p.empty() {
+		return
+	}
+	clip := r.clipMask(gs.Clip)
+	r.raster.reset()
+	p.fill(r.raster, gs.CTM)
+	m := r.raster.rasterize(evenOdd)
+	if src, ok := r.colorPainter(gs.ColorspaceNonStroking, gs.ColorNonStroking, gs.ColorComponentsNonStroking,
+		newOverprint(gs, false), resources, gs.StreamMatrix, clip, m); ok {
+		paint(r.dst, m, clip, src, clamp01(gs.FillAlpha))
+	}
+}
+
+// strokePath strokes `p` with the stroking color and line parameters of `gs`.
+func (r *renderer) strokePath(p *path, gs contentstream.GraphicsState, resources *model.PdfPageResources) {
+	if p.empty() {
+		return
+	}
+	clip := r.clipMask(gs.Clip)
+	style := strokeStyle{
+		width:      gs.LineWidth,
+		cap:        gs.LineCap,
+		join:       gs.LineJoin,
+		miterLimit: gs.MiterLimit,
+		dash:       gs.DashArray,
+		dashPhase:  gs.DashPhase,
+	}
+	r.raster.reset()
+	p.stroke(r.raster, gs.CTM, style)
+	m := r.raster.rasterize(false)
+	if src, ok := r.colorPainter(gs.ColorspaceStroking, gs.ColorStroking, gs.ColorComponentsStroking,
+		newOverprint(gs, true), resources, gs.StreamMatrix, clip, m); ok {
+		paint(r.dst, m, clip, src, clamp01(gs.StrokeAlpha))
+	}
+}
+
+// colorPainter returns the painter of color `c` with components `comps` of colorspace `cs`, painted
+// with overprint control `op` and clip `clip`, for the pixels covered by `m`: a solid color or, for
+// colors of shading patterns in `resources`, the colors of the shading, mapped to device space by
+// its pattern matrix and `base`. The bool return flag is false if the color cannot be painted.
+// Tiling patterns are not supported.
+func (r *renderer) colorPainter(cs model.PdfColorspace, c model.PdfColor, comps []float64, op overprint,
+	resources *model.PdfPageResources, base contentstream.Matrix, clip, m *mask) (painter, bool) {
+	if col, ok := r.solidColor(cs, c, comps, op); ok {
+		return solid(col), true
+	}
+	pc, ok := c.(*model.PdfColorPattern)
+	if !ok || resources == nil {
+		return nil, false
+	}
+	pattern, found := resources.GetPatternByName(pc.PatternName)
+	if !found || !pattern.IsShading() {
+		return nil, false
+	}
+	sp := pattern.GetAsShadingPattern()
+	if sp.Shading == nil {
+		return nil, false
+	}
+	bounds := m.rect.Intersect(r.dst.Rect)
+	if clip != nil {
+		bounds = bounds.Intersect(clip.rect)
+	}
+	img, err := renderShading(sp.Shading, getMatrix(sp.Matrix).Mult(base), bounds, true, r.shadingColors(op))
+	if err != nil {
+		common.Log.Debug("Unable to render shading pattern %s: %v", pc.PatternName, err)
+		return nil, false
+	}
+	return imagePainter(img), true
+}
+
+// solidColor returns the color painted for color `c` with components `comps` of colorspace `cs` with
+// overprint control `op`: its RGB color, or the ink of the rendered plate. The bool return flag is
+// false if the color cannot be painted as a solid color.
+func (r *renderer) solidColor(cs model.PdfColorspace, c model.PdfColor, comps []float64, op overprint) (rgba, bool) {
+	if r.sep != nil {
+		return r.sep.color(cs, c, comps, op)
+	}
+	return toRGBA(cs, c)
+}
+
+// fillColor returns the color painted for the nonstroking color of `gs` by stencil masks. Colors that
+// cannot be painted as solid colors, such as patterns, are painted black, or leave the rendered plate
+// unchanged.
+func (r *renderer) fillColor(gs contentstream.GraphicsState) rgba {
+	col, ok := r.solidColor(gs.ColorspaceNonStroking, gs.ColorNonStroking, gs.ColorComponentsNonStroking,
+		newOverprint(gs, false))
+	if !ok && r.sep == nil {
+		return rgba{0, 0, 0, 1}
+	}
+	return col
+}
+
+// shadingColors returns the colorFunc of the colors of shadings painted with overprint control `op`.
+func (r *renderer) shadingColors(op overprint) colorFunc {
+	if r.sep == nil {
+		return rgbColor
+	}
+	return func(cs model.PdfColorspace, comps []float64) rgba {
+		col, _ := r.sep.color(cs, nil, comps, op)
+		return col
+	}
+}
+
+// imageColors returns the imageFunc of the colors of images painted with overprint control `op`.
+func (r *renderer) imageColors(op overprint) imageFunc {
+	if r.sep == nil {
+		return func(img *model.Image, cs model.PdfColorspace, decode core.PdfObject) (*image.NRGBA, error) {
+			return colorImage(img, cs)
+		}
+	}
+	return func(img *model.Image, cs model.PdfColorspace, decode core.PdfObject) (*image.NRGBA, error) {
+		return r.sep.image(img, cs, decode, op)
+	}
+}
+
+// toRGBA returns color `c` of colorspace `cs` converted to RGB. The bool return flag is false if the
+// color cannot be converted, e.g. for patterns.
+func toRGBA(cs model.PdfColorspace, c model.PdfColor) (rgba, bool) {
+	if cs == nil || c == nil {
+		return rgba{}, false
+	}
+	converted, err := cs.ColorToRGB(c)
+	if err != nil {
+		common.Log.Debug("Unable to convert color %v of %s: %v", c, cs, err)
+		return rgba{}, false
+	}
+	rgb, ok := converted.(*model.PdfColorDeviceRGB)
+	if !ok {
+		return rgba{}, false
+	}
+	return rgba{rgb.R(), rgb.G(), rgb.B(), 1}, true
+}
+
+// drawImageXObject paints XObject `name` of `resources` if it is an image.
+func (r *renderer) drawImageXObject(resources *model.PdfPageResources, name core.PdfObjectName,
+	gs contentstream.GraphicsState) {
+	if resources == nil {
+		return
+	}
+	stream, xtype := resources.GetXObjectByName(name)
+	if xtype != model.XObjectTypeImage {
+		return
+	}
+	img, err := loadImageXObject(stream, r.fillColor(gs), r.imageColors(newOverprint(gs, false)))
+	if err != nil {
+		common.Log.Debug("Unable to load image %s: %v", name, err)
+		return
+	}
+	r.drawImage(img, gs.CTM, r.clipMask(gs.Clip), clamp01(gs.FillAlpha))
+}
+
+// renderStream paints Type 3 glyph procedure `stream` unless it is already being painted.
+func (r *renderer) renderStream(stream *core.PdfObjectStream, resources *model.PdfPageResources,
+	gs contentstream.GraphicsState, depth int) {
+	if r.active[stream] {
+		common.Log.Debug("Stream invokes itself, skipping")
+		return
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("Unable to decode stream: %v", err)
+		return
+	}
+	r.active[stream] = true
+	defer delete(r.active, stream)
+	if err := r.render(string(data), resources, gs, depth); err != nil {
+		// The page is rendered even if a nested stream is invalid.
+		common.Log.Debug("Error rendering nested stream: %v", err)
+	}
+}
+
+// getMatrix returns the matrix of array `obj` [a b c d e f], or the identity matrix if `obj` is not a
+// valid matrix, e.g. if it is nil.
+func getMatrix(obj core.PdfObject) contentstream.Matrix {
+	if arr, ok := core.TraceToDirectObject(obj).(*core.PdfObjectArray); ok {
+		if f, err := arr.GetAsFloat64Slice(); err == nil && len(f) == 6 {
+			return contentstream.NewMatrix(f[0], f[1], f[2], f[3], f[4], f[5])
+		}
+		common.Log.Debug("Invalid matrix %s", obj)
+	}
+	return contentstream.IdentityMatrix()
+}
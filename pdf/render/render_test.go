@@ -0,0 +1,403 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// makeTestPDF returns a PDF file with objects `objects`, numbered from 1. Object 1 is the catalog.
+func makeTestPDF(objects []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n")
+	var offsets []int
+	for i, obj := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// makeStreamObject returns a stream object with dictionary entries `entries` and data `data`.
+func makeStreamObject(entries, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", entries, len(data), data)
+}
+
+// renderTestPage renders a page with page dictionary entries `entries` and content stream
+// `contents`. `objects` are added to the file from object number 5.
+func renderTestPage(t *testing.T, entries, contents string, opts RenderOptions, objects ...string) *image.RGBA {
+	page := loadTestPage(t, entries, contents, objects...)
+	img, err := RenderPage(page, opts)
+	if err != nil {
+		t.Fatalf("Error rendering page: %v", err)
+	}
+	return img
+}
+
+// loadTestPage returns a page with page dictionary entries `entries` and content stream `contents`.
+// `objects` are added to the file from object number 5.
+func loadTestPage(t *testing.T, entries, contents string, objects ...string) *model.PdfPage {
+	data := makeTestPDF(append([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R " + entries + " >>",
+		makeStreamObject("", contents),
+	}, objects...))
+	reader, err := model.NewPdfReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error reading PDF: %v", err)
+	}
+	page, err := reader.GetPage(1)
+	if err != nil {
+		t.Fatalf("Error loading page: %v", err)
+	}
+	return page
+}
+
+// checkPixels checks the colors of pixels of `img`: point to [r g b].
+func checkPixels(t *testing.T, img *image.RGBA, expected map[image.Point][3]uint8) {
+	checkPixelsNear(t, img, expected, 0)
+}
+
+// checkPixelsNear checks the colors of pixels of `img`, point to [r g b], with tolerance `tol` for
+// each component.
+func checkPixelsNear(t *testing.T, img *image.RGBA, expected map[image.Point][3]uint8, tol int) {
+	for pt, exp := range expected {
+		c := img.RGBAAt(pt.X, pt.Y)
+		got := [3]uint8{c.R, c.G, c.B}
+		for i := range got {
+			if d := int(got[i]) - int(exp[i]); d < -tol || d > tol {
+				t.Errorf("Pixel %v: %v != %v", pt, got, exp)
+				break
+			}
+		}
+	}
+}
+
+var (
+	white = [3]uint8{255, 255, 255}
+	black = [3]uint8{0, 0, 0}
+	red   = [3]uint8{255, 0, 0}
+	green = [3]uint8{0, 255, 0}
+	blue  = [3]uint8{0, 0, 255}
+)
+
+func TestRenderPaths(t *testing.T) {
+	// A red square, a blue line with square caps, a square with a hole filled by the even-odd rule and
+	// a half transparent black square.
+	contents := `1 0 0 rg 10 10 30 30 re f
+0 0 1 RG 4 w 2 J 60 10 m 90 10 l S
+0 1 0 rg 10 60 m 40 60 l 40 90 l 10 90 l h 20 70 10 10 re f*
+/GS1 gs 0 g 60 60 30 30 re f`
+	img := renderTestPage(t, "/MediaBox [0 0 100 100] "+
+		"/Resources << /ExtGState << /GS1 << /ca 0.5 >> >> >>", contents, RenderOptions{})
+	if img.Rect != image.Rect(0, 0, 100, 100) {
+		t.Fatalf("Image size %v", img.Rect)
+	}
+	checkPixels(t, img, map[image.Point][3]uint8{
+		{20, 80}: red, {9, 80}: white, {40, 80}: white,
+		// The line extends from y=8 to 12 and from x=58 to 92.
+		{75, 90}: blue, {58, 89}: blue, {91, 88}: blue, {75, 86}: white, {93, 90}: white,
+		{15, 15}: green, {25, 25}: white,
+		{75, 25}: {128, 128, 128},
+	})
+
+	// Shapes that cover part of a pixel are anti-aliased.
+	img = renderTestPage(t, "/MediaBox [0 0 10 10]", "0 g 0 0 5.5 10 re f", RenderOptions{})
+	checkPixels(t, img, map[image.Point][3]uint8{{4, 5}: black, {5, 5}: {128, 128, 128}, {6, 5}: white})
+}
+
+func TestRenderClip(t *testing.T) {
+	contents := `q 0 0 50 100 re W n 0 g 0 0 100 100 re f Q
+q 1 0 0 rg 50 50 m 100 50 l 100 100 l h W* n 0 0 100 100 re f Q`
+	img := renderTestPage(t, "/MediaBox [0 0 100 100]", contents, RenderOptions{})
+	checkPixels(t, img, map[image.Point][3]uint8{
+		{25, 50}: black, {75, 75}: white, {90, 20}: red, {55, 30}: white,
+	})
+
+	// Text with rendering mode 7 adds its glyphs to the clipping path at the end of the text object.
+	contents = "BT /F1 50 Tf 7 Tr 10 20 Td (I) Tj ET 0 0 1 rg 0 0 100 100 re f"
+	img = renderTestPage(t, "/MediaBox [0 0 100 100] /Resources << /Font << "+
+		"/F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> >> >>", contents, RenderOptions{})
+	checkPixels(t, img, map[image.Point][3]uint8{{17, 60}: blue, {50, 50}: white, {17, 90}: white})
+}
+
+func TestRenderForms(t *testing.T) {
+	// The form is scaled by its matrix and clipped to its bounding box.
+	form := makeStreamObject("/Type /XObject /Subtype /Form /BBox [0 0 10 10] /Matrix [2 0 0 2 0 0]",
+		"1 0 0 rg 0 0 20 20 re f")
+	img := renderTestPage(t, "/MediaBox [0 0 100 100] /Resources << /XObject << /Fm1 5 0 R >> >>",
+		"q 1 0 0 1 10 10 cm /Fm1 Do Q 0 0 1 rg 60 60 10 10 re f", RenderOptions{}, form)
+	checkPixels(t, img, map[image.Point][3]uint8{
+		{20, 80}: red, {29, 71}: red, {40, 60}: white, {5, 95}: white, {65, 35}: blue,
+	})
+}
+
+func TestRenderImages(t *testing.T) {
+	// A 2x1 RGB image scaled to the top half of the page and a stencil mask painted blue in the left
+	// half of the bottom half.
+	contents := "q 100 0 0 50 0 50 cm BI /W 2 /H 1 /CS /RGB /BPC 8 ID \xff\x00\x00\x00\xff\x00 EI Q\n" +
+		"q 0 0 1 rg 100 0 0 50 0 0 cm BI /W 8 /H 1 /IM true ID \x0f EI Q"
+	img := renderTestPage(t, "/MediaBox [0 0 100 100]", contents, RenderOptions{})
+	checkPixels(t, img, map[image.Point][3]uint8{
+		{25, 25}: red, {75, 25}: green, {25, 75}: blue, {75, 75}: white,
+	})
+}
+
+// Images with sizes larger than their data and masks that mask themselves are skipped without
+// panicking.
+func TestRenderInvalidImages(t *testing.T) {
+	contents := "q 100 0 0 100 0 0 cm /Im1 Do /Im2 Do BI /W 3037000500 /H 3037000500 /CS /G /BPC 8 ID \x00 EI Q"
+	img := renderTestPage(t, "/MediaBox [0 0 100 100] /Resources << /XObject << /Im1 5 0 R /Im2 6 0 R >> >>",
+		contents, RenderOptions{},
+		makeStreamObject("/Type /XObject /Subtype /Image /Width 3037000500 /Height 3037000500 "+
+			"/ColorSpace /DeviceGray /BitsPerComponent 8", "\x00"),
+		makeStreamObject("/Type /XObject /Subtype /Image /Width 1 /Height 1 /ColorSpace /DeviceGray "+
+			"/BitsPerComponent 8 /Mask 6 0 R", "\x00"))
+	checkPixels(t, img, map[image.Point][3]uint8{{50, 50}: black})
+}
+
+func TestRenderText(t *testing.T) {
+	// "I" of the standard 14 font Helvetica is drawn with a substitute font.
+	contents := "BT /F1 50 Tf 1 0 0 rg 10 20 Td (I) Tj ET"
+	img := renderTestPage(t, "/MediaBox [0 0 100 100] /Resources << /Font << "+
+		"/F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> >> >>", contents, RenderOptions{})
+	// The glyph is 13.9 wide (278/1000 em) and has the height of capitals (about 36).
+	painted := image.Rectangle{}
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			if c := img.RGBAAt(x, y); c.G < 128 {
+				if c.R != 255 {
+					t.Errorf("Pixel %d,%d not red: %v", x, y, c)
+				}
+				painted = painted.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if painted.Min.X < 10 || painted.Max.X > 24 || painted.Min.Y < 40 || painted.Max.Y > 80 ||
+		painted.Dy() < 30 {
+		t.Errorf("Glyph painted in %v", painted)
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	// The CropBox is rendered at 144 DPI, rotated by 90 degrees clockwise.
+	entries := "/MediaBox [0 0 100 50] /CropBox [0 0 50 50] /Rotate 90"
+	contents := "0 g 0 0 25 10 re f"
+	img := renderTestPage(t, entries, contents, RenderOptions{DPI: 144})
+	if img.Rect != image.Rect(0, 0, 100, 100) {
+		t.Fatalf("Image size %v", img.Rect)
+	}
+	// The bottom left corner of the page is at the top left.
+	checkPixels(t, img, map[image.Point][3]uint8{{10, 10}: black, {10, 60}: white, {30, 10}: white})
+
+	img = renderTestPage(t, entries, contents, RenderOptions{Box: MediaBox})
+	if img.Rect != image.Rect(0, 0, 50, 100) {
+		t.Fatalf("Image size %v", img.Rect)
+	}
+}
+
+func TestRenderShadings(t *testing.T) {
+	redToBlue := "<< /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [0 0 1] /N 1 >>"
+	shadings := "/Resources << /Shading << " +
+		"/Axial << /ShadingType 2 /ColorSpace /DeviceRGB /Coords [0 0 100 0] /Function " + redToBlue + " >> " +
+		"/Short << /ShadingType 2 /ColorSpace /DeviceRGB /Coords [20 0 80 0] /Extend [true false] " +
+		"/Function " + redToBlue + " >> " +
+		"/Stitched << /ShadingType 2 /ColorSpace /DeviceRGB /Coords [0 0 100 0] /Function << " +
+		"/FunctionType 3 /Domain [0 1] /Bounds [0.5] /Encode [0 1 0 1] /Functions [" +
+		"<< /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [0 1 0] /N 1 >> " +
+		"<< /FunctionType 2 /Domain [0 1] /C0 [0 1 0] /C1 [0 0 1] /N 1 >>] >> >> " +
+		"/Radial << /ShadingType 3 /ColorSpace /DeviceGray /Coords [50 50 0 50 50 40] " +
+		"/Function << /FunctionType 2 /Domain [0 1] /C0 [0] /C1 [1] /N 1 >> >> " +
+		"/Function << /ShadingType 1 /ColorSpace /DeviceGray /Matrix [100 0 0 100 0 0] /Function 5 0 R >> " +
+		">> >>"
+	// The function of the function-based shading is the gray level x.
+	function := makeStreamObject("/FunctionType 4 /Domain [0 1 0 1] /Range [0 1]", "{ pop }")
+	pageEntries := "/MediaBox [0 0 100 100] " + shadings
+
+	cases := []struct {
+		shading  string
+		expected map[image.Point][3]uint8
+	}{
+		{"Axial", map[image.Point][3]uint8{
+			{10, 50}: {228, 0, 27}, {50, 90}: {128, 0, 127}, {99, 10}: {1, 0, 254},
+		}},
+		{"Short", map[image.Point][3]uint8{
+			{10, 50}: red, {50, 50}: {128, 0, 127}, {90, 50}: white,
+		}},
+		{"Stitched", map[image.Point][3]uint8{
+			{25, 50}: {125, 130, 0}, {50, 50}: {0, 254, 1}, {75, 50}: {0, 125, 130},
+		}},
+		{"Radial", map[image.Point][3]uint8{
+			{50, 50}: {6, 6, 6}, {70, 50}: {131, 131, 131}, {50, 95}: white,
+		}},
+		{"Function", map[image.Point][3]uint8{
+			{25, 10}: {65, 65, 65}, {75, 90}: {192, 192, 192},
+		}},
+	}
+	for _, c := range cases {
+		img := renderTestPage(t, pageEntries, "/"+c.shading+" sh", RenderOptions{}, function)
+		for pt, exp := range c.expected {
+			checkPixelsNear(t, img, map[image.Point][3]uint8{pt: exp}, 3)
+			if t.Failed() {
+				t.Fatalf("Shading %s", c.shading)
+			}
+		}
+	}
+
+	// The shading is clipped.
+	img := renderTestPage(t, pageEntries, "0 0 50 100 re W n /Axial sh", RenderOptions{}, function)
+	checkPixelsNear(t, img, map[image.Point][3]uint8{{10, 50}: {228, 0, 27}, {60, 50}: white}, 3)
+}
+
+func TestRenderMeshShadings(t *testing.T) {
+	// A triangle with red, green and blue vertices with 8 bit flags, coordinates and components.
+	triangle := makeStreamObject("/ShadingType 4 /ColorSpace /DeviceRGB /BitsPerCoordinate 8 "+
+		"/BitsPerComponent 8 /BitsPerFlag 8 /Decode [0 255 0 255 0 1 0 1 0 1]",
+		"\x00\x00\x00\xff\x00\x00"+"\x00\x78\x00\x00\xff\x00"+"\x00\x00\x3c\x00\x00\xff")
+
+	// A lattice of 2 x 2 vertices from black to white.
+	lattice := makeStreamObject("/ShadingType 5 /ColorSpace /DeviceGray /BitsPerCoordinate 8 "+
+		"/BitsPerComponent 8 /VerticesPerRow 2 /Decode [0 255 0 255 0 1]",
+		"\x00\x00\x00"+"\x78\x00\xff"+"\x00\x3c\x00"+"\x78\x3c\xff")
+
+	// A Coons patch covering the left half of the page from black to gray and a second patch that
+	// shares its right edge (edge flag 2), from gray to white.
+	coons := makeStreamObject("/ShadingType 6 /ColorSpace /DeviceGray /BitsPerCoordinate 8 "+
+		"/BitsPerComponent 8 /BitsPerFlag 8 /Decode [0 255 0 255 0 1]",
+		"\x00"+"\x00\x00"+"\x00\x14"+"\x00\x28"+"\x00\x3c"+"\x14\x3c"+"\x28\x3c"+"\x3c\x3c"+
+			"\x3c\x28"+"\x3c\x14"+"\x3c\x00"+"\x28\x00"+"\x14\x00"+"\x00\x00\x80\x80"+
+			"\x02"+"\x50\x00"+"\x64\x00"+"\x78\x00"+"\x78\x14"+"\x78\x28"+"\x78\x3c"+"\x64\x3c"+
+			"\x50\x3c"+"\xff\xff")
+
+	// The left patch as a tensor-product patch.
+	tensor := makeStreamObject("/ShadingType 7 /ColorSpace /DeviceGray /BitsPerCoordinate 8 "+
+		"/BitsPerComponent 8 /BitsPerFlag 8 /Decode [0 255 0 255 0 1]",
+		"\x00"+"\x00\x00"+"\x00\x14"+"\x00\x28"+"\x00\x3c"+"\x14\x3c"+"\x28\x3c"+"\x3c\x3c"+
+			"\x3c\x28"+"\x3c\x14"+"\x3c\x00"+"\x28\x00"+"\x14\x00"+
+			"\x14\x14"+"\x14\x28"+"\x28\x28"+"\x28\x14"+"\x00\x00\x80\x80")
+
+	pageEntries := "/MediaBox [0 0 120 60] /Resources << /Shading << " +
+		"/Triangle 5 0 R /Lattice 6 0 R /Coons 7 0 R /Tensor 8 0 R >> >>"
+	cases := []struct {
+		shading  string
+		expected map[image.Point][3]uint8
+	}{
+		{"Triangle", map[image.Point][3]uint8{
+			{1, 58}: {245, 3, 6}, {30, 45}: {128, 64, 63}, {100, 10}: white,
+		}},
+		{"Lattice", map[image.Point][3]uint8{
+			{1, 30}: {3, 3, 3}, {60, 10}: {128, 128, 128}, {119, 50}: {253, 253, 253},
+		}},
+		{"Coons", map[image.Point][3]uint8{
+			{30, 30}: {65, 65, 65}, {60, 10}: {128, 128, 128}, {90, 50}: {192, 192, 192},
+		}},
+		{"Tensor", map[image.Point][3]uint8{
+			{30, 30}: {65, 65, 65}, {90, 50}: white,
+		}},
+	}
+	objects := []string{triangle, lattice, coons, tensor}
+	for _, c := range cases {
+		img := renderTestPage(t, pageEntries, "/"+c.shading+" sh", RenderOptions{}, objects...)
+		checkPixelsNear(t, img, c.expected, 3)
+		if t.Failed() {
+			t.Fatalf("Shading %s", c.shading)
+		}
+	}
+}
+
+func TestRenderShadingPattern(t *testing.T) {
+	// A rectangle is filled with an axial shading pattern, with the shading's background outside its
+	// extent. The pattern matrix maps the shading to the right half of the page.
+	pattern := "<< /PatternType 2 /Matrix [1 0 0 1 50 0] /Shading << /ShadingType 2 /ColorSpace /DeviceRGB " +
+		"/Coords [0 0 40 0] /Background [0 1 0] " +
+		"/Function << /FunctionType 2 /Domain [0 1] /C0 [1 0 0] /C1 [0 0 1] /N 1 >> >> >>"
+	img := renderTestPage(t, "/MediaBox [0 0 100 100] /Resources << /Pattern << /P1 5 0 R >> >>",
+		"/Pattern cs /P1 scn 10 10 85 80 re f", RenderOptions{}, pattern)
+	checkPixelsNear(t, img, map[image.Point][3]uint8{
+		{5, 50}: white, {20, 50}: green, {51, 50}: {246, 0, 9}, {89, 50}: {3, 0, 252}, {93, 50}: green,
+		{70, 95}: white,
+	}, 3)
+}
+
+func TestAnalyzeInks(t *testing.T) {
+	// The left half of the page is painted with 50% of each process colorant and the bottom half with
+	// a spot colorant: 100% on the right and 50% on the left, where it is painted with overprint.
+	resources := "/Resources << /ColorSpace << /CS0 [/Separation /Spot#201 /DeviceCMYK " +
+		"<< /FunctionType 2 /Domain [0 1] /C0 [0 0 0 0] /C1 [0 1 1 0] /N 1 >>] >> " +
+		"/ExtGState << /GS0 << /op true >> >> >>"
+	contents := `0.5 0.5 0.5 0.5 k 0 0 50 100 re f
+/CS0 cs 50 0 50 50 re f
+q /GS0 gs 0.5 scn 0 0 50 50 re f Q`
+	page := loadTestPage(t, "/MediaBox [0 0 100 100] "+resources, contents)
+	report, err := AnalyzeInks(page, InkOptions{TotalInkLimit: 240})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	checkInks(t, report, []string{"Cyan", "Magenta", "Yellow", "Black", "Spot 1"},
+		[]float64{25, 25, 25, 25, 37.5})
+	spot := report.Separations[4]
+	if spot.Process || spot.AlternateSpace.String() != "DeviceCMYK" {
+		t.Errorf("Spot alternate space %v", spot.AlternateSpace)
+	} else if cmyk, ok := spot.Alternate.(*model.PdfColorDeviceCMYK); !ok || cmyk.M() != 1 || cmyk.C() != 0 {
+		t.Errorf("Spot alternate %v", spot.Alternate)
+	}
+	if !report.ExceedsLimit || math.Abs(report.MaxTotalInk-250) > 1 || report.ExceedingArea != 25 {
+		t.Errorf("Total ink %.2f, exceeding area %.2f", report.MaxTotalInk, report.ExceedingArea)
+	}
+
+	// Without overprint, the spot colorant knocks out the process colorants.
+	page = loadTestPage(t, "/MediaBox [0 0 100 100] "+resources, strings.Replace(contents, "/GS0 gs ", "", 1))
+	report, err = AnalyzeInks(page, InkOptions{TotalInkLimit: 240})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	checkInks(t, report, []string{"Cyan", "Magenta", "Yellow", "Black", "Spot 1"},
+		[]float64{12.5, 12.5, 12.5, 12.5, 37.5})
+	if report.ExceedsLimit || math.Abs(report.MaxTotalInk-200) > 1 {
+		t.Errorf("Total ink %.2f, exceeding area %.2f", report.MaxTotalInk, report.ExceedingArea)
+	}
+
+	// Gray levels are black ink, and an image of the spot colorant paints its plate alone.
+	contents = "0.75 g 0 50 100 50 re f q 100 0 0 50 0 0 cm BI /W 2 /H 1 /CS /CS0 /BPC 8 /F /AHx ID FF80> EI Q"
+	page = loadTestPage(t, "/MediaBox [0 0 100 100] "+resources, contents)
+	report, err = AnalyzeInks(page, InkOptions{})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	checkInks(t, report, []string{"Black", "Spot 1"}, []float64{12.5, 37.5})
+	if report.ExceedsLimit || report.TotalInkLimit != DefaultTotalInkLimit {
+		t.Errorf("Limit %.0f exceeded", report.TotalInkLimit)
+	}
+}
+
+// checkInks checks that the separations of `report` have names `names` and coverages `coverages`, to
+// within 0.5%.
+func checkInks(t *testing.T, report *InkReport, names []string, coverages []float64) {
+	if len(report.Separations) != len(names) {
+		t.Fatalf("Separations %v != %v", report.Separations, names)
+	}
+	for i, plate := range report.Separations {
+		if plate.Name != names[i] || plate.Process != (processIndex(names[i]) >= 0) ||
+			math.Abs(plate.Coverage-coverages[i]) > 0.5 {
+			t.Errorf("Separation %d: %s %.2f%%, expected %s %.2f%%", i, plate.Name, plate.Coverage,
+				names[i], coverages[i])
+		}
+	}
+}
@@ -0,0 +1,353 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+)
+
+// rampSize is the number of colors sampled from the functions of shadings of one parametric
+// variable, such as axial and radial shadings.
+const rampSize = 512
+
+// RenderShading renders `shading` on the pixels `bounds` of a device, with the shading's target
+// coordinate space mapped to device space by `m`. Pixels outside the area painted by the shading are
+// transparent, unless `background` is true and the shading has a Background color, which fills
+// them. The background is used by shading patterns and ignored by the sh operator.
+func RenderShading(shading *model.PdfShading, m contentstream.Matrix, bounds image.Rectangle,
+	background bool) (*image.NRGBA, error) {
+	return renderShading(shading, m, bounds, background, rgbColor)
+}
+
+// renderShading renders `shading` as RenderShading does, with the colors given by `colors`.
+func renderShading(shading *model.PdfShading, m contentstream.Matrix, bounds image.Rectangle,
+	background bool, colors colorFunc) (*image.NRGBA, error) {
+	if shading == nil || shading.ColorSpace == nil {
+		return nil, errors.New("Invalid shading")
+	}
+	sr := &shadingRenderer{
+		dst:    image.NewNRGBA(bounds),
+		m:      m,
+		colors: colors,
+	}
+	if background && shading.Background != nil {
+		f, err := shading.Background.ToFloat64Array()
+		if err == nil {
+			s := sr.newShader(shading.ColorSpace, nil)
+			sr.fill(s.color(f))
+		}
+	}
+
+	var err error
+	switch ctx := shading.GetContext().(type) {
+	case *model.PdfShadingType1:
+		err = sr.functionBased(shading, ctx)
+	case *model.PdfShadingType2:
+		err = sr.axial(shading, ctx)
+	case *model.PdfShadingType3:
+		err = sr.radial(shading, ctx)
+	case *model.PdfShadingType4:
+		err = sr.freeFormMesh(shading, ctx)
+	case *model.PdfShadingType5:
+		err = sr.latticeMesh(shading, ctx)
+	case *model.PdfShadingType6:
+		err = sr.patchMesh(shading, ctx.Function, ctx.BitsPerCoordinate, ctx.BitsPerComponent,
+			ctx.BitsPerFlag, ctx.Decode, false)
+	case *model.PdfShadingType7:
+		err = sr.patchMesh(shading, ctx.Function, ctx.BitsPerCoordinate, ctx.BitsPerComponent,
+			ctx.BitsPerFlag, ctx.Decode, true)
+	default:
+		err = errors.New("Unsupported shading type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if shading.BBox != nil {
+		// The shading is clipped to its bounding box in shading space.
+		b := shading.BBox
+		raster := newRasterizer(bounds)
+		raster.addPolygon(transformPoints([]point{{b.Llx, b.Lly}, {b.Urx, b.Lly}, {b.Urx, b.Ury},
+			{b.Llx, b.Ury}}, m))
+		sr.clip(raster.rasterize(false))
+	}
+	return sr.dst, nil
+}
+
+// colorFunc returns the color painted for the color with components `comps` of colorspace `cs`. The
+// color is transparent if it cannot be painted.
+type colorFunc func(cs model.PdfColorspace, comps []float64) rgba
+
+// rgbColor is the colorFunc of colors converted to RGB by the model colorspaces.
+func rgbColor(cs model.PdfColorspace, comps []float64) rgba {
+	c, err := cs.ColorFromFloats(comps)
+	if err != nil {
+		common.Log.Debug("Invalid shading color %v: %v", comps, err)
+		return rgba{}
+	}
+	col, _ := toRGBA(cs, c)
+	return col
+}
+
+// shader computes the colors of a shading from color components or the parametric variables of
+// its functions.
+type shader struct {
+	cs     model.PdfColorspace
+	funcs  []model.PdfFunction
+	colors colorFunc
+	decode []float64 // Ranges of the components of cs.
+	// ramp holds the colors of the parametric variable from t0 to t1, if sampled.
+	ramp   []rgba
+	t0, t1 float64
+}
+
+// newShader returns a shader for colorspace `cs` and functions `funcs` with the colors of the
+// shading renderer. If `funcs` is empty, the colors are given by components of `cs`.
+func (sr *shadingRenderer) newShader(cs model.PdfColorspace, funcs []model.PdfFunction) *shader {
+	return &shader{cs: cs, funcs: funcs, colors: sr.colors, decode: cs.DecodeArray()}
+}
+
+// sampleRamp samples the colors of the parametric variable of the shader's functions from `t0` to
+// `t1`, which are used instead of evaluating the functions for each color.
+func (s *shader) sampleRamp(t0, t1 float64) {
+	s.t0, s.t1 = t0, t1
+	s.ramp = make([]rgba, rampSize)
+	for i := range s.ramp {
+		s.ramp[i] = s.eval([]float64{t0 + (t1-t0)*float64(i)/(rampSize-1)})
+	}
+}
+
+// color returns the color for `v`: the inputs of the shader's functions or the color components.
+// The color is transparent if it cannot be computed.
+func (s *shader) color(v []float64) rgba {
+	if s.ramp != nil && len(v) == 1 {
+		i := 0
+		if s.t1 != s.t0 {
+			i = clampInt(int(math.Floor((v[0]-s.t0)/(s.t1-s.t0)*(rampSize-1)+0.5)), 0, rampSize-1)
+		}
+		return s.ramp[i]
+	}
+	return s.eval(v)
+}
+
+// eval returns the color for `v`, evaluating the shader's functions.
+func (s *shader) eval(v []float64) rgba {
+	comps := v
+	if len(s.funcs) > 0 {
+		var err error
+		if comps, err = evalFunctions(s.funcs, v); err != nil {
+			common.Log.Debug("Unable to evaluate shading function: %v", err)
+			return rgba{}
+		}
+	}
+	clamped := make([]float64, len(comps))
+	for i, c := range comps {
+		if 2*i+1 < len(s.decode) {
+			c = math.Min(math.Max(c, s.decode[2*i]), s.decode[2*i+1])
+		}
+		clamped[i] = c
+	}
+	return s.colors(s.cs, clamped)
+}
+
+// evalFunctions evaluates the functions `funcs` of a shading at `v`: either a single function with
+// an output for each color component, or an array of 1-output functions, one per component.
+func evalFunctions(funcs []model.PdfFunction, v []float64) ([]float64, error) {
+	if len(funcs) == 1 {
+		return funcs[0].Evaluate(v)
+	}
+	var out []float64
+	for _, f := range funcs {
+		y, err := f.Evaluate(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(y) != 1 {
+			return nil, errors.New("Function output count mismatch")
+		}
+		out = append(out, y[0])
+	}
+	return out, nil
+}
+
+// shadingRenderer paints the colors of a shading on an image.
+type shadingRenderer struct {
+	dst    *image.NRGBA
+	m      contentstream.Matrix // Maps shading space to device space.
+	colors colorFunc
+}
+
+// set sets pixel (`x`, `y`) to `c`.
+func (sr *shadingRenderer) set(x, y int, c rgba) {
+	i := sr.dst.PixOffset(x, y)
+	pix := sr.dst.Pix[i : i+4 : i+4]
+	pix[0] = uint8(clamp01(c.r)*255 + 0.5)
+	pix[1] = uint8(clamp01(c.g)*255 + 0.5)
+	pix[2] = uint8(clamp01(c.b)*255 + 0.5)
+	pix[3] = uint8(clamp01(c.a)*255 + 0.5)
+}
+
+// fill sets all pixels to `c`.
+func (sr *shadingRenderer) fill(c rgba) {
+	b := sr.dst.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			sr.set(x, y, c)
+		}
+	}
+}
+
+// clip scales the alpha of the pixels by their coverage by `m`.
+func (sr *shadingRenderer) clip(m *mask) {
+	b := sr.dst.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			i := sr.dst.PixOffset(x, y) + 3
+			sr.dst.Pix[i] = uint8(float32(sr.dst.Pix[i])*m.at(x, y) + 0.5)
+		}
+	}
+}
+
+// eachPixel calls `f` with the center of each pixel in shading space. `f` returns the color of the
+// pixel and false if the pixel is not painted.
+func (sr *shadingRenderer) eachPixel(f func(x, y float64) (rgba, bool)) error {
+	inv, ok := invertMatrix(sr.m)
+	if !ok {
+		return errors.New("Shading matrix not invertible")
+	}
+	b := sr.dst.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			u, v := inv.Transform(float64(x)+0.5, float64(y)+0.5)
+			if c, ok := f(u, v); ok {
+				sr.set(x, y, c)
+			}
+		}
+	}
+	return nil
+}
+
+// functionBased paints function-based shading `ctx` (8.7.4.5.2 "Type 1 (Function-Based) Shadings").
+func (sr *shadingRenderer) functionBased(shading *model.PdfShading, ctx *model.PdfShadingType1) error {
+	domain := []float64{0, 1, 0, 1}
+	if ctx.Domain != nil {
+		if f, err := ctx.Domain.ToFloat64Array(); err == nil && len(f) == 4 {
+			domain = f
+		}
+	}
+	inv, ok := invertMatrix(getMatrix(ctx.Matrix))
+	if !ok {
+		return errors.New("Shading matrix not invertible")
+	}
+	s := sr.newShader(shading.ColorSpace, ctx.Function)
+	return sr.eachPixel(func(x, y float64) (rgba, bool) {
+		u, v := inv.Transform(x, y)
+		if u < domain[0] || u > domain[1] || v < domain[2] || v > domain[3] {
+			return rgba{}, false
+		}
+		return s.color([]float64{u, v}), true
+	})
+}
+
+// axial paints axial shading `ctx` (8.7.4.5.3 "Type 2 (Axial) Shadings").
+func (sr *shadingRenderer) axial(shading *model.PdfShading, ctx *model.PdfShadingType2) error {
+	coords, err := ctx.Coords.ToFloat64Array()
+	if err != nil || len(coords) != 4 {
+		return errors.New("Invalid Coords")
+	}
+	t0, t1 := getDomain(ctx.Domain)
+	extend0, extend1 := getExtend(ctx.Extend)
+	s := sr.newShader(shading.ColorSpace, ctx.Function)
+	s.sampleRamp(t0, t1)
+
+	dx, dy := coords[2]-coords[0], coords[3]-coords[1]
+	d2 := dx*dx + dy*dy
+	return sr.eachPixel(func(x, y float64) (rgba, bool) {
+		var u float64
+		if d2 > 0 {
+			u = ((x-coords[0])*dx + (y-coords[1])*dy) / d2
+		}
+		switch {
+		case u < 0 && !extend0, u > 1 && !extend1:
+			return rgba{}, false
+		}
+		u = clamp01(u)
+		return s.color([]float64{t0 + u*(t1-t0)}), true
+	})
+}
+
+// radial paints radial shading `ctx` (8.7.4.5.4 "Type 3 (Radial) Shadings").
+func (sr *shadingRenderer) radial(shading *model.PdfShading, ctx *model.PdfShadingType3) error {
+	coords, err := ctx.Coords.ToFloat64Array()
+	if err != nil || len(coords) != 6 {
+		return errors.New("Invalid Coords")
+	}
+	t0, t1 := getDomain(ctx.Domain)
+	extend0, extend1 := getExtend(ctx.Extend)
+	s := sr.newShader(shading.ColorSpace, ctx.Function)
+	s.sampleRamp(t0, t1)
+
+	x0, y0, r0 := coords[0], coords[1], coords[2]
+	cdx, cdy, dr := coords[3]-x0, coords[4]-y0, coords[5]-r0
+	a := cdx*cdx + cdy*cdy - dr*dr
+	// valid returns true if the circle with parameter `s` is painted.
+	valid := func(s float64) bool {
+		if r0+s*dr < 0 {
+			return false
+		}
+		return (s >= 0 || extend0) && (s <= 1 || extend1)
+	}
+	return sr.eachPixel(func(x, y float64) (rgba, bool) {
+		// The point is on the circle with parameter s if |p - c(s)| = r(s). The circles with larger s
+		// are painted over those with smaller s.
+		px, py := x-x0, y-y0
+		b := px*cdx + py*cdy + r0*dr
+		c := px*px + py*py - r0*r0
+		var roots []float64
+		if math.Abs(a) < 1e-12 {
+			if b != 0 {
+				roots = []float64{c / (2 * b)}
+			}
+		} else if disc := b*b - a*c; disc >= 0 {
+			sq := math.Sqrt(disc)
+			roots = []float64{(b + sq) / a, (b - sq) / a}
+			if roots[1] > roots[0] {
+				roots[0], roots[1] = roots[1], roots[0]
+			}
+		}
+		for _, u := range roots {
+			if valid(u) {
+				return s.color([]float64{t0 + clamp01(u)*(t1-t0)}), true
+			}
+		}
+		return rgba{}, false
+	})
+}
+
+// getDomain returns the domain [t0 t1] of the parametric variable of an axial or radial shading.
+func getDomain(domain *core.PdfObjectArray) (float64, float64) {
+	if domain != nil {
+		if f, err := domain.ToFloat64Array(); err == nil && len(f) == 2 {
+			return f[0], f[1]
+		}
+	}
+	return 0, 1
+}
+
+// getExtend returns whether an axial or radial shading extends beyond its starting and ending points.
+func getExtend(extend *core.PdfObjectArray) (bool, bool) {
+	if extend == nil || len(*extend) != 2 {
+		return false, false
+	}
+	return isTrue((*extend)[0]), isTrue((*extend)[1])
+}
@@ -0,0 +1,199 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package render
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/unidoc/unidoc/common"
+	"github.com/unidoc/unidoc/pdf/contentstream"
+	"github.com/unidoc/unidoc/pdf/core"
+	"github.com/unidoc/unidoc/pdf/model"
+	"github.com/unidoc/unidoc/pdf/model/fonts"
+)
+
+// showText paints the glyphs of string `data` shown in text state `ts` and graphics state `gs` of a
+// content stream with resources `resources` at nesting depth `depth`, and advances the text matrix of
+// `ts` past them. Glyphs shown with clipping text rendering modes are added to the clipping path by
+// the content stream processor.
+func (r *renderer) showText(ts *contentstream.TextState, gs contentstream.GraphicsState,
+	resources *model.PdfPageResources, data []byte, depth int) {
+	font := ts.Font
+	fill := ts.Tr == 0 || ts.Tr == 2 || ts.Tr == 4 || ts.Tr == 6
+	stroke := ts.Tr == 1 || ts.Tr == 2 || ts.Tr == 5 || ts.Tr == 6
+	for _, g := range ts.Glyphs(data) {
+		if !fill && !stroke {
+			continue
+		}
+		if charProc, ok := font.GetType3CharProc(g.Code); ok {
+			if fill && depth < maxNestingDepth {
+				r.drawType3Glyph(font, charProc, g.Matrix, gs, resources, depth)
+			}
+		} else if outline := glyphOutline(font, g.Code, g.Width); outline != nil {
+			p := newPath(gs.CTM)
+			appendOutline(p, outline, g.Matrix)
+			if fill {
+				r.fillPath(p, gs, resources, false)
+			}
+			if stroke {
+				r.strokePath(p, gs, resources)
+			}
+		}
+	}
+}
+
+// drawType3Glyph paints the glyph of Type 3 font `font` drawn by glyph procedure `charProc`, with its
+// text space mapped to user space by `glyph`. Type 3 glyph procedures are content streams in glyph
+// space, which is mapped to text space by the font matrix.
+func (r *renderer) drawType3Glyph(font *model.PdfFont, charProc *core.PdfObjectStream,
+	glyph contentstream.Matrix, gs contentstream.GraphicsState, resources *model.PdfPageResources, depth int) {
+	fm, ok := font.GetType3FontMatrix()
+	if !ok || len(fm) != 6 {
+		return
+	}
+	if glyphResources := font.GetType3Resources(); glyphResources != nil {
+		resources = glyphResources
+	}
+	gs.CTM = contentstream.NewMatrix(fm[0], fm[1], fm[2], fm[3], fm[4], fm[5]).Mult(glyph).Mult(gs.CTM)
+	gs.Text = contentstream.NewGraphicsState().Text
+	gs.Path = contentstream.Path{}
+	r.renderStream(charProc, resources, gs, depth+1)
+}
+
+// appendOutline appends glyph outline `outline`, mapped to the space of `p` by `m`, to `p`.
+func appendOutline(p *path, outline *fonts.GlyphOutline, m contentstream.Matrix) {
+	pt := func(seg fonts.OutlineSegment, i int) point {
+		x, y := m.Transform(seg.Points[i].X, seg.Points[i].Y)
+		return point{x, y}
+	}
+	for _, seg := range outline.Segments {
+		switch seg.Op {
+		case fonts.OutlineMoveTo:
+			p.moveTo(pt(seg, 0))
+		case fonts.OutlineLineTo:
+			p.lineTo(pt(seg, 0))
+		case fonts.OutlineQuadTo:
+			p.quadTo(pt(seg, 0), pt(seg, 1))
+		case fonts.OutlineCubeTo:
+			p.curveTo(pt(seg, 0), pt(seg, 1), pt(seg, 2))
+		case fonts.OutlineClose:
+			p.closePath()
+		}
+	}
+}
+
+// glyphOutline returns the outline of the glyph of character code `code` of `font` in text space
+// units, from the font's embedded font program. Glyphs of fonts without embedded font programs, such
+// as the standard 14 fonts, are drawn with the glyph for their unicode character in a similar
+// fallback font, scaled horizontally to the glyph's width `width`. Returns nil if there is no glyph.
+func glyphOutline(font *model.PdfFont, code uint64, width float64) *fonts.GlyphOutline {
+	if outline, ok := font.GetGlyphOutline(code); ok {
+		return outline
+	}
+	if font.Subtype() == "Type3" {
+		// Type 3 glyphs without glyph procedures are not drawn.
+		return nil
+	}
+
+	s, ok := font.CharcodeToUnicode(code)
+	if !ok || s == "" {
+		return nil
+	}
+	program := fallbackFont(font)
+	if program == nil {
+		return nil
+	}
+	var gid int
+	for _, cmap := range [][2]int{{3, 10}, {3, 1}, {0, 4}, {0, 3}} {
+		if gid, ok = program.CmapLookup(cmap[0], cmap[1], uint32([]rune(s)[0])); ok {
+			break
+		}
+	}
+	if !ok {
+		return nil
+	}
+	outline, err := program.GIDOutline(gid)
+	if err != nil {
+		common.Log.Debug("Unable to load fallback glyph %d: %v", gid, err)
+		return nil
+	}
+
+	sx := 1 / float64(program.UnitsPerEm())
+	sy := sx
+	if adv, ok := program.GIDAdvance(gid); ok && adv > 0 && width > 0 {
+		sx = width / adv
+	}
+	for i, seg := range outline.Segments {
+		for j := 0; j < seg.NumPoints(); j++ {
+			outline.Segments[i].Points[j].X *= sx
+			outline.Segments[i].Points[j].Y *= sy
+		}
+	}
+	return outline
+}
+
+// fallbackFontData are the TrueType font programs used to draw the glyphs of fonts without embedded
+// font programs, by monospace, bold and italic style.
+var fallbackFontData = map[[3]bool][]byte{
+	{false, false, false}: goregular.TTF,
+	{false, true, false}:  gobold.TTF,
+	{false, false, true}:  goitalic.TTF,
+	{false, true, true}:   gobolditalic.TTF,
+	{true, false, false}:  gomono.TTF,
+	{true, true, false}:   gomonobold.TTF,
+	{true, false, true}:   gomonoitalic.TTF,
+	{true, true, true}:    gomonobolditalic.TTF,
+}
+
+var (
+	fallbackFontsLock sync.Mutex
+	fallbackFonts     = map[[3]bool]*fonts.TrueTypeFont{}
+)
+
+// fallbackFont returns the fallback font program for `font`, chosen by the style given by its name
+// and the flags of its font descriptor.
+func fallbackFont(font *model.PdfFont) *fonts.TrueTypeFont {
+	name := strings.ToLower(font.BaseFont())
+	var flags int64
+	if descriptor := font.GetFontDescriptor(); descriptor != nil {
+		if f, ok := core.TraceToDirectObject(descriptor.Flags).(*core.PdfObjectInteger); ok {
+			flags = int64(*f)
+		}
+	}
+	const (
+		fixedPitchFlag = 1 << 0
+		italicFlag     = 1 << 6
+		forceBoldFlag  = 1 << 18
+	)
+	style := [3]bool{
+		strings.Contains(name, "courier") || strings.Contains(name, "mono") || flags&fixedPitchFlag != 0,
+		strings.Contains(name, "bold") || strings.Contains(name, "black") || strings.Contains(name, "heavy") ||
+			flags&forceBoldFlag != 0,
+		strings.Contains(name, "italic") || strings.Contains(name, "oblique") || flags&italicFlag != 0,
+	}
+
+	fallbackFontsLock.Lock()
+	defer fallbackFontsLock.Unlock()
+	if program, has := fallbackFonts[style]; has {
+		return program
+	}
+	program, err := fonts.ParseTrueType(fallbackFontData[style])
+	if err != nil {
+		common.Log.Debug("Unable to load fallback font: %v", err)
+	}
+	fallbackFonts[style] = program
+	return program
+}